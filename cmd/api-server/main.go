@@ -63,19 +63,99 @@ func main() {
 	// Инициализация сервисов
 	weatherService := service.NewWeatherService(weatherRepo)
 	weatherService.SetTimezone(cfg.Location.Timezone)
+	if cfg.Reanalysis.Baseline {
+		weatherService.SetReanalysisBaseline(repository.NewReanalysisRepository(pool), cfg.Reanalysis.Model, cfg.Reanalysis.BaselineYears)
+	}
+	if cfg.Normals.Enabled() {
+		weatherService.SetClimateNormals(repository.NewClimateNormalsRepository(pool), cfg.Normals.Station, cfg.Normals.Period)
+	}
+	if cfg.Climatology.Enabled {
+		weatherService.SetClimatology(service.ClimatologySettings{
+			WindowDays:     cfg.Climatology.WindowDays,
+			LowPercentile:  cfg.Climatology.LowPercentile,
+			HighPercentile: cfg.Climatology.HighPercentile,
+			MinYears:       cfg.Climatology.MinYears,
+		})
+	}
 	sensorService := service.NewSensorService(sensorRepo)
 	forecastService := service.NewForecastService(forecastRepo)
+	if cfg.Forecast.BiasCorrection {
+		forecastService.SetBiasCorrector(service.NewForecastBiasCorrector(forecastRepo, cfg.Forecast.BiasMinSamples))
+	}
 	geomagneticService := service.NewGeomagneticService(geomagneticRepo, cfg.Geomagnetic.AlertThreshold)
 	var hydroService *service.HydroService
+	var hydroForecastService *service.HydroForecastService
+	var rainRiverService *service.RainRiverService
 	if cfg.Hydro.Enabled {
 		hydroRepo := repository.NewHydroRepository(pool)
 		hydroService = service.NewHydroService(hydroRepo, cfg.Hydro.StationUUID, cfg.Hydro.ZeroPostBSM, cfg.Hydro.UpstreamStationUUIDs()...)
+		hydroForecastService = service.NewHydroForecastService(hydroService, service.HydroForecastSettings{
+			TrainingDays:   cfg.Hydro.ForecastTrainingDays,
+			MaxLagHours:    cfg.Hydro.ForecastMaxLagHours,
+			Hours:          cfg.Hydro.ForecastHours,
+			MinCorrelation: cfg.Hydro.ForecastMinCorrelation,
+		})
+		rainRiverService = service.NewRainRiverService(weatherService, forecastService, hydroService, service.RainRiverSettings{
+			Days:          cfg.Hydro.RainResponseDays,
+			ResponseHours: cfg.Hydro.RainResponseHours,
+			MinRainMM:     cfg.Hydro.RainResponseMinRainMM,
+		})
 	}
+	agroService := service.NewAgroService(weatherService, forecastService, service.AgroSettings{
+		GDDBase:         cfg.Agro.GDDBaseTemp,
+		CropCoefficient: cfg.Agro.CropCoefficient,
+		SoilWaterMM:     cfg.Agro.SoilWaterMM,
+		Latitude:        cfg.Location.Latitude,
+		Elevation:       cfg.Location.Elevation,
+		WindHeight:      cfg.Agro.WindHeight,
+	})
+	heatingService := service.NewHeatingService(weatherService, forecastService, service.HeatingSettings{
+		BaseTemp:        &cfg.Heating.BaseTemp,
+		CoolingBaseTemp: &cfg.Heating.CoolingBaseTemp,
+		ThresholdTemp:   &cfg.Heating.ThresholdTemp,
+		ThresholdDays:   cfg.Heating.ThresholdDays,
+	})
+	indoorService := service.NewIndoorClimateService(weatherService, heatingService, service.IndoorSettings{
+		ComfortTempMin:     cfg.Indoor.ComfortTempMin,
+		ComfortTempMax:     cfg.Indoor.ComfortTempMax,
+		ComfortHumidityMin: cfg.Indoor.ComfortHumidityMin,
+		ComfortHumidityMax: cfg.Indoor.ComfortHumidityMax,
+		MoldHumidity:       cfg.Indoor.MoldHumidity,
+		DryHumidity:        cfg.Indoor.DryHumidity,
+		WallFactor:         cfg.Indoor.WallFactor,
+	})
+	droughtService := service.NewDroughtService(weatherService, service.DroughtSettings{
+		SPIThreshold: cfg.Drought.SPIThreshold,
+		GTKThreshold: cfg.Drought.GTKThreshold,
+		DrySpellDays: cfg.Drought.DrySpellDays,
+	})
+	meteoService := service.NewMeteoSensitivityService(weatherService, forecastService, geomagneticService)
 	dashboardService := service.NewDashboardService(weatherService, forecastService, geomagneticService, hydroService)
+	clothingRules, err := service.LoadClothingRules(cfg.Clothing.RulesFile)
+	if err != nil {
+		log.Fatalf("failed to load clothing rules: %v", err)
+	}
+	clothingService := service.NewClothingService(weatherService, forecastService, clothingRules)
+	dashboardService.SetClothingService(clothingService)
 	sunService, err := service.NewSunService(cfg.Location.Latitude, cfg.Location.Longitude, cfg.Location.Timezone)
 	if err != nil {
 		log.Fatalf("failed to create sun service: %v", err)
 	}
+	sunService.SetElevation(cfg.Location.Elevation)
+	weatherService.SetSunshine(sunService)
+
+	// Оценка выработки солнечных панелей (опционально, если задана мощность)
+	var pvService *service.PVService
+	if cfg.PV.Enabled() {
+		pvService = service.NewPVService(weatherService, sunService, service.PVSettings{
+			CapacityKWp:     cfg.PV.CapacityKWp,
+			Tilt:            cfg.PV.Tilt,
+			Azimuth:         cfg.PV.Azimuth,
+			LossesPct:       cfg.PV.LossesPct,
+			TempCoefficient: &cfg.PV.TempCoefficient,
+		})
+		slog.Info("pv service created", "capacity_kwp", cfg.PV.CapacityKWp)
+	}
 
 	// IPGeolocation client для точных данных о луне
 	var astronomyClient *ipgeolocation.Client
@@ -93,6 +173,18 @@ func main() {
 	}
 	slog.Info("moon service created successfully")
 
+	activityService := service.NewActivityService(weatherService, forecastService, moonService, service.ActivitySettings{
+		Activities: cfg.Activity.Activities,
+		Hours:      cfg.Activity.Hours,
+	})
+	uvService := service.NewUVService(weatherService, forecastService, service.UVSettings{
+		AlertMinutes:    cfg.UV.AlertBurnMinutes,
+		DefaultSkinType: cfg.UV.DefaultSkinType,
+	})
+	auroraService := service.NewAuroraService(geomagneticService, sunService, moonService, forecastService, service.AuroraSettings{
+		AlertProbability: cfg.Aurora.AlertProbability,
+	})
+
 	// Narodmon сервис (опционально, только если включен)
 	var narodmonService *service.NarodmonService
 	if cfg.Narodmon.Enabled {
@@ -103,8 +195,13 @@ func main() {
 	// Инициализация хендлеров
 	weatherHandler := api.NewWeatherHandler(weatherService)
 	sensorHandler := api.NewSensorHandler(sensorService)
-	hydroHandler := api.NewHydroHandler(hydroService)
+	hydroHandler := api.NewHydroHandler(hydroService, hydroForecastService, rainRiverService)
+	climateHandler := api.NewClimateHandler(weatherService)
 	dashboardHandler := api.NewDashboardHandler(dashboardService)
+	pvHandler := api.NewPVHandler(pvService)
+	activityHandler := api.NewActivityHandler(activityService)
+	uvHandler := api.NewUVHandler(uvService)
+	geomagneticHandler := api.NewGeomagneticHandler(geomagneticService)
 
 	// Web handler - try Docker path first, then local development path
 	templatesDir := "templates"
@@ -115,7 +212,7 @@ func main() {
 	}
 
 	slog.Info("creating web handler", "templatesDir", templatesDir)
-	webHandler, err := web.NewHandler(templatesDir, weatherService, sunService, moonService, forecastService, photoRepo, narodmonService, cfg.Narodmon.DeviceURL, geomagneticService, hydroService)
+	webHandler, err := web.NewHandler(templatesDir, weatherService, sunService, moonService, forecastService, photoRepo, narodmonService, cfg.Narodmon.DeviceURL, geomagneticService, hydroService, agroService, heatingService, indoorService, pvService, droughtService, meteoService, activityService, clothingService, uvService, auroraService, hydroForecastService, rainRiverService)
 	if err != nil {
 		log.Fatalf("failed to create web handler: %v", err)
 	}
@@ -142,6 +239,7 @@ func main() {
 	mux.HandleFunc("GET /api/weather/stats", weatherHandler.GetStats)
 	mux.HandleFunc("GET /api/weather/chart", weatherHandler.GetChartData)
 	mux.HandleFunc("GET /api/weather/events", weatherHandler.GetEvents)
+	mux.HandleFunc("GET /api/weather/onthisday", weatherHandler.GetOnThisDay)
 
 	// Sensors API
 	mux.HandleFunc("GET /api/sensors", sensorHandler.GetAll)
@@ -150,6 +248,24 @@ func main() {
 	// Hydro API
 	mux.HandleFunc("GET /api/hydro/current", hydroHandler.GetCurrent)
 	mux.HandleFunc("GET /api/hydro/history", hydroHandler.GetHistory)
+	mux.HandleFunc("GET /api/hydro/gauges", hydroHandler.GetGauges)
+	mux.HandleFunc("GET /api/hydro/forecast", hydroHandler.GetForecast)
+	mux.HandleFunc("GET /api/hydro/rain-response", hydroHandler.GetRainResponse)
+
+	// Climate API
+	mux.HandleFunc("GET /api/climate/anomaly", climateHandler.GetAnomaly)
+
+	// PV API
+	mux.HandleFunc("GET /api/pv", pvHandler.GetReport)
+
+	// Activities API
+	mux.HandleFunc("GET /api/activities", activityHandler.GetActivities)
+
+	// UV API
+	mux.HandleFunc("GET /api/uv", uvHandler.GetExposure)
+
+	// Geomagnetic API
+	mux.HandleFunc("GET /api/geomagnetic/daily", geomagneticHandler.GetDaily)
 
 	// Web pages
 	mux.HandleFunc("GET /", webHandler.Dashboard)
@@ -159,6 +275,7 @@ func main() {
 	mux.HandleFunc("GET /insights/report", webHandler.InsightsReport)
 	mux.HandleFunc("GET /insights/report/story", webHandler.InsightsStory)
 	mux.HandleFunc("GET /insights/report/text", webHandler.InsightsText)
+	mux.HandleFunc("GET /garden", webHandler.Garden)
 	mux.HandleFunc("GET /help", webHandler.Help)
 	mux.HandleFunc("GET /gallery", webHandler.Gallery)
 
@@ -171,6 +288,9 @@ func main() {
 	mux.HandleFunc("GET /detail/solar", webHandler.DetailSolar)
 	mux.HandleFunc("GET /detail/geomagnetic", webHandler.DetailGeomagnetic)
 	mux.HandleFunc("GET /detail/water-level", webHandler.DetailWaterLevel)
+	mux.HandleFunc("GET /detail/water-level/map", webHandler.DetailWaterLevelMap)
+	mux.HandleFunc("GET /detail/indoor", webHandler.DetailIndoor)
+	mux.HandleFunc("GET /detail/pv", webHandler.DetailPV)
 
 	// HTMX widgets
 	mux.HandleFunc("GET /widgets/current", webHandler.CurrentWeatherWidget)
@@ -179,7 +299,10 @@ func main() {
 	mux.HandleFunc("GET /widgets/sun", webHandler.SunTimesWidget)
 	slog.Info("sun widget route registered")
 	mux.HandleFunc("GET /widgets/events", webHandler.WeatherEventsWidget)
+	mux.HandleFunc("GET /widgets/onthisday", webHandler.OnThisDayWidget)
 	mux.HandleFunc("GET /widgets/forecast", webHandler.ForecastWidget)
+	mux.HandleFunc("GET /widgets/activities", webHandler.ActivitiesWidget)
+	mux.HandleFunc("GET /widgets/clothing", webHandler.ClothingWidget)
 	mux.HandleFunc("GET /widgets/water-level", webHandler.WaterLevelWidget)
 	mux.HandleFunc("GET /widgets/narodmon-status", webHandler.NarodmonStatusWidget)
 
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/iRootPro/weather/internal/config"
+	"github.com/iRootPro/weather/internal/models"
+	"github.com/iRootPro/weather/internal/repository"
+	"github.com/iRootPro/weather/pkg/database"
+	"github.com/iRootPro/weather/pkg/openmeteo"
+)
+
+// ERA5 публикуется с задержкой около 5 дней — более свежие дни архив не отдаёт
+const archiveLagDays = 6
+
+func main() {
+	fromFlag := flag.String("from", "", "начальная дата YYYY-MM-DD (по умолчанию — день после последнего загруженного или REANALYSIS_START_DATE)")
+	toFlag := flag.String("to", "", "конечная дата YYYY-MM-DD включительно (по умолчанию — 6 дней назад)")
+	chunkDays := flag.Int("chunk-days", 366, "дней в одном запросе к архиву")
+	flag.Parse()
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	cfg, err := config.Load()
+	if err != nil {
+		logger.Error("failed to load config", "error", err)
+		os.Exit(1)
+	}
+
+	var logLevel slog.Level
+	switch cfg.Log.Level {
+	case "debug":
+		logLevel = slog.LevelDebug
+	case "warn":
+		logLevel = slog.LevelWarn
+	case "error":
+		logLevel = slog.LevelError
+	default:
+		logLevel = slog.LevelInfo
+	}
+	logger = slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: logLevel}))
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	pool, err := database.NewPostgresPool(ctx, cfg.DB.DSN())
+	if err != nil {
+		logger.Error("failed to connect to database", "error", err)
+		os.Exit(1)
+	}
+	defer pool.Close()
+
+	repo := repository.NewReanalysisRepository(pool)
+	source := cfg.Reanalysis.Model
+	if source == "" {
+		source = models.ReanalysisSourceERA5
+	}
+
+	from, to, err := resolveRange(ctx, repo, source, cfg.Reanalysis.StartDate, *fromFlag, *toFlag)
+	if err != nil {
+		logger.Error("invalid backfill range", "error", err)
+		os.Exit(1)
+	}
+	if from.After(to) {
+		logger.Info("reanalysis archive is up to date", "source", source, "last_date", to.Format("2006-01-02"))
+		return
+	}
+
+	backfill := &Backfill{
+		logger: logger,
+		client: openmeteo.NewClient(time.Duration(cfg.Reanalysis.APITimeout) * time.Second),
+		repo:   repo,
+		source: source,
+		cfg:    cfg.Location,
+	}
+
+	logger.Info("starting climate backfill",
+		"source", source,
+		"from", from.Format("2006-01-02"),
+		"to", to.Format("2006-01-02"),
+	)
+	saved, err := backfill.Run(ctx, from, to, *chunkDays)
+	if err != nil {
+		logger.Error("climate backfill failed", "saved_days", saved, "error", err)
+		os.Exit(1)
+	}
+	logger.Info("climate backfill finished", "saved_days", saved)
+}
+
+// resolveRange определяет период загрузки: явные флаги или продолжение с последнего загруженного дня
+func resolveRange(ctx context.Context, repo repository.ReanalysisRepository, source, startDate, fromParam, toParam string) (time.Time, time.Time, error) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	to := today.AddDate(0, 0, -archiveLagDays)
+	if toParam != "" {
+		parsed, err := time.Parse("2006-01-02", toParam)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("bad -to: %w", err)
+		}
+		to = parsed
+	}
+
+	if fromParam != "" {
+		from, err := time.Parse("2006-01-02", fromParam)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("bad -from: %w", err)
+		}
+		return from, to, nil
+	}
+
+	from, err := time.Parse("2006-01-02", startDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("bad REANALYSIS_START_DATE: %w", err)
+	}
+	latest, err := repo.GetLatestDate(ctx, source)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if latest != nil && !latest.Before(from) {
+		from = latest.AddDate(0, 0, 1)
+	}
+	return from, to, nil
+}
+
+type Backfill struct {
+	logger *slog.Logger
+	client *openmeteo.Client
+	repo   repository.ReanalysisRepository
+	source string
+	cfg    config.LocationConfig
+}
+
+// Run загружает архив частями и сохраняет каждую часть сразу, чтобы прерванный запуск можно было продолжить
+func (b *Backfill) Run(ctx context.Context, from, to time.Time, chunkDays int) (int, error) {
+	if chunkDays <= 0 {
+		chunkDays = 366
+	}
+	saved := 0
+	for chunkStart := from; !chunkStart.After(to); chunkStart = chunkStart.AddDate(0, 0, chunkDays) {
+		chunkEnd := chunkStart.AddDate(0, 0, chunkDays-1)
+		if chunkEnd.After(to) {
+			chunkEnd = to
+		}
+
+		resp, err := b.client.GetArchive(ctx, openmeteo.ArchiveRequest{
+			Latitude:  b.cfg.Latitude,
+			Longitude: b.cfg.Longitude,
+			StartDate: chunkStart,
+			EndDate:   chunkEnd,
+			Daily:     openmeteo.GetDefaultArchiveDailyParams(),
+			Timezone:  b.cfg.Timezone,
+			Model:     b.source,
+			WindUnit:  "ms",
+		})
+		if err != nil {
+			return saved, fmt.Errorf("failed to fetch %s — %s: %w", chunkStart.Format("2006-01-02"), chunkEnd.Format("2006-01-02"), err)
+		}
+
+		days := buildReanalysisDays(resp.Daily, b.source, time.Now())
+		if err := b.repo.SaveBatch(ctx, days); err != nil {
+			return saved, err
+		}
+		saved += len(days)
+		b.logger.Info("reanalysis chunk saved",
+			"from", chunkStart.Format("2006-01-02"),
+			"to", chunkEnd.Format("2006-01-02"),
+			"days", len(days),
+		)
+
+		// Бесплатный тариф Open-Meteo ограничивает частоту запросов к архиву
+		select {
+		case <-ctx.Done():
+			return saved, ctx.Err()
+		case <-time.After(2 * time.Second):
+		}
+	}
+	return saved, nil
+}
+
+// buildReanalysisDays переводит суточный ответ архива в строки reanalysis_daily.
+// Дни без температуры и осадков пропускаются: архив отдаёт null для ещё не рассчитанных дат.
+func buildReanalysisDays(daily openmeteo.DailyData, source string, fetchedAt time.Time) []models.ReanalysisDay {
+	days := make([]models.ReanalysisDay, 0, len(daily.Time))
+	for i, raw := range daily.Time {
+		date, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			continue
+		}
+		day := models.ReanalysisDay{
+			Date:                  date,
+			Source:                source,
+			TempMin:               float32At(daily.TemperatureMin, i),
+			TempMax:               float32At(daily.TemperatureMax, i),
+			TempAvg:               float32At(daily.TemperatureMean, i),
+			PrecipitationSum:      float32At(daily.PrecipitationSum, i),
+			WindSpeedMax:          float32At(daily.WindSpeedMax, i),
+			WindGustsMax:          float32At(daily.WindGustsMax, i),
+			ShortwaveRadiationSum: float32At(daily.ShortwaveRadiationSum, i),
+			FetchedAt:             fetchedAt,
+		}
+		if day.TempMin == nil && day.TempMax == nil && day.PrecipitationSum == nil {
+			continue
+		}
+		days = append(days, day)
+	}
+	return days
+}
+
+func float32At(values []*float64, i int) *float32 {
+	if i >= len(values) || values[i] == nil {
+		return nil
+	}
+	v := float32(*values[i])
+	return &v
+}
@@ -11,7 +11,9 @@ import (
 	"github.com/iRootPro/weather/internal/config"
 	"github.com/iRootPro/weather/internal/models"
 	"github.com/iRootPro/weather/internal/repository"
+	"github.com/iRootPro/weather/internal/service"
 	"github.com/iRootPro/weather/pkg/database"
+	"github.com/iRootPro/weather/pkg/metno"
 	"github.com/iRootPro/weather/pkg/openmeteo"
 )
 
@@ -59,16 +61,36 @@ func main() {
 	// Репозиторий
 	forecastRepo := repository.NewForecastRepository(pool)
 
-	// Open-Meteo клиент
-	omClient := openmeteo.NewClient(time.Duration(cfg.Forecast.APITimeout) * time.Second)
+	// Источники прогноза: первым идёт основной (Open-Meteo best_match), остальные — ансамбль
+	timeout := time.Duration(cfg.Forecast.APITimeout) * time.Second
+	location := service.ForecastLocation{
+		Latitude:  cfg.Location.Latitude,
+		Longitude: cfg.Location.Longitude,
+		Timezone:  cfg.Location.Timezone,
+	}
+	omClient := openmeteo.NewClient(timeout)
+	providers := []service.ForecastProvider{
+		service.NewOpenMeteoProvider(omClient, models.ForecastModelBestMatch, location, cfg.Forecast.HourlyHours, cfg.Forecast.DailyDays),
+	}
+	for _, model := range cfg.Forecast.EnsembleModelList() {
+		providers = append(providers, service.NewOpenMeteoProvider(omClient, model, location, cfg.Forecast.HourlyHours, cfg.Forecast.DailyDays))
+	}
+	if cfg.Forecast.MetNorwayEnabled {
+		metClient := metno.NewClient(timeout, "", cfg.Forecast.MetNorwayUserAgent)
+		metProvider, err := service.NewMetNorwayProvider(metClient, location, cfg.Forecast.HourlyHours, cfg.Forecast.DailyDays)
+		if err != nil {
+			logger.Error("failed to create MET Norway provider", "error", err)
+		} else {
+			providers = append(providers, metProvider)
+		}
+	}
 
 	// Создаем fetcher
 	fetcher := &Fetcher{
-		logger:   logger,
-		client:   omClient,
-		repo:     forecastRepo,
-		location: cfg.Location,
-		config:   cfg.Forecast,
+		logger:    logger,
+		providers: providers,
+		repo:      forecastRepo,
+		location:  cfg.Location,
 	}
 
 	// Выполняем первый запрос сразу при старте
@@ -89,6 +111,7 @@ func main() {
 		"update_interval", cfg.Forecast.UpdateInterval,
 		"hourly_hours", cfg.Forecast.HourlyHours,
 		"daily_days", cfg.Forecast.DailyDays,
+		"providers", len(providers),
 	)
 
 	for {
@@ -107,123 +130,47 @@ func main() {
 }
 
 type Fetcher struct {
-	logger   *slog.Logger
-	client   *openmeteo.Client
-	repo     repository.ForecastRepository
-	location config.LocationConfig
-	config   config.ForecastConfig
+	logger    *slog.Logger
+	providers []service.ForecastProvider // первый — основной прогноз
+	repo      repository.ForecastRepository
+	location  config.LocationConfig
 }
 
 func (f *Fetcher) FetchAndSave(ctx context.Context) error {
 	startTime := time.Now()
 
-	// Запрос к Open-Meteo API
-	req := openmeteo.ForecastRequest{
-		Latitude:  f.location.Latitude,
-		Longitude: f.location.Longitude,
-		Timezone:  f.location.Timezone,
-		Hourly:    openmeteo.GetDefaultHourlyParams(),
-		Daily:     openmeteo.GetDefaultDailyParams(),
-	}
-
-	resp, err := f.client.GetForecast(ctx, req)
+	// Фиксируем пары «прогноз — наблюдение» за прошедшие часы до перезаписи прогноза
+	pairs, err := f.repo.SaveVerificationPairs(ctx, startTime.Add(-48*time.Hour), startTime.Add(24*time.Hour), f.location.Timezone)
 	if err != nil {
-		return err
+		f.logger.Warn("failed to save forecast verification pairs", "error", err)
+	} else if pairs > 0 {
+		f.logger.Debug("forecast verification pairs saved", "count", pairs)
 	}
 
-	fetchedAt := time.Now()
-
-	// Конвертируем почасовой прогноз
-	hourlyData := make([]models.ForecastData, 0)
-	for i := 0; i < len(resp.Hourly.Time) && i < f.config.HourlyHours; i++ {
-		// Open-Meteo возвращает время в формате "2025-12-29T00:00"
-		forecastTime, err := time.Parse("2006-01-02T15:04", resp.Hourly.Time[i])
-		if err != nil {
-			f.logger.Warn("failed to parse hourly time", "time", resp.Hourly.Time[i], "error", err)
-			continue
-		}
-
-		temp := float32(resp.Hourly.Temperature[i])
-		feelsLike := float32(resp.Hourly.FeelsLike[i])
-		precip := float32(resp.Hourly.Precipitation[i])
-		windSpeed := float32(resp.Hourly.WindSpeed[i])
-		windGusts := float32(resp.Hourly.WindGusts[i])
-		pressure := float32(resp.Hourly.Pressure[i])
-		uvIndex := float32(resp.Hourly.UVIndex[i])
-
-		precipProb := int16(resp.Hourly.PrecipitationProbability[i])
-		windDir := int16(resp.Hourly.WindDirection[i])
-		cloudCover := int16(resp.Hourly.CloudCover[i])
-		humidity := int16(resp.Hourly.Humidity[i])
-		weatherCode := int16(resp.Hourly.WeatherCode[i])
-
-		description := models.GetWeatherDescription(weatherCode)
-
-		data := models.ForecastData{
-			ForecastTime:             forecastTime,
-			Temperature:              &temp,
-			FeelsLike:                &feelsLike,
-			PrecipitationProbability: &precipProb,
-			Precipitation:            &precip,
-			WindSpeed:                &windSpeed,
-			WindDirection:            &windDir,
-			WindGusts:                &windGusts,
-			CloudCover:               &cloudCover,
-			Pressure:                 &pressure,
-			Humidity:                 &humidity,
-			UVIndex:                  &uvIndex,
-			WeatherCode:              &weatherCode,
-			WeatherDescription:       &description,
-			ForecastType:             "hourly",
-			FetchedAt:                fetchedAt,
+	saved := 0
+	for i, provider := range f.providers {
+		data, err := provider.Fetch(ctx)
+		if err == nil {
+			err = f.repo.SaveBatch(ctx, data)
 		}
-		hourlyData = append(hourlyData, data)
-	}
-
-	// Конвертируем дневной прогноз
-	dailyData := make([]models.ForecastData, 0)
-	for i := 0; i < len(resp.Daily.Time) && i < f.config.DailyDays; i++ {
-		forecastTime, err := time.Parse("2006-01-02", resp.Daily.Time[i])
 		if err != nil {
-			f.logger.Warn("failed to parse daily time", "time", resp.Daily.Time[i], "error", err)
+			// Без основного прогноза цикл считается неудачным, остальные модели необязательны
+			if i == 0 {
+				return err
+			}
+			f.logger.Warn("failed to fetch ensemble forecast",
+				"provider", provider.Provider(),
+				"model", provider.Model(),
+				"error", err,
+			)
 			continue
 		}
-
-		tempMin := float32(resp.Daily.TemperatureMin[i])
-		tempMax := float32(resp.Daily.TemperatureMax[i])
-		precipSum := float32(resp.Daily.PrecipitationSum[i])
-		windSpeedMax := float32(resp.Daily.WindSpeedMax[i])
-		windGustsMax := float32(resp.Daily.WindGustsMax[i])
-		uvIndexMax := float32(resp.Daily.UVIndexMax[i])
-
-		precipProb := int16(resp.Daily.PrecipitationProbability[i])
-		windDir := int16(resp.Daily.WindDirection[i])
-		weatherCode := int16(resp.Daily.WeatherCode[i])
-
-		description := models.GetWeatherDescription(weatherCode)
-
-		data := models.ForecastData{
-			ForecastTime:             forecastTime,
-			TemperatureMin:           &tempMin,
-			TemperatureMax:           &tempMax,
-			PrecipitationProbability: &precipProb,
-			Precipitation:            &precipSum,
-			WindSpeed:                &windSpeedMax,
-			WindDirection:            &windDir,
-			WindGusts:                &windGustsMax,
-			UVIndex:                  &uvIndexMax,
-			WeatherCode:              &weatherCode,
-			WeatherDescription:       &description,
-			ForecastType:             "daily",
-			FetchedAt:                fetchedAt,
-		}
-		dailyData = append(dailyData, data)
-	}
-
-	// Сохраняем все данные батчами
-	allData := append(hourlyData, dailyData...)
-	if err := f.repo.SaveBatch(ctx, allData); err != nil {
-		return err
+		saved++
+		f.logger.Debug("forecast saved",
+			"provider", provider.Provider(),
+			"model", provider.Model(),
+			"rows", len(data),
+		)
 	}
 
 	// Удаляем старые прогнозы (старше 7 дней)
@@ -234,8 +181,8 @@ func (f *Fetcher) FetchAndSave(ctx context.Context) error {
 
 	elapsed := time.Since(startTime)
 	f.logger.Info("forecast fetched and saved successfully",
-		"hourly_count", len(hourlyData),
-		"daily_count", len(dailyData),
+		"providers_saved", saved,
+		"providers_total", len(f.providers),
 		"elapsed", elapsed,
 	)
 
@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
@@ -11,7 +13,9 @@ import (
 	"github.com/iRootPro/weather/internal/config"
 	"github.com/iRootPro/weather/internal/models"
 	"github.com/iRootPro/weather/internal/repository"
+	"github.com/iRootPro/weather/internal/service"
 	"github.com/iRootPro/weather/pkg/database"
+	"github.com/iRootPro/weather/pkg/swpc"
 	"github.com/iRootPro/weather/pkg/xras"
 )
 
@@ -55,20 +59,34 @@ func main() {
 
 	repo := repository.NewGeomagneticRepository(pool)
 
-	client, err := xras.NewClient(
-		time.Duration(cfg.Geomagnetic.APITimeout)*time.Second,
-		cfg.Geomagnetic.URL,
-		cfg.Geomagnetic.ProxyURL,
-	)
-	if err != nil {
-		logger.Error("failed to create xras client", "error", err)
+	if !service.ValidKpForecastMerge(cfg.Geomagnetic.ForecastMerge) {
+		logger.Error("invalid forecast merge strategy", "strategy", cfg.Geomagnetic.ForecastMerge)
 		os.Exit(1)
 	}
 
 	fetcher := &Fetcher{
-		logger: logger,
-		client: client,
-		repo:   repo,
+		logger:        logger,
+		repo:          repo,
+		sources:       cfg.Geomagnetic.Sources,
+		forecastMerge: cfg.Geomagnetic.ForecastMerge,
+
+		dailyRetentionDays: max(cfg.Geomagnetic.DailyRetentionDays, 90),
+	}
+	timeout := time.Duration(cfg.Geomagnetic.APITimeout) * time.Second
+	for _, source := range cfg.Geomagnetic.Sources {
+		switch source {
+		case sourceXras:
+			fetcher.xras, err = xras.NewClient(timeout, cfg.Geomagnetic.URL, cfg.Geomagnetic.ProxyURL)
+			if err != nil {
+				logger.Error("failed to create xras client", "error", err)
+				os.Exit(1)
+			}
+		case sourceSWPC:
+			fetcher.swpc = swpc.NewClient(timeout, cfg.Geomagnetic.SWPCKpURL, cfg.Geomagnetic.SWPCForecastURL)
+		default:
+			logger.Error("unknown geomagnetic source", "source", source)
+			os.Exit(1)
+		}
 	}
 
 	logger.Info("performing initial geomagnetic fetch")
@@ -83,6 +101,8 @@ func main() {
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 
 	logger.Info("geomagnetic-fetcher service started",
+		"sources", cfg.Geomagnetic.Sources,
+		"forecast_merge", cfg.Geomagnetic.ForecastMerge,
 		"update_interval", cfg.Geomagnetic.UpdateInterval,
 		"alert_threshold", cfg.Geomagnetic.AlertThreshold,
 	)
@@ -101,26 +121,87 @@ func main() {
 	}
 }
 
+// Названия источников в GEOMAGNETIC_SOURCES
+const (
+	sourceXras = "xras"
+	sourceSWPC = "swpc"
+)
+
 type Fetcher struct {
-	logger *slog.Logger
-	client *xras.Client
-	repo   repository.GeomagneticRepository
+	logger        *slog.Logger
+	xras          *xras.Client
+	swpc          *swpc.Client
+	repo          repository.GeomagneticRepository
+	sources       []string
+	forecastMerge string
+	// Сколько дней хранить суточные F10.7/Sn/Ap
+	dailyRetentionDays int
 }
 
+// FetchAndSave опрашивает источники по порядку, объединяет их Kp в одну серию и
+// сохраняет её. Недоступный источник пропускается — ошибка, только если не ответил ни один.
 func (f *Fetcher) FetchAndSave(ctx context.Context) error {
 	startTime := time.Now()
+	now := time.Now()
 
-	resp, err := f.client.GetKpData(ctx)
-	if err != nil {
+	var series [][]models.GeomagneticKp
+	var dailies []models.GeomagneticDaily
+	var errs []error
+	for _, source := range f.sources {
+		var slots []models.GeomagneticKp
+		var err error
+		switch source {
+		case sourceXras:
+			slots, dailies, err = f.fetchXras(ctx, now)
+		case sourceSWPC:
+			slots, err = f.fetchSWPC(ctx, now)
+		}
+		if err != nil {
+			f.logger.Warn("geomagnetic source failed", "source", source, "error", err)
+			errs = append(errs, fmt.Errorf("%s: %w", source, err))
+			continue
+		}
+		f.logger.Debug("geomagnetic source fetched", "source", source, "kp_slots", len(slots))
+		series = append(series, slots)
+	}
+	if len(series) == 0 {
+		return fmt.Errorf("all geomagnetic sources failed: %w", errors.Join(errs...))
+	}
+
+	slots := service.MergeKpSources(series, f.forecastMerge)
+	if err := f.repo.SaveKpBatch(ctx, slots); err != nil {
+		return err
+	}
+	if err := f.repo.SaveDailyBatch(ctx, dailies); err != nil {
 		return err
 	}
 
+	// Retention: слоты Kp — 90 дней, суточные показатели — dailyRetentionDays.
+	if err := f.repo.DeleteOlderThan(ctx, now.AddDate(0, 0, -90), now.AddDate(0, 0, -f.dailyRetentionDays)); err != nil {
+		f.logger.Warn("failed to clean old geomagnetic data", "error", err)
+	}
+
+	f.logger.Info("geomagnetic fetched and saved",
+		"sources", len(series),
+		"kp_slots", len(slots),
+		"daily", len(dailies),
+		"elapsed", time.Since(startTime),
+	)
+	return nil
+}
+
+// fetchXras возвращает слоты Kp и суточные показатели солнечной активности xras.ru.
+func (f *Fetcher) fetchXras(ctx context.Context, now time.Time) ([]models.GeomagneticKp, []models.GeomagneticDaily, error) {
+	resp, err := f.xras.GetKpData(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	loc, err := xras.ParseTzone(resp.Tzone)
 	if err != nil {
 		f.logger.Warn("failed to parse tzone, using fallback", "tzone", resp.Tzone, "error", err)
 	}
 
-	now := time.Now()
 	slots := make([]models.GeomagneticKp, 0, len(resp.Data)*8)
 	dailies := make([]models.GeomagneticDaily, 0, len(resp.Data))
 
@@ -139,7 +220,7 @@ func (f *Fetcher) FetchAndSave(ctx context.Context) error {
 			slots = append(slots, models.GeomagneticKp{
 				SlotTime:   slotTime.UTC(),
 				Kp:         float32(*slot),
-				Source:     "xras.ru",
+				Source:     models.KpSourceXras,
 				IsForecast: slotTime.After(now),
 				FetchedAt:  now,
 			})
@@ -164,26 +245,42 @@ func (f *Fetcher) FetchAndSave(ctx context.Context) error {
 			})
 		}
 	}
+	return slots, dailies, nil
+}
 
-	if err := f.repo.SaveKpBatch(ctx, slots); err != nil {
-		return err
+// fetchSWPC объединяет наблюдённый Kp и прогноз на трое суток NOAA SWPC. Наблюдение
+// из продукта planetary k-index точнее строк observed/estimated прогнозного продукта.
+func (f *Fetcher) fetchSWPC(ctx context.Context, now time.Time) ([]models.GeomagneticKp, error) {
+	forecast, forecastErr := f.swpc.GetKpForecast(ctx)
+	if forecastErr != nil {
+		f.logger.Warn("failed to fetch swpc kp forecast", "error", forecastErr)
 	}
-	if err := f.repo.SaveDailyBatch(ctx, dailies); err != nil {
-		return err
+	observed, observedErr := f.swpc.GetPlanetaryKp(ctx)
+	if observedErr != nil {
+		f.logger.Warn("failed to fetch swpc planetary kp", "error", observedErr)
+	}
+	if forecastErr != nil && observedErr != nil {
+		return nil, errors.Join(forecastErr, observedErr)
 	}
 
-	// Retention: храним 90 дней истории.
-	cutoff := now.AddDate(0, 0, -90)
-	if err := f.repo.DeleteOlderThan(ctx, cutoff); err != nil {
-		f.logger.Warn("failed to clean old geomagnetic data", "error", err)
+	bySlot := make(map[int64]models.GeomagneticKp, len(forecast)+len(observed))
+	for _, entries := range [][]swpc.KpEntry{forecast, observed} {
+		for _, e := range entries {
+			bySlot[e.Time.Unix()] = models.GeomagneticKp{
+				SlotTime:   e.Time,
+				Kp:         float32(e.Kp),
+				Source:     models.KpSourceSWPC,
+				IsForecast: e.IsPredicted(),
+				FetchedAt:  now,
+			}
+		}
 	}
 
-	f.logger.Info("geomagnetic fetched and saved",
-		"kp_slots", len(slots),
-		"daily", len(dailies),
-		"elapsed", time.Since(startTime),
-	)
-	return nil
+	slots := make([]models.GeomagneticKp, 0, len(bySlot))
+	for _, slot := range bySlot {
+		slots = append(slots, slot)
+	}
+	return slots, nil
 }
 
 func float32Ptr(p *float64) *float32 {
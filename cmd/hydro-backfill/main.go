@@ -0,0 +1,245 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/iRootPro/weather/internal/config"
+	"github.com/iRootPro/weather/internal/models"
+	"github.com/iRootPro/weather/internal/repository"
+	"github.com/iRootPro/weather/pkg/database"
+	"github.com/iRootPro/weather/pkg/emercit"
+)
+
+func main() {
+	fromFlag := flag.String("from", "", "начальная дата YYYY-MM-DD (по умолчанию — 5 лет назад, но не раньше срока HYDRO_RETENTION_DAYS)")
+	toFlag := flag.String("to", "", "конечная дата YYYY-MM-DD включительно (по умолчанию — сегодня)")
+	chunkDays := flag.Int("chunk-days", 31, "дней в одном запросе к архиву поста")
+	stationFlag := flag.String("station", "", "station_uuid одного поста (по умолчанию — все настроенные и сохранённые hydro-fetcher)")
+	flag.Parse()
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	cfg, err := config.Load()
+	if err != nil {
+		logger.Error("failed to load config", "error", err)
+		os.Exit(1)
+	}
+
+	var logLevel slog.Level
+	switch cfg.Log.Level {
+	case "debug":
+		logLevel = slog.LevelDebug
+	case "warn":
+		logLevel = slog.LevelWarn
+	case "error":
+		logLevel = slog.LevelError
+	default:
+		logLevel = slog.LevelInfo
+	}
+	logger = slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: logLevel}))
+
+	now := time.Now()
+	from, to, err := resolveRange(*fromFlag, *toFlag, now)
+	if err != nil {
+		logger.Error("invalid backfill range", "error", err)
+		os.Exit(1)
+	}
+	// Архив старше срока хранения hydro-fetcher удалит при следующей очистке — не загружаем его впустую
+	if cfg.Hydro.RetentionDays > 0 {
+		cutoff := now.AddDate(0, 0, -cfg.Hydro.RetentionDays)
+		if from.Before(cutoff) {
+			if *fromFlag != "" {
+				logger.Warn("backfill range starts before hydro retention, set HYDRO_RETENTION_DAYS=0 to keep a multi-year archive",
+					"from", from.Format("2006-01-02"),
+					"clamped_from", cutoff.Format("2006-01-02"),
+					"retention_days", cfg.Hydro.RetentionDays)
+			}
+			from = cutoff
+		}
+		if !from.Before(to) {
+			logger.Error("backfill range is entirely older than hydro retention", "retention_days", cfg.Hydro.RetentionDays)
+			os.Exit(1)
+		}
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	pool, err := database.NewPostgresPool(ctx, cfg.DB.DSN())
+	if err != nil {
+		logger.Error("failed to connect to database", "error", err)
+		os.Exit(1)
+	}
+	defer pool.Close()
+
+	repo := repository.NewHydroRepository(pool)
+	stations, err := resolveStations(ctx, repo, cfg.Hydro, *stationFlag)
+	if err != nil {
+		logger.Error("failed to resolve hydro stations", "error", err)
+		os.Exit(1)
+	}
+	if len(stations) == 0 {
+		logger.Error("no hydro stations to backfill", "station", *stationFlag)
+		os.Exit(1)
+	}
+
+	backfill := &Backfill{
+		logger: logger,
+		client: emercit.NewClient(time.Duration(cfg.Hydro.APITimeout)*time.Second, cfg.Hydro.BaseURL, cfg.Hydro.Username, cfg.Hydro.Password),
+		repo:   repo,
+	}
+
+	logger.Info("starting hydro backfill",
+		"stations", len(stations),
+		"from", from.Format("2006-01-02"),
+		"to", to.Format("2006-01-02"),
+	)
+	total := 0
+	failed := 0
+	for _, station := range stations {
+		saved, err := backfill.Run(ctx, station, from, to, *chunkDays)
+		total += saved
+		if err != nil {
+			if ctx.Err() != nil {
+				logger.Error("hydro backfill interrupted", "saved_readings", total, "error", err)
+				os.Exit(1)
+			}
+			failed++
+			logger.Error("hydro backfill failed for station", "station_uuid", station.StationUUID, "saved_readings", saved, "error", err)
+			continue
+		}
+		logger.Info("hydro station backfilled", "station_uuid", station.StationUUID, "saved_readings", saved)
+	}
+	if failed > 0 {
+		logger.Error("hydro backfill finished with errors", "saved_readings", total, "failed_stations", failed)
+		os.Exit(1)
+	}
+	logger.Info("hydro backfill finished", "saved_readings", total)
+}
+
+// resolveRange определяет период загрузки по флагам; конечная дата включается целиком
+func resolveRange(fromParam, toParam string, now time.Time) (time.Time, time.Time, error) {
+	to := now
+	if toParam != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", toParam, time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("bad -to: %w", err)
+		}
+		to = parsed.AddDate(0, 0, 1).Add(-time.Second)
+		if to.After(now) {
+			to = now
+		}
+	}
+
+	from := time.Date(now.Year()-5, now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	if fromParam != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", fromParam, time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("bad -from: %w", err)
+		}
+		from = parsed
+	}
+	if from.After(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("-from %s is after -to %s", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	}
+	return from, to, nil
+}
+
+// resolveStations собирает посты для загрузки: основной и верхние из конфигурации, а также
+// посты, которые hydro-fetcher уже сохранил в hydro_gauges (в том числе из HYDRO_MONITORED_STATIONS)
+func resolveStations(ctx context.Context, repo repository.HydroRepository, cfg config.HydroConfig, only string) ([]config.HydroStationRef, error) {
+	stations := cfg.Stations()
+	seen := make(map[string]bool, len(stations))
+	for _, station := range stations {
+		seen[station.StationUUID] = true
+	}
+	gauges, err := repo.ListGauges(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, gauge := range gauges {
+		if seen[gauge.StationUUID] || gauge.WaterLevelUUID == "" {
+			continue
+		}
+		seen[gauge.StationUUID] = true
+		stations = append(stations, config.HydroStationRef{StationUUID: gauge.StationUUID, WaterLevelUUID: gauge.WaterLevelUUID})
+	}
+	if only == "" {
+		return stations, nil
+	}
+	for _, station := range stations {
+		if station.StationUUID == only {
+			return []config.HydroStationRef{station}, nil
+		}
+	}
+	return nil, nil
+}
+
+type Backfill struct {
+	logger *slog.Logger
+	client *emercit.Client
+	repo   repository.HydroRepository
+}
+
+// Run загружает архив поста частями и сохраняет каждую часть сразу. Повторный запуск безопасен:
+// значения перезаписываются по (observed_at, station_uuid).
+func (b *Backfill) Run(ctx context.Context, station config.HydroStationRef, from, to time.Time, chunkDays int) (int, error) {
+	if chunkDays <= 0 {
+		chunkDays = 31
+	}
+	saved := 0
+	for chunkStart := from; chunkStart.Before(to); chunkStart = chunkStart.AddDate(0, 0, chunkDays) {
+		chunkEnd := chunkStart.AddDate(0, 0, chunkDays)
+		if chunkEnd.After(to) {
+			chunkEnd = to
+		}
+
+		history, err := b.client.GetWaterLevelHistory(ctx, station.WaterLevelUUID, chunkStart, chunkEnd)
+		if err != nil {
+			return saved, fmt.Errorf("failed to fetch %s — %s: %w", chunkStart.Format("2006-01-02"), chunkEnd.Format("2006-01-02"), err)
+		}
+
+		readings := buildArchiveReadings(station, history, time.Now())
+		if err := b.repo.SaveReadingsBatch(ctx, readings); err != nil {
+			return saved, err
+		}
+		saved += len(readings)
+		b.logger.Info("hydro chunk saved",
+			"station_uuid", station.StationUUID,
+			"from", chunkStart.Format("2006-01-02"),
+			"to", chunkEnd.Format("2006-01-02"),
+			"readings", len(readings),
+		)
+
+		// Публичный сервис Эмерсит не рассчитан на частые тяжёлые запросы к архиву
+		select {
+		case <-ctx.Done():
+			return saved, ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+	return saved, nil
+}
+
+func buildArchiveReadings(station config.HydroStationRef, history emercit.HistoryResponse, fetchedAt time.Time) []models.HydroLevelReading {
+	points := history.Points(station.WaterLevelUUID)
+	out := make([]models.HydroLevelReading, 0, len(points))
+	for _, p := range points {
+		out = append(out, models.HydroLevelReading{
+			StationUUID:    station.StationUUID,
+			WaterLevelUUID: station.WaterLevelUUID,
+			ObservedAt:     p.ObservedAt,
+			LevelBSM:       float32(p.BS),
+			LevelZeroM:     emercit.Float32Ptr(p.Zero),
+			FetchedAt:      fetchedAt,
+		})
+	}
+	return out
+}
@@ -55,7 +55,7 @@ func main() {
 	repo := repository.NewHydroRepository(pool)
 	client := emercit.NewClient(time.Duration(cfg.Hydro.APITimeout)*time.Second, cfg.Hydro.BaseURL, cfg.Hydro.Username, cfg.Hydro.Password)
 
-	fetcher := &Fetcher{logger: logger, client: client, repo: repo, config: cfg.Hydro}
+	fetcher := &Fetcher{logger: logger, client: client, repo: repo, config: cfg.Hydro, location: cfg.Location}
 
 	logger.Info("performing initial hydro fetch")
 	if err := fetcher.FetchAndSave(ctx); err != nil {
@@ -68,7 +68,7 @@ func main() {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 
-	logger.Info("hydro-fetcher service started", "update_interval", cfg.Hydro.UpdateInterval, "stations", len(cfg.Hydro.Stations()), "station_uuid", cfg.Hydro.StationUUID)
+	logger.Info("hydro-fetcher service started", "update_interval", cfg.Hydro.UpdateInterval, "stations", len(cfg.Hydro.Stations()), "monitored", cfg.Hydro.MonitoredStations, "station_uuid", cfg.Hydro.StationUUID)
 	for {
 		select {
 		case <-ticker.C:
@@ -83,10 +83,11 @@ func main() {
 }
 
 type Fetcher struct {
-	logger *slog.Logger
-	client *emercit.Client
-	repo   repository.HydroRepository
-	config config.HydroConfig
+	logger   *slog.Logger
+	client   *emercit.Client
+	repo     repository.HydroRepository
+	config   config.HydroConfig
+	location config.LocationConfig
 }
 
 func (f *Fetcher) FetchAndSave(ctx context.Context) error {
@@ -99,7 +100,8 @@ func (f *Fetcher) FetchAndSave(ctx context.Context) error {
 	}
 
 	stations := f.config.Stations()
-	readings := make([]models.HydroLevelReading, 0, len(stations))
+	monitored := f.monitoredStations(actual, stations)
+	readings := make([]models.HydroLevelReading, 0, len(stations)+len(monitored))
 	savedGauges := 0
 	from := now.Add(-time.Duration(f.config.HistoryHours) * time.Hour)
 
@@ -149,6 +151,28 @@ func (f *Fetcher) FetchAndSave(ctx context.Context) error {
 		}
 	}
 
+	// Для постов обзорной карты хватает текущего значения на каждом опросе:
+	// запрашивать историю по десяткам постов каждые 10 минут незачем, пропуски закрывает hydro-backfill.
+	for _, stationRef := range monitored {
+		station := actual[stationRef.StationUUID]
+		mch, ok := station.MCHs["waterlevel"][stationRef.WaterLevelUUID]
+		if !ok {
+			f.logger.Warn("skipping monitored hydro station", "station_uuid", stationRef.StationUUID, "waterlevel_uuid", stationRef.WaterLevelUUID)
+			continue
+		}
+		if err := f.repo.SaveGauge(ctx, buildGauge(stationRef.StationUUID, stationRef.WaterLevelUUID, station, mch, now)); err != nil {
+			f.logger.Warn("failed to save monitored hydro gauge", "station_uuid", stationRef.StationUUID, "error", err)
+			continue
+		}
+		savedGauges++
+
+		if reading, err := buildActualReading(stationRef.StationUUID, stationRef.WaterLevelUUID, mch, now); err != nil {
+			f.logger.Warn("failed to build actual reading", "station_uuid", stationRef.StationUUID, "error", err)
+		} else if reading != nil {
+			readings = append(readings, *reading)
+		}
+	}
+
 	if err := f.repo.SaveReadingsBatch(ctx, readings); err != nil {
 		return err
 	}
@@ -164,6 +188,39 @@ func (f *Fetcher) FetchAndSave(ctx context.Context) error {
 	return nil
 }
 
+// monitoredStations — дополнительные посты из HYDRO_MONITORED_STATIONS, кроме уже настроенных.
+// Для all посты отбираются по расстоянию от метеостанции; пропущенный канал уровня берётся из ответа источника.
+func (f *Fetcher) monitoredStations(actual emercit.ActualResponse, configured []config.HydroStationRef) []config.HydroStationRef {
+	seen := make(map[string]bool, len(configured))
+	for _, ref := range configured {
+		seen[ref.StationUUID] = true
+	}
+	var out []config.HydroStationRef
+	add := func(ref config.HydroStationRef) {
+		if seen[ref.StationUUID] {
+			return
+		}
+		if ref.WaterLevelUUID == "" {
+			ref.WaterLevelUUID = actual[ref.StationUUID].WaterLevelUUID()
+		}
+		if ref.WaterLevelUUID == "" {
+			f.logger.Warn("monitored hydro station has no waterlevel channel", "station_uuid", ref.StationUUID)
+			return
+		}
+		seen[ref.StationUUID] = true
+		out = append(out, ref)
+	}
+	if f.config.MonitoredAll() {
+		for _, ref := range actual.WaterLevelStations(f.location.Latitude, f.location.Longitude, f.config.MonitoredRadiusKm) {
+			add(config.HydroStationRef{StationUUID: ref.StationUUID, WaterLevelUUID: ref.WaterLevelUUID})
+		}
+	}
+	for _, ref := range f.config.MonitoredStationRefs() {
+		add(ref)
+	}
+	return out
+}
+
 func buildGauge(stationUUID, waterLevelUUID string, st emercit.Station, mch emercit.MCH, fetchedAt time.Time) *models.HydroGauge {
 	return &models.HydroGauge{
 		StationUUID:          stationUUID,
@@ -212,25 +269,15 @@ func buildActualReading(stationUUID, waterLevelUUID string, mch emercit.MCH, fet
 }
 
 func buildHistoryReadings(stationUUID, waterLevelUUID string, history emercit.HistoryResponse, fetchedAt time.Time) []models.HydroLevelReading {
-	series, ok := history[waterLevelUUID]
-	if !ok {
-		return nil
-	}
-	out := make([]models.HydroLevelReading, 0, len(series.Values))
-	for _, v := range series.Values {
-		if v.BS == nil || v.Time == "" {
-			continue
-		}
-		observedAt, err := emercit.ParseTime(v.Time)
-		if err != nil {
-			continue
-		}
+	points := history.Points(waterLevelUUID)
+	out := make([]models.HydroLevelReading, 0, len(points))
+	for _, p := range points {
 		out = append(out, models.HydroLevelReading{
 			StationUUID:    stationUUID,
 			WaterLevelUUID: waterLevelUUID,
-			ObservedAt:     observedAt,
-			LevelBSM:       float32(*v.BS),
-			LevelZeroM:     emercit.Float32Ptr(v.Zero),
+			ObservedAt:     p.ObservedAt,
+			LevelBSM:       float32(p.BS),
+			LevelZeroM:     emercit.Float32Ptr(p.Zero),
 			FetchedAt:      fetchedAt,
 		})
 	}
@@ -57,11 +57,19 @@ func main() {
 	notifRepo := repository.NewMaxNotificationRepository(pool)
 
 	weatherService := service.NewWeatherService(weatherRepo)
+	if cfg.Normals.Enabled() {
+		weatherService.SetClimateNormals(repository.NewClimateNormalsRepository(pool), cfg.Normals.Station, cfg.Normals.Period)
+	}
 	forecastService := service.NewForecastService(forecastRepo)
+	if cfg.Forecast.BiasCorrection {
+		forecastService.SetBiasCorrector(service.NewForecastBiasCorrector(forecastRepo, cfg.Forecast.BiasMinSamples))
+	}
 	sunService, err := service.NewSunService(cfg.Location.Latitude, cfg.Location.Longitude, cfg.Location.Timezone)
 	if err != nil {
 		log.Fatalf("failed to create sun service: %v", err)
 	}
+	sunService.SetElevation(cfg.Location.Elevation)
+	weatherService.SetSunshine(sunService)
 	geomagneticService := service.NewGeomagneticService(geomagRepo, cfg.Geomagnetic.AlertThreshold)
 
 	client := maxbot.NewClient(cfg.Max.Token, time.Duration(cfg.Max.UpdateTimeout+10)*time.Second)
@@ -75,13 +83,40 @@ func main() {
 	}
 
 	handler := maxbot.NewBotHandler(client, weatherService, forecastService, userRepo, subRepo, logger)
-	notifier := maxbot.NewNotifier(client, weatherService, subRepo, notifRepo, userRepo, cfg.Max.NotifyInterval, logger)
-	dailySummary := maxbot.NewDailySummaryService(client, weatherService, sunService, geomagneticService, subRepo, cfg.Max.DailySummaryTime, logger)
+	rainNowcast := service.NewRainNowcastService(weatherService, forecastService)
+	frostRisk := service.NewFrostRiskService(weatherService, forecastService)
+	var hydroAlerts *service.HydroAlertService
+	if cfg.Hydro.Enabled {
+		hydroService := service.NewHydroService(repository.NewHydroRepository(pool), cfg.Hydro.StationUUID, cfg.Hydro.ZeroPostBSM, cfg.Hydro.UpstreamStationUUIDs()...)
+		hydroAlerts = service.NewHydroAlertService(hydroService, service.HydroAlertSettings{
+			RiseCmPerHour:       cfg.Hydro.AlertRiseCmPerHour,
+			UpstreamRiseCm:      cfg.Hydro.AlertUpstreamRiseCm,
+			UpstreamWindowHours: cfg.Hydro.AlertUpstreamWindowHours,
+			HysteresisCm:        cfg.Hydro.AlertHysteresisCm,
+		})
+	}
+	notifier := maxbot.NewNotifier(client, weatherService, subRepo, notifRepo, userRepo, rainNowcast, frostRisk, hydroAlerts, cfg.Max.NotifyInterval, logger)
+	clothingRules, err := service.LoadClothingRules(cfg.Clothing.RulesFile)
+	if err != nil {
+		log.Fatalf("failed to load clothing rules: %v", err)
+	}
+	clothingService := service.NewClothingService(weatherService, forecastService, clothingRules)
+	dailySummary := maxbot.NewDailySummaryService(client, weatherService, sunService, geomagneticService, clothingService, subRepo, cfg.Max.DailySummaryTime, logger)
+	agroService := service.NewAgroService(weatherService, forecastService, service.AgroSettings{
+		GDDBase:         cfg.Agro.GDDBaseTemp,
+		CropCoefficient: cfg.Agro.CropCoefficient,
+		SoilWaterMM:     cfg.Agro.SoilWaterMM,
+		Latitude:        cfg.Location.Latitude,
+		Elevation:       cfg.Location.Elevation,
+		WindHeight:      cfg.Agro.WindHeight,
+	})
+	gardenWeekly := maxbot.NewGardenWeeklyService(client, agroService, subRepo, cfg.Agro.Weekday(), cfg.Agro.WeeklyTime, logger)
 
 	runCtx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	go notifier.Start(runCtx)
 	go dailySummary.Start(runCtx)
+	go gardenWeekly.Start(runCtx)
 
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
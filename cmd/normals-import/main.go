@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/iRootPro/weather/internal/config"
+	"github.com/iRootPro/weather/internal/models"
+	"github.com/iRootPro/weather/internal/repository"
+	"github.com/iRootPro/weather/pkg/climatenormals"
+	"github.com/iRootPro/weather/pkg/database"
+)
+
+func main() {
+	file := flag.String("file", "", "CSV с нормами: month,day,temp_avg,temp_min,temp_max,precipitation")
+	station := flag.String("station", "", "индекс метеостанции (по умолчанию NORMALS_STATION)")
+	period := flag.String("period", "", "базовый период норм (по умолчанию NORMALS_PERIOD)")
+	flag.Parse()
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	cfg, err := config.Load()
+	if err != nil {
+		logger.Error("failed to load config", "error", err)
+		os.Exit(1)
+	}
+	if *station == "" {
+		*station = cfg.Normals.Station
+	}
+	if *period == "" {
+		*period = cfg.Normals.Period
+	}
+	if *file == "" || *station == "" {
+		logger.Error("normals file and station are required", "file", *file, "station", *station)
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		logger.Error("failed to open normals file", "error", err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	records, err := climatenormals.Parse(f)
+	if err != nil {
+		logger.Error("failed to parse normals file", "file", *file, "error", err)
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	pool, err := database.NewPostgresPool(ctx, cfg.DB.DSN())
+	if err != nil {
+		logger.Error("failed to connect to database", "error", err)
+		os.Exit(1)
+	}
+	defer pool.Close()
+
+	normals := buildNormals(records, *station, *period, time.Now())
+	if err := repository.NewClimateNormalsRepository(pool).SaveBatch(ctx, normals); err != nil {
+		logger.Error("failed to save normals", "error", err)
+		os.Exit(1)
+	}
+
+	monthly := 0
+	for _, n := range normals {
+		if n.Day == 0 {
+			monthly++
+		}
+	}
+	logger.Info("climate normals imported",
+		"station", *station,
+		"period", *period,
+		"monthly", monthly,
+		"daily", len(normals)-monthly,
+	)
+	if monthly < 12 {
+		logger.Warn("not all monthly normals are present; days without a daily normal in those months will not be compared", "monthly", monthly)
+	}
+}
+
+func buildNormals(records []climatenormals.Record, station, period string, importedAt time.Time) []models.ClimateNormal {
+	normals := make([]models.ClimateNormal, 0, len(records))
+	for _, r := range records {
+		normals = append(normals, models.ClimateNormal{
+			StationCode:   station,
+			Period:        period,
+			Month:         r.Month,
+			Day:           r.Day,
+			TempAvg:       float32Ptr(r.TempAvg),
+			TempMin:       float32Ptr(r.TempMin),
+			TempMax:       float32Ptr(r.TempMax),
+			Precipitation: float32Ptr(r.Precipitation),
+			ImportedAt:    importedAt,
+		})
+	}
+	return normals
+}
+
+func float32Ptr(v *float64) *float32 {
+	if v == nil {
+		return nil
+	}
+	f := float32(*v)
+	return &f
+}
@@ -67,11 +67,22 @@ func main() {
 
 	// Инициализация сервисов
 	weatherService := service.NewWeatherService(weatherRepo)
+	if cfg.Reanalysis.Baseline {
+		weatherService.SetReanalysisBaseline(repository.NewReanalysisRepository(pool), cfg.Reanalysis.Model, cfg.Reanalysis.BaselineYears)
+	}
+	if cfg.Normals.Enabled() {
+		weatherService.SetClimateNormals(repository.NewClimateNormalsRepository(pool), cfg.Normals.Station, cfg.Normals.Period)
+	}
 	forecastService := service.NewForecastService(forecastRepo)
+	if cfg.Forecast.BiasCorrection {
+		forecastService.SetBiasCorrector(service.NewForecastBiasCorrector(forecastRepo, cfg.Forecast.BiasMinSamples))
+	}
 	sunService, err := service.NewSunService(cfg.Location.Latitude, cfg.Location.Longitude, cfg.Location.Timezone)
 	if err != nil {
 		log.Fatalf("failed to create sun service: %v", err)
 	}
+	sunService.SetElevation(cfg.Location.Elevation)
+	weatherService.SetSunshine(sunService)
 
 	// IPGeolocation client для точных данных о луне
 	var astronomyClient *ipgeolocation.Client
@@ -86,6 +97,19 @@ func main() {
 	}
 
 	geomagneticService := service.NewGeomagneticService(geomagRepo, cfg.Geomagnetic.AlertThreshold)
+	clothingRules, err := service.LoadClothingRules(cfg.Clothing.RulesFile)
+	if err != nil {
+		log.Fatalf("failed to load clothing rules: %v", err)
+	}
+	clothingService := service.NewClothingService(weatherService, forecastService, clothingRules)
+	activityService := service.NewActivityService(weatherService, forecastService, moonService, service.ActivitySettings{
+		Activities: cfg.Activity.Activities,
+		Hours:      cfg.Activity.Hours,
+	})
+	uvService := service.NewUVService(weatherService, forecastService, service.UVSettings{
+		AlertMinutes:    cfg.UV.AlertBurnMinutes,
+		DefaultSkinType: cfg.UV.DefaultSkinType,
+	})
 
 	slog.Info("services initialized")
 
@@ -106,6 +130,10 @@ func main() {
 		moonService,
 		forecastService,
 		geomagneticService,
+		activityService,
+		clothingService,
+		uvService,
+		cfg.PV.Enabled(),
 		userRepo,
 		subRepo,
 		notifRepo,
@@ -116,6 +144,32 @@ func main() {
 		logger,
 	)
 
+	heatingService := service.NewHeatingService(weatherService, forecastService, service.HeatingSettings{
+		BaseTemp:        &cfg.Heating.BaseTemp,
+		CoolingBaseTemp: &cfg.Heating.CoolingBaseTemp,
+		ThresholdTemp:   &cfg.Heating.ThresholdTemp,
+		ThresholdDays:   cfg.Heating.ThresholdDays,
+	})
+
+	// Уведомления об уровне реки и прогноз по верхним постам для раннего предупреждения о паводке
+	var hydroForecastService *service.HydroForecastService
+	var hydroAlerts *service.HydroAlertService
+	if cfg.Hydro.Enabled {
+		hydroService := service.NewHydroService(repository.NewHydroRepository(pool), cfg.Hydro.StationUUID, cfg.Hydro.ZeroPostBSM, cfg.Hydro.UpstreamStationUUIDs()...)
+		hydroForecastService = service.NewHydroForecastService(hydroService, service.HydroForecastSettings{
+			TrainingDays:   cfg.Hydro.ForecastTrainingDays,
+			MaxLagHours:    cfg.Hydro.ForecastMaxLagHours,
+			Hours:          cfg.Hydro.ForecastHours,
+			MinCorrelation: cfg.Hydro.ForecastMinCorrelation,
+		})
+		hydroAlerts = service.NewHydroAlertService(hydroService, service.HydroAlertSettings{
+			RiseCmPerHour:       cfg.Hydro.AlertRiseCmPerHour,
+			UpstreamRiseCm:      cfg.Hydro.AlertUpstreamRiseCm,
+			UpstreamWindowHours: cfg.Hydro.AlertUpstreamWindowHours,
+			HysteresisCm:        cfg.Hydro.AlertHysteresisCm,
+		})
+	}
+
 	// Создание notifier
 	notifier := telegram.NewNotifier(
 		bot,
@@ -125,6 +179,29 @@ func main() {
 		userRepo,
 		geomagRepo,
 		cfg.Geomagnetic.AlertThreshold,
+		service.NewRainNowcastService(weatherService, forecastService),
+		service.NewFrostRiskService(weatherService, forecastService),
+		heatingService,
+		service.NewIndoorClimateService(weatherService, heatingService, service.IndoorSettings{
+			ComfortTempMin:     cfg.Indoor.ComfortTempMin,
+			ComfortTempMax:     cfg.Indoor.ComfortTempMax,
+			ComfortHumidityMin: cfg.Indoor.ComfortHumidityMin,
+			ComfortHumidityMax: cfg.Indoor.ComfortHumidityMax,
+			MoldHumidity:       cfg.Indoor.MoldHumidity,
+			DryHumidity:        cfg.Indoor.DryHumidity,
+			WallFactor:         cfg.Indoor.WallFactor,
+		}),
+		service.NewDroughtService(weatherService, service.DroughtSettings{
+			SPIThreshold: cfg.Drought.SPIThreshold,
+			GTKThreshold: cfg.Drought.GTKThreshold,
+			DrySpellDays: cfg.Drought.DrySpellDays,
+		}),
+		uvService,
+		service.NewAuroraService(geomagneticService, sunService, moonService, forecastService, service.AuroraSettings{
+			AlertProbability: cfg.Aurora.AlertProbability,
+		}),
+		hydroForecastService,
+		hydroAlerts,
 		cfg.Telegram.NotifyInterval,
 		logger,
 	)
@@ -136,12 +213,60 @@ func main() {
 		sunService,
 		forecastService,
 		geomagneticService,
+		clothingService,
 		subRepo,
 		userRepo,
 		cfg.Telegram.DailySummaryTime,
 		logger,
 	)
 
+	// Еженедельная сводка «Огород»
+	agroService := service.NewAgroService(weatherService, forecastService, service.AgroSettings{
+		GDDBase:         cfg.Agro.GDDBaseTemp,
+		CropCoefficient: cfg.Agro.CropCoefficient,
+		SoilWaterMM:     cfg.Agro.SoilWaterMM,
+		Latitude:        cfg.Location.Latitude,
+		Elevation:       cfg.Location.Elevation,
+		WindHeight:      cfg.Agro.WindHeight,
+	})
+	gardenWeekly := telegram.NewGardenWeeklyService(
+		bot,
+		agroService,
+		subRepo,
+		cfg.Agro.Weekday(),
+		cfg.Agro.WeeklyTime,
+		logger,
+	)
+
+	// Утренний индекс метеочувствительности
+	meteoDaily := telegram.NewMeteoDailyService(
+		bot,
+		service.NewMeteoSensitivityService(weatherService, forecastService, geomagneticService),
+		subRepo,
+		cfg.Meteo.SendTime,
+		logger,
+	)
+
+	// Ежемесячный отчёт о выработке солнечных панелей (если задана мощность)
+	var pvMonthly *telegram.PVMonthlyService
+	if cfg.PV.Enabled() {
+		pvService := service.NewPVService(weatherService, sunService, service.PVSettings{
+			CapacityKWp:     cfg.PV.CapacityKWp,
+			Tilt:            cfg.PV.Tilt,
+			Azimuth:         cfg.PV.Azimuth,
+			LossesPct:       cfg.PV.LossesPct,
+			TempCoefficient: &cfg.PV.TempCoefficient,
+		})
+		pvMonthly = telegram.NewPVMonthlyService(
+			bot,
+			pvService,
+			subRepo,
+			cfg.PV.MonthlyDay,
+			cfg.PV.MonthlyTime,
+			logger,
+		)
+	}
+
 	// Контекст с поддержкой отмены
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -152,6 +277,17 @@ func main() {
 	// Запуск daily summary service в фоне
 	go dailySummary.Start(ctx)
 
+	// Запуск еженедельной сводки «Огород» в фоне
+	go gardenWeekly.Start(ctx)
+
+	// Запуск утреннего индекса метеочувствительности в фоне
+	go meteoDaily.Start(ctx)
+
+	// Запуск отчёта о выработке панелей в фоне
+	if pvMonthly != nil {
+		go pvMonthly.Start(ctx)
+	}
+
 	// Настройка Long Polling
 	u := tgbotapi.NewUpdate(0)
 	u.Timeout = cfg.Telegram.UpdateTimeout
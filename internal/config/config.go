@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 	"github.com/joho/godotenv"
@@ -23,12 +24,26 @@ type Config struct {
 	Astronomy   AstronomyConfig   `yaml:"astronomy"`
 	Geomagnetic GeomagneticConfig `yaml:"geomagnetic"`
 	Hydro       HydroConfig       `yaml:"hydro"`
+	Agro        AgroConfig        `yaml:"agro"`
+	Reanalysis  ReanalysisConfig  `yaml:"reanalysis"`
+	Normals     NormalsConfig     `yaml:"normals"`
+	Climatology ClimatologyConfig `yaml:"climatology"`
+	Heating     HeatingConfig     `yaml:"heating"`
+	Indoor      IndoorConfig      `yaml:"indoor"`
+	PV          PVConfig          `yaml:"pv"`
+	Drought     DroughtConfig     `yaml:"drought"`
+	Meteo       MeteoConfig       `yaml:"meteo"`
+	Activity    ActivityConfig    `yaml:"activity"`
+	Clothing    ClothingConfig    `yaml:"clothing"`
+	UV          UVConfig          `yaml:"uv"`
+	Aurora      AuroraConfig      `yaml:"aurora"`
 }
 
 type LocationConfig struct {
 	Latitude  float64 `env:"LOCATION_LATITUDE" env-default:"44.995574"`
 	Longitude float64 `env:"LOCATION_LONGITUDE" env-default:"41.128354"`
 	Timezone  string  `env:"LOCATION_TIMEZONE" env-default:"Europe/Moscow"`
+	Elevation float64 `env:"LOCATION_ELEVATION" env-default:"158"` // высота над уровнем моря, м
 }
 
 type DBConfig struct {
@@ -98,10 +113,31 @@ type MaxConfig struct {
 }
 
 type ForecastConfig struct {
-	UpdateInterval int `env:"FORECAST_UPDATE_INTERVAL" env-default:"3600"` // секунды (по умолчанию 1 час)
-	HourlyHours    int `env:"FORECAST_HOURLY_HOURS" env-default:"48"`      // сколько часов вперед получать почасовой прогноз
-	DailyDays      int `env:"FORECAST_DAILY_DAYS" env-default:"7"`         // сколько дней вперед получать дневной прогноз
-	APITimeout     int `env:"FORECAST_API_TIMEOUT" env-default:"30"`       // таймаут API запросов в секундах
+	UpdateInterval int  `env:"FORECAST_UPDATE_INTERVAL" env-default:"3600"` // секунды (по умолчанию 1 час)
+	HourlyHours    int  `env:"FORECAST_HOURLY_HOURS" env-default:"48"`      // сколько часов вперед получать почасовой прогноз
+	DailyDays      int  `env:"FORECAST_DAILY_DAYS" env-default:"7"`         // сколько дней вперед получать дневной прогноз
+	APITimeout     int  `env:"FORECAST_API_TIMEOUT" env-default:"30"`       // таймаут API запросов в секундах
+	BiasCorrection bool `env:"FORECAST_BIAS_CORRECTION" env-default:"true"` // коррекция прогноза по данным станции
+	BiasMinSamples int  `env:"FORECAST_BIAS_MIN_SAMPLES" env-default:"30"`  // минимум пар «прогноз — наблюдение» на час и сезон
+
+	EnsembleModels     string `env:"FORECAST_ENSEMBLE_MODELS" env-default:"icon_seamless,ecmwf_ifs025,gfs_seamless"` // модели Open-Meteo для ансамбля через запятую
+	MetNorwayEnabled   bool   `env:"FORECAST_METNO_ENABLED" env-default:"true"`                                      // MET Norway Locationforecast в ансамбле
+	MetNorwayUserAgent string `env:"FORECAST_METNO_USER_AGENT" env-default:""`                                       // User-Agent для api.met.no (обязателен по правилам API)
+}
+
+// EnsembleModelList возвращает модели Open-Meteo для ансамбля без пустых и повторов
+func (c ForecastConfig) EnsembleModelList() []string {
+	seen := map[string]bool{}
+	out := make([]string, 0)
+	for _, item := range strings.Split(c.EnsembleModels, ",") {
+		model := strings.TrimSpace(item)
+		if model == "" || model == "best_match" || seen[model] {
+			continue
+		}
+		seen[model] = true
+		out = append(out, model)
+	}
+	return out
 }
 
 type NarodmonConfig struct {
@@ -126,6 +162,13 @@ type GeomagneticConfig struct {
 	APITimeout     int     `env:"GEOMAGNETIC_API_TIMEOUT" env-default:"30"`                       // Таймаут HTTP запроса (секунды)
 	AlertThreshold float32 `env:"GEOMAGNETIC_ALERT_THRESHOLD" env-default:"5"`                    // Порог Kp для Telegram-алерта (G1=5)
 	ProxyURL       string  `env:"GEOMAGNETIC_HTTPS_PROXY" env-default:""`                         // Опциональный HTTPS прокси для xras.ru
+	// Источники Kp в порядке приоритета: xras, swpc. Если один недоступен, данные берутся из остальных
+	Sources         []string `env:"GEOMAGNETIC_SOURCES" env-separator:"," env-default:"xras,swpc"`
+	SWPCKpURL       string   `env:"GEOMAGNETIC_SWPC_KP_URL" env-default:"https://services.swpc.noaa.gov/products/noaa-planetary-k-index.json"`                // Наблюдённый Kp NOAA SWPC
+	SWPCForecastURL string   `env:"GEOMAGNETIC_SWPC_FORECAST_URL" env-default:"https://services.swpc.noaa.gov/products/noaa-planetary-k-index-forecast.json"` // Прогноз Kp NOAA SWPC на 3 суток
+	ForecastMerge   string   `env:"GEOMAGNETIC_FORECAST_MERGE" env-default:"average"`                                                                         // Прогноз из нескольких источников: average — среднее, prefer — первый источник
+	// Суточные F10.7, Sn и Ap хранятся дольше слотов Kp — для долгосрочных графиков солнечной активности
+	DailyRetentionDays int `env:"GEOMAGNETIC_DAILY_RETENTION_DAYS" env-default:"1095"`
 }
 
 type HydroConfig struct {
@@ -141,6 +184,128 @@ type HydroConfig struct {
 	HistoryHours     int     `env:"HYDRO_HISTORY_HOURS" env-default:"48"`
 	RetentionDays    int     `env:"HYDRO_RETENTION_DAYS" env-default:"365"`
 	ZeroPostBSM      float32 `env:"HYDRO_ZERO_POST_BS_M" env-default:"168.98"` // отметка нуля поста по AllRivers, м БСВ
+
+	MonitoredStations string  `env:"HYDRO_MONITORED_STATIONS" env-default:""`     // посты для обзорной карты: all или station_uuid[:waterlevel_uuid] через запятую
+	MonitoredRadiusKm float64 `env:"HYDRO_MONITORED_RADIUS_KM" env-default:"150"` // для all: посты не дальше от метеостанции, км; 0 — без ограничения
+
+	ForecastTrainingDays   int     `env:"HYDRO_FORECAST_TRAINING_DAYS" env-default:"30"`    // окно обучения связей с верхними постами, сут
+	ForecastMaxLagHours    int     `env:"HYDRO_FORECAST_MAX_LAG_HOURS" env-default:"48"`    // максимальное время добегания, ч
+	ForecastHours          int     `env:"HYDRO_FORECAST_HOURS" env-default:"24"`            // горизонт прогноза уровня, ч
+	ForecastMinCorrelation float64 `env:"HYDRO_FORECAST_MIN_CORRELATION" env-default:"0.3"` // пост со слабее связью в прогнозе не участвует
+
+	AlertRiseCmPerHour       float32 `env:"HYDRO_ALERT_RISE_CM_PER_HOUR" env-default:"5"`      // быстрый подъём на основном посту, см/ч
+	AlertUpstreamRiseCm      float32 `env:"HYDRO_ALERT_UPSTREAM_RISE_CM" env-default:"30"`     // подъём верхнего поста за окно, см
+	AlertUpstreamWindowHours int     `env:"HYDRO_ALERT_UPSTREAM_WINDOW_HOURS" env-default:"6"` // окно подъёма верхнего поста, ч
+	AlertHysteresisCm        float32 `env:"HYDRO_ALERT_HYSTERESIS_CM" env-default:"5"`         // статус снижается, когда уровень ниже порога на столько, см
+
+	RainResponseDays      int     `env:"HYDRO_RAIN_RESPONSE_DAYS" env-default:"365"`      // история дождей и уровня для анализа отклика, сут
+	RainResponseHours     int     `env:"HYDRO_RAIN_RESPONSE_HOURS" env-default:"72"`      // сколько часов после дождя искать пик уровня
+	RainResponseMinRainMM float64 `env:"HYDRO_RAIN_RESPONSE_MIN_RAIN_MM" env-default:"5"` // дожди слабее не разбираются, мм
+}
+
+type AgroConfig struct {
+	GDDBaseTemp     float64 `env:"AGRO_GDD_BASE_TEMP" env-default:"10"`     // базовая температура для градусо-дней, °C
+	CropCoefficient float64 `env:"AGRO_CROP_COEFFICIENT" env-default:"1.0"` // Kc: расход воды культурой относительно ET0
+	SoilWaterMM     float64 `env:"AGRO_SOIL_WATER_MM" env-default:"60"`     // доступная влагоёмкость корнеобитаемого слоя, мм
+	WindHeight      float64 `env:"AGRO_WIND_HEIGHT" env-default:"2"`        // высота анемометра над землёй, м
+	WeeklyDay       string  `env:"AGRO_WEEKLY_DAY" env-default:"friday"`    // день недели для сообщения «Огород»
+	WeeklyTime      string  `env:"AGRO_WEEKLY_TIME" env-default:"18:00"`    // время отправки сообщения «Огород»
+}
+
+// Weekday возвращает день недели еженедельного сообщения (по умолчанию пятница)
+func (c AgroConfig) Weekday() time.Weekday {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(strings.TrimSpace(c.WeeklyDay), d.String()) {
+			return d
+		}
+	}
+	return time.Friday
+}
+
+type ReanalysisConfig struct {
+	Baseline      bool   `env:"REANALYSIS_BASELINE" env-default:"true"`         // показывать норму по реанализу в архиве и инсайтах
+	BaselineYears int    `env:"REANALYSIS_BASELINE_YEARS" env-default:"30"`     // сколько лет реанализа брать для нормы
+	Model         string `env:"REANALYSIS_MODEL" env-default:"era5"`            // модель исторического архива Open-Meteo
+	StartDate     string `env:"REANALYSIS_START_DATE" env-default:"1991-01-01"` // с какой даты загружать архив при первом запуске
+	APITimeout    int    `env:"REANALYSIS_API_TIMEOUT" env-default:"120"`       // таймаут запроса к архиву в секундах
+}
+
+type NormalsConfig struct {
+	Station string `env:"NORMALS_STATION" env-default:""`         // индекс метеостанции Росгидромета с нормами; пусто — аномалии выключены
+	Period  string `env:"NORMALS_PERIOD" env-default:"1991-2020"` // базовый период норм
+}
+
+// Enabled сообщает, настроено ли сравнение с официальными нормами
+func (c NormalsConfig) Enabled() bool {
+	return c.Station != ""
+}
+
+type ClimatologyConfig struct {
+	Enabled        bool    `env:"CLIMATOLOGY_ENABLED" env-default:"true"`       // события unusual по климатологии станции
+	WindowDays     int     `env:"CLIMATOLOGY_WINDOW_DAYS" env-default:"7"`      // ± дней вокруг даты для выборки
+	LowPercentile  float64 `env:"CLIMATOLOGY_LOW_PERCENTILE" env-default:"5"`   // ниже — необычно низкое значение
+	HighPercentile float64 `env:"CLIMATOLOGY_HIGH_PERCENTILE" env-default:"95"` // выше — необычно высокое значение
+	MinYears       int     `env:"CLIMATOLOGY_MIN_YEARS" env-default:"2"`        // минимум лет истории для выводов
+}
+
+type HeatingConfig struct {
+	BaseTemp        float64 `env:"HEATING_BASE_TEMP" env-default:"18"`         // база градусо-суток отопления, °C
+	CoolingBaseTemp float64 `env:"HEATING_COOLING_BASE_TEMP" env-default:"18"` // база градусо-суток охлаждения, °C
+	ThresholdTemp   float64 `env:"HEATING_THRESHOLD_TEMP" env-default:"8"`     // порог среднесуточной температуры отопительного сезона, °C
+	ThresholdDays   int     `env:"HEATING_THRESHOLD_DAYS" env-default:"5"`     // суток подряд ниже (выше) порога для начала (окончания)
+}
+
+type IndoorConfig struct {
+	ComfortTempMin     float64 `env:"INDOOR_COMFORT_TEMP_MIN" env-default:"20"`     // нижняя граница комфортной температуры, °C
+	ComfortTempMax     float64 `env:"INDOOR_COMFORT_TEMP_MAX" env-default:"24"`     // верхняя граница комфортной температуры, °C
+	ComfortHumidityMin float64 `env:"INDOOR_COMFORT_HUMIDITY_MIN" env-default:"40"` // нижняя граница комфортной влажности, %
+	ComfortHumidityMax float64 `env:"INDOOR_COMFORT_HUMIDITY_MAX" env-default:"60"` // верхняя граница комфортной влажности, %
+	MoldHumidity       float64 `env:"INDOOR_MOLD_HUMIDITY" env-default:"70"`        // влажность, выше которой растёт риск плесени, %
+	DryHumidity        float64 `env:"INDOOR_DRY_HUMIDITY" env-default:"30"`         // влажность, ниже которой воздух сухой в отопительный сезон, %
+	WallFactor         float64 `env:"INDOOR_WALL_FACTOR" env-default:"0.7"`         // температурный фактор внутренней поверхности наружной стены
+}
+
+type DroughtConfig struct {
+	SPIThreshold float64 `env:"DROUGHT_SPI_THRESHOLD" env-default:"-1.5"` // SPI, при переходе ниже которого приходит предупреждение
+	GTKThreshold float64 `env:"DROUGHT_GTK_THRESHOLD" env-default:"0.7"`  // ГТК Селянинова за 30 суток для предупреждения
+	DrySpellDays int     `env:"DROUGHT_DRY_SPELL_DAYS" env-default:"20"`  // суток подряд без дождя для предупреждения
+}
+
+type MeteoConfig struct {
+	SendTime string `env:"METEO_SEND_TIME" env-default:"08:00"` // время утренней рассылки индекса метеочувствительности
+}
+
+type ActivityConfig struct {
+	Activities []string `env:"ACTIVITIES" env-separator:"," env-default:"running,cycling,fishing,barbecue,laundry"` // оцениваемые активности через запятую
+	Hours      int      `env:"ACTIVITIES_HOURS" env-default:"12"`                                                   // на сколько часов вперёд оценивать
+}
+
+type ClothingConfig struct {
+	RulesFile string `env:"CLOTHING_RULES_FILE"` // JSON с правилами советов по одежде (пусто — встроенные правила)
+}
+
+type UVConfig struct {
+	AlertBurnMinutes int `env:"UV_ALERT_BURN_MINUTES" env-default:"30"` // предупреждать, когда до покраснения кожи остаётся не больше, мин
+	DefaultSkinType  int `env:"UV_DEFAULT_SKIN_TYPE" env-default:"2"`   // фототип по Фицпатрику для пользователей, не указавших свой
+}
+
+type AuroraConfig struct {
+	AlertProbability int `env:"AURORA_ALERT_PROBABILITY" env-default:"10"` // шанс увидеть сияние этой ночью для уведомления, %
+}
+
+type PVConfig struct {
+	CapacityKWp     float64 `env:"PV_CAPACITY_KWP" env-default:"0"`        // пиковая мощность панелей, кВт (0 — расчёт выработки отключён)
+	Tilt            float64 `env:"PV_TILT" env-default:"30"`               // наклон панелей от горизонта, °
+	Azimuth         float64 `env:"PV_AZIMUTH" env-default:"180"`           // ориентация от севера по часовой стрелке, ° (180 — юг)
+	LossesPct       float64 `env:"PV_LOSSES" env-default:"14"`             // потери инвертора, проводов и загрязнения, %
+	TempCoefficient float64 `env:"PV_TEMP_COEFFICIENT" env-default:"-0.4"` // температурный коэффициент мощности, %/°C
+	MonthlyDay      int     `env:"PV_MONTHLY_DAY" env-default:"1"`         // день месяца для отчёта о выработке за прошлый месяц
+	MonthlyTime     string  `env:"PV_MONTHLY_TIME" env-default:"10:00"`    // время отправки отчёта о выработке
+}
+
+// Enabled сообщает, задана ли солнечная электростанция
+func (c PVConfig) Enabled() bool {
+	return c.CapacityKWp > 0
 }
 
 type HydroStationRef struct {
@@ -171,6 +336,32 @@ func (c HydroConfig) Stations() []HydroStationRef {
 	return stations
 }
 
+// MonitoredAll — отслеживать все посты источника с каналом уровня воды
+func (c HydroConfig) MonitoredAll() bool {
+	return strings.EqualFold(strings.TrimSpace(c.MonitoredStations), "all")
+}
+
+// MonitoredStationRefs — явно перечисленные дополнительные посты. Канал уровня может быть
+// не указан: его подставляет hydro-fetcher из ответа источника.
+func (c HydroConfig) MonitoredStationRefs() []HydroStationRef {
+	if c.MonitoredAll() {
+		return nil
+	}
+	var out []HydroStationRef
+	for _, item := range strings.Split(c.MonitoredStations, ",") {
+		parts := strings.Split(strings.TrimSpace(item), ":")
+		if len(parts) > 2 || strings.TrimSpace(parts[0]) == "" {
+			continue
+		}
+		ref := HydroStationRef{StationUUID: strings.TrimSpace(parts[0])}
+		if len(parts) == 2 {
+			ref.WaterLevelUUID = strings.TrimSpace(parts[1])
+		}
+		out = append(out, ref)
+	}
+	return out
+}
+
 func (c HydroConfig) UpstreamStationUUIDs() []string {
 	stations := c.Stations()
 	out := make([]string, 0, len(stations))
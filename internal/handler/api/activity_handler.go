@@ -0,0 +1,44 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/iRootPro/weather/internal/service"
+)
+
+type ActivityHandler struct {
+	activityService *service.ActivityService
+}
+
+func NewActivityHandler(activityService *service.ActivityService) *ActivityHandler {
+	return &ActivityHandler{activityService: activityService}
+}
+
+// GET /api/activities?activity=fishing
+func (h *ActivityHandler) GetActivities(w http.ResponseWriter, r *http.Request) {
+	key := r.URL.Query().Get("activity")
+	if key == "" {
+		forecast, err := h.activityService.GetForecast(r.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		respondJSON(w, forecast)
+		return
+	}
+
+	if _, _, ok := service.ActivityName(key); !ok {
+		http.Error(w, "unknown activity", http.StatusBadRequest)
+		return
+	}
+	activity, _, err := h.activityService.GetActivity(r.Context(), key)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if activity == nil {
+		http.Error(w, "activity not enabled", http.StatusNotFound)
+		return
+	}
+	respondJSON(w, activity)
+}
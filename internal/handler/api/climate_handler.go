@@ -0,0 +1,34 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/iRootPro/weather/internal/service"
+)
+
+type ClimateHandler struct {
+	weatherService *service.WeatherService
+}
+
+func NewClimateHandler(weatherService *service.WeatherService) *ClimateHandler {
+	return &ClimateHandler{weatherService: weatherService}
+}
+
+// GET /api/climate/anomaly?period=day|month|season&date=2026-10-17|2026-10|2026-autumn
+func (h *ClimateHandler) GetAnomaly(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	anomaly, err := h.weatherService.GetClimateAnomaly(r.Context(), query.Get("period"), query.Get("date"))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrClimateNormalsDisabled):
+			http.Error(w, "Climate normals not configured", http.StatusServiceUnavailable)
+		case errors.Is(err, service.ErrInvalidClimatePeriod):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	respondJSON(w, anomaly)
+}
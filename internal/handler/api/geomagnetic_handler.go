@@ -0,0 +1,41 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/iRootPro/weather/internal/service"
+)
+
+type GeomagneticHandler struct {
+	geomagneticService *service.GeomagneticService
+}
+
+func NewGeomagneticHandler(geomagneticService *service.GeomagneticService) *GeomagneticHandler {
+	return &GeomagneticHandler{geomagneticService: geomagneticService}
+}
+
+// GET /api/geomagnetic/daily?days=90
+func (h *GeomagneticHandler) GetDaily(w http.ResponseWriter, r *http.Request) {
+	if h.geomagneticService == nil {
+		http.Error(w, "Geomagnetic service not configured", http.StatusServiceUnavailable)
+		return
+	}
+	days := service.SOLAR_DEFAULT_DAYS
+	if v := r.URL.Query().Get("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > service.SOLAR_MAX_DAYS {
+			http.Error(w, "days must be 1-"+strconv.Itoa(service.SOLAR_MAX_DAYS), http.StatusBadRequest)
+			return
+		}
+		days = n
+	}
+
+	activity, err := h.geomagneticService.GetSolarActivity(r.Context(), time.Now(), days)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	respondJSON(w, activity)
+}
@@ -8,11 +8,13 @@ import (
 )
 
 type HydroHandler struct {
-	hydroService *service.HydroService
+	hydroService    *service.HydroService
+	forecastService *service.HydroForecastService
+	rainRiver       *service.RainRiverService
 }
 
-func NewHydroHandler(hydroService *service.HydroService) *HydroHandler {
-	return &HydroHandler{hydroService: hydroService}
+func NewHydroHandler(hydroService *service.HydroService, forecastService *service.HydroForecastService, rainRiver *service.RainRiverService) *HydroHandler {
+	return &HydroHandler{hydroService: hydroService, forecastService: forecastService, rainRiver: rainRiver}
 }
 
 func (h *HydroHandler) GetCurrent(w http.ResponseWriter, r *http.Request) {
@@ -45,3 +47,45 @@ func (h *HydroHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
 	}
 	respondJSON(w, data)
 }
+
+// GET /api/hydro/gauges
+func (h *HydroHandler) GetGauges(w http.ResponseWriter, r *http.Request) {
+	if h.hydroService == nil {
+		http.Error(w, "Hydro service not configured", http.StatusServiceUnavailable)
+		return
+	}
+	overview, err := h.hydroService.GetOverview(r.Context(), time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	respondJSON(w, overview)
+}
+
+// GET /api/hydro/forecast
+func (h *HydroHandler) GetForecast(w http.ResponseWriter, r *http.Request) {
+	if h.forecastService == nil {
+		http.Error(w, "Hydro forecast service not configured", http.StatusServiceUnavailable)
+		return
+	}
+	forecast, err := h.forecastService.GetForecast(r.Context(), time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	respondJSON(w, forecast)
+}
+
+// GET /api/hydro/rain-response
+func (h *HydroHandler) GetRainResponse(w http.ResponseWriter, r *http.Request) {
+	if h.rainRiver == nil {
+		http.Error(w, "Rain response service not configured", http.StatusServiceUnavailable)
+		return
+	}
+	analysis, err := h.rainRiver.GetAnalysis(r.Context(), time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	respondJSON(w, analysis)
+}
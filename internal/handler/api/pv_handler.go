@@ -0,0 +1,34 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/iRootPro/weather/internal/service"
+)
+
+type PVHandler struct {
+	pvService *service.PVService
+}
+
+func NewPVHandler(pvService *service.PVService) *PVHandler {
+	return &PVHandler{pvService: pvService}
+}
+
+// GET /api/pv?date=2026-10-17
+func (h *PVHandler) GetReport(w http.ResponseWriter, r *http.Request) {
+	if h.pvService == nil {
+		http.Error(w, "PV system not configured", http.StatusServiceUnavailable)
+		return
+	}
+	report, err := h.pvService.GetReport(r.Context(), r.URL.Query().Get("date"))
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidPVDate) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	respondJSON(w, report)
+}
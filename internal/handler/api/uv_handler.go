@@ -0,0 +1,40 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/iRootPro/weather/internal/models"
+	"github.com/iRootPro/weather/internal/service"
+)
+
+type UVHandler struct {
+	uvService *service.UVService
+}
+
+func NewUVHandler(uvService *service.UVService) *UVHandler {
+	return &UVHandler{uvService: uvService}
+}
+
+// GET /api/uv?skin_type=2
+func (h *UVHandler) GetExposure(w http.ResponseWriter, r *http.Request) {
+	skinType := 0
+	if v := r.URL.Query().Get("skin_type"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || !service.ValidSkinType(n) {
+			http.Error(w, "skin_type must be 1-6", http.StatusBadRequest)
+			return
+		}
+		skinType = n
+	}
+
+	exposure, err := h.uvService.GetExposure(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if skinType != 0 {
+		exposure.SkinTypes = []models.UVSkinType{exposure.SkinTypes[skinType-1]}
+	}
+	respondJSON(w, exposure)
+}
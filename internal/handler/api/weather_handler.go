@@ -2,6 +2,7 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strings"
 	"time"
@@ -112,6 +113,21 @@ func (h *WeatherHandler) GetEvents(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, events)
 }
 
+// GET /api/weather/onthisday?date=2026-10-18
+func (h *WeatherHandler) GetOnThisDay(w http.ResponseWriter, r *http.Request) {
+	onThisDay, err := h.weatherService.GetOnThisDay(r.Context(), r.URL.Query().Get("date"))
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidOnThisDayDate) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	respondJSON(w, onThisDay)
+}
+
 func parseTimeRange(r *http.Request) (time.Time, time.Time, error) {
 	fromStr := r.URL.Query().Get("from")
 	toStr := r.URL.Query().Get("to")
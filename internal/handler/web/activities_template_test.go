@@ -0,0 +1,39 @@
+package web
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+func TestActivitiesPartialRendersScores(t *testing.T) {
+	tmpl := loadPartial(t, "activities.html")
+
+	start := time.Date(2026, time.October, 19, 7, 0, 0, 0, time.UTC)
+	now := models.ActivityHour{Time: start, Score: 45, Rating: "так себе", Reasons: []string{"давление скачет"}, Observed: true}
+	best := models.ActivityHour{Time: start.Add(time.Hour), Score: 85, Rating: "отлично"}
+	change := -2.4
+	forecast := &models.ActivityForecast{
+		GeneratedAt:    start,
+		Hours:          12,
+		PressureChange: &change,
+		MoonPhase:      "Полнолуние",
+		Activities: []models.ActivityScore{{
+			Key: models.ActivityFishing, Name: "Рыбалка", Icon: "🎣",
+			Now: now, Best: best, Summary: "отлично с 08:00 до 09:00",
+			Hours: []models.ActivityHour{now, best},
+		}},
+	}
+
+	var output bytes.Buffer
+	if err := tmpl.Execute(&output, forecast); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	for _, want := range []string{"ближайшие 12 ч", "-2.4 мм за 3 ч", "Полнолуние", "Рыбалка", "отлично с 08:00 до 09:00", "сейчас · так себе", "07:00 — 45 (так себе), давление скачет"} {
+		if !bytes.Contains(output.Bytes(), []byte(want)) {
+			t.Fatalf("rendered widget is missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package web
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+func TestClothingPartialRendersWindows(t *testing.T) {
+	tmpl := loadPartial(t, "clothing.html")
+
+	day := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+	advice := &models.ClothingAdvice{
+		Date: day,
+		Windows: []models.ClothingWindow{
+			{Name: "Утро", From: day.Add(7 * time.Hour), To: day.Add(10 * time.Hour), FeelsLikeMin: -2, FeelsLikeMax: 1, Clothes: "тёплая куртка, свитер и шапка"},
+			{Name: "Вечер", From: day.Add(17 * time.Hour), To: day.Add(20 * time.Hour), FeelsLikeMin: 6, FeelsLikeMax: 6, Clothes: "демисезонная куртка или пальто", Extras: []string{"☂️ зонт"}},
+		},
+	}
+
+	var output bytes.Buffer
+	if err := tmpl.Execute(&output, advice); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	for _, want := range []string{"19 октября", "07:00–10:00", "ощущается -2…&#43;1°", "ощущается &#43;6°", "тёплая куртка, свитер и шапка", "☂️ зонт"} {
+		if !bytes.Contains(output.Bytes(), []byte(want)) {
+			t.Fatalf("rendered widget is missing %q", want)
+		}
+	}
+}
@@ -610,6 +610,16 @@ func (h *Handler) DetailRain(w http.ResponseWriter, r *http.Request) {
 		slog.Error("failed to get 30d chart data", "error", err)
 	}
 
+	// Drought indices: precipitation deficit, SPI and Selyaninov GTK
+	var drought *models.DroughtIndex
+	if h.droughtService != nil {
+		drought, err = h.droughtService.GetDroughtIndex(ctx)
+		if err != nil {
+			slog.Warn("failed to get drought index", "error", err)
+			drought = nil
+		}
+	}
+
 	// Prepare template data
 	templateData := struct {
 		ActivePage string
@@ -644,6 +654,10 @@ func (h *Handler) DetailRain(w http.ResponseWriter, r *http.Request) {
 			"Chart7d":   toJSON(prepareRainChartData(chart7d)),
 			"Chart30d":  toJSON(prepareRainChartData(chart30d)),
 			"HasCharts": len(chart24h) > 0,
+
+			// Drought
+			"Drought":      drought,
+			"DroughtChart": toJSON(prepareDroughtChartData(drought)),
 		},
 	}
 
@@ -713,6 +727,29 @@ func (h *Handler) DetailSolar(w http.ResponseWriter, r *http.Request) {
 		slog.Error("failed to get 30d chart data", "error", err)
 	}
 
+	// Sun position and sunshine duration against the clear-sky model
+	var sunPosition *models.SolarPosition
+	if h.sunService != nil {
+		position := h.sunService.GetSolarPosition(now)
+		sunPosition = &position
+	}
+	sunshineToday, err := h.weatherService.GetSunshineDay(ctx, now)
+	if err != nil {
+		slog.Warn("failed to get today sunshine", "error", err)
+	}
+	sunshineYesterday, err := h.weatherService.GetSunshineDay(ctx, now.AddDate(0, 0, -1))
+	if err != nil {
+		slog.Warn("failed to get yesterday sunshine", "error", err)
+	}
+
+	// Minutes to sunburn by skin type and today's UV dose curve
+	var uvExposure *models.UVExposure
+	if h.uvService != nil {
+		if uvExposure, err = h.uvService.GetExposure(ctx); err != nil {
+			slog.Warn("failed to get uv exposure", "error", err)
+		}
+	}
+
 	// Prepare template data
 	templateData := struct {
 		ActivePage string
@@ -743,6 +780,13 @@ func (h *Handler) DetailSolar(w http.ResponseWriter, r *http.Request) {
 			"RecordUV":        records.UVIndexMax.Value,
 			"RecordUVTime":    formatRussianDateTime(records.UVIndexMax.Time),
 
+			// Sunshine
+			"SunPosition":       sunPosition,
+			"SunshineToday":     sunshineToday,
+			"SunshineYesterday": sunshineYesterday,
+			"PVEnabled":         h.pvService != nil,
+			"UVExposure":        uvExposure,
+
 			// Chart data (as JSON)
 			"Chart24h":  toJSON(prepareSolarChartData(chart24h)),
 			"Chart7d":   toJSON(prepareSolarChartData(chart7d)),
@@ -926,6 +970,36 @@ func prepareRainChartData(data []models.WeatherData) map[string]interface{} {
 	}
 }
 
+func prepareDroughtChartData(index *models.DroughtIndex) map[string]interface{} {
+	if index == nil {
+		return map[string]interface{}{}
+	}
+	labels := make([]string, 0, len(index.Trend))
+	spi30 := make([]*float64, 0, len(index.Trend))
+	spi90 := make([]*float64, 0, len(index.Trend))
+	gtk30 := make([]*float64, 0, len(index.Trend))
+	rain30 := make([]*float64, 0, len(index.Trend))
+	normal30 := make([]*float64, 0, len(index.Trend))
+
+	for _, point := range index.Trend {
+		labels = append(labels, point.Date.Format("02.01"))
+		spi30 = append(spi30, point.SPI30)
+		spi90 = append(spi90, point.SPI90)
+		gtk30 = append(gtk30, point.GTK30)
+		rain30 = append(rain30, point.Rain30)
+		normal30 = append(normal30, point.Normal30)
+	}
+
+	return map[string]interface{}{
+		"labels":   labels,
+		"spi30":    spi30,
+		"spi90":    spi90,
+		"gtk30":    gtk30,
+		"rain30":   rain30,
+		"normal30": normal30,
+	}
+}
+
 func prepareSolarChartData(data []models.WeatherData) map[string]interface{} {
 	labels := make([]string, 0, len(data))
 	solarRadiation := make([]float64, 0, len(data))
@@ -0,0 +1,44 @@
+package web
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+func TestRainTemplateRendersDroughtSection(t *testing.T) {
+	tmpl := loadTemplate(t, "detail/rain.html")
+
+	date := time.Date(2026, time.August, 1, 0, 0, 0, 0, time.UTC)
+	lastRain := date.AddDate(0, 0, -25)
+	observed, normal, deficit, percent := 12.0, 60.0, 48.0, 20.0
+	spi, gtk := -2.1, 0.35
+	drought := &models.DroughtIndex{
+		Date:          date,
+		Baseline:      models.DroughtBaselineReanalysis,
+		BaselineLabel: "реанализ ERA5",
+		DryStreak:     25,
+		LastRain:      &lastRain,
+		Deficits:      []models.PrecipitationDeficit{{Days: 30, Observed: &observed, Normal: &normal, Deficit: &deficit, PercentOfNormal: &percent}},
+		SPI:           []models.SPIValue{{Days: 30, Value: &spi, Category: "extremely_dry", Label: "экстремально сухо", Samples: 150}},
+		GTK:           models.HydrothermalCoefficient{Value: &gtk, Category: "severe_drought", Label: "сильная засуха"},
+		Level:         models.RiskHigh,
+		Summary:       "25 суток без дождя",
+	}
+	var output bytes.Buffer
+	data := PageData{ActivePage: "dashboard", Data: map[string]any{
+		"Drought":      drought,
+		"DroughtChart": toJSON(prepareDroughtChartData(drought)),
+	}}
+	if err := tmpl.Execute(&output, data); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	for _, want := range []string{"Засуха и увлажнение", "25 суток без дождя", "экстремально сухо", "сильная засуха", "реанализ ERA5"} {
+		if !bytes.Contains(output.Bytes(), []byte(want)) {
+			t.Fatalf("rendered page is missing %q", want)
+		}
+	}
+}
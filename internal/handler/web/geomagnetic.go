@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/iRootPro/weather/internal/models"
@@ -167,16 +168,20 @@ func (h *Handler) DetailGeomagnetic(w http.ResponseWriter, r *http.Request) {
 
 	// Точки для Chart.js: метка ISO 8601 в UTC + значение Kp + цвет столбца
 	type chartPoint struct {
-		Time  string  `json:"time"`
-		Kp    float32 `json:"kp"`
-		Color string  `json:"color"`
+		Time       string  `json:"time"`
+		Kp         float32 `json:"kp"`
+		Color      string  `json:"color"`
+		Source     string  `json:"source"`
+		IsForecast bool    `json:"is_forecast"`
 	}
 	points := make([]chartPoint, 0, len(detail.Kp))
 	for _, slot := range detail.Kp {
 		points = append(points, chartPoint{
-			Time:  slot.SlotTime.UTC().Format(time.RFC3339),
-			Kp:    slot.Kp,
-			Color: models.ClassifyKp(slot.Kp).HexColor(),
+			Time:       slot.SlotTime.UTC().Format(time.RFC3339),
+			Kp:         slot.Kp,
+			Color:      models.ClassifyKp(slot.Kp).HexColor(),
+			Source:     slot.Source,
+			IsForecast: slot.IsForecast,
 		})
 	}
 	chartJSON, err := json.Marshal(points)
@@ -220,6 +225,25 @@ func (h *Handler) DetailGeomagnetic(w http.ResponseWriter, r *http.Request) {
 
 	card := h.buildGeomagneticCard(ctx)
 
+	// Шанс увидеть полярное сияние в ближайшие ночи
+	var aurora *models.AuroraForecast
+	if h.auroraService != nil {
+		if aurora, err = h.auroraService.GetForecast(ctx); err != nil {
+			slog.Warn("failed to get aurora forecast", "error", err)
+		}
+	}
+
+	// Тренды F10.7, числа пятен и Ap с 27-дневной повторяемостью возмущений
+	solarJSON := "null"
+	solar, err := h.geomagneticService.GetSolarActivity(ctx, now, service.SOLAR_DEFAULT_DAYS)
+	if err != nil {
+		slog.Warn("failed to get solar activity", "error", err)
+	} else if b, err := json.Marshal(solar); err != nil {
+		slog.Warn("failed to marshal solar activity", "error", err)
+	} else {
+		solarJSON = string(b)
+	}
+
 	data := PageData{
 		ActivePage: "dashboard",
 		Data: map[string]any{
@@ -228,6 +252,10 @@ func (h *Handler) DetailGeomagnetic(w http.ResponseWriter, r *http.Request) {
 			"ChartJSON": string(chartJSON),
 			"NowISO":    now.UTC().Format(time.RFC3339),
 			"DailyRows": rows,
+			"Aurora":    aurora,
+			"Sources":   kpSourceRows(detail.Kp),
+			"Solar":     solar,
+			"SolarJSON": solarJSON,
 		},
 	}
 
@@ -237,6 +265,54 @@ func (h *Handler) DetailGeomagnetic(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// kpSourceRow — вклад источника в показанную серию Kp
+type kpSourceRow struct {
+	Name      string
+	URL       string
+	Observed  int
+	Forecast  int
+	FetchedAt string
+}
+
+// kpSourceURLs — сайты источников Kp для ссылок в подвале страницы
+var kpSourceURLs = map[string]string{
+	models.KpSourceXras: "https://xras.ru/",
+	models.KpSourceSWPC: "https://www.swpc.noaa.gov/",
+}
+
+// kpSourceRows считает, сколько наблюдённых и прогнозных слотов дал каждый источник.
+// Усреднённый прогноз («xras.ru+swpc.noaa.gov») засчитывается всем его источникам.
+func kpSourceRows(slots []models.GeomagneticKp) []kpSourceRow {
+	var rows []kpSourceRow
+	index := make(map[string]int)
+	fetched := make(map[string]time.Time)
+	for _, slot := range slots {
+		for _, name := range strings.Split(slot.Source, "+") {
+			if name == "" {
+				continue
+			}
+			i, ok := index[name]
+			if !ok {
+				i = len(rows)
+				index[name] = i
+				rows = append(rows, kpSourceRow{Name: name, URL: kpSourceURLs[name]})
+			}
+			if slot.IsForecast {
+				rows[i].Forecast++
+			} else {
+				rows[i].Observed++
+			}
+			if slot.FetchedAt.After(fetched[name]) {
+				fetched[name] = slot.FetchedAt
+			}
+		}
+	}
+	for i := range rows {
+		rows[i].FetchedAt = fetched[rows[i].Name].Local().Format("02.01 15:04")
+	}
+	return rows
+}
+
 func formatNullableFloat(p *float32, format string) string {
 	if p == nil {
 		return "—"
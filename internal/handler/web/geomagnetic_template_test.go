@@ -0,0 +1,84 @@
+package web
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+var geomagneticTestEvening = time.Date(2026, time.May, 10, 0, 0, 0, 0, time.UTC)
+
+// renderGeomagnetic рисует страницу геомагнитной обстановки с пустой карточкой и графиком
+// и дополнительными разделами из extra
+func renderGeomagnetic(t *testing.T, extra map[string]any) []byte {
+	t.Helper()
+	tmpl := loadTemplate(t, "detail/geomagnetic.html")
+	data := map[string]any{
+		"Card":      GeomagneticCardData{},
+		"ChartJSON": "[]",
+		"NowISO":    geomagneticTestEvening.Format(time.RFC3339),
+	}
+	for key, value := range extra {
+		data[key] = value
+	}
+	var output bytes.Buffer
+	if err := tmpl.Execute(&output, PageData{ActivePage: "dashboard", Data: data}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	return output.Bytes()
+}
+
+func assertRendered(t *testing.T, output []byte, wants ...string) {
+	t.Helper()
+	for _, want := range wants {
+		if !bytes.Contains(output, []byte(want)) {
+			t.Fatalf("rendered page is missing %q", want)
+		}
+	}
+}
+
+func TestGeomagneticTemplateRendersAurora(t *testing.T) {
+	evening := geomagneticTestEvening
+	best := evening.Add(23 * time.Hour)
+	cloud := 20.0
+	aurora := &models.AuroraForecast{
+		GeomagneticLatitude: 40.7,
+		KpNeeded:            7.4,
+		Nights: []models.AuroraNight{
+			{Date: evening, DarkFrom: evening.Add(21 * time.Hour), DarkTo: evening.Add(26 * time.Hour), MaxKp: 8.7, MoonIllumination: 12, CloudCover: &cloud, Probability: 31, BestTime: &best, Hours: []models.AuroraHour{{Time: best, Kp: 8.7}}},
+			{Date: evening.AddDate(0, 0, 1), MoonIllumination: 6},
+		},
+	}
+
+	output := renderGeomagnetic(t, map[string]any{"Aurora": aurora})
+	assertRendered(t, output, "Полярное сияние", "40.7°", "Kp от 7.4", "31%", "Kp до 8.7", "лучше около 23:00", "темно 21:00–02:00", "облачность 20%", "нет прогноза Kp")
+}
+
+func TestGeomagneticTemplateRendersKpSource(t *testing.T) {
+	evening := geomagneticTestEvening
+	output := renderGeomagnetic(t, map[string]any{
+		"Sources": kpSourceRows([]models.GeomagneticKp{
+			{SlotTime: evening, Kp: 2, Source: models.KpSourceSWPC, FetchedAt: evening},
+			{SlotTime: evening.Add(3 * time.Hour), Kp: 3, Source: models.KpSourceXras + "+" + models.KpSourceSWPC, IsForecast: true, FetchedAt: evening},
+		}),
+	})
+	assertRendered(t, output, `href="https://www.swpc.noaa.gov/"`, "наблюдение: 1, прогноз: 1 слотов", "наблюдение: 0, прогноз: 1 слотов")
+}
+
+func TestGeomagneticTemplateRendersSolarWeek(t *testing.T) {
+	evening := geomagneticTestEvening
+	f10Avg, rate := 142.5, 60
+	f10 := float32(138)
+	ap := float32(30)
+	solar := &models.SolarActivity{
+		Days:           []models.SolarActivityDay{{Date: evening, F10: &f10, Ap: &ap, Disturbed: true, Recurrent: true}},
+		F10Avg:         &f10Avg,
+		RecurrenceRate: &rate,
+		Outlook:        []models.SolarActivityOutlook{{Date: evening.AddDate(0, 0, 5), SourceDate: evening.AddDate(0, 0, -22), Ap: &ap}},
+	}
+
+	output := renderGeomagnetic(t, map[string]any{"Solar": solar, "SolarJSON": "null"})
+	assertRendered(t, output, "Солнечная активность · 1 суток", "142 <span", "60%", "Ap 30")
+}
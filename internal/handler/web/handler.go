@@ -27,9 +27,21 @@ type Handler struct {
 	narodmonURL        string
 	geomagneticService *service.GeomagneticService
 	hydroService       *service.HydroService
+	agroService        *service.AgroService
+	heatingService     *service.HeatingService
+	indoorService      *service.IndoorClimateService
+	pvService          *service.PVService
+	droughtService     *service.DroughtService
+	meteoService       *service.MeteoSensitivityService
+	activityService    *service.ActivityService
+	clothingService    *service.ClothingService
+	uvService          *service.UVService
+	auroraService      *service.AuroraService
+	hydroForecast      *service.HydroForecastService
+	rainRiver          *service.RainRiverService
 }
 
-func NewHandler(templatesDir string, weatherService *service.WeatherService, sunService *service.SunService, moonService *service.MoonService, forecastService *service.ForecastService, photoRepo repository.PhotoRepository, narodmonService *service.NarodmonService, narodmonURL string, geomagneticService *service.GeomagneticService, hydroService *service.HydroService) (*Handler, error) {
+func NewHandler(templatesDir string, weatherService *service.WeatherService, sunService *service.SunService, moonService *service.MoonService, forecastService *service.ForecastService, photoRepo repository.PhotoRepository, narodmonService *service.NarodmonService, narodmonURL string, geomagneticService *service.GeomagneticService, hydroService *service.HydroService, agroService *service.AgroService, heatingService *service.HeatingService, indoorService *service.IndoorClimateService, pvService *service.PVService, droughtService *service.DroughtService, meteoService *service.MeteoSensitivityService, activityService *service.ActivityService, clothingService *service.ClothingService, uvService *service.UVService, auroraService *service.AuroraService, hydroForecast *service.HydroForecastService, rainRiver *service.RainRiverService) (*Handler, error) {
 	return &Handler{
 		templatesDir:       templatesDir,
 		weatherService:     weatherService,
@@ -41,6 +53,18 @@ func NewHandler(templatesDir string, weatherService *service.WeatherService, sun
 		narodmonURL:        narodmonURL,
 		geomagneticService: geomagneticService,
 		hydroService:       hydroService,
+		agroService:        agroService,
+		heatingService:     heatingService,
+		indoorService:      indoorService,
+		pvService:          pvService,
+		droughtService:     droughtService,
+		meteoService:       meteoService,
+		activityService:    activityService,
+		clothingService:    clothingService,
+		uvService:          uvService,
+		auroraService:      auroraService,
+		hydroForecast:      hydroForecast,
+		rainRiver:          rainRiver,
 	}, nil
 }
 
@@ -235,6 +259,33 @@ func (h *Handler) Insights(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if h.heatingService != nil {
+		heating, err := h.heatingService.GetSummary(r.Context())
+		if err != nil {
+			slog.Warn("failed to get heating season summary", "error", err)
+		} else {
+			archive.Heating = heating
+		}
+	}
+
+	if h.meteoService != nil {
+		meteo, err := h.meteoService.GetMeteoSensitivity(r.Context())
+		if err != nil {
+			slog.Warn("failed to get meteosensitivity index", "error", err)
+		} else {
+			archive.MeteoSensitivity = meteo
+		}
+	}
+
+	if h.geomagneticService != nil {
+		solar, err := h.geomagneticService.GetSolarActivity(r.Context(), time.Now(), 2*service.SOLAR_WEEK_DAYS)
+		if err != nil {
+			slog.Warn("failed to get solar activity", "error", err)
+		} else {
+			archive.SolarWeek = &solar.Week
+		}
+	}
+
 	tmpl, err := h.parseTemplate("insights.html")
 	if err != nil {
 		slog.Error("failed to parse archive template", "error", err)
@@ -348,6 +399,9 @@ func buildInsightsShareText(insights *models.WeatherInsightsPage) string {
 	if insights.SameMonthBenchmark.Available {
 		lines = append(lines, fmt.Sprintf("📚 Архив: %s", insights.SameMonthBenchmark.Verdict))
 	}
+	if insights.ReanalysisBenchmark.Available {
+		lines = append(lines, fmt.Sprintf("🛰️ Многолетний фон (%s, модель): %s", insights.ReanalysisBenchmark.SourceLabel, insights.ReanalysisBenchmark.Verdict))
+	}
 	lines = append(lines, "", "Полный отчёт:", reportURL)
 	return strings.Join(lines, "\n")
 }
@@ -372,6 +426,33 @@ func (h *Handler) getInsightsFromRequest(r *http.Request) (*models.WeatherInsigh
 	return h.weatherService.GetInsightsForMonth(r.Context(), selectedMonth)
 }
 
+// Garden renders the garden page: degree days, chill hours, evapotranspiration and watering advice
+func (h *Handler) Garden(w http.ResponseWriter, r *http.Request) {
+	summary, err := h.agroService.GetSummary(r.Context())
+	if err != nil {
+		slog.Error("failed to get agro summary", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	tmpl, err := h.parseTemplate("garden.html")
+	if err != nil {
+		slog.Error("failed to parse garden template", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	data := PageData{
+		ActivePage: "garden",
+		Data:       summary,
+	}
+
+	if err := tmpl.Execute(w, data); err != nil {
+		slog.Error("failed to render garden", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
 // Help renders the help/reference page
 func (h *Handler) Help(w http.ResponseWriter, r *http.Request) {
 	tmpl, err := h.parseTemplate("help.html")
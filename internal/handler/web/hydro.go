@@ -177,17 +177,7 @@ func buildWaterLevelMini(snap *models.HydroSnapshot) *WaterLevelMiniData {
 		RiskBarClass:      riskBarClass(snap.Status, 0),
 	}
 	if snap.Gauge != nil {
-		mini.StationName = snap.Gauge.HolderName
-		if snap.Gauge.Locality != nil && *snap.Gauge.Locality != "" {
-			if mini.StationName != "" {
-				mini.StationName += " · " + *snap.Gauge.Locality
-			} else {
-				mini.StationName = *snap.Gauge.Locality
-			}
-		}
-		if mini.StationName == "" {
-			mini.StationName = snap.Gauge.Name
-		}
+		mini.StationName = hydroGaugeTitle(snap.Gauge)
 		mini.ObjectName = snap.Gauge.MonitoringObject
 		mini.Role = hydroStationRole(mini.ObjectName)
 	}
@@ -218,6 +208,22 @@ func buildWaterLevelMini(snap *models.HydroSnapshot) *WaterLevelMiniData {
 	return mini
 }
 
+// hydroGaugeTitle — «владелец · населённый пункт», а без них — название поста из источника
+func hydroGaugeTitle(gauge *models.HydroGauge) string {
+	title := gauge.HolderName
+	if gauge.Locality != nil && *gauge.Locality != "" {
+		if title != "" {
+			title += " · " + *gauge.Locality
+		} else {
+			title = *gauge.Locality
+		}
+	}
+	if title == "" {
+		title = gauge.Name
+	}
+	return title
+}
+
 func hydroStationRole(objectName string) string {
 	if strings.Contains(strings.ToLower(objectName), "кубан") {
 		return "выше по руслу"
@@ -374,13 +380,36 @@ func (h *Handler) DetailWaterLevel(w http.ResponseWriter, r *http.Request) {
 	chartJSON, _ := json.Marshal(points)
 
 	card := h.buildWaterLevelCard(r)
+	var forecast *models.HydroForecast
+	if h.hydroForecast != nil {
+		forecast, err = h.hydroForecast.GetForecast(r.Context(), now)
+		if err != nil {
+			slog.Warn("failed to get hydro forecast", "error", err)
+		}
+	}
+	forecastPoints := []models.HydroForecastPoint{}
+	if forecast != nil && forecast.Available {
+		forecastPoints = forecast.Points
+	}
+	forecastJSON, _ := json.Marshal(forecastPoints)
+	var rainRiver *models.RainRiverAnalysis
+	if h.rainRiver != nil {
+		rainRiver, err = h.rainRiver.GetAnalysis(r.Context(), now)
+		if err != nil {
+			slog.Warn("failed to get rain river analysis", "error", err)
+		}
+	}
 	data := PageData{
 		ActivePage: "dashboard",
 		Data: map[string]any{
-			"Card":      card,
-			"Gauge":     gauge,
-			"ChartJSON": string(chartJSON),
-			"Rows":      buildWaterLevelRows(readings),
+			"Card":         card,
+			"Gauge":        gauge,
+			"ChartJSON":    string(chartJSON),
+			"Forecast":     buildWaterLevelForecast(forecast),
+			"ForecastJSON": string(forecastJSON),
+			"RainRiver":    buildWaterLevelRainRiver(rainRiver),
+			"RainJSON":     string(rainRiverChartJSON(rainRiver)),
+			"Rows":         buildWaterLevelRows(readings),
 		},
 	}
 	tmpl, err := h.parseTemplate("detail/water_level.html")
@@ -395,6 +424,160 @@ func (h *Handler) DetailWaterLevel(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// WaterLevelForecastData — прогноз уровня по верхним постам для страницы уровня воды
+type WaterLevelForecastData struct {
+	Available    bool
+	Reason       string
+	Hours        int
+	TrainingDays int
+	PeakText     string
+	PeakTime     string
+	PeakStatus   string
+	PeakClass    string
+	ChangeText   string
+	ChangeClass  string
+	PreventionAt string
+	DangerAt     string
+	Links        []WaterLevelLinkData
+}
+
+type WaterLevelLinkData struct {
+	StationName string
+	Used        bool
+	Text        string
+}
+
+func buildWaterLevelForecast(forecast *models.HydroForecast) *WaterLevelForecastData {
+	if forecast == nil {
+		return nil
+	}
+	out := &WaterLevelForecastData{
+		Available:    forecast.Available,
+		Reason:       forecast.Reason,
+		Hours:        len(forecast.Points),
+		TrainingDays: forecast.TrainingDays,
+	}
+	for _, link := range forecast.Links {
+		row := WaterLevelLinkData{StationName: link.StationName, Used: link.Used}
+		switch {
+		case link.Used:
+			row.Text = fmt.Sprintf("добегание ~%d ч, до поста доходит %.0f%% подъёма, r = %.2f", link.LagHours, link.Gain*100, link.Correlation)
+		case link.Samples == 0:
+			row.Text = "мало данных для оценки связи"
+		default:
+			row.Text = fmt.Sprintf("связь слабая (r = %.2f), в прогнозе не участвует", link.Correlation)
+		}
+		out.Links = append(out.Links, row)
+	}
+	if !forecast.Available || forecast.Peak == nil {
+		return out
+	}
+	out.PeakText = fmt.Sprintf("%.3f м", forecast.Peak.LevelBSM)
+	out.PeakTime = forecast.Peak.Time.In(time.Local).Format("02.01 15:04")
+	out.PeakStatus = forecast.PeakStatus.Label()
+	out.PeakClass = forecast.PeakStatus.TextColor()
+	out.ChangeText = formatSignedFloat(float32(forecast.ChangeCm), "%.0f см")
+	out.ChangeClass = changeClass(float32(forecast.ChangeCm))
+	if forecast.PreventionAt != nil {
+		out.PreventionAt = forecast.PreventionAt.In(time.Local).Format("02.01 15:04")
+	}
+	if forecast.DangerAt != nil {
+		out.DangerAt = forecast.DangerAt.In(time.Local).Format("02.01 15:04")
+	}
+	return out
+}
+
+// WaterLevelRainRiverData — отклик реки на дожди станции для страницы уровня воды
+type WaterLevelRainRiverData struct {
+	Summary        string
+	Days           int
+	MinRainMM      float64
+	RegressionText string
+	ExpectedText   string
+	ExpectedNote   string
+	Episodes       []WaterLevelRainEpisodeRow
+}
+
+type WaterLevelRainEpisodeRow struct {
+	Date      string
+	Rain      string
+	Rise      string
+	Lag       string
+	Recession string
+	Complete  bool
+}
+
+func buildWaterLevelRainRiver(analysis *models.RainRiverAnalysis) *WaterLevelRainRiverData {
+	if analysis == nil {
+		return nil
+	}
+	out := &WaterLevelRainRiverData{Summary: analysis.Summary, Days: analysis.Days, MinRainMM: analysis.MinRainMM}
+	if reg := analysis.Regression; reg != nil {
+		out.RegressionText = fmt.Sprintf("подъём ≈ %.1f + %.2f × осадки, см; R² = %.2f по %d эпизодам", reg.Intercept, reg.Slope, reg.R2, reg.Samples)
+	}
+	if e := analysis.Expected; e != nil {
+		switch {
+		case e.RainMM == 0:
+			out.ExpectedText = fmt.Sprintf("В прогнозе на %d ч осадков нет", e.Hours)
+		case e.BelowThreshold:
+			out.ExpectedText = fmt.Sprintf("В прогнозе на %d ч %.1f мм — заметного подъёма не ожидается", e.Hours, e.RainMM)
+		default:
+			out.ExpectedText = fmt.Sprintf("В прогнозе на %d ч %.1f мм — ожидаемый подъём %.0f см (%.0f–%.0f)", e.Hours, e.RainMM, e.RiseCm, e.LowerCm, e.UpperCm)
+			if e.PeakAt != nil {
+				out.ExpectedNote = "пик около " + e.PeakAt.In(time.Local).Format("02.01 15:04")
+			}
+		}
+	}
+	// Последние эпизоды сверху
+	for i := len(analysis.Episodes) - 1; i >= 0 && len(out.Episodes) < 12; i-- {
+		e := analysis.Episodes[i]
+		row := WaterLevelRainEpisodeRow{
+			Date:      e.RainStart.In(time.Local).Format("02.01.2006 15:04"),
+			Rain:      fmt.Sprintf("%.1f", e.RainMM),
+			Rise:      fmt.Sprintf("%.0f", e.RiseCm),
+			Lag:       fmt.Sprintf("%.0f", e.LagHours),
+			Recession: "—",
+			Complete:  e.Complete,
+		}
+		if e.RecessionHours != nil {
+			row.Recession = fmt.Sprintf("%.0f", *e.RecessionHours)
+		}
+		out.Episodes = append(out.Episodes, row)
+	}
+	return out
+}
+
+// rainRiverChartJSON — точки «осадки — подъём» завершённых эпизодов и линия регрессии
+func rainRiverChartJSON(analysis *models.RainRiverAnalysis) []byte {
+	type point struct {
+		X    float64 `json:"x"`
+		Y    float64 `json:"y"`
+		Date string  `json:"date,omitempty"`
+	}
+	points := []point{}
+	line := []point{}
+	if analysis != nil {
+		var maxRain float64
+		for _, e := range analysis.Episodes {
+			if !e.Complete {
+				continue
+			}
+			points = append(points, point{X: e.RainMM, Y: e.RiseCm, Date: e.RainStart.In(time.Local).Format("02.01.2006")})
+			maxRain = max(maxRain, e.RainMM)
+		}
+		if reg := analysis.Regression; reg != nil {
+			// Линия начинается там, где регрессия даёт нулевой подъём, если это правее нуля осадков
+			start := point{X: 0, Y: reg.Intercept}
+			if reg.Intercept < 0 && reg.Slope > 0 {
+				start = point{X: -reg.Intercept / reg.Slope}
+			}
+			line = append(line, start, point{X: maxRain, Y: reg.Intercept + reg.Slope*maxRain})
+		}
+	}
+	data, _ := json.Marshal(map[string]any{"points": points, "line": line})
+	return data
+}
+
 type waterLevelRow struct {
 	Time  string
 	Level string
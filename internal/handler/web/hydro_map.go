@@ -0,0 +1,241 @@
+package web
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+const (
+	waterLevelMapWidth     = 800
+	waterLevelMapMaxHeight = 560
+	waterLevelMapPadding   = 40
+	// Показание старше этого срока не окрашивает пост статусом: пост, скорее всего, не передаёт данные
+	waterLevelMapStaleAfter = 6 * time.Hour
+)
+
+// WaterLevelMapData — обзорная карта всех отслеживаемых гидропостов
+type WaterLevelMapData struct {
+	Width         int
+	Height        int
+	Gauges        []WaterLevelMapGauge
+	Legend        []WaterLevelMapLegend
+	WithoutCoords int
+}
+
+type WaterLevelMapGauge struct {
+	StationUUID  string
+	Name         string
+	ObjectName   string
+	Primary      bool
+	HasCoords    bool
+	X            int
+	Y            int
+	Color        string
+	StatusLabel  string
+	StatusText   string
+	LevelText    string
+	ChangeText   string
+	ChangeClass  string
+	ToPrevention string
+	ObservedAt   string
+	Stale        bool
+	status       models.HydroStatus
+}
+
+type WaterLevelMapLegend struct {
+	Label string
+	Color string
+	Count int
+}
+
+func (h *Handler) DetailWaterLevelMap(w http.ResponseWriter, r *http.Request) {
+	if h.hydroService == nil {
+		http.Error(w, "Hydro service not configured", http.StatusServiceUnavailable)
+		return
+	}
+	now := time.Now()
+	overview, err := h.hydroService.GetOverview(r.Context(), now)
+	if err != nil {
+		slog.Error("failed to get hydro overview", "error", err)
+		http.Error(w, "Failed to load data", http.StatusInternalServerError)
+		return
+	}
+	data := PageData{
+		ActivePage: "dashboard",
+		Data: map[string]any{
+			"Map": buildWaterLevelMap(overview, h.hydroService.PrimaryStationUUID(), now),
+		},
+	}
+	tmpl, err := h.parseTemplate("detail/water_level_map.html")
+	if err != nil {
+		slog.Error("failed to parse water level map template", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	if err := tmpl.Execute(w, data); err != nil {
+		slog.Error("failed to render water level map", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// buildWaterLevelMap раскладывает посты на SVG. Проекция равнопромежуточная: долгота сжимается
+// косинусом средней широты, поэтому на масштабе бассейна форма расстановки постов не искажается.
+// В таблице посты идут от самого тревожного статуса к норме, основной пост — первым среди равных.
+func buildWaterLevelMap(overview []*models.HydroSnapshot, primaryUUID string, now time.Time) WaterLevelMapData {
+	out := WaterLevelMapData{Width: waterLevelMapWidth}
+	for _, snap := range overview {
+		if snap == nil || snap.Gauge == nil {
+			continue
+		}
+		out.Gauges = append(out.Gauges, buildWaterLevelMapGauge(snap, primaryUUID, now))
+	}
+
+	minX, maxX := math.Inf(1), math.Inf(-1)
+	minY, maxY := math.Inf(1), math.Inf(-1)
+	var sumLat float64
+	located := 0
+	for _, snap := range overview {
+		if snap == nil || snap.Gauge == nil || snap.Gauge.Latitude == nil || snap.Gauge.Longitude == nil {
+			continue
+		}
+		sumLat += *snap.Gauge.Latitude
+		located++
+	}
+	if located == 0 {
+		out.WithoutCoords = len(out.Gauges)
+		sortWaterLevelMapGauges(out.Gauges)
+		out.Legend = waterLevelMapLegend(out.Gauges)
+		return out
+	}
+	kx := math.Cos(sumLat / float64(located) * math.Pi / 180)
+	project := func(lat, lon float64) (float64, float64) {
+		return lon * kx, -lat
+	}
+	for _, snap := range overview {
+		if snap == nil || snap.Gauge == nil || snap.Gauge.Latitude == nil || snap.Gauge.Longitude == nil {
+			continue
+		}
+		x, y := project(*snap.Gauge.Latitude, *snap.Gauge.Longitude)
+		minX, maxX = math.Min(minX, x), math.Max(maxX, x)
+		minY, maxY = math.Min(minY, y), math.Max(maxY, y)
+	}
+	// Один пост или посты на одной линии: даём рамке минимальный размер ~10 км, чтобы не делить на ноль
+	const minSpan = 0.1
+	if maxX-minX < minSpan {
+		mid := (minX + maxX) / 2
+		minX, maxX = mid-minSpan/2, mid+minSpan/2
+	}
+	if maxY-minY < minSpan {
+		mid := (minY + maxY) / 2
+		minY, maxY = mid-minSpan/2, mid+minSpan/2
+	}
+	inner := float64(waterLevelMapWidth - 2*waterLevelMapPadding)
+	scale := math.Min(inner/(maxX-minX), float64(waterLevelMapMaxHeight-2*waterLevelMapPadding)/(maxY-minY))
+	offsetX := (inner - (maxX-minX)*scale) / 2
+	out.Height = int(math.Round((maxY-minY)*scale)) + 2*waterLevelMapPadding
+
+	i := 0
+	for _, snap := range overview {
+		if snap == nil || snap.Gauge == nil {
+			continue
+		}
+		gauge := &out.Gauges[i]
+		i++
+		if snap.Gauge.Latitude == nil || snap.Gauge.Longitude == nil {
+			out.WithoutCoords++
+			continue
+		}
+		x, y := project(*snap.Gauge.Latitude, *snap.Gauge.Longitude)
+		gauge.HasCoords = true
+		gauge.X = waterLevelMapPadding + int(math.Round(offsetX+(x-minX)*scale))
+		gauge.Y = waterLevelMapPadding + int(math.Round((y-minY)*scale))
+	}
+	sortWaterLevelMapGauges(out.Gauges)
+	out.Legend = waterLevelMapLegend(out.Gauges)
+	return out
+}
+
+func buildWaterLevelMapGauge(snap *models.HydroSnapshot, primaryUUID string, now time.Time) WaterLevelMapGauge {
+	status := snap.Status
+	if !snap.HasData || snap.Current == nil {
+		status = models.HydroStatusUnknown
+	}
+	gauge := WaterLevelMapGauge{
+		StationUUID: snap.Gauge.StationUUID,
+		Name:        hydroGaugeTitle(snap.Gauge),
+		ObjectName:  snap.Gauge.MonitoringObject,
+		Primary:     snap.Gauge.StationUUID == primaryUUID,
+		LevelText:   "—",
+	}
+	if snap.Current != nil {
+		gauge.LevelText = fmt.Sprintf("%.2f м", snap.Current.LevelBSM)
+		gauge.ObservedAt = snap.Current.ObservedAt.In(time.Local).Format("02.01 15:04")
+		if now.Sub(snap.Current.ObservedAt) > waterLevelMapStaleAfter {
+			gauge.Stale = true
+			status = models.HydroStatusUnknown
+		}
+		if snap.Current.ChangeCmPerHour != nil {
+			gauge.ChangeText = formatSignedFloat(*snap.Current.ChangeCmPerHour, "%.0f см/ч")
+			gauge.ChangeClass = changeClass(*snap.Current.ChangeCmPerHour)
+		} else if snap.Change24hM != nil {
+			cm := *snap.Change24hM * 100
+			gauge.ChangeText = formatSignedFloat(cm, "%.0f см/сут")
+			gauge.ChangeClass = changeClass(cm)
+		}
+	}
+	if snap.ToPreventionM != nil && !gauge.Stale {
+		gauge.ToPrevention = formatDistanceToThreshold(*snap.ToPreventionM)
+	}
+	gauge.status = status
+	gauge.Color = status.HexColor()
+	gauge.StatusLabel = status.Label()
+	gauge.StatusText = status.TextColor()
+	if gauge.Stale {
+		gauge.StatusLabel = "данные устарели"
+	}
+	return gauge
+}
+
+func sortWaterLevelMapGauges(gauges []WaterLevelMapGauge) {
+	sort.SliceStable(gauges, func(i, j int) bool {
+		ri, rj := waterLevelMapStatusRank(gauges[i].status), waterLevelMapStatusRank(gauges[j].status)
+		if ri != rj {
+			return ri > rj
+		}
+		return gauges[i].Primary && !gauges[j].Primary
+	})
+}
+
+func waterLevelMapStatusRank(status models.HydroStatus) int {
+	switch status {
+	case models.HydroStatusDanger:
+		return 4
+	case models.HydroStatusPrevention:
+		return 3
+	case models.HydroStatusNear:
+		return 2
+	case models.HydroStatusNormal:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func waterLevelMapLegend(gauges []WaterLevelMapGauge) []WaterLevelMapLegend {
+	order := []models.HydroStatus{models.HydroStatusDanger, models.HydroStatusPrevention, models.HydroStatusNear, models.HydroStatusNormal, models.HydroStatusUnknown}
+	counts := map[models.HydroStatus]int{}
+	for _, gauge := range gauges {
+		counts[gauge.status]++
+	}
+	out := make([]WaterLevelMapLegend, 0, len(order))
+	for _, status := range order {
+		out = append(out, WaterLevelMapLegend{Label: status.Label(), Color: status.HexColor(), Count: counts[status]})
+	}
+	return out
+}
@@ -0,0 +1,149 @@
+package web
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+func TestWaterLevelTemplateRendersForecast(t *testing.T) {
+	tmpl := loadTemplate(t, "detail/water_level.html")
+
+	base := time.Date(2026, time.April, 20, 12, 0, 0, 0, time.UTC)
+	peak := models.HydroForecastPoint{Time: base.Add(8 * time.Hour), LevelBSM: 251.3, LowerBSM: 251.1, UpperBSM: 251.5}
+	preventionAt := base.Add(6 * time.Hour)
+	forecast := &models.HydroForecast{
+		BaseTime:     base,
+		BaseLevelBSM: 250.6,
+		TrainingDays: 30,
+		Available:    true,
+		Links: []models.HydroUpstreamLink{
+			{StationName: "Кубань, Невинномысск", LagHours: 9, Gain: 0.62, Correlation: 0.81, Samples: 600, Used: true},
+			{StationName: "Уруп, Урупский аул", LagHours: 3, Correlation: 0.12, Samples: 600},
+		},
+		Points:        []models.HydroForecastPoint{peak},
+		Peak:          &peak,
+		PeakStatus:    models.HydroStatusPrevention,
+		PreventionAt:  &preventionAt,
+		CurrentStatus: models.HydroStatusNear,
+		ChangeCm:      70,
+	}
+	var output bytes.Buffer
+	data := PageData{ActivePage: "dashboard", Data: map[string]any{
+		"Card":         WaterLevelCardData{},
+		"ChartJSON":    "[]",
+		"Forecast":     buildWaterLevelForecast(forecast),
+		"ForecastJSON": "[]",
+	}}
+	if err := tmpl.Execute(&output, data); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	for _, want := range []string{"Прогноз уровня по верхним постам", "251.300 м", "70 см", "добегание ~9 ч, до поста доходит 62% подъёма", "связь слабая (r = 0.12)", "неблагоприятный — к"} {
+		if !bytes.Contains(output.Bytes(), []byte(want)) {
+			t.Fatalf("rendered page is missing %q", want)
+		}
+	}
+
+	output.Reset()
+	data.Data.(map[string]any)["Forecast"] = buildWaterLevelForecast(&models.HydroForecast{Reason: "нет измерений основного поста"})
+	if err := tmpl.Execute(&output, data); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !bytes.Contains(output.Bytes(), []byte("Прогноз недоступен: нет измерений основного поста")) {
+		t.Fatal("rendered page is missing unavailable forecast reason")
+	}
+}
+
+func TestWaterLevelTemplateRendersRainRiver(t *testing.T) {
+	tmpl := loadTemplate(t, "detail/water_level.html")
+
+	start := time.Date(2026, time.May, 3, 14, 0, 0, 0, time.UTC)
+	recession := 18.0
+	lag := 11.0
+	peak := start.Add(40 * time.Hour)
+	analysis := &models.RainRiverAnalysis{
+		Days:      365,
+		MinRainMM: 5,
+		Episodes: []models.RainRiverEpisode{
+			{RainStart: start, RainMM: 24.5, RiseCm: 41, LagHours: 11, RecessionHours: &recession, Complete: true},
+		},
+		Regression:     &models.RainRiverRegression{Slope: 1.62, Intercept: -2.1, R2: 0.71, Residual: 6, Samples: 9},
+		MedianLagHours: &lag,
+		Expected:       &models.RainRiverExpectation{Hours: 48, RainMM: 18, RiseCm: 27, LowerCm: 19, UpperCm: 35, PeakAt: &peak},
+		Summary:        "Каждые 10 мм дождя на станции поднимают реку в среднем на 16 см",
+	}
+	var output bytes.Buffer
+	data := PageData{ActivePage: "dashboard", Data: map[string]any{
+		"Card":         WaterLevelCardData{},
+		"ChartJSON":    "[]",
+		"ForecastJSON": "[]",
+		"RainRiver":    buildWaterLevelRainRiver(analysis),
+		"RainJSON":     string(rainRiverChartJSON(analysis)),
+	}}
+	if err := tmpl.Execute(&output, data); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	for _, want := range []string{"Дожди и подъём реки", "поднимают реку в среднем на 16 см", "ожидаемый подъём 27 см (19–35)", "R² = 0.71 по 9 эпизодам", "rainRiverChart", "24.5"} {
+		if !bytes.Contains(output.Bytes(), []byte(want)) {
+			t.Fatalf("rendered page is missing %q", want)
+		}
+	}
+}
+
+// hydroMapSnapshot — пост с одним показанием и порогом НЯ на метр выше текущего уровня
+func hydroMapSnapshot(uuid, object string, lat, lon *float64, levelBSM float32, observedAt time.Time, status models.HydroStatus) *models.HydroSnapshot {
+	prevention := levelBSM + 1
+	return &models.HydroSnapshot{
+		Gauge:   &models.HydroGauge{StationUUID: uuid, Name: "АГК " + uuid, MonitoringObject: object, Latitude: lat, Longitude: lon, FloodingPreventionBM: &prevention},
+		Current: &models.HydroLevelReading{StationUUID: uuid, ObservedAt: observedAt, LevelBSM: levelBSM},
+		Status:  status,
+		HasData: true,
+	}
+}
+
+func TestWaterLevelMapTemplateRendersGauges(t *testing.T) {
+	tmpl := loadTemplate(t, "detail/water_level_map.html")
+
+	now := time.Date(2026, time.May, 10, 12, 0, 0, 0, time.UTC)
+	coord := func(v float64) *float64 { return &v }
+	overview := []*models.HydroSnapshot{
+		hydroMapSnapshot("armavir", "р. Кубань", coord(45.0), coord(41.13), 170.2, now.Add(-20*time.Minute), models.HydroStatusNormal),
+		hydroMapSnapshot("urup", "р. Уруп", coord(44.6), coord(41.3), 402.5, now.Add(-20*time.Minute), models.HydroStatusPrevention),
+		hydroMapSnapshot("laba", "р. Лаба", coord(44.9), coord(40.7), 120.1, now.Add(-10*time.Hour), models.HydroStatusNormal),
+		hydroMapSnapshot("nocoords", "р. Зеленчук", nil, nil, 300, now.Add(-20*time.Minute), models.HydroStatusNormal),
+	}
+	mapData := buildWaterLevelMap(overview, "armavir", now)
+
+	if mapData.Gauges[0].StationUUID != "urup" || !mapData.Gauges[1].Primary {
+		t.Fatalf("gauges are not ordered by status with primary first among equals: %+v", mapData.Gauges)
+	}
+	if mapData.WithoutCoords != 1 || mapData.Height <= 2*waterLevelMapPadding {
+		t.Fatalf("unexpected map layout: height %d, without coords %d", mapData.Height, mapData.WithoutCoords)
+	}
+	for _, gauge := range mapData.Gauges {
+		if !gauge.HasCoords {
+			continue
+		}
+		if gauge.X < waterLevelMapPadding || gauge.X > mapData.Width-waterLevelMapPadding || gauge.Y < waterLevelMapPadding || gauge.Y > mapData.Height-waterLevelMapPadding {
+			t.Fatalf("gauge %s is outside the map: (%d, %d) in %dx%d", gauge.StationUUID, gauge.X, gauge.Y, mapData.Width, mapData.Height)
+		}
+		if gauge.StationUUID == "laba" && (!gauge.Stale || gauge.Color != models.HydroStatusUnknown.HexColor()) {
+			t.Fatalf("stale gauge should be grey: %+v", gauge)
+		}
+	}
+
+	var output bytes.Buffer
+	data := PageData{ActivePage: "dashboard", Data: map[string]any{"Map": mapData}}
+	if err := tmpl.Execute(&output, data); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	for _, want := range []string{"Гидропосты бассейна", "<svg viewBox=\"0 0 800", "fill=\"#f59e0b\"", "р. Уруп", "402.50 м", "данные устарели", "Без координат, только в таблице: 1", "неблагоприятный уровень · 1"} {
+		if !bytes.Contains(output.Bytes(), []byte(want)) {
+			t.Fatalf("rendered page is missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package web
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// DetailIndoor рендерит детальную страницу микроклимата в доме.
+func (h *Handler) DetailIndoor(w http.ResponseWriter, r *http.Request) {
+	if h.indoorService == nil {
+		http.Error(w, "Indoor climate service not configured", http.StatusServiceUnavailable)
+		return
+	}
+
+	climate, err := h.indoorService.GetIndoorClimate(r.Context())
+	if err != nil {
+		slog.Error("failed to get indoor climate", "error", err)
+		http.Error(w, "Failed to load data", http.StatusInternalServerError)
+		return
+	}
+
+	// Точки для Chart.js: метка ISO 8601 в UTC, комнатные температура и влажность, влажность у стены
+	type chartPoint struct {
+		Time            string   `json:"time"`
+		Temp            float64  `json:"temp"`
+		Humidity        float64  `json:"humidity"`
+		SurfaceHumidity *float64 `json:"surface_humidity"`
+	}
+	points := make([]chartPoint, 0, len(climate.Hours))
+	for _, hour := range climate.Hours {
+		points = append(points, chartPoint{
+			Time:            hour.Time.UTC().Format(time.RFC3339),
+			Temp:            hour.TempIndoor,
+			Humidity:        hour.HumidityIndoor,
+			SurfaceHumidity: hour.SurfaceHumidity,
+		})
+	}
+	chartJSON, err := json.Marshal(points)
+	if err != nil {
+		slog.Error("failed to marshal indoor chart points", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	tmpl, err := h.parseTemplate("detail/indoor.html")
+	if err != nil {
+		slog.Error("failed to parse indoor detail template", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	data := PageData{
+		ActivePage: "dashboard",
+		Data: map[string]any{
+			"Climate":   climate,
+			"ChartJSON": string(chartJSON),
+		},
+	}
+	if err := tmpl.Execute(w, data); err != nil {
+		slog.Error("failed to render indoor detail", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
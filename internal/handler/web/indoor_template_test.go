@@ -0,0 +1,37 @@
+package web
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+func TestIndoorTemplateRendersClimate(t *testing.T) {
+	tmpl := loadTemplate(t, "detail/indoor.html")
+
+	now := time.Date(2026, time.January, 12, 14, 0, 0, 0, time.UTC)
+	surfaceTemp, surfaceHumidity, outdoor := 13.9, 100.0, 2.9
+	current := models.IndoorHour{Time: now, TempIndoor: 22, HumidityIndoor: 65, SurfaceTemp: &surfaceTemp, SurfaceHumidity: &surfaceHumidity, AbsoluteHumidity: 12.6, OutdoorAbsolute: &outdoor}
+	var output bytes.Buffer
+	data := PageData{ActivePage: "dashboard", Data: map[string]any{
+		"Climate": &models.IndoorClimate{
+			HasData: true, From: now.AddDate(0, 0, -7), To: now, Current: &current,
+			Comfort:     models.IndoorComfort{Hours: 168, ComfortPct: 40, TooHumidPct: 60, TempMin: 20, TempMax: 24, HumidityMin: 40, HumidityMax: 60},
+			Mold:        models.IndoorMoldRisk{Level: models.RiskHigh, Reasons: []string{"у наружной стены влажность ≥ 80% — 30 ч, до 30 ч подряд"}},
+			Ventilation: models.IndoorVentilation{Advice: models.VentilationAdvised, Text: "Проветрите", IndoorAbsolute: 12.6, OutdoorAbsolute: 2.9, IndoorAfterAiring: 15},
+			Hours:       []models.IndoorHour{current},
+		},
+		"ChartJSON": "[]",
+	}}
+	if err := tmpl.Execute(&output, data); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	for _, want := range []string{"Риск плесени: высокий", "у наружной стены", "Проветрите", "12.6 г/м³", "13.9 °C"} {
+		if !bytes.Contains(output.Bytes(), []byte(want)) {
+			t.Fatalf("rendered page is missing %q", want)
+		}
+	}
+}
@@ -52,3 +52,95 @@ func TestInsightsTemplateRendersArchiveControls(t *testing.T) {
 		t.Fatal("daily pointer values were not rendered as measurements")
 	}
 }
+
+func TestInsightsTemplateRendersHeatingSeason(t *testing.T) {
+	tmpl := loadTemplate(t, "insights.html")
+
+	day := time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC)
+	var output bytes.Buffer
+	data := PageData{ActivePage: "insights", Data: &models.WeatherArchivePage{
+		Period: "month", Metric: "all", PeriodLabel: "Октябрь 2026",
+		Summary: models.WeatherArchiveSummary{DaysWithData: 1, DaysInPeriod: 1},
+		Heating: &models.HeatingSummary{
+			BaseTemp: 18, CoolingBaseTemp: 18, ThresholdTemp: 8, ThresholdDays: 5,
+			StatusText: "Отопление пока не требуется. 2 из 5 суток подряд ниже +8 °C",
+			Days:       []models.DegreeDay{{Date: day, TempAvg: 6.5, HDD: 11.5}},
+			Months:     []models.DegreeDaysTotal{{Label: "Октябрь 2026", Days: 17, TempAvg: 11.2, HDD: 115}},
+			Season:     models.DegreeDaysTotal{Label: "Отопительный год 2026/27", Days: 109, HDD: 140, CDD: 260},
+		},
+	}}
+	if err := tmpl.Execute(&output, data); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	for _, want := range []string{"Градусо-сутки · Отопительный год 2026/27", "2 из 5 суток подряд", "Октябрь 2026", "11.5"} {
+		if !bytes.Contains(output.Bytes(), []byte(want)) {
+			t.Fatalf("heating section is missing %q", want)
+		}
+	}
+}
+
+func TestInsightsTemplateRendersMeteoSensitivity(t *testing.T) {
+	tmpl := loadTemplate(t, "insights.html")
+
+	day := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+	pressure, kp := -9.4, 5.3
+	today := models.MeteoSensitivityDay{
+		Date: day, Index: 6.2, Level: models.RiskHigh, Forecast: true,
+		Factors: []models.MeteoFactor{
+			{Key: models.MeteoFactorPressure, Label: "Давление", Value: &pressure, Unit: "мм/сут", Score: 3, Weight: 0.35, Text: "давление упало на 9 мм за сутки"},
+			{Key: models.MeteoFactorHumidity, Label: "Влажность", Unit: "%", Weight: 0.15},
+			{Key: models.MeteoFactorGeomagnetic, Label: "Геомагнитка", Value: &kp, Unit: "Kp", Score: 2, Weight: 0.25, Text: "магнитная буря G1 (Kp 5)"},
+		},
+	}
+	var output bytes.Buffer
+	data := PageData{ActivePage: "insights", Data: &models.WeatherArchivePage{
+		Period: "month", Metric: "all", PeriodLabel: "Октябрь 2026",
+		Summary: models.WeatherArchiveSummary{DaysWithData: 1, DaysInPeriod: 1},
+		MeteoSensitivity: &models.MeteoSensitivity{
+			Today:  today,
+			Title:  "Тяжёлый день для метеочувствительных",
+			Advice: []string{"Давление падает: больше воды, меньше нагрузок"},
+			Month:  []models.MeteoSensitivityDay{today},
+		},
+	}}
+	if err := tmpl.Execute(&output, data); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	for _, want := range []string{"Сегодня: Тяжёлый день для метеочувствительных", "6.2 из 10", "-9.4", "упало на 9 мм", "нет данных", "больше воды", `"index":6.2`} {
+		if !bytes.Contains(output.Bytes(), []byte(want)) {
+			t.Fatalf("meteosensitivity section is missing %q", want)
+		}
+	}
+}
+
+func TestInsightsTemplateRendersSolarWeek(t *testing.T) {
+	tmpl := loadTemplate(t, "insights.html")
+
+	day := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+	maxApDate := day.AddDate(0, 0, -3)
+	f10, prevF10, ap := 150.0, 140.0, 7.9
+	maxAp := float32(25)
+	var output bytes.Buffer
+	data := PageData{ActivePage: "insights", Data: &models.WeatherArchivePage{
+		Period: "month", Metric: "all", PeriodLabel: "Октябрь 2026",
+		Summary: models.WeatherArchiveSummary{DaysWithData: 1, DaysInPeriod: 1},
+		SolarWeek: &models.SolarActivityWeek{
+			From: day.AddDate(0, 0, -6), To: day,
+			F10Avg: &f10, PrevF10Avg: &prevF10, ApAvg: &ap,
+			MaxAp: &maxAp, MaxApDate: &maxApDate, DisturbedDays: 1,
+			Outlook: []models.SolarActivityOutlook{{Date: day.AddDate(0, 0, 2), SourceDate: day.AddDate(0, 0, -25)}},
+			Summary: "Поток F10.7 в среднем 150 sfu (+10 к прошлой неделе)",
+		},
+	}}
+	if err := tmpl.Execute(&output, data); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	for _, want := range []string{"Солнце за неделю", "возмущённых дней: 1", "Поток F10.7 в среднем 150 sfu", "неделей раньше 140", "максимум 25", "повтор"} {
+		if !bytes.Contains(output.Bytes(), []byte(want)) {
+			t.Fatalf("solar week section is missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/iRootPro/weather/internal/service"
+)
+
+// DetailPV рендерит страницу оценки выработки солнечных панелей.
+func (h *Handler) DetailPV(w http.ResponseWriter, r *http.Request) {
+	if h.pvService == nil {
+		http.Error(w, "PV system not configured", http.StatusServiceUnavailable)
+		return
+	}
+
+	report, err := h.pvService.GetReport(r.Context(), r.URL.Query().Get("date"))
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidPVDate) {
+			http.Error(w, "Invalid date", http.StatusBadRequest)
+			return
+		}
+		slog.Error("failed to get pv report", "error", err)
+		http.Error(w, "Failed to load data", http.StatusInternalServerError)
+		return
+	}
+
+	// Точки для Chart.js: почасовая выработка выбранных суток и суточная за 30 дней
+	type hourPoint struct {
+		Time   string  `json:"time"`
+		Energy float64 `json:"energy"`
+		POA    float64 `json:"poa"`
+		GHI    float64 `json:"ghi"`
+	}
+	type dayPoint struct {
+		Date   string  `json:"date"`
+		Energy float64 `json:"energy"`
+	}
+	hours := make([]hourPoint, 0, len(report.Day.Hours))
+	for _, hour := range report.Day.Hours {
+		hours = append(hours, hourPoint{
+			Time:   hour.Time.UTC().Format(time.RFC3339),
+			Energy: hour.EnergyKWh,
+			POA:    hour.POA,
+			GHI:    hour.GHI,
+		})
+	}
+	days := make([]dayPoint, 0, len(report.Days))
+	for _, day := range report.Days {
+		days = append(days, dayPoint{Date: day.Date.Format("2006-01-02"), Energy: day.EnergyKWh})
+	}
+	hoursJSON, err := json.Marshal(hours)
+	if err != nil {
+		slog.Error("failed to marshal pv hourly points", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	daysJSON, err := json.Marshal(days)
+	if err != nil {
+		slog.Error("failed to marshal pv daily points", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	tmpl, err := h.parseTemplate("detail/pv.html")
+	if err != nil {
+		slog.Error("failed to parse pv detail template", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	data := PageData{
+		ActivePage: "dashboard",
+		Data: map[string]any{
+			"Report":    report,
+			"PrevDate":  report.Day.Date.AddDate(0, 0, -1).Format("2006-01-02"),
+			"NextDate":  report.Day.Date.AddDate(0, 0, 1).Format("2006-01-02"),
+			"HasNext":   !report.Day.Partial,
+			"HoursJSON": string(hoursJSON),
+			"DaysJSON":  string(daysJSON),
+		},
+	}
+	if err := tmpl.Execute(w, data); err != nil {
+		slog.Error("failed to render pv detail", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
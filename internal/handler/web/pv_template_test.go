@@ -0,0 +1,43 @@
+package web
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+func TestPVTemplateRendersReport(t *testing.T) {
+	tmpl := loadTemplate(t, "detail/pv.html")
+
+	date := time.Date(2026, time.June, 21, 0, 0, 0, 0, time.UTC)
+	cellTemp := 48.5
+	hour := models.PVHour{Time: date.Add(12 * time.Hour), GHI: 880, POA: 910, CellTemp: &cellTemp, PowerKW: 3.52, EnergyKWh: 3.52}
+	var output bytes.Buffer
+	data := PageData{ActivePage: "dashboard", Data: map[string]any{
+		"Report": &models.PVReport{
+			System: models.PVSystem{CapacityKWp: 5, Tilt: 30, Azimuth: 180, LossesPct: 14, TempCoefficient: -0.4},
+			Day:    models.PVDay{Date: date, EnergyKWh: 31.4, SpecificYield: 6.28, Insolation: 8.1, PeakPowerKW: 3.52, Hours: []models.PVHour{hour}},
+			Days:   []models.PVDay{{Date: date, EnergyKWh: 31.4}},
+			Months: []models.PVMonth{
+				{Month: date.AddDate(0, -1, -20), Label: "Май 2026", Days: 31, EnergyKWh: 702, SpecificYield: 140, AvgDailyKWh: 22.6},
+				{Month: date.AddDate(0, 0, -20), Label: "Июнь 2026", Partial: true},
+			},
+		},
+		"PrevDate":  "2026-06-20",
+		"NextDate":  "2026-06-22",
+		"HasNext":   true,
+		"HoursJSON": "[]",
+		"DaysJSON":  "[]",
+	}}
+	if err := tmpl.Execute(&output, data); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	for _, want := range []string{"31.4", "6.28 кВт·ч/кВт", "48.5", "Май 2026", "Июнь 2026 (идёт)", "нет данных", "date=2026-06-22"} {
+		if !bytes.Contains(output.Bytes(), []byte(want)) {
+			t.Fatalf("rendered page is missing %q", want)
+		}
+	}
+}
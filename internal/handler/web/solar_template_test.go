@@ -0,0 +1,47 @@
+package web
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+func TestSolarTemplateRendersUVExposure(t *testing.T) {
+	tmpl := loadTemplate(t, "detail/solar.html")
+
+	noon := time.Date(2026, time.June, 21, 13, 0, 0, 0, time.UTC)
+	uv, burn := 7.0, 24
+	exposure := &models.UVExposure{
+		UVIndex:      &uv,
+		Source:       "station",
+		Level:        "высокий",
+		PeakUV:       7.4,
+		PeakTime:     noon,
+		DailyDoseSED: 38.2,
+		SkinTypes: []models.UVSkinType{
+			{Type: 2, Name: "светлая, легко сгорает", MED: 250, BurnMinutes: &burn, DailyMEDs: 15.3},
+		},
+		Hours: []models.UVHour{
+			{Time: noon.Add(-time.Hour), UVIndex: 6.8, DoseSED: 20.1},
+			{Time: noon, UVIndex: 7.4, Forecast: true, DoseSED: 26.8},
+		},
+	}
+	var output bytes.Buffer
+	data := PageData{ActivePage: "dashboard", Data: map[string]any{
+		"UVExposure": exposure,
+		"Chart24h":   "[]",
+		"Chart7d":    "[]",
+		"Chart30d":   "[]",
+	}}
+	if err := tmpl.Execute(&output, data); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	for _, want := range []string{"UV и солнечный ожог", "7.0", "в 13:00", "38.2 SED", "24 мин", "15.3", "13:00 · прогноз", "26.80"} {
+		if !bytes.Contains(output.Bytes(), []byte(want)) {
+			t.Fatalf("rendered page is missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package web
+
+import (
+	"html/template"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// testHandler — Handler без сервисов, читающий шаблоны из internal/web/templates
+func testHandler(t *testing.T) *Handler {
+	t.Helper()
+	_, filename, _, ok := runtime.Caller(0)
+	if !ok {
+		t.Fatal("could not locate test file")
+	}
+	return &Handler{templatesDir: filepath.Join(filepath.Dir(filename), "..", "..", "web", "templates")}
+}
+
+// loadTemplate разбирает страницу вместе с base.html
+func loadTemplate(t *testing.T, name string) *template.Template {
+	t.Helper()
+	tmpl, err := testHandler(t).parseTemplate(name)
+	if err != nil {
+		t.Fatalf("parseTemplate(%q) error = %v", name, err)
+	}
+	return tmpl
+}
+
+// loadPartial разбирает шаблон виджета
+func loadPartial(t *testing.T, name string) *template.Template {
+	t.Helper()
+	tmpl, err := testHandler(t).parsePartial(name)
+	if err != nil {
+		t.Fatalf("parsePartial(%q) error = %v", name, err)
+	}
+	return tmpl
+}
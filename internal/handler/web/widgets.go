@@ -518,6 +518,28 @@ func (h *Handler) WeatherEventsWidget(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// OnThisDayWidget renders the weather of today's date in previous years
+func (h *Handler) OnThisDayWidget(w http.ResponseWriter, r *http.Request) {
+	onThisDay, err := h.weatherService.GetOnThisDay(r.Context(), "")
+	if err != nil {
+		slog.Error("failed to get on this day history", "error", err)
+		http.Error(w, "Failed to load on this day history", http.StatusInternalServerError)
+		return
+	}
+
+	tmpl, err := h.parsePartial("on_this_day.html")
+	if err != nil {
+		slog.Error("failed to parse on this day template", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := tmpl.Execute(w, onThisDay); err != nil {
+		slog.Error("failed to render on this day widget", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
 // ForecastWidget renders the weather forecast widget
 func (h *Handler) ForecastWidget(w http.ResponseWriter, r *http.Request) {
 	if h.forecastService == nil {
@@ -564,9 +586,12 @@ func (h *Handler) ForecastWidget(w http.ResponseWriter, r *http.Request) {
 		WeatherDescription       string // "Снег", "Дождь", "Облачно" и т.д.
 		PrecipitationProbability int16
 		HasPrecipitation         bool
+		Uncertainty              string // "medium" / "high", пусто — модели согласны
+		UncertaintyText          string // "±2° по моделям"
 	}
 
 	cards := make([]ForecastCard, 0)
+	ensembleMembers := 0
 
 	// Добавляем почасовые карточки (каждые 3 часа, максимум 3 карточки)
 	hourCount := 0
@@ -591,6 +616,13 @@ func (h *Handler) ForecastWidget(w http.ResponseWriter, r *http.Request) {
 				PrecipitationProbability: hf.PrecipitationProbability,
 				HasPrecipitation:         hf.PrecipitationProbability > 0,
 			}
+			if e := hf.Ensemble; e != nil {
+				ensembleMembers = max(ensembleMembers, len(e.Members))
+				if e.Uncertainty != models.ForecastUncertaintyLow {
+					card.Uncertainty = e.Uncertainty
+					card.UncertaintyText = fmt.Sprintf("±%.0f° по моделям", max(e.Temperature.Spread, 1))
+				}
+			}
 			cards = append(cards, card)
 			hourCount++
 		}
@@ -615,15 +647,24 @@ func (h *Handler) ForecastWidget(w http.ResponseWriter, r *http.Request) {
 			PrecipitationProbability: df.PrecipitationProbability,
 			HasPrecipitation:         df.PrecipitationProbability > 0,
 		}
+		if e := df.Ensemble; e != nil {
+			ensembleMembers = max(ensembleMembers, len(e.Members))
+			if e.Uncertainty != models.ForecastUncertaintyLow {
+				card.Uncertainty = e.Uncertainty
+				card.UncertaintyText = dailyUncertaintyText(e)
+			}
+		}
 		cards = append(cards, card)
 	}
 
 	templateData := struct {
-		Cards      []ForecastCard
-		NoForecast bool
+		Cards           []ForecastCard
+		NoForecast      bool
+		EnsembleMembers int
 	}{
-		Cards:      cards,
-		NoForecast: len(cards) == 0,
+		Cards:           cards,
+		NoForecast:      len(cards) == 0,
+		EnsembleMembers: ensembleMembers,
 	}
 
 	tmpl, err := h.parsePartial("forecast.html")
@@ -639,6 +680,74 @@ func (h *Handler) ForecastWidget(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// ActivitiesWidget renders suitability scores for outdoor activities
+func (h *Handler) ActivitiesWidget(w http.ResponseWriter, r *http.Request) {
+	if h.activityService == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	forecast, err := h.activityService.GetForecast(r.Context())
+	if err != nil {
+		slog.Error("failed to get activity forecast", "error", err)
+		http.Error(w, "Failed to load activities", http.StatusInternalServerError)
+		return
+	}
+
+	tmpl, err := h.parsePartial("activities.html")
+	if err != nil {
+		slog.Error("failed to parse activities template", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := tmpl.Execute(w, forecast); err != nil {
+		slog.Error("failed to render activities widget", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// ClothingWidget renders what to wear for the rest of the day
+func (h *Handler) ClothingWidget(w http.ResponseWriter, r *http.Request) {
+	if h.clothingService == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	advice, err := h.clothingService.GetAdvice(r.Context())
+	if err != nil {
+		slog.Error("failed to get clothing advice", "error", err)
+		http.Error(w, "Failed to load clothing advice", http.StatusInternalServerError)
+		return
+	}
+	if len(advice.Windows) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	tmpl, err := h.parsePartial("clothing.html")
+	if err != nil {
+		slog.Error("failed to parse clothing template", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := tmpl.Execute(w, advice); err != nil {
+		slog.Error("failed to render clothing widget", "error", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// dailyUncertaintyText коротко объясняет, в чём расходятся модели
+func dailyUncertaintyText(e *models.DailyEnsemble) string {
+	total := len(e.Members)
+	if e.RainyMembers > 0 && e.RainyMembers < total {
+		return fmt.Sprintf("дождь у %d из %d моделей", e.RainyMembers, total)
+	}
+	spread := max(e.TemperatureMin.Spread, e.TemperatureMax.Spread, 1)
+	return fmt.Sprintf("±%.0f° по моделям", spread)
+}
+
 // NarodmonStatusWidget renders the narodmon status widget
 func (h *Handler) NarodmonStatusWidget(w http.ResponseWriter, r *http.Request) {
 	// Если сервис не настроен - не показываем виджет
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -64,6 +65,52 @@ func (c *Client) SendMessageToUser(ctx context.Context, userID int64, body NewMe
 	return c.do(ctx, http.MethodPost, "/messages", q, body, nil)
 }
 
+// UploadImage uploads an image and returns a message attachment for it: /uploads
+// issues an upload URL, then the file is posted there as the "data" form field.
+func (c *Client) UploadImage(ctx context.Context, name string, data []byte) (*ImageAttachment, error) {
+	q := url.Values{}
+	q.Set("type", "image")
+	var endpoint UploadEndpoint
+	if err := c.do(ctx, http.MethodPost, "/uploads", q, nil, &endpoint); err != nil {
+		return nil, err
+	}
+	if endpoint.URL == "" {
+		return nil, fmt.Errorf("max api returned empty upload url")
+	}
+
+	var form bytes.Buffer
+	w := multipart.NewWriter(&form)
+	part, err := w.CreateFormFile("data", name)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := part.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, &form)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", c.token)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	payload, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("max image upload failed: status=%d body=%s", resp.StatusCode, string(payload))
+	}
+	if !json.Valid(payload) {
+		return nil, fmt.Errorf("max image upload returned invalid payload")
+	}
+	return &ImageAttachment{Type: "image", Payload: payload}, nil
+}
+
 func (c *Client) AnswerCallback(ctx context.Context, callbackID, notification string) error {
 	q := url.Values{}
 	q.Set("callback_id", callbackID)
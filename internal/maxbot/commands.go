@@ -17,6 +17,11 @@ const (
 	EventWind         = "wind"
 	EventPressure     = "pressure"
 	EventDailySummary = "daily_summary"
+	EventRainSoon     = "rain_soon"
+	EventFrostRisk    = "frost_risk"
+	EventIceRisk      = "ice_risk"
+	EventGardenWeekly = "garden_weekly"
+	EventHydro        = "hydro"
 )
 
 func subscriptionTypeForWeatherEvent(eventType string) string {
@@ -29,6 +34,12 @@ func subscriptionTypeForWeatherEvent(eventType string) string {
 		return EventWind
 	case "pressure_rise", "pressure_drop":
 		return EventPressure
+	case "rain_soon":
+		return EventRainSoon
+	case "frost_risk":
+		return EventFrostRisk
+	case "ice_risk":
+		return EventIceRisk
 	default:
 		return ""
 	}
@@ -11,17 +11,18 @@ import (
 )
 
 type DailySummaryService struct {
-	client     *Client
-	weatherSvc *service.WeatherService
-	sunSvc     *service.SunService
-	geomagSvc  *service.GeomagneticService
-	subRepo    repository.MaxSubscriptionRepository
-	sendTime   string
-	logger     *slog.Logger
+	client      *Client
+	weatherSvc  *service.WeatherService
+	sunSvc      *service.SunService
+	geomagSvc   *service.GeomagneticService
+	clothingSvc *service.ClothingService
+	subRepo     repository.MaxSubscriptionRepository
+	sendTime    string
+	logger      *slog.Logger
 }
 
-func NewDailySummaryService(client *Client, weatherSvc *service.WeatherService, sunSvc *service.SunService, geomagSvc *service.GeomagneticService, subRepo repository.MaxSubscriptionRepository, sendTime string, logger *slog.Logger) *DailySummaryService {
-	return &DailySummaryService{client: client, weatherSvc: weatherSvc, sunSvc: sunSvc, geomagSvc: geomagSvc, subRepo: subRepo, sendTime: sendTime, logger: logger}
+func NewDailySummaryService(client *Client, weatherSvc *service.WeatherService, sunSvc *service.SunService, geomagSvc *service.GeomagneticService, clothingSvc *service.ClothingService, subRepo repository.MaxSubscriptionRepository, sendTime string, logger *slog.Logger) *DailySummaryService {
+	return &DailySummaryService{client: client, weatherSvc: weatherSvc, sunSvc: sunSvc, geomagSvc: geomagSvc, clothingSvc: clothingSvc, subRepo: subRepo, sendTime: sendTime, logger: logger}
 }
 
 func (s *DailySummaryService) Start(ctx context.Context) {
@@ -57,36 +58,18 @@ func (s *DailySummaryService) sendDailySummary(ctx context.Context) {
 		return
 	}
 
-	current, err := s.weatherSvc.GetLatest(ctx)
+	input, err := telegram.BuildDailySummaryInput(ctx, telegram.DailySummarySources{
+		Weather:     s.weatherSvc,
+		Sun:         s.sunSvc,
+		Geomagnetic: s.geomagSvc,
+		Clothing:    s.clothingSvc,
+	}, s.logger)
 	if err != nil {
 		s.logger.Error("failed to get current weather for max daily summary", "error", err)
 		return
 	}
-	yesterdaySame, err := s.weatherSvc.GetDataNearTime(ctx, current.Time.Add(-24*time.Hour))
-	if err != nil {
-		s.logger.Warn("failed to get yesterday weather", "error", err)
-	}
-
-	now := time.Now()
-	nightStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-	nightEnd := time.Date(now.Year(), now.Month(), now.Day(), 7, 0, 0, 0, now.Location())
-	nightMinMax, err := s.weatherSvc.GetMinMaxInRange(ctx, nightStart, nightEnd)
-	if err != nil {
-		s.logger.Warn("failed to get night min/max", "error", err)
-	}
-	dailyMinMax, err := s.weatherSvc.GetDailyMinMax(ctx)
-	if err != nil {
-		s.logger.Warn("failed to get daily min/max", "error", err)
-	}
-
-	var geomagSnap *service.DashboardSnapshot
-	if s.geomagSvc != nil {
-		if snap, err := s.geomagSvc.GetDashboardSnapshot(ctx, time.Now()); err == nil && snap != nil && snap.HasData {
-			geomagSnap = snap
-		}
-	}
 
-	text := telegram.FormatDailySummary(current, yesterdaySame, nightMinMax, dailyMinMax, s.sunSvc.GetTodaySunTimesWithComparison(), nil, geomagSnap)
+	text := telegram.FormatDailySummary(input)
 	for _, userID := range subscribers {
 		if err := s.client.SendMessageToUser(ctx, userID, textMessage(text)); err != nil {
 			s.logger.Error("failed to send max daily summary", "user_id", userID, "error", err)
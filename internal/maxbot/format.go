@@ -35,6 +35,11 @@ func GetEventTypeName(eventType string) string {
 		EventWind:         "Ветер",
 		EventPressure:     "Давление",
 		EventDailySummary: "Утренняя сводка",
+		EventRainSoon:     "Скоро дождь",
+		EventFrostRisk:    "Заморозки",
+		EventIceRisk:      "Гололёд",
+		EventGardenWeekly: "Огород (раз в неделю)",
+		EventHydro:        "Уровень воды",
 	}
 	if name, ok := names[eventType]; ok {
 		return name
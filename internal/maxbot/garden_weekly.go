@@ -0,0 +1,71 @@
+package maxbot
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/iRootPro/weather/internal/repository"
+	"github.com/iRootPro/weather/internal/service"
+	"github.com/iRootPro/weather/internal/telegram"
+)
+
+type GardenWeeklyService struct {
+	client   *Client
+	agroSvc  *service.AgroService
+	subRepo  repository.MaxSubscriptionRepository
+	weekday  time.Weekday
+	sendTime string
+	logger   *slog.Logger
+}
+
+func NewGardenWeeklyService(client *Client, agroSvc *service.AgroService, subRepo repository.MaxSubscriptionRepository, weekday time.Weekday, sendTime string, logger *slog.Logger) *GardenWeeklyService {
+	return &GardenWeeklyService{client: client, agroSvc: agroSvc, subRepo: subRepo, weekday: weekday, sendTime: sendTime, logger: logger}
+}
+
+func (s *GardenWeeklyService) Start(ctx context.Context) {
+	s.logger.Info("max garden weekly service started", "weekday", s.weekday, "send_time", s.sendTime)
+	hour, minute := 18, 0
+	if parsed, err := time.Parse("15:04", s.sendTime); err == nil {
+		hour, minute = parsed.Hour(), parsed.Minute()
+	}
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+	lastSent := time.Time{}
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("max garden weekly service stopped")
+			return
+		case now := <-ticker.C:
+			if now.Weekday() == s.weekday && now.Hour() == hour && now.Minute() == minute && !(lastSent.Year() == now.Year() && lastSent.YearDay() == now.YearDay()) {
+				s.sendGardenWeekly(ctx)
+				lastSent = now
+			}
+		}
+	}
+}
+
+func (s *GardenWeeklyService) sendGardenWeekly(ctx context.Context) {
+	subscribers, err := s.subRepo.GetActiveSubscribers(ctx, EventGardenWeekly)
+	if err != nil {
+		s.logger.Error("failed to get max garden weekly subscribers", "error", err)
+		return
+	}
+	if len(subscribers) == 0 {
+		return
+	}
+
+	summary, err := s.agroSvc.GetSummary(ctx)
+	if err != nil {
+		s.logger.Error("failed to get agro summary for max", "error", err)
+		return
+	}
+
+	text := telegram.FormatGardenWeekly(summary)
+	for _, userID := range subscribers {
+		if err := s.client.SendMessageToUser(ctx, userID, textMessage(text)); err != nil {
+			s.logger.Error("failed to send max garden weekly", "user_id", userID, "error", err)
+		}
+	}
+}
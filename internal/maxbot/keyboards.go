@@ -32,7 +32,11 @@ func subscriptionKeyboard() []interface{} {
 			Payload: InlineKeyboardPayload{Buttons: [][]Button{
 				{{Type: "callback", Text: "🌅 Утренняя сводка", Payload: "sub_daily_summary"}},
 				{{Type: "callback", Text: "🔔 Все события", Payload: "sub_all"}},
-				{{Type: "callback", Text: "🌧️ Дождь", Payload: "sub_rain"}, {Type: "callback", Text: "🌡️ Температура", Payload: "sub_temperature"}},
+				{{Type: "callback", Text: "🌧️ Дождь", Payload: "sub_rain"}, {Type: "callback", Text: "☂️ Скоро дождь", Payload: "sub_rain_soon"}},
+				{{Type: "callback", Text: "🌡️ Температура", Payload: "sub_temperature"}},
+				{{Type: "callback", Text: "❄️ Заморозки", Payload: "sub_frost_risk"}, {Type: "callback", Text: "🧊 Гололёд", Payload: "sub_ice_risk"}},
+				{{Type: "callback", Text: "🥕 Огород (раз в неделю)", Payload: "sub_garden_weekly"}},
+				{{Type: "callback", Text: "💧 Уровень воды", Payload: "sub_hydro"}},
 				{{Type: "callback", Text: "💨 Ветер", Payload: "sub_wind"}, {Type: "callback", Text: "🔽 Давление", Payload: "sub_pressure"}},
 				{{Type: "callback", Text: "❌ Отписаться от всех", Payload: "unsub_all"}},
 			}},
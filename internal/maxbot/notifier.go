@@ -18,12 +18,15 @@ type Notifier struct {
 	subRepo    repository.MaxSubscriptionRepository
 	notifRepo  repository.MaxNotificationRepository
 	userRepo   repository.MaxUserRepository
+	rainSoon   *service.RainNowcastService
+	frostRisk  *service.FrostRiskService
+	hydro      *service.HydroAlertService
 	interval   time.Duration
 	logger     *slog.Logger
 }
 
-func NewNotifier(client *Client, weatherSvc *service.WeatherService, subRepo repository.MaxSubscriptionRepository, notifRepo repository.MaxNotificationRepository, userRepo repository.MaxUserRepository, interval int, logger *slog.Logger) *Notifier {
-	return &Notifier{client: client, weatherSvc: weatherSvc, subRepo: subRepo, notifRepo: notifRepo, userRepo: userRepo, interval: time.Duration(interval) * time.Second, logger: logger}
+func NewNotifier(client *Client, weatherSvc *service.WeatherService, subRepo repository.MaxSubscriptionRepository, notifRepo repository.MaxNotificationRepository, userRepo repository.MaxUserRepository, rainSoon *service.RainNowcastService, frostRisk *service.FrostRiskService, hydro *service.HydroAlertService, interval int, logger *slog.Logger) *Notifier {
+	return &Notifier{client: client, weatherSvc: weatherSvc, subRepo: subRepo, notifRepo: notifRepo, userRepo: userRepo, rainSoon: rainSoon, frostRisk: frostRisk, hydro: hydro, interval: time.Duration(interval) * time.Second, logger: logger}
 }
 
 func (n *Notifier) Start(ctx context.Context) {
@@ -51,6 +54,111 @@ func (n *Notifier) checkAndNotify(ctx context.Context) {
 	for _, event := range events {
 		n.processEvent(ctx, event)
 	}
+	n.checkRainSoon(ctx)
+	n.checkFrostRisk(ctx)
+	n.checkHydro(ctx)
+}
+
+func (n *Notifier) checkRainSoon(ctx context.Context) {
+	if n.rainSoon == nil {
+		return
+	}
+	event, err := n.rainSoon.GetRainSoonEvent(ctx)
+	if err != nil {
+		n.logger.Error("failed to get rain nowcast for max", "error", err)
+		return
+	}
+	if event != nil {
+		n.processEvent(ctx, *event)
+	}
+}
+
+func (n *Notifier) checkFrostRisk(ctx context.Context) {
+	if n.frostRisk == nil {
+		return
+	}
+	events, err := n.frostRisk.GetFrostRiskEvents(ctx)
+	if err != nil {
+		n.logger.Error("failed to get frost risk for max", "error", err)
+		return
+	}
+	for _, event := range events {
+		n.processEvent(ctx, event)
+	}
+}
+
+// checkHydro sends water level alerts with the level chart attached. The chart
+// is uploaded once per alert; if it fails, subscribers get the text alone.
+func (n *Notifier) checkHydro(ctx context.Context) {
+	if n.hydro == nil {
+		return
+	}
+	subscribers, err := n.getSubscribersForEvent(ctx, EventHydro)
+	if err != nil || len(subscribers) == 0 {
+		return
+	}
+	now := time.Now()
+	alerts, err := n.hydro.GetAlerts(ctx, now)
+	if err != nil {
+		n.logger.Error("failed to get hydro alerts for max", "error", err)
+		return
+	}
+	type recipient struct {
+		maxUserID int64
+		userID    int64
+	}
+	for _, alert := range alerts {
+		// Collect recipients first so the chart is rendered and uploaded only when
+		// someone still needs this alert
+		var pending []recipient
+		for _, maxUserID := range subscribers {
+			user, err := n.userRepo.GetByUserID(ctx, maxUserID)
+			if err != nil {
+				n.logger.Error("failed to get max user", "max_user_id", maxUserID, "error", err)
+				continue
+			}
+			wasSent, err := n.notifRepo.WasRecentlySent(ctx, user.ID, alert.Key, service.HYDRO_ALERT_HISTORY_HOURS*time.Hour)
+			if err != nil {
+				n.logger.Error("failed to check max hydro dedup", "user_id", user.ID, "error", err)
+				continue
+			}
+			if !wasSent {
+				pending = append(pending, recipient{maxUserID: maxUserID, userID: user.ID})
+			}
+		}
+		if len(pending) == 0 {
+			continue
+		}
+
+		body := textMessage(telegram.FormatHydroAlert(alert))
+		if image, err := n.uploadHydroChart(ctx, alert, now); err != nil {
+			n.logger.Warn("failed to attach hydro chart for max", "station_uuid", alert.StationUUID, "error", err)
+		} else {
+			body.Attachments = []interface{}{image}
+		}
+		eventData, _ := json.Marshal(alert)
+		for _, r := range pending {
+			if err := n.client.SendMessageToUser(ctx, r.maxUserID, body); err != nil {
+				n.logger.Error("failed to send max hydro alert", "max_user_id", r.maxUserID, "error", err)
+				continue
+			}
+			if err := n.notifRepo.Create(ctx, &models.MaxNotification{UserID: r.userID, EventType: alert.Key, EventData: eventData, SentAt: time.Now()}); err != nil {
+				n.logger.Error("failed to save max notification", "error", err)
+			}
+		}
+	}
+}
+
+func (n *Notifier) uploadHydroChart(ctx context.Context, alert models.HydroAlert, now time.Time) (*ImageAttachment, error) {
+	readings, gauge, err := n.hydro.ChartData(ctx, alert.StationUUID, now)
+	if err != nil {
+		return nil, err
+	}
+	png, err := telegram.GenerateHydroChart(readings, gauge, alert.StationName)
+	if err != nil {
+		return nil, err
+	}
+	return n.client.UploadImage(ctx, "hydro.png", png)
 }
 
 func (n *Notifier) processEvent(ctx context.Context, event models.WeatherEvent) {
@@ -98,7 +206,14 @@ func (n *Notifier) sendNotification(ctx context.Context, maxUserID int64, event
 		return
 	}
 	subscriptionType := subscriptionTypeForWeatherEvent(event.Type)
-	wasSent, err := n.notifRepo.WasRecentlySent(ctx, user.ID, subscriptionType, 60*time.Minute)
+	dedupWindow := 60 * time.Minute
+	switch subscriptionType {
+	case EventRainSoon:
+		dedupWindow = service.RAIN_SOON_EPISODE_HOURS * time.Hour
+	case EventFrostRisk, EventIceRisk:
+		dedupWindow = 12 * time.Hour
+	}
+	wasSent, err := n.notifRepo.WasRecentlySent(ctx, user.ID, subscriptionType, dedupWindow)
 	if err != nil {
 		n.logger.Error("failed to check max notification dedup", "user_id", user.ID, "error", err)
 		return
@@ -82,6 +82,17 @@ type InlineKeyboardAttachment struct {
 	Payload InlineKeyboardPayload `json:"payload"`
 }
 
+// ImageAttachment references an uploaded image. Payload is the upload server
+// response passed through as is.
+type ImageAttachment struct {
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+type UploadEndpoint struct {
+	URL string `json:"url"`
+}
+
 type ReplyKeyboardAttachment struct {
 	Type    string          `json:"type"`
 	Buttons [][]ReplyButton `json:"buttons"`
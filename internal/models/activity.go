@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// Виды активностей для оценки погоды
+const (
+	ActivityRunning  = "running"
+	ActivityCycling  = "cycling"
+	ActivityFishing  = "fishing"
+	ActivityBarbecue = "barbecue"
+	ActivityLaundry  = "laundry"
+)
+
+// ActivityHour — оценка условий для активности на час
+type ActivityHour struct {
+	Time     time.Time `json:"time"`
+	Score    int       `json:"score"` // 0..100
+	Rating   string    `json:"rating"`
+	Reasons  []string  `json:"reasons,omitempty"` // что снижает оценку
+	Observed bool      `json:"observed"`          // текущий час — по показаниям станции
+}
+
+// ActivityScore — оценка активности на ближайшие часы
+type ActivityScore struct {
+	Key      string         `json:"key"`
+	Name     string         `json:"name"`
+	Icon     string         `json:"icon"`
+	Now      ActivityHour   `json:"now"`
+	Best     ActivityHour   `json:"best"`
+	BestFrom time.Time      `json:"best_from"` // окно хороших условий вокруг лучшего часа
+	BestTo   time.Time      `json:"best_to"`   // конец окна (исключительно)
+	Summary  string         `json:"summary"`
+	Hours    []ActivityHour `json:"hours"`
+}
+
+// ActivityForecast — оценки активностей на ближайшие часы
+type ActivityForecast struct {
+	GeneratedAt    time.Time       `json:"generated_at"`
+	Hours          int             `json:"hours"`
+	PressureChange *float64        `json:"pressure_change,omitempty"` // изменение давления за 3 часа по станции, мм рт. ст.
+	MoonPhase      string          `json:"moon_phase"`
+	MoonAge        float64         `json:"moon_age"` // суток от новолуния
+	Activities     []ActivityScore `json:"activities"`
+}
@@ -0,0 +1,76 @@
+package models
+
+import "time"
+
+// AgroDailyInput — суточные агрегаты станции для агрометеорологических расчётов
+type AgroDailyInput struct {
+	Date time.Time `json:"date"`
+
+	TempMin *float32 `json:"temp_min,omitempty"`
+	TempMax *float32 `json:"temp_max,omitempty"`
+	TempAvg *float32 `json:"temp_avg,omitempty"`
+
+	HumidityMin *int16 `json:"humidity_min,omitempty"`
+	HumidityMax *int16 `json:"humidity_max,omitempty"`
+
+	WindAvg     *float32 `json:"wind_avg,omitempty"`     // м/с на высоте датчика
+	SolarAvg    *float32 `json:"solar_avg,omitempty"`    // средняя за сутки радиация, Вт/м²
+	PressureAvg *float32 `json:"pressure_avg,omitempty"` // абсолютное давление, мм рт.ст.
+	RainTotal   *float32 `json:"rain_total,omitempty"`   // мм, max(rain_daily)
+
+	ChillHours float32 `json:"chill_hours"` // часов с температурой 0…7.2 °C
+	Coverage   float32 `json:"coverage"`    // доля суток, покрытая измерениями (0..1)
+}
+
+// AgroDay — агрометеорологические показатели за сутки
+type AgroDay struct {
+	Date       time.Time `json:"date"`
+	TempMin    float32   `json:"temp_min"`
+	TempMax    float32   `json:"temp_max"`
+	GDD        float64   `json:"gdd"`         // градусо-дни выше базовой температуры
+	ChillHours float64   `json:"chill_hours"` // часы охлаждения
+	ET0        float64   `json:"et0"`         // эталонная эвапотранспирация FAO-56, мм
+	ETc        float64   `json:"etc"`         // расход воды культурой (ET0 × Kc), мм
+	Rain       float64   `json:"rain"`        // осадки, мм
+	SoilWater  float64   `json:"soil_water"`  // доступная влага в почве на конец суток, мм
+	Depletion  float64   `json:"depletion"`   // дефицит до полной влагоёмкости, мм
+	ET0Method  string    `json:"et0_method"`  // "penman-monteith" или "hargreaves" (нет данных радиации)
+}
+
+// Уровни рекомендации по поливу
+const (
+	WateringNotNeeded = "not_needed"
+	WateringSoon      = "soon"
+	WateringNow       = "now"
+)
+
+// AgroSummary — сводка для страницы «Огород» и еженедельного сообщения бота
+type AgroSummary struct {
+	GeneratedAt time.Time `json:"generated_at"`
+
+	GDDBase        float64   `json:"gdd_base"`
+	GDDSeasonStart time.Time `json:"gdd_season_start"`
+	GDDSeason      float64   `json:"gdd_season"`
+	GDDWeek        float64   `json:"gdd_week"`
+
+	ChillSeasonStart time.Time `json:"chill_season_start"`
+	ChillHours       float64   `json:"chill_hours"`
+
+	CropCoefficient float64 `json:"crop_coefficient"`
+	ET0Week         float64 `json:"et0_week"`
+	ETcWeek         float64 `json:"etc_week"`
+	RainWeek        float64 `json:"rain_week"`
+
+	SoilWaterCapacity float64 `json:"soil_water_capacity"` // доступная влагоёмкость корнеобитаемого слоя, мм
+	SoilWater         float64 `json:"soil_water"`
+	Depletion         float64 `json:"depletion"`
+	DepletionPercent  int     `json:"depletion_percent"`
+
+	ForecastRain  float64 `json:"forecast_rain"`  // ожидаемые осадки на 3 дня, мм
+	WaterNowMM    float64 `json:"water_now_mm"`   // полить сейчас, мм = л/м²
+	WaterWeekMM   float64 `json:"water_week_mm"`  // план полива на неделю, мм = л/м²
+	WateringLevel string  `json:"watering_level"` // not_needed, soon, now
+	Advice        string  `json:"advice"`
+
+	Days []AgroDay `json:"days"` // последние дни, новые в конце
+}
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// AuroraHour — шанс увидеть полярное сияние в тёмный час ночи
+type AuroraHour struct {
+	Time        time.Time `json:"time"`
+	Kp          float32   `json:"kp"`
+	IsForecast  bool      `json:"is_forecast"`
+	CloudCover  *float64  `json:"cloud_cover,omitempty"` // %, nil — прогноза облачности нет
+	Probability int       `json:"probability"`           // 0..100
+}
+
+// AuroraNight — оценка видимости сияния за ночь по лучшему часу
+type AuroraNight struct {
+	Date             time.Time    `json:"date"`      // вечер, с которого начинается ночь
+	DarkFrom         time.Time    `json:"dark_from"` // Солнце ниже −12°
+	DarkTo           time.Time    `json:"dark_to"`
+	MaxKp            float32      `json:"max_kp"`
+	MoonIllumination float64      `json:"moon_illumination"`     // %
+	CloudCover       *float64     `json:"cloud_cover,omitempty"` // средняя за тёмные часы, %
+	Probability      int          `json:"probability"`           // 0..100
+	BestTime         *time.Time   `json:"best_time,omitempty"`
+	Hours            []AuroraHour `json:"hours"`
+}
+
+// AuroraForecast — видимость сияния на ближайшие ночи для широты станции
+type AuroraForecast struct {
+	GeneratedAt         time.Time     `json:"generated_at"`
+	GeomagneticLatitude float64       `json:"geomagnetic_latitude"`
+	KpNeeded            float64       `json:"kp_needed"` // Kp, с которого сияние может показаться над северным горизонтом
+	Nights              []AuroraNight `json:"nights"`
+}
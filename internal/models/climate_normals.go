@@ -0,0 +1,59 @@
+package models
+
+import "time"
+
+// ClimateNormalsPeriod1991 — текущий стандартный базовый период ВМО
+const ClimateNormalsPeriod1991 = "1991-2020"
+
+// ClimateNormal — официальная норма метеостанции за календарный месяц (Day == 0)
+// или за календарный день. Осадки месячной нормы — сумма за месяц.
+type ClimateNormal struct {
+	StationCode string `json:"station_code"`
+	Period      string `json:"period"`
+	Month       int    `json:"month"`
+	Day         int    `json:"day"`
+
+	TempAvg       *float32 `json:"temp_avg,omitempty"`
+	TempMin       *float32 `json:"temp_min,omitempty"`
+	TempMax       *float32 `json:"temp_max,omitempty"`
+	Precipitation *float32 `json:"precipitation,omitempty"` // мм
+
+	ImportedAt time.Time `json:"imported_at"`
+}
+
+// ClimateAnomaly — отклонение измерений станции от официальной нормы за день, месяц или сезон.
+// Норма берётся по тем же календарным дням, за которые у станции есть данные.
+type ClimateAnomaly struct {
+	Available     bool      `json:"available"`
+	Kind          string    `json:"kind"` // day, month, season, year, range
+	Label         string    `json:"label"`
+	From          time.Time `json:"from"`
+	To            time.Time `json:"to"` // не включительно
+	StationCode   string    `json:"station_code"`
+	NormalsPeriod string    `json:"normals_period"`
+	DaysInPeriod  int       `json:"days_in_period"`
+	DaysWithData  int       `json:"days_with_data"`
+
+	TempAvg     float64 `json:"temp_avg"`
+	TempNormal  float64 `json:"temp_normal"`
+	TempAnomaly float64 `json:"temp_anomaly"` // станция минус норма, °C
+	HasTemp     bool    `json:"has_temp"`
+
+	TempMin        float64 `json:"temp_min"`
+	TempMinNormal  float64 `json:"temp_min_normal"`
+	TempMinAnomaly float64 `json:"temp_min_anomaly"`
+	HasTempMin     bool    `json:"has_temp_min"`
+
+	TempMax        float64 `json:"temp_max"`
+	TempMaxNormal  float64 `json:"temp_max_normal"`
+	TempMaxAnomaly float64 `json:"temp_max_anomaly"`
+	HasTempMax     bool    `json:"has_temp_max"`
+
+	Precipitation        float64 `json:"precipitation"`
+	PrecipitationNormal  float64 `json:"precipitation_normal"`
+	PrecipitationPercent int     `json:"precipitation_percent"` // осадки станции в процентах от нормы
+	HasPrecipitation     bool    `json:"has_precipitation"`
+
+	TempText          string `json:"temp_text"`          // «на 4.2° теплее нормы»
+	PrecipitationText string `json:"precipitation_text"` // «осадков 140% нормы»
+}
@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+// Метрики климатологии станции. Часовые — средние за час, суточные — агрегаты календарных суток.
+const (
+	ClimatologyTemperature = "temperature" // часовая средняя температура, °C
+	ClimatologyHumidity    = "humidity"    // часовая средняя влажность, %
+	ClimatologyPressure    = "pressure"    // часовое среднее давление, мм рт.ст.
+	ClimatologyTempMax     = "temp_max"    // максимум температуры за сутки, °C
+	ClimatologyTempMin     = "temp_min"    // минимум температуры за сутки, °C
+	ClimatologyRain        = "rain"        // сумма осадков за сутки, мм
+)
+
+// ClimatologyHourly сообщает, сравнивается ли метрика по часам (иначе — по календарным суткам).
+func ClimatologyHourly(metric string) bool {
+	switch metric {
+	case ClimatologyTemperature, ClimatologyHumidity, ClimatologyPressure:
+		return true
+	}
+	return false
+}
+
+// ClimatologyQuery описывает окно климатологии: те же часы (или сутки) в ±WindowDays
+// вокруг даты Before во все прошлые годы до Before.
+type ClimatologyQuery struct {
+	Metric         string
+	Month          time.Month
+	Day            int
+	Hour           int // только для часовых метрик
+	WindowDays     int
+	Before         time.Time
+	Timezone       string
+	LowPercentile  float64 // 0..1
+	HighPercentile float64 // 0..1
+}
+
+// ClimatologyStats — распределение значений метрики в окне климатологии станции.
+type ClimatologyStats struct {
+	Metric  string  `json:"metric"`
+	Samples int     `json:"samples"` // часов или суток в окне
+	Years   int     `json:"years"`
+	Mean    float64 `json:"mean"`
+	StdDev  float64 `json:"std_dev"`
+	Low     float64 `json:"low"`  // нижний перцентиль
+	High    float64 `json:"high"` // верхний перцентиль
+	Min     float64 `json:"min"`
+	Max     float64 `json:"max"`
+
+	// Только тот же календарный день (для суточных метрик): рекорды даты
+	DateYears int     `json:"date_years"`
+	DateMin   float64 `json:"date_min"`
+	DateMax   float64 `json:"date_max"`
+}
+
+// ZScore возвращает отклонение значения в стандартных отклонениях климатологии.
+func (s ClimatologyStats) ZScore(value float64) float64 {
+	if s.StdDev <= 0 {
+		return 0
+	}
+	return (value - s.Mean) / s.StdDev
+}
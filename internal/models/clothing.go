@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// ClothingWindow — совет по одежде на отрезок дня (дорога на работу, день, дорога домой)
+type ClothingWindow struct {
+	Name              string    `json:"name"` // «Утро»
+	From              time.Time `json:"from"`
+	To                time.Time `json:"to"`
+	FeelsLikeMin      float64   `json:"feels_like_min"`
+	FeelsLikeMax      float64   `json:"feels_like_max"`
+	WindMax           float64   `json:"wind_max"` // м/с
+	PrecipProbability int       `json:"precipitation_probability"`
+	UVMax             float64   `json:"uv_max"`
+	Clothes           string    `json:"clothes"`
+	Extras            []string  `json:"extras,omitempty"` // зонт, крем и т.п. для этого отрезка
+}
+
+// ClothingAdvice — что надеть на день по прогнозу
+type ClothingAdvice struct {
+	Date    time.Time        `json:"date"`
+	Windows []ClothingWindow `json:"windows"`
+	Extras  []string         `json:"extras,omitempty"` // что взять с собой за день, без повторов
+	Summary string           `json:"summary"`          // «утром тёплая куртка, днём и вечером ветровка»
+}
@@ -0,0 +1,65 @@
+package models
+
+import "time"
+
+// Источник нормы осадков для индексов засухи
+const (
+	DroughtBaselineReanalysis = "reanalysis"
+	DroughtBaselineNormals    = "normals"
+)
+
+// PrecipitationDeficit — осадки за скользящее окно в сравнении с нормой
+type PrecipitationDeficit struct {
+	Days            int      `json:"days"`
+	Observed        *float64 `json:"observed,omitempty"`          // мм, нет при пропусках данных станции
+	Normal          *float64 `json:"normal,omitempty"`            // мм
+	Deficit         *float64 `json:"deficit,omitempty"`           // норма − факт, мм (отрицательный — избыток)
+	PercentOfNormal *float64 `json:"percent_of_normal,omitempty"` // факт в % от нормы
+}
+
+// SPIValue — стандартизированный индекс осадков за окно
+type SPIValue struct {
+	Days     int      `json:"days"`
+	Value    *float64 `json:"value,omitempty"`
+	Category string   `json:"category,omitempty"` // extremely_dry … extremely_wet
+	Label    string   `json:"label,omitempty"`
+	Samples  int      `json:"samples"` // сумм осадков того же окна в прошлые годы
+}
+
+// HydrothermalCoefficient — гидротермический коэффициент Селянинова (ГТК)
+type HydrothermalCoefficient struct {
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+	Value    *float64  `json:"value,omitempty"` // нет вне вегетационного периода
+	Rain     float64   `json:"rain"`            // осадки за сутки со средней выше +10 °C, мм
+	TempSum  float64   `json:"temp_sum"`        // сумма активных температур, °C
+	WarmDays int       `json:"warm_days"`
+	Category string    `json:"category,omitempty"`
+	Label    string    `json:"label,omitempty"`
+}
+
+// DroughtPoint — значения индексов на конец суток для графика тренда
+type DroughtPoint struct {
+	Date     time.Time `json:"date"`
+	Rain30   *float64  `json:"rain_30,omitempty"`
+	Normal30 *float64  `json:"normal_30,omitempty"`
+	SPI30    *float64  `json:"spi_30,omitempty"`
+	SPI90    *float64  `json:"spi_90,omitempty"`
+	GTK30    *float64  `json:"gtk_30,omitempty"`
+}
+
+// DroughtIndex — мониторинг засухи на последние полные сутки
+type DroughtIndex struct {
+	Date          time.Time               `json:"date"`
+	Baseline      string                  `json:"baseline,omitempty"` // reanalysis | normals, пусто — нормы нет
+	BaselineLabel string                  `json:"baseline_label,omitempty"`
+	DryStreak     int                     `json:"dry_streak"` // суток подряд без дождя
+	LastRain      *time.Time              `json:"last_rain,omitempty"`
+	Deficits      []PrecipitationDeficit  `json:"deficits"`
+	SPI           []SPIValue              `json:"spi"`
+	GTK           HydrothermalCoefficient `json:"gtk"`        // за 30 суток
+	GTKSeason     HydrothermalCoefficient `json:"gtk_season"` // с 1 апреля
+	Level         RiskLevel               `json:"level"`
+	Summary       string                  `json:"summary"`
+	Trend         []DroughtPoint          `json:"trend"`
+}
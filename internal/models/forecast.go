@@ -2,6 +2,15 @@ package models
 
 import "time"
 
+// Источники прогноза
+const (
+	ForecastProviderOpenMeteo = "open-meteo"
+	ForecastProviderMetNorway = "met-norway"
+
+	ForecastModelBestMatch = "best_match" // основной прогноз Open-Meteo
+	ForecastModelMetNorway = "locationforecast"
+)
+
 // ForecastData представляет данные прогноза погоды
 type ForecastData struct {
 	ID           int64     `json:"id" db:"id"`
@@ -35,6 +44,10 @@ type ForecastData struct {
 	// Тип прогноза
 	ForecastType string `json:"forecast_type" db:"forecast_type"` // "hourly" или "daily"
 
+	// Источник прогноза
+	Provider string `json:"provider" db:"provider"` // "open-meteo", "met-norway"
+	Model    string `json:"model" db:"model"`       // "best_match", "icon_seamless", ...
+
 	// Время получения данных
 	FetchedAt time.Time `json:"fetched_at" db:"fetched_at"`
 }
@@ -51,6 +64,18 @@ type HourlyForecast struct {
 	WeatherCode              int16     `json:"weather_code"`
 	WeatherDescription       string    `json:"weather_description"`
 	Icon                     string    `json:"icon"`
+	Humidity                 int16     `json:"humidity"`
+	CloudCover               int16     `json:"cloud_cover"`
+	UVIndex                  float32   `json:"uv_index"`
+
+	// Исходные значения Open-Meteo до коррекции под станцию
+	RawTemperature float32 `json:"raw_temperature"`
+	RawHumidity    int16   `json:"raw_humidity"`
+	RawWindSpeed   float32 `json:"raw_wind_speed"`
+	BiasCorrected  bool    `json:"bias_corrected"`
+
+	// Разброс между моделями (nil — доступен только основной прогноз)
+	Ensemble *HourlyEnsemble `json:"ensemble,omitempty"`
 }
 
 // DailyForecast представляет дневной прогноз (упрощенная структура для API)
@@ -65,6 +90,61 @@ type DailyForecast struct {
 	WeatherCode              int16     `json:"weather_code"`
 	WeatherDescription       string    `json:"weather_description"`
 	Icon                     string    `json:"icon"`
+
+	// Исходные значения Open-Meteo до коррекции под станцию
+	RawTemperatureMin float32 `json:"raw_temperature_min"`
+	RawTemperatureMax float32 `json:"raw_temperature_max"`
+	RawWindSpeedMax   float32 `json:"raw_wind_speed_max"`
+	BiasCorrected     bool    `json:"bias_corrected"`
+
+	// Разброс между моделями (nil — доступен только основной прогноз)
+	Ensemble *DailyEnsemble `json:"ensemble,omitempty"`
+}
+
+// Уровни неопределённости ансамбля
+const (
+	ForecastUncertaintyLow    = "low"
+	ForecastUncertaintyMedium = "medium"
+	ForecastUncertaintyHigh   = "high"
+)
+
+// EnsembleStat — среднее и разброс (стандартное отклонение) величины по моделям
+type EnsembleStat struct {
+	Mean   float32 `json:"mean"`
+	Spread float32 `json:"spread"`
+	Min    float32 `json:"min"`
+	Max    float32 `json:"max"`
+}
+
+// HourlyEnsemble — сводка нескольких моделей на один час
+type HourlyEnsemble struct {
+	Members       []string     `json:"members"` // provider/model
+	Temperature   EnsembleStat `json:"temperature"`
+	Precipitation EnsembleStat `json:"precipitation"`
+	WindSpeed     EnsembleStat `json:"wind_speed"`
+	Uncertainty   string       `json:"uncertainty"`
+}
+
+// DailyEnsemble — сводка нескольких моделей на один день
+type DailyEnsemble struct {
+	Members          []string     `json:"members"` // provider/model
+	TemperatureMin   EnsembleStat `json:"temperature_min"`
+	TemperatureMax   EnsembleStat `json:"temperature_max"`
+	PrecipitationSum EnsembleStat `json:"precipitation_sum"`
+	WindSpeedMax     EnsembleStat `json:"wind_speed_max"`
+	RainyMembers     int          `json:"rainy_members"` // сколько моделей дают ≥1 мм
+	Uncertainty      string       `json:"uncertainty"`
+}
+
+// ForecastVerification — пара «прогноз Open-Meteo — наблюдение станции» за один час
+type ForecastVerification struct {
+	ForecastTime        time.Time `json:"forecast_time"`
+	ForecastTemperature *float32  `json:"forecast_temperature,omitempty"`
+	ForecastHumidity    *int16    `json:"forecast_humidity,omitempty"`
+	ForecastWindSpeed   *float32  `json:"forecast_wind_speed,omitempty"`
+	ObservedTemperature *float32  `json:"observed_temperature,omitempty"`
+	ObservedHumidity    *float32  `json:"observed_humidity,omitempty"`
+	ObservedWindSpeed   *float32  `json:"observed_wind_speed,omitempty"`
 }
 
 // GetWeatherDescription возвращает текстовое описание по WMO коду погоды
@@ -0,0 +1,59 @@
+package models
+
+import "time"
+
+// RiskLevel — уровень риска заморозка или гололёда
+type RiskLevel string
+
+const (
+	RiskNone   RiskLevel = "none"
+	RiskLow    RiskLevel = "low"
+	RiskMedium RiskLevel = "medium"
+	RiskHigh   RiskLevel = "high"
+)
+
+// Rank возвращает порядковый номер уровня для сравнения
+func (l RiskLevel) Rank() int {
+	switch l {
+	case RiskLow:
+		return 1
+	case RiskMedium:
+		return 2
+	case RiskHigh:
+		return 3
+	}
+	return 0
+}
+
+// Label возвращает название уровня для сообщений
+func (l RiskLevel) Label() string {
+	switch l {
+	case RiskLow:
+		return "низкий"
+	case RiskMedium:
+		return "умеренный"
+	case RiskHigh:
+		return "высокий"
+	}
+	return "нет"
+}
+
+// FrostRisk — оценка риска заморозка и гололёда на ближайшую ночь
+type FrostRisk struct {
+	NightStart time.Time `json:"night_start"` // локальное время с пометкой UTC, как в прогнозе
+	NightEnd   time.Time `json:"night_end"`
+
+	MinTemperature     float32   `json:"min_temperature"`      // минимум прогноза за ночь, °C (2 м)
+	MinTemperatureTime time.Time `json:"min_temperature_time"` // час минимума
+	SurfaceMin         float32   `json:"surface_min"`          // оценка минимума у поверхности с учётом выхолаживания
+	CloudCover         int16     `json:"cloud_cover"`          // средняя облачность за ночь, %
+	WindSpeed          float32   `json:"wind_speed"`           // средний ветер за ночь, м/с
+	NightPrecipitation float32   `json:"night_precipitation"`  // осадки в прогнозе за ночь, мм
+	DewPoint           *float32  `json:"dew_point,omitempty"`  // текущая точка росы на станции
+	RecentRain         float32   `json:"recent_rain"`          // осадки на станции за последние часы, мм
+
+	Frost        RiskLevel `json:"frost_risk"`
+	FrostReasons []string  `json:"frost_reasons"`
+	Ice          RiskLevel `json:"ice_risk"`
+	IceReasons   []string  `json:"ice_reasons"`
+}
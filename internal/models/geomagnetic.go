@@ -2,11 +2,18 @@ package models
 
 import "time"
 
+// Источники индекса Kp. Прогноз, усреднённый по нескольким источникам,
+// записывается с источниками через «+»: "xras.ru+swpc.noaa.gov".
+const (
+	KpSourceXras = "xras.ru"
+	KpSourceSWPC = "swpc.noaa.gov"
+)
+
 // GeomagneticKp — одна запись 3-часового слота индекса Kp.
 type GeomagneticKp struct {
 	SlotTime   time.Time
 	Kp         float32
-	Source     string
+	Source     string // источник значения, после объединения — один на слот
 	IsForecast bool
 	FetchedAt  time.Time
 }
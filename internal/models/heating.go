@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// DegreeDay — градусо-сутки отопления и охлаждения за одни сутки
+type DegreeDay struct {
+	Date     time.Time `json:"date"`
+	TempAvg  float64   `json:"temp_avg"` // среднесуточная температура, °C
+	HDD      float64   `json:"hdd"`      // градусо-сутки отопления: max(0, база − средняя)
+	CDD      float64   `json:"cdd"`      // градусо-сутки охлаждения: max(0, средняя − база)
+	Forecast bool      `json:"forecast"` // средняя по прогнозу (мин + макс) / 2
+}
+
+// DegreeDaysTotal — сумма градусо-суток за месяц или сезон
+type DegreeDaysTotal struct {
+	Label   string    `json:"label"`
+	From    time.Time `json:"from"`
+	To      time.Time `json:"to"`
+	Days    int       `json:"days"` // суток с наблюдениями
+	TempAvg float64   `json:"temp_avg"`
+	HDD     float64   `json:"hdd"`
+	CDD     float64   `json:"cdd"`
+}
+
+// Смена режима отопления
+const (
+	HeatingTransitionStart = "start"
+	HeatingTransitionEnd   = "end"
+)
+
+// HeatingTransition — выполнение критерия начала или окончания отопительного сезона:
+// N суток подряд со среднесуточной температурой ниже (выше) порога
+type HeatingTransition struct {
+	Kind     string    `json:"kind"`
+	RunFrom  time.Time `json:"run_from"` // первые сутки серии
+	MetOn    time.Time `json:"met_on"`   // сутки, на которых серия достигла нужной длины
+	Date     time.Time `json:"date"`     // начало (окончание) отопления — следующие сутки
+	Forecast bool      `json:"forecast"` // серия достроена прогнозом
+}
+
+// HeatingSummary — градусо-сутки и состояние отопительного сезона
+type HeatingSummary struct {
+	GeneratedAt     time.Time `json:"generated_at"`
+	BaseTemp        float64   `json:"base_temp"`         // база градусо-суток отопления, °C
+	CoolingBaseTemp float64   `json:"cooling_base_temp"` // база градусо-суток охлаждения, °C
+	ThresholdTemp   float64   `json:"threshold_temp"`    // порог среднесуточной температуры, °C
+	ThresholdDays   int       `json:"threshold_days"`    // суток подряд для смены режима
+
+	HeatingOn  bool               `json:"heating_on"`     // по наблюдениям до вчерашнего дня
+	Streak     int                `json:"streak"`         // суток подряд, уже выполняющих критерий следующей смены
+	Last       *HeatingTransition `json:"last,omitempty"` // последняя наблюдённая смена в отопительном году
+	Next       *HeatingTransition `json:"next,omitempty"` // ожидаемая смена по прогнозу
+	StatusText string             `json:"status_text"`
+
+	Days   []DegreeDay       `json:"days"`   // последние сутки и прогноз
+	Months []DegreeDaysTotal `json:"months"` // месяцы отопительного года
+	Season DegreeDaysTotal   `json:"season"` // отопительный год с 1 июля
+}
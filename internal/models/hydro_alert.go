@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// HydroAlertKind — повод уведомления об уровне воды
+type HydroAlertKind string
+
+const (
+	HydroAlertStatus       HydroAlertKind = "status"        // смена статуса основного поста
+	HydroAlertRapidRise    HydroAlertKind = "rapid_rise"    // быстрый подъём на основном посту
+	HydroAlertUpstreamRise HydroAlertKind = "upstream_rise" // подъём на верхнем посту
+)
+
+// HydroAlert — эпизод, о котором нужно сообщить подписчикам. Key одинаков для всего
+// эпизода, поэтому повторные проверки того же эпизода не дают новых сообщений.
+type HydroAlert struct {
+	Kind        HydroAlertKind `json:"kind"`
+	Key         string         `json:"key"`
+	StationUUID string         `json:"station_uuid"`
+	StationName string         `json:"station_name"`
+	StartedAt   time.Time      `json:"started_at"` // начало эпизода
+	ObservedAt  time.Time      `json:"observed_at"`
+	LevelBSM    float32        `json:"level_bs_m"`
+
+	From HydroStatus `json:"from,omitempty"` // для смены статуса
+	To   HydroStatus `json:"to,omitempty"`
+
+	ChangeCmPerHour float32 `json:"change_cm_per_hour,omitempty"` // для быстрого подъёма
+	RiseCm          float32 `json:"rise_cm,omitempty"`            // для подъёма на верхнем посту
+	WindowHours     int     `json:"window_hours,omitempty"`
+}
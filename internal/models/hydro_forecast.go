@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// HydroUpstreamLink — связь верхнего поста с основным, выученная по истории уровней
+type HydroUpstreamLink struct {
+	StationUUID string  `json:"station_uuid"`
+	StationName string  `json:"station_name"`
+	LagHours    int     `json:"lag_hours"`   // время добегания
+	Gain        float64 `json:"gain"`        // доля подъёма верхнего поста, дошедшая до основного
+	Correlation float64 `json:"correlation"` // корреляция часовых изменений уровня при этом сдвиге
+	Samples     int     `json:"samples"`
+	Used        bool    `json:"used"` // корреляция достаточна, пост участвует в прогнозе
+}
+
+// HydroForecastPoint — прогноз уровня основного поста на час вперёд
+type HydroForecastPoint struct {
+	Time         time.Time `json:"time"`
+	LevelBSM     float64   `json:"level_bs_m"`
+	LowerBSM     float64   `json:"lower_bs_m"`
+	UpperBSM     float64   `json:"upper_bs_m"`
+	Extrapolated bool      `json:"extrapolated"` // дальше времени добегания: уровень верхних постов считается неизменным
+}
+
+// HydroForecast — прогноз уровня основного поста по верхним постам
+type HydroForecast struct {
+	GeneratedAt  time.Time            `json:"generated_at"`
+	BaseTime     time.Time            `json:"base_time"` // час последнего измерения основного поста
+	BaseLevelBSM float64              `json:"base_level_bs_m"`
+	TrainingDays int                  `json:"training_days"`
+	Links        []HydroUpstreamLink  `json:"links"`
+	Points       []HydroForecastPoint `json:"points"`
+	Available    bool                 `json:"available"`
+	Reason       string               `json:"reason,omitempty"` // почему прогноза нет
+
+	Peak          *HydroForecastPoint `json:"peak,omitempty"`
+	PeakStatus    HydroStatus         `json:"peak_status"`
+	PreventionAt  *time.Time          `json:"prevention_at,omitempty"` // прогноз достигает неблагоприятного уровня
+	DangerAt      *time.Time          `json:"danger_at,omitempty"`
+	CurrentStatus HydroStatus         `json:"current_status"`
+	ChangeCm      float64             `json:"change_cm"` // изменение к концу горизонта
+}
@@ -0,0 +1,74 @@
+package models
+
+import "time"
+
+// IndoorHour — часовое среднее комнатного датчика и расчётные показатели
+type IndoorHour struct {
+	Time             time.Time `json:"time"`
+	TempIndoor       float64   `json:"temp_indoor"`                // °C
+	HumidityIndoor   float64   `json:"humidity_indoor"`            // %
+	TempOutdoor      *float64  `json:"temp_outdoor,omitempty"`     // °C
+	SurfaceTemp      *float64  `json:"surface_temp,omitempty"`     // оценка температуры внутренней поверхности наружной стены, °C
+	SurfaceHumidity  *float64  `json:"surface_humidity,omitempty"` // относительная влажность у поверхности стены, %
+	AbsoluteHumidity float64   `json:"absolute_humidity"`          // г/м³
+	OutdoorAbsolute  *float64  `json:"outdoor_absolute,omitempty"` // г/м³
+}
+
+// IndoorComfort — доля часов в зоне комфорта и вне её
+type IndoorComfort struct {
+	Hours       int     `json:"hours"`
+	ComfortPct  float64 `json:"comfort_pct"` // и температура, и влажность в норме
+	TooColdPct  float64 `json:"too_cold_pct"`
+	TooWarmPct  float64 `json:"too_warm_pct"`
+	TooDryPct   float64 `json:"too_dry_pct"`
+	TooHumidPct float64 `json:"too_humid_pct"`
+	TempMin     float64 `json:"temp_min"` // границы зоны комфорта
+	TempMax     float64 `json:"temp_max"`
+	HumidityMin float64 `json:"humidity_min"`
+	HumidityMax float64 `json:"humidity_max"`
+}
+
+// IndoorMoldRisk — риск плесени: длительная высокая влажность в комнате и у холодной стены
+type IndoorMoldRisk struct {
+	Level          RiskLevel `json:"level"`
+	HumidHours     int       `json:"humid_hours"`     // часов с влажностью в комнате выше порога
+	LongestHumid   int       `json:"longest_humid"`   // самая длинная серия таких часов подряд
+	SurfaceHours   int       `json:"surface_hours"`   // часов с влажностью у стены ≥ 80 %
+	LongestSurface int       `json:"longest_surface"` // самая длинная серия у стены
+	Reasons        []string  `json:"reasons"`
+}
+
+// IndoorVentilation — совет по проветриванию по абсолютной влажности дома и на улице
+type IndoorVentilation struct {
+	Advice            string  `json:"advice"` // "ventilate", "avoid" или "neutral"
+	Text              string  `json:"text"`
+	IndoorAbsolute    float64 `json:"indoor_absolute"`     // г/м³
+	OutdoorAbsolute   float64 `json:"outdoor_absolute"`    // г/м³
+	IndoorAfterAiring float64 `json:"indoor_after_airing"` // влажность в комнате, если заменить воздух уличным и нагреть до комнатной температуры, %
+}
+
+// Советы по проветриванию
+const (
+	VentilationAdvised = "ventilate"
+	VentilationAvoid   = "avoid"
+	VentilationNeutral = "neutral"
+)
+
+// IndoorClimate — аналитика микроклимата в доме по комнатному датчику станции
+type IndoorClimate struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	HasData     bool      `json:"has_data"`
+	From        time.Time `json:"from"`
+	To          time.Time `json:"to"`
+
+	Current     *IndoorHour       `json:"current,omitempty"`
+	Comfort     IndoorComfort     `json:"comfort"`
+	Mold        IndoorMoldRisk    `json:"mold"`
+	Ventilation IndoorVentilation `json:"ventilation"`
+
+	HeatingSeason bool   `json:"heating_season"`
+	DryAir        bool   `json:"dry_air"` // сухой воздух в отопительный сезон
+	DryAirText    string `json:"dry_air_text,omitempty"`
+
+	Hours []IndoorHour `json:"hours"`
+}
@@ -19,6 +19,10 @@ type DailyWeatherInsight struct {
 	SolarRadiationMax *float32 `json:"solar_radiation_max,omitempty"`
 	UVIndexMax        *float32 `json:"uv_index_max,omitempty"`
 
+	SunshineHours *float32 `json:"sunshine_hours,omitempty"` // WMO sunshine duration estimated from radiation vs clear sky
+	SunshinePct   *float32 `json:"sunshine_pct,omitempty"`   // share of astronomically possible sunshine
+	CloudinessPct *float32 `json:"cloudiness_pct,omitempty"` // 0 = clear, 100 = overcast
+
 	PressureAvg *float32 `json:"pressure_avg,omitempty"`
 	HumidityAvg *int16   `json:"humidity_avg,omitempty"`
 }
@@ -56,10 +60,11 @@ type MonthlyWeatherInsights struct {
 	StrongWindDays int              `json:"strong_wind_days"` // gust >= 15 m/s
 	MaxWindGustDay *DayInsightValue `json:"max_wind_gust_day,omitempty"`
 
-	SunnyDays   int              `json:"sunny_days"`   // max solar radiation >= 500 W/m²
-	CloudyDays  int              `json:"cloudy_days"`  // max solar radiation < 150 W/m²
-	HighUVDays  int              `json:"high_uv_days"` // UV >= 6
-	SunniestDay *DayInsightValue `json:"sunniest_day,omitempty"`
+	SunnyDays     int              `json:"sunny_days"`     // sunshine >= 60% of possible, or max solar radiation >= 500 W/m² without sunshine data
+	CloudyDays    int              `json:"cloudy_days"`    // sunshine < 20% of possible, or max solar radiation < 150 W/m²
+	HighUVDays    int              `json:"high_uv_days"`   // UV >= 6
+	SunshineHours float64          `json:"sunshine_hours"` // estimated sunshine duration, h
+	SunniestDay   *DayInsightValue `json:"sunniest_day,omitempty"`
 
 	ComfortableDays int `json:"comfortable_days"` // 18..26°C, dry, gust < 8 m/s
 }
@@ -98,6 +103,10 @@ type WeatherArchiveBenchmark struct {
 	TempDelta        float64 `json:"temp_delta"`
 	Verdict          string  `json:"verdict"`
 	StatusText       string  `json:"status_text"`
+
+	// ModelData marks a norm calculated from reanalysis rather than station observations.
+	ModelData   bool   `json:"model_data"`
+	SourceLabel string `json:"source_label,omitempty"`
 }
 
 // RollingWeatherPeriod compares a recent rolling window with the preceding window.
@@ -236,8 +245,10 @@ type WeatherInsightsPage struct {
 
 	Season             WeatherSeasonContext    `json:"season"`
 	SameMonthBenchmark WeatherArchiveBenchmark `json:"same_month_benchmark"`
-	Last7Days          RollingWeatherPeriod    `json:"last_7_days"`
-	Last30Days         RollingWeatherPeriod    `json:"last_30_days"`
+	// ReanalysisBenchmark is the long-term norm from reanalysis model data, not station observations.
+	ReanalysisBenchmark WeatherArchiveBenchmark `json:"reanalysis_benchmark"`
+	Last7Days           RollingWeatherPeriod    `json:"last_7_days"`
+	Last30Days          RollingWeatherPeriod    `json:"last_30_days"`
 
 	DayTypes        []WeatherDayTypeSummary  `json:"day_types"`
 	DominantDayType WeatherDayTypeSummary    `json:"dominant_day_type"`
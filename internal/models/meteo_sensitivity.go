@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// Факторы индекса метеочувствительности
+const (
+	MeteoFactorPressure    = "pressure"
+	MeteoFactorTemperature = "temperature"
+	MeteoFactorHumidity    = "humidity"
+	MeteoFactorGeomagnetic = "geomagnetic"
+)
+
+// MeteoFactor — вклад одного фактора в индекс метеочувствительности
+type MeteoFactor struct {
+	Key    string   `json:"key"` // pressure | temperature | humidity | geomagnetic
+	Label  string   `json:"label"`
+	Value  *float64 `json:"value,omitempty"` // Δp мм рт. ст., Δt °C, влажность %, Kp; нет — нет данных
+	Unit   string   `json:"unit"`
+	Score  int      `json:"score"`  // 0..3
+	Weight float64  `json:"weight"` // доля фактора в индексе
+	Text   string   `json:"text,omitempty"`
+}
+
+// MeteoSensitivityDay — индекс метеочувствительности за сутки
+type MeteoSensitivityDay struct {
+	Date     time.Time     `json:"date"`
+	Index    float64       `json:"index"` // 0..10
+	Level    RiskLevel     `json:"level"`
+	Forecast bool          `json:"forecast"` // сегодняшние сутки: прогноз и последние наблюдения
+	Factors  []MeteoFactor `json:"factors"`
+}
+
+// MeteoSensitivity — индекс на сегодня с советами и история за месяц
+type MeteoSensitivity struct {
+	GeneratedAt time.Time             `json:"generated_at"`
+	Today       MeteoSensitivityDay   `json:"today"`
+	Title       string                `json:"title"` // «Неблагоприятный день»
+	Advice      []string              `json:"advice"`
+	Month       []MeteoSensitivityDay `json:"month"` // от старых к новым, последние — сегодня
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// RainSignal — вклад одного признака в прогноз дождя на ближайший час
+type RainSignal struct {
+	Name      string  `json:"name"`      // "forecast", "pressure", "humidity", "solar"
+	Score     float64 `json:"score"`     // 0..1
+	Weight    float64 `json:"weight"`    // вес признака
+	Available bool    `json:"available"` // признак удалось посчитать (солнце — только днём)
+	Detail    string  `json:"detail"`    // "давление −1.6 мм за 3 ч"
+}
+
+// RainNowcast — оценка «дождь в ближайший час» по прогнозу и трендам станции
+type RainNowcast struct {
+	Time           time.Time    `json:"time"`
+	Confidence     int          `json:"confidence"` // 0..100
+	Signals        []RainSignal `json:"signals"`
+	Raining        bool         `json:"raining"`         // дождь уже идёт
+	RainedRecently bool         `json:"rained_recently"` // дождь был недавно — эпизод ещё не закончился
+}
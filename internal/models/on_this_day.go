@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// OnThisDayYear is the weather observed by the station on the same calendar date in one year.
+type OnThisDayYear struct {
+	Year      int       `json:"year"`
+	Date      time.Time `json:"date"`
+	TempMin   *float32  `json:"temp_min,omitempty"`
+	TempMax   *float32  `json:"temp_max,omitempty"`
+	RainTotal *float32  `json:"rain_total,omitempty"` // mm per day, based on max(rain_daily)
+	Partial   bool      `json:"partial"`              // the day is not over yet
+}
+
+// OnThisDayRank is the place of the requested date's value among all years with data, 1 being the most extreme.
+type OnThisDayRank struct {
+	Place int    `json:"place"`
+	Of    int    `json:"of"`
+	Text  string `json:"text"`
+}
+
+// OnThisDay compares a date with the same calendar date in previous years of station observations.
+type OnThisDay struct {
+	Date  time.Time `json:"date"`
+	Label string    `json:"label"` // e.g. "18 октября"
+
+	Current *OnThisDayYear  `json:"current,omitempty"`
+	Years   []OnThisDayYear `json:"years"` // previous years, newest first
+
+	TempMaxRank *OnThisDayRank `json:"temp_max_rank,omitempty"` // 1 = warmest
+	TempMinRank *OnThisDayRank `json:"temp_min_rank,omitempty"` // 1 = coldest
+	RainRank    *OnThisDayRank `json:"rain_rank,omitempty"`     // 1 = wettest, rainy days only
+
+	Warmest *OnThisDayYear `json:"warmest,omitempty"` // highest max among previous years
+	Coldest *OnThisDayYear `json:"coldest,omitempty"` // lowest min among previous years
+	Wettest *OnThisDayYear `json:"wettest,omitempty"` // most rain among previous years, if it rained at all
+}
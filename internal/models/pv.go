@@ -0,0 +1,72 @@
+package models
+
+import "time"
+
+// PVHourInput — средние за час радиация и температура воздуха для расчёта выработки
+type PVHourInput struct {
+	Time        time.Time `json:"time"`      // начало часа
+	Radiation   float64   `json:"radiation"` // суммарная на горизонтальную поверхность, Вт/м²
+	TempOutdoor *float64  `json:"temp_outdoor,omitempty"`
+}
+
+// PVSystem — параметры солнечной электростанции
+type PVSystem struct {
+	CapacityKWp     float64 `json:"capacity_kwp"`     // пиковая мощность модулей, кВт
+	Tilt            float64 `json:"tilt"`             // наклон модулей от горизонта, °
+	Azimuth         float64 `json:"azimuth"`          // ориентация от севера по часовой стрелке, ° (180 — юг)
+	LossesPct       float64 `json:"losses_pct"`       // потери инвертора, проводов, загрязнения, %
+	TempCoefficient float64 `json:"temp_coefficient"` // температурный коэффициент мощности, %/°C
+}
+
+// PVHour — оценка выработки за час
+type PVHour struct {
+	Time      time.Time `json:"time"`
+	GHI       float64   `json:"ghi"`                 // измеренная радиация на горизонталь, Вт/м²
+	POA       float64   `json:"poa"`                 // радиация в плоскости модулей, Вт/м²
+	CellTemp  *float64  `json:"cell_temp,omitempty"` // температура модулей, °C
+	PowerKW   float64   `json:"power_kw"`            // средняя мощность за час
+	EnergyKWh float64   `json:"energy_kwh"`
+}
+
+// PVDay — оценка выработки за сутки
+type PVDay struct {
+	Date          time.Time `json:"date"`
+	Partial       bool      `json:"partial"`        // сутки ещё не закончились
+	EnergyKWh     float64   `json:"energy_kwh"`     // выработка, кВт·ч
+	SpecificYield float64   `json:"specific_yield"` // кВт·ч на 1 кВт пиковой мощности
+	Insolation    float64   `json:"insolation"`     // инсоляция в плоскости модулей, кВт·ч/м²
+	PeakPowerKW   float64   `json:"peak_power_kw"`
+	Hours         []PVHour  `json:"hours,omitempty"`
+}
+
+// PVMonth — оценка выработки за календарный месяц
+type PVMonth struct {
+	Month         time.Time `json:"month"` // первое число месяца
+	Label         string    `json:"label"` // «Октябрь 2026»
+	Partial       bool      `json:"partial"`
+	Days          int       `json:"days"` // суток с наблюдениями радиации
+	EnergyKWh     float64   `json:"energy_kwh"`
+	SpecificYield float64   `json:"specific_yield"`
+	AvgDailyKWh   float64   `json:"avg_daily_kwh"`
+}
+
+// PVReport — выработка за выбранные сутки, последние 30 суток и 12 месяцев
+type PVReport struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	System      PVSystem  `json:"system"`
+	Day         PVDay     `json:"day"`
+	Days        []PVDay   `json:"days"`   // от старых к новым, без почасовой раскладки
+	Months      []PVMonth `json:"months"` // от старых к новым
+}
+
+// PVMonthlyReport — итог месяца в сравнении с предыдущими месяцами
+type PVMonthlyReport struct {
+	System        PVSystem  `json:"system"`
+	Month         PVMonth   `json:"month"`
+	PreviousMonth *PVMonth  `json:"previous_month,omitempty"`
+	LastYear      *PVMonth  `json:"last_year,omitempty"` // тот же месяц год назад
+	BestDay       *PVDay    `json:"best_day,omitempty"`
+	WorstDay      *PVDay    `json:"worst_day,omitempty"`
+	Rank          int       `json:"rank"`   // место месяца по выработке среди Months (1 — лучший)
+	Months        []PVMonth `json:"months"` // последние 12 месяцев по отчётный включительно
+}
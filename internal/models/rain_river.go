@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// RainRiverEpisode — отклик уровня реки на один дождь станции
+type RainRiverEpisode struct {
+	RainStart      time.Time `json:"rain_start"`
+	RainEnd        time.Time `json:"rain_end"`
+	RainMM         float64   `json:"rain_mm"`
+	MaxRateMM      float64   `json:"max_rate_mm"` // максимум за час, мм
+	BaseLevelBSM   float64   `json:"base_level_bs_m"`
+	PeakLevelBSM   float64   `json:"peak_level_bs_m"`
+	PeakAt         time.Time `json:"peak_at"`
+	RiseCm         float64   `json:"rise_cm"`
+	LagHours       float64   `json:"lag_hours"`                 // от центра масс осадков до пика
+	RecessionHours *float64  `json:"recession_hours,omitempty"` // от пика до спада на половину подъёма
+	Complete       bool      `json:"complete"`                  // окно отклика наблюдалось целиком
+}
+
+// RainRiverRegression — линейная связь подъёма с суммой осадков по завершённым эпизодам
+type RainRiverRegression struct {
+	Slope     float64 `json:"slope"` // см подъёма на мм осадков
+	Intercept float64 `json:"intercept"`
+	R2        float64 `json:"r2"`
+	Residual  float64 `json:"residual"` // стандартное отклонение остатков, см
+	Samples   int     `json:"samples"`
+}
+
+// RainRiverExpectation — ожидаемый подъём от осадков в прогнозе
+type RainRiverExpectation struct {
+	Hours          int        `json:"hours"`
+	RainMM         float64    `json:"rain_mm"`
+	RiseCm         float64    `json:"rise_cm"`
+	LowerCm        float64    `json:"lower_cm"`
+	UpperCm        float64    `json:"upper_cm"`
+	BelowThreshold bool       `json:"below_threshold"` // осадков меньше, чем в самых слабых разобранных эпизодах
+	PeakAt         *time.Time `json:"peak_at,omitempty"`
+}
+
+// RainRiverAnalysis — как дожди станции переходят в подъём реки у гидропоста
+type RainRiverAnalysis struct {
+	Days                 int                   `json:"days"`
+	MinRainMM            float64               `json:"min_rain_mm"`
+	Episodes             []RainRiverEpisode    `json:"episodes"`
+	Regression           *RainRiverRegression  `json:"regression,omitempty"`
+	MedianLagHours       *float64              `json:"median_lag_hours,omitempty"`
+	MedianRecessionHours *float64              `json:"median_recession_hours,omitempty"`
+	Expected             *RainRiverExpectation `json:"expected,omitempty"`
+	Summary              string                `json:"summary"`
+}
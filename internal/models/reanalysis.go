@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// ReanalysisSourceERA5 — реанализ ECMWF ERA5 из исторического архива Open-Meteo
+const ReanalysisSourceERA5 = "era5"
+
+// ReanalysisDay — суточные значения реанализа для координат станции.
+// Это модельные данные сетки ~25 км, а не измерения станции.
+type ReanalysisDay struct {
+	Date   time.Time `json:"date"`
+	Source string    `json:"source"`
+
+	TempMin *float32 `json:"temp_min,omitempty"`
+	TempMax *float32 `json:"temp_max,omitempty"`
+	TempAvg *float32 `json:"temp_avg,omitempty"`
+
+	PrecipitationSum      *float32 `json:"precipitation_sum,omitempty"`       // мм
+	WindSpeedMax          *float32 `json:"wind_speed_max,omitempty"`          // м/с
+	WindGustsMax          *float32 `json:"wind_gusts_max,omitempty"`          // м/с
+	ShortwaveRadiationSum *float32 `json:"shortwave_radiation_sum,omitempty"` // МДж/м²
+
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// DailyInsight приводит день реанализа к формату суточных агрегатов станции,
+// чтобы те же функции считали по нему нормы и сводки.
+func (d ReanalysisDay) DailyInsight() DailyWeatherInsight {
+	return DailyWeatherInsight{
+		Date:         d.Date,
+		TempMin:      d.TempMin,
+		TempMax:      d.TempMax,
+		TempAvg:      d.TempAvg,
+		RainTotal:    d.PrecipitationSum,
+		WindSpeedMax: d.WindSpeedMax,
+		WindGustMax:  d.WindGustsMax,
+	}
+}
@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// SolarActivityDay — суточные индексы солнечной и геомагнитной активности
+type SolarActivityDay struct {
+	Date      time.Time `json:"date"`
+	F10       *float32  `json:"f10,omitempty"` // поток радиоизлучения 10,7 см, sfu
+	Sn        *float32  `json:"sn,omitempty"`  // число солнечных пятен
+	Ap        *float32  `json:"ap,omitempty"`
+	MaxKp     *float32  `json:"max_kp,omitempty"`
+	Disturbed bool      `json:"disturbed"` // Ap или максимум Kp выше спокойного уровня
+	Recurrent bool      `json:"recurrent"` // возмущение повторило день оборотом Солнца раньше
+}
+
+// SolarActivityOutlook — день, на который через оборот Солнца приходится возмущённый день
+type SolarActivityOutlook struct {
+	Date       time.Time `json:"date"`
+	SourceDate time.Time `json:"source_date"` // возмущённый день 27 суток назад
+	Ap         *float32  `json:"ap,omitempty"`
+	MaxKp      *float32  `json:"max_kp,omitempty"`
+}
+
+// SolarActivityWeek — сводка последних 7 суток в сравнении с предыдущими
+type SolarActivityWeek struct {
+	From          time.Time              `json:"from"`
+	To            time.Time              `json:"to"`
+	F10Avg        *float64               `json:"f10_avg,omitempty"`
+	SnAvg         *float64               `json:"sn_avg,omitempty"`
+	ApAvg         *float64               `json:"ap_avg,omitempty"`
+	PrevF10Avg    *float64               `json:"prev_f10_avg,omitempty"`
+	PrevSnAvg     *float64               `json:"prev_sn_avg,omitempty"`
+	PrevApAvg     *float64               `json:"prev_ap_avg,omitempty"`
+	MaxAp         *float32               `json:"max_ap,omitempty"`
+	MaxApDate     *time.Time             `json:"max_ap_date,omitempty"`
+	DisturbedDays int                    `json:"disturbed_days"`
+	Outlook       []SolarActivityOutlook `json:"outlook"` // ожидаемые возмущения на следующие 7 суток
+	Summary       string                 `json:"summary"`
+}
+
+// SolarActivity — ряды F10.7, Sn и Ap с 27-дневной повторяемостью возмущений
+type SolarActivity struct {
+	From           time.Time              `json:"from"`
+	To             time.Time              `json:"to"`
+	Days           []SolarActivityDay     `json:"days"` // от старых к новым
+	F10Avg         *float64               `json:"f10_avg,omitempty"`
+	SnAvg          *float64               `json:"sn_avg,omitempty"`
+	ApAvg          *float64               `json:"ap_avg,omitempty"`
+	RecurrenceRate *int                   `json:"recurrence_rate,omitempty"` // % возмущённых дней, повторившихся через 27 суток
+	Outlook        []SolarActivityOutlook `json:"outlook"`                   // ожидаемые возмущения на оборот вперёд
+	Week           SolarActivityWeek      `json:"week"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// SolarRadiationSample — средняя солнечная радиация за 10-минутный интервал
+type SolarRadiationSample struct {
+	Time      time.Time `json:"time"` // начало интервала
+	Radiation float64   `json:"radiation"`
+}
+
+// SolarPosition — положение Солнца на небе для координат станции
+type SolarPosition struct {
+	Time      time.Time `json:"time"`
+	Elevation float64   `json:"elevation"` // высота над горизонтом, °
+	Azimuth   float64   `json:"azimuth"`   // азимут от севера по часовой стрелке, °
+	ClearSky  float64   `json:"clear_sky"` // суммарная радиация при ясном небе, Вт/м²
+}
+
+// SunshineHour — солнечное сияние и облачность за час
+type SunshineHour struct {
+	Time            time.Time `json:"time"`
+	Elevation       float64   `json:"elevation"`                // высота Солнца в середине часа, °
+	Radiation       *float64  `json:"radiation,omitempty"`      // измеренная, Вт/м²
+	ClearSky        float64   `json:"clear_sky"`                // модель ясного неба, Вт/м²
+	CloudinessPct   *float64  `json:"cloudiness_pct,omitempty"` // 0 — ясно, 100 — сплошная облачность
+	SunshineMinutes float64   `json:"sunshine_minutes"`
+}
+
+// SunshineDay — продолжительность солнечного сияния за сутки по критерию WMO
+type SunshineDay struct {
+	Date          time.Time      `json:"date"`
+	Partial       bool           `json:"partial"` // сутки ещё не закончились
+	SunshineHours float64        `json:"sunshine_hours"`
+	PossibleHours float64        `json:"possible_hours"` // астрономически возможная продолжительность (по текущий момент для сегодня)
+	RelativePct   float64        `json:"relative_pct"`   // доля возможного сияния
+	CloudinessPct *float64       `json:"cloudiness_pct,omitempty"`
+	Hours         []SunshineHour `json:"hours"`
+}
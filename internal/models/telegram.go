@@ -15,6 +15,7 @@ type TelegramUser struct {
 	LanguageCode string    `json:"language_code" db:"language_code"`
 	IsBot        bool      `json:"is_bot" db:"is_bot"`
 	IsActive     bool      `json:"is_active" db:"is_active"`
+	SkinType     *int16    `json:"skin_type,omitempty" db:"skin_type"` // фототип кожи 1–6, nil — не указан
 	CreatedAt    time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
 }
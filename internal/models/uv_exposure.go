@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// UVSkinType — время до покраснения кожи для фототипа по Фицпатрику
+type UVSkinType struct {
+	Type        int     `json:"type"` // 1..6
+	Name        string  `json:"name"`
+	MED         float64 `json:"med"`                    // минимальная эритемная доза, Дж/м²
+	BurnMinutes *int    `json:"burn_minutes,omitempty"` // при текущем UV; nil — UV ниже 1, сгореть нельзя
+	DailyMEDs   float64 `json:"daily_meds"`             // доза за день на открытом солнце в долях MED
+}
+
+// UVHour — средний UV-индекс за час и накопленная с утра доза
+type UVHour struct {
+	Time     time.Time `json:"time"` // местное время станции
+	UVIndex  float64   `json:"uv_index"`
+	Forecast bool      `json:"forecast"` // false — замер станции
+	DoseSED  float64   `json:"dose_sed"` // накопленная с утра доза, SED (100 Дж/м²)
+}
+
+// UVExposure — текущий UV, время до ожога по фототипам и дневная кривая дозы
+type UVExposure struct {
+	GeneratedAt  time.Time    `json:"generated_at"`
+	UVIndex      *float64     `json:"uv_index,omitempty"`
+	Source       string       `json:"source,omitempty"` // station | forecast
+	Level        string       `json:"level,omitempty"`  // низкий … экстремальный
+	PeakUV       float64      `json:"peak_uv"`
+	PeakTime     time.Time    `json:"peak_time"`
+	DailyDoseSED float64      `json:"daily_dose_sed"` // замер с утра плюс прогноз до вечера
+	SkinTypes    []UVSkinType `json:"skin_types"`
+	Hours        []UVHour     `json:"hours"`
+}
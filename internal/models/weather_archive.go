@@ -28,6 +28,7 @@ type WeatherArchiveSummary struct {
 
 	UVIndexMax        float64 `json:"uv_index_max"`
 	SolarRadiationMax float64 `json:"solar_radiation_max"`
+	SunshineHours     float64 `json:"sunshine_hours"` // estimated sunshine duration, h
 	HasSun            bool    `json:"has_sun"`
 }
 
@@ -69,6 +70,26 @@ type WeatherArchiveDaySearch struct {
 	MatchedDays int     `json:"matched_days"`
 }
 
+// WeatherArchiveBaseline is the long-term mean for the same calendar period taken from
+// reanalysis model data. It is a background reference, not station observations.
+type WeatherArchiveBaseline struct {
+	Available   bool   `json:"available"`
+	Source      string `json:"source"`
+	SourceLabel string `json:"source_label"`
+	YearFrom    int    `json:"year_from"`
+	YearTo      int    `json:"year_to"`
+	Years       int    `json:"years"`
+
+	TempAvg   float64 `json:"temp_avg"`
+	TempDelta float64 `json:"temp_delta"` // station minus baseline
+	HasTemp   bool    `json:"has_temp"`
+
+	RainTotal        float64 `json:"rain_total"`
+	RainDays         float64 `json:"rain_days"`
+	RainRatioPercent int     `json:"rain_ratio_percent"` // station rain as percent of baseline
+	HasRain          bool    `json:"has_rain"`
+}
+
 // WeatherArchivePage is the data contract for the interactive HTMX weather archive.
 type WeatherArchivePage struct {
 	GeneratedAt time.Time `json:"generated_at"`
@@ -91,5 +112,11 @@ type WeatherArchivePage struct {
 	Coverage WeatherArchiveCoverage  `json:"coverage"`
 	Events   []WeatherArchiveEvent   `json:"events"`
 	Search   WeatherArchiveDaySearch `json:"search"`
+	Baseline WeatherArchiveBaseline  `json:"baseline"`
+	Normals  ClimateAnomaly          `json:"normals"`
+	Heating  *HeatingSummary         `json:"heating,omitempty"`
 	Daily    []DailyWeatherInsight   `json:"daily"`
+
+	MeteoSensitivity *MeteoSensitivity  `json:"meteo_sensitivity,omitempty"`
+	SolarWeek        *SolarActivityWeek `json:"solar_week,omitempty"`
 }
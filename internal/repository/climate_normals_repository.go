@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+type climateNormalsRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewClimateNormalsRepository(pool *pgxpool.Pool) ClimateNormalsRepository {
+	return &climateNormalsRepository{pool: pool}
+}
+
+func (r *climateNormalsRepository) SaveBatch(ctx context.Context, data []models.ClimateNormal) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	batch := &pgx.Batch{}
+	query := `
+		INSERT INTO climate_normals (
+			station_code, period, month, day,
+			temp_avg, temp_min, temp_max, precipitation, imported_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (station_code, period, month, day)
+		DO UPDATE SET
+			temp_avg = EXCLUDED.temp_avg,
+			temp_min = EXCLUDED.temp_min,
+			temp_max = EXCLUDED.temp_max,
+			precipitation = EXCLUDED.precipitation,
+			imported_at = EXCLUDED.imported_at`
+
+	for _, n := range data {
+		batch.Queue(query,
+			n.StationCode, n.Period, n.Month, n.Day,
+			n.TempAvg, n.TempMin, n.TempMax, n.Precipitation, n.ImportedAt,
+		)
+	}
+
+	br := r.pool.SendBatch(ctx, batch)
+	defer br.Close()
+
+	for i := 0; i < len(data); i++ {
+		if _, err := br.Exec(); err != nil {
+			return fmt.Errorf("failed to execute batch item %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// GetByStation returns all monthly and daily normals of the station for the period.
+// The table holds at most 378 rows per station, so callers index them in memory.
+func (r *climateNormalsRepository) GetByStation(ctx context.Context, stationCode, period string) ([]models.ClimateNormal, error) {
+	query := `
+		SELECT station_code, period, month, day,
+			temp_avg, temp_min, temp_max, precipitation, imported_at
+		FROM climate_normals
+		WHERE station_code = $1 AND period = $2
+		ORDER BY month ASC, day ASC`
+
+	rows, err := r.pool.Query(ctx, query, stationCode, period)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query climate normals: %w", err)
+	}
+	defer rows.Close()
+
+	var result []models.ClimateNormal
+	for rows.Next() {
+		var n models.ClimateNormal
+		var month, day int16
+		if err := rows.Scan(
+			&n.StationCode, &n.Period, &month, &day,
+			&n.TempAvg, &n.TempMin, &n.TempMax, &n.Precipitation, &n.ImportedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan climate normal: %w", err)
+		}
+		n.Month, n.Day = int(month), int(day)
+		result = append(result, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("climate normals rows error: %w", err)
+	}
+
+	return result, nil
+}
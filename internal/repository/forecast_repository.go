@@ -37,12 +37,12 @@ func (r *forecastRepository) save(ctx context.Context, data *models.ForecastData
 			wind_speed, wind_direction, wind_gusts,
 			cloud_cover, pressure, humidity, uv_index,
 			weather_code, weather_description,
-			forecast_type, fetched_at
+			forecast_type, fetched_at, provider, model
 		) VALUES (
 			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
-			$11, $12, $13, $14, $15, $16, $17, $18
+			$11, $12, $13, $14, $15, $16, $17, $18, $19, $20
 		)
-		ON CONFLICT (forecast_time, forecast_type)
+		ON CONFLICT (forecast_time, forecast_type, provider, model)
 		DO UPDATE SET
 			temperature = EXCLUDED.temperature,
 			temperature_min = EXCLUDED.temperature_min,
@@ -61,13 +61,14 @@ func (r *forecastRepository) save(ctx context.Context, data *models.ForecastData
 			weather_description = EXCLUDED.weather_description,
 			fetched_at = EXCLUDED.fetched_at`
 
+	provider, model := forecastSource(data)
 	_, err := r.pool.Exec(ctx, query,
 		data.ForecastTime, data.Temperature, data.TemperatureMin, data.TemperatureMax, data.FeelsLike,
 		data.PrecipitationProbability, data.Precipitation,
 		data.WindSpeed, data.WindDirection, data.WindGusts,
 		data.CloudCover, data.Pressure, data.Humidity, data.UVIndex,
 		data.WeatherCode, data.WeatherDescription,
-		data.ForecastType, data.FetchedAt,
+		data.ForecastType, data.FetchedAt, provider, model,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to insert forecast data: %w", err)
@@ -89,12 +90,12 @@ func (r *forecastRepository) SaveBatch(ctx context.Context, data []models.Foreca
 			wind_speed, wind_direction, wind_gusts,
 			cloud_cover, pressure, humidity, uv_index,
 			weather_code, weather_description,
-			forecast_type, fetched_at
+			forecast_type, fetched_at, provider, model
 		) VALUES (
 			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
-			$11, $12, $13, $14, $15, $16, $17, $18
+			$11, $12, $13, $14, $15, $16, $17, $18, $19, $20
 		)
-		ON CONFLICT (forecast_time, forecast_type)
+		ON CONFLICT (forecast_time, forecast_type, provider, model)
 		DO UPDATE SET
 			temperature = EXCLUDED.temperature,
 			temperature_min = EXCLUDED.temperature_min,
@@ -114,13 +115,14 @@ func (r *forecastRepository) SaveBatch(ctx context.Context, data []models.Foreca
 			fetched_at = EXCLUDED.fetched_at`
 
 	for _, d := range data {
+		provider, model := forecastSource(&d)
 		batch.Queue(query,
 			d.ForecastTime, d.Temperature, d.TemperatureMin, d.TemperatureMax, d.FeelsLike,
 			d.PrecipitationProbability, d.Precipitation,
 			d.WindSpeed, d.WindDirection, d.WindGusts,
 			d.CloudCover, d.Pressure, d.Humidity, d.UVIndex,
 			d.WeatherCode, d.WeatherDescription,
-			d.ForecastType, d.FetchedAt,
+			d.ForecastType, d.FetchedAt, provider, model,
 		)
 	}
 
@@ -144,14 +146,15 @@ func (r *forecastRepository) GetHourlyForecast(ctx context.Context, from, to tim
 			wind_speed, wind_direction, wind_gusts,
 			cloud_cover, pressure, humidity, uv_index,
 			weather_code, weather_description,
-			forecast_type, fetched_at
+			forecast_type, fetched_at, provider, model
 		FROM forecast_data
 		WHERE forecast_type = 'hourly'
+			AND provider = $3 AND model = $4
 			AND forecast_time >= $1
 			AND forecast_time <= $2
 		ORDER BY forecast_time ASC`
 
-	return r.query(ctx, query, from, to)
+	return r.query(ctx, query, from, to, models.ForecastProviderOpenMeteo, models.ForecastModelBestMatch)
 }
 
 func (r *forecastRepository) GetDailyForecast(ctx context.Context, from, to time.Time) ([]models.ForecastData, error) {
@@ -161,14 +164,33 @@ func (r *forecastRepository) GetDailyForecast(ctx context.Context, from, to time
 			wind_speed, wind_direction, wind_gusts,
 			cloud_cover, pressure, humidity, uv_index,
 			weather_code, weather_description,
-			forecast_type, fetched_at
+			forecast_type, fetched_at, provider, model
 		FROM forecast_data
 		WHERE forecast_type = 'daily'
+			AND provider = $3 AND model = $4
 			AND forecast_time >= $1
 			AND forecast_time <= $2
 		ORDER BY forecast_time ASC`
 
-	return r.query(ctx, query, from, to)
+	return r.query(ctx, query, from, to, models.ForecastProviderOpenMeteo, models.ForecastModelBestMatch)
+}
+
+// GetModelForecasts возвращает прогнозы всех провайдеров и моделей за период (для ансамбля)
+func (r *forecastRepository) GetModelForecasts(ctx context.Context, forecastType string, from, to time.Time) ([]models.ForecastData, error) {
+	query := `
+		SELECT id, forecast_time, temperature, temperature_min, temperature_max, feels_like,
+			precipitation_probability, precipitation,
+			wind_speed, wind_direction, wind_gusts,
+			cloud_cover, pressure, humidity, uv_index,
+			weather_code, weather_description,
+			forecast_type, fetched_at, provider, model
+		FROM forecast_data
+		WHERE forecast_type = $1
+			AND forecast_time >= $2
+			AND forecast_time <= $3
+		ORDER BY forecast_time ASC, provider, model`
+
+	return r.query(ctx, query, forecastType, from, to)
 }
 
 func (r *forecastRepository) GetLatestHourly(ctx context.Context, hours int) ([]models.ForecastData, error) {
@@ -194,6 +216,92 @@ func (r *forecastRepository) DeleteOldForecasts(ctx context.Context, olderThan t
 	return nil
 }
 
+// SaveVerificationPairs сохраняет пары «прогноз — наблюдение» за прошедшие часы.
+// Open-Meteo отдаёт локальное время без зоны, поэтому forecast_time переводится
+// в реальный момент через timezone станции. Уже записанные пары не перезаписываются.
+func (r *forecastRepository) SaveVerificationPairs(ctx context.Context, from, to time.Time, timezone string) (int64, error) {
+	query := `
+		INSERT INTO forecast_verification (
+			forecast_time, forecast_temperature, forecast_humidity, forecast_wind_speed,
+			observed_temperature, observed_humidity, observed_wind_speed,
+			forecast_fetched_at
+		)
+		SELECT f.forecast_time, f.temperature, f.humidity, f.wind_speed,
+			o.temp, o.humidity, o.wind_speed,
+			f.fetched_at
+		FROM forecast_data f
+		CROSS JOIN LATERAL (
+			SELECT
+				AVG(w.temp_outdoor) AS temp,
+				AVG(w.humidity_outdoor) AS humidity,
+				AVG(w.wind_speed) AS wind_speed,
+				COUNT(*) AS samples
+			FROM weather_data w
+			WHERE w.time >= ((f.forecast_time AT TIME ZONE 'UTC') AT TIME ZONE $3) - INTERVAL '15 minutes'
+				AND w.time < ((f.forecast_time AT TIME ZONE 'UTC') AT TIME ZONE $3) + INTERVAL '15 minutes'
+		) o
+		WHERE f.forecast_type = 'hourly'
+			AND f.provider = $4 AND f.model = $5
+			AND f.forecast_time >= $1
+			AND f.forecast_time <= $2
+			AND ((f.forecast_time AT TIME ZONE 'UTC') AT TIME ZONE $3) + INTERVAL '15 minutes' <= NOW()
+			AND o.samples > 0
+		ON CONFLICT (forecast_time) DO NOTHING`
+
+	tag, err := r.pool.Exec(ctx, query, from, to, timezone, models.ForecastProviderOpenMeteo, models.ForecastModelBestMatch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to save forecast verification pairs: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// GetVerificationPairs возвращает пары «прогноз — наблюдение» за период
+func (r *forecastRepository) GetVerificationPairs(ctx context.Context, from, to time.Time) ([]models.ForecastVerification, error) {
+	query := `
+		SELECT forecast_time, forecast_temperature, forecast_humidity, forecast_wind_speed,
+			observed_temperature, observed_humidity, observed_wind_speed
+		FROM forecast_verification
+		WHERE forecast_time >= $1 AND forecast_time <= $2
+		ORDER BY forecast_time ASC`
+
+	rows, err := r.pool.Query(ctx, query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query forecast verification: %w", err)
+	}
+	defer rows.Close()
+
+	var result []models.ForecastVerification
+	for rows.Next() {
+		var v models.ForecastVerification
+		if err := rows.Scan(
+			&v.ForecastTime, &v.ForecastTemperature, &v.ForecastHumidity, &v.ForecastWindSpeed,
+			&v.ObservedTemperature, &v.ObservedHumidity, &v.ObservedWindSpeed,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan forecast verification: %w", err)
+		}
+		result = append(result, v)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return result, nil
+}
+
+// forecastSource возвращает провайдера и модель строки; пустые значения — основной прогноз
+func forecastSource(data *models.ForecastData) (string, string) {
+	provider, model := data.Provider, data.Model
+	if provider == "" {
+		provider = models.ForecastProviderOpenMeteo
+	}
+	if model == "" {
+		model = models.ForecastModelBestMatch
+	}
+	return provider, model
+}
+
 func (r *forecastRepository) query(ctx context.Context, query string, args ...interface{}) ([]models.ForecastData, error) {
 	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
@@ -210,7 +318,7 @@ func (r *forecastRepository) query(ctx context.Context, query string, args ...in
 			&data.WindSpeed, &data.WindDirection, &data.WindGusts,
 			&data.CloudCover, &data.Pressure, &data.Humidity, &data.UVIndex,
 			&data.WeatherCode, &data.WeatherDescription,
-			&data.ForecastType, &data.FetchedAt,
+			&data.ForecastType, &data.FetchedAt, &data.Provider, &data.Model,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan forecast data: %w", err)
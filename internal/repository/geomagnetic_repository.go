@@ -20,8 +20,9 @@ func NewGeomagneticRepository(pool *pgxpool.Pool) GeomagneticRepository {
 	return &geomagneticRepository{pool: pool}
 }
 
-// SaveKpBatch вставляет/обновляет 3-часовые слоты Kp.
-// UPSERT обновляет только слоты не старше 24 часов — прошлое не переписываем.
+// SaveKpBatch вставляет/обновляет 3-часовые слоты Kp — по одной записи на слот
+// из уже объединённой серии источников. UPSERT обновляет только слоты не старше
+// 24 часов — прошлое не переписываем.
 func (r *geomagneticRepository) SaveKpBatch(ctx context.Context, data []models.GeomagneticKp) error {
 	if len(data) == 0 {
 		return nil
@@ -30,8 +31,9 @@ func (r *geomagneticRepository) SaveKpBatch(ctx context.Context, data []models.G
 	query := `
 		INSERT INTO geomagnetic_kp (slot_time, kp, source, is_forecast, fetched_at)
 		VALUES ($1, $2, $3, $4, $5)
-		ON CONFLICT (slot_time, source) DO UPDATE SET
+		ON CONFLICT (slot_time) DO UPDATE SET
 			kp = EXCLUDED.kp,
+			source = EXCLUDED.source,
 			is_forecast = EXCLUDED.is_forecast,
 			fetched_at = EXCLUDED.fetched_at
 		WHERE geomagnetic_kp.slot_time > NOW() - INTERVAL '24 hours'`
@@ -182,12 +184,13 @@ func (r *geomagneticRepository) GetDailyRange(ctx context.Context, from, to time
 	return out, nil
 }
 
-// DeleteOlderThan удаляет слоты старше threshold. Используется для retention.
-func (r *geomagneticRepository) DeleteOlderThan(ctx context.Context, threshold time.Time) error {
-	if _, err := r.pool.Exec(ctx, `DELETE FROM geomagnetic_kp WHERE slot_time < $1`, threshold); err != nil {
+// DeleteOlderThan удаляет слоты Kp старше kpThreshold и суточные показатели старше
+// dailyThreshold. Используется для retention.
+func (r *geomagneticRepository) DeleteOlderThan(ctx context.Context, kpThreshold, dailyThreshold time.Time) error {
+	if _, err := r.pool.Exec(ctx, `DELETE FROM geomagnetic_kp WHERE slot_time < $1`, kpThreshold); err != nil {
 		return fmt.Errorf("failed to delete old geomagnetic_kp: %w", err)
 	}
-	if _, err := r.pool.Exec(ctx, `DELETE FROM geomagnetic_daily WHERE date < $1`, threshold); err != nil {
+	if _, err := r.pool.Exec(ctx, `DELETE FROM geomagnetic_daily WHERE date < $1`, dailyThreshold); err != nil {
 		return fmt.Errorf("failed to delete old geomagnetic_daily: %w", err)
 	}
 	return nil
@@ -107,6 +107,33 @@ func (r *hydroRepository) GetGauge(ctx context.Context, stationUUID string) (*mo
 	return &g, nil
 }
 
+func (r *hydroRepository) ListGauges(ctx context.Context) ([]models.HydroGauge, error) {
+	query := `SELECT station_uuid, waterlevel_uuid, name, short_name, holder_name, area, district, locality,
+		monitoring_object, latitude, longitude, fix_bs_m, dry_bs_m, flooding_prevention_bs_m,
+		flooding_danger_bs_m, fetched_at
+		FROM hydro_gauges ORDER BY monitoring_object, name`
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list hydro gauges: %w", err)
+	}
+	defer rows.Close()
+
+	var out []models.HydroGauge
+	for rows.Next() {
+		var g models.HydroGauge
+		if err := rows.Scan(&g.StationUUID, &g.WaterLevelUUID, &g.Name, &g.ShortName, &g.HolderName, &g.Area,
+			&g.District, &g.Locality, &g.MonitoringObject, &g.Latitude, &g.Longitude, &g.FixBSM, &g.DryBSM,
+			&g.FloodingPreventionBM, &g.FloodingDangerBSM, &g.FetchedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan hydro gauge: %w", err)
+		}
+		out = append(out, g)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return out, nil
+}
+
 func (r *hydroRepository) GetLatest(ctx context.Context, stationUUID string) (*models.HydroLevelReading, error) {
 	query := `SELECT observed_at, station_uuid, waterlevel_uuid, level_bs_m, level_zero_m,
 		change_cm_per_hour, lead_text, state_code, level_code, raw_data, fetched_at
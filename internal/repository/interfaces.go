@@ -18,6 +18,11 @@ type WeatherRepository interface {
 	GetDailyMinMax(ctx context.Context) (*DailyMinMax, error)
 	GetDataForEventDetection(ctx context.Context, from, to time.Time) ([]models.WeatherData, error)
 	GetDailyInsights(ctx context.Context, from, to time.Time, timezone string) ([]models.DailyWeatherInsight, error)
+	GetDailyInsightsOnDate(ctx context.Context, month time.Month, day int, before time.Time, timezone string) ([]models.DailyWeatherInsight, error)
+	GetDailyAgro(ctx context.Context, from, to time.Time, timezone string) ([]models.AgroDailyInput, error)
+	GetSolarRadiationSeries(ctx context.Context, from, to time.Time) ([]models.SolarRadiationSample, error)
+	GetPVInputs(ctx context.Context, from, to time.Time) ([]models.PVHourInput, error)
+	GetClimatology(ctx context.Context, q models.ClimatologyQuery) (*models.ClimatologyStats, error)
 }
 
 type SensorRepository interface {
@@ -32,6 +37,7 @@ type TelegramUserRepository interface {
 	GetAll(ctx context.Context) ([]models.TelegramUser, error)
 	GetAllActive(ctx context.Context) ([]models.TelegramUser, error)
 	UpdateActivity(ctx context.Context, chatID int64, isActive bool) error
+	UpdateSkinType(ctx context.Context, chatID int64, skinType int) error
 }
 
 type TelegramSubscriptionRepository interface {
@@ -77,7 +83,10 @@ type ForecastRepository interface {
 	GetDailyForecast(ctx context.Context, from, to time.Time) ([]models.ForecastData, error)
 	GetLatestHourly(ctx context.Context, hours int) ([]models.ForecastData, error)
 	GetLatestDaily(ctx context.Context, days int) ([]models.ForecastData, error)
+	GetModelForecasts(ctx context.Context, forecastType string, from, to time.Time) ([]models.ForecastData, error)
 	DeleteOldForecasts(ctx context.Context, olderThan time.Time) error
+	SaveVerificationPairs(ctx context.Context, from, to time.Time, timezone string) (int64, error)
+	GetVerificationPairs(ctx context.Context, from, to time.Time) ([]models.ForecastVerification, error)
 }
 
 type PhotoRepository interface {
@@ -106,16 +115,28 @@ type GeomagneticRepository interface {
 	GetMaxKpForDay(ctx context.Context, day time.Time) (*models.GeomagneticKp, error)
 	GetForecastedStorms(ctx context.Context, from, to time.Time, threshold float32) ([]models.GeomagneticKp, error)
 	GetDailyRange(ctx context.Context, from, to time.Time) ([]models.GeomagneticDaily, error)
-	DeleteOlderThan(ctx context.Context, threshold time.Time) error
+	DeleteOlderThan(ctx context.Context, kpThreshold, dailyThreshold time.Time) error
 }
 
 type HydroRepository interface {
 	SaveGauge(ctx context.Context, gauge *models.HydroGauge) error
 	SaveReadingsBatch(ctx context.Context, data []models.HydroLevelReading) error
 	GetGauge(ctx context.Context, stationUUID string) (*models.HydroGauge, error)
+	ListGauges(ctx context.Context) ([]models.HydroGauge, error)
 	GetLatest(ctx context.Context, stationUUID string) (*models.HydroLevelReading, error)
 	GetPreviousBefore(ctx context.Context, stationUUID string, before time.Time) (*models.HydroLevelReading, error)
 	GetNearBefore(ctx context.Context, stationUUID string, target time.Time, window time.Duration) (*models.HydroLevelReading, error)
 	GetRange(ctx context.Context, stationUUID string, from, to time.Time) ([]models.HydroLevelReading, error)
 	DeleteOlderThan(ctx context.Context, threshold time.Time) error
 }
+
+type ReanalysisRepository interface {
+	SaveBatch(ctx context.Context, data []models.ReanalysisDay) error
+	GetRange(ctx context.Context, source string, from, to time.Time, months []int) ([]models.ReanalysisDay, error)
+	GetLatestDate(ctx context.Context, source string) (*time.Time, error)
+}
+
+type ClimateNormalsRepository interface {
+	SaveBatch(ctx context.Context, data []models.ClimateNormal) error
+	GetByStation(ctx context.Context, stationCode, period string) ([]models.ClimateNormal, error)
+}
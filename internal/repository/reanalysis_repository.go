@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+type reanalysisRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewReanalysisRepository(pool *pgxpool.Pool) ReanalysisRepository {
+	return &reanalysisRepository{pool: pool}
+}
+
+func (r *reanalysisRepository) SaveBatch(ctx context.Context, data []models.ReanalysisDay) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	batch := &pgx.Batch{}
+	query := `
+		INSERT INTO reanalysis_daily (
+			date, source, temp_min, temp_max, temp_avg,
+			precipitation_sum, wind_speed_max, wind_gusts_max, shortwave_radiation_sum,
+			fetched_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (date, source)
+		DO UPDATE SET
+			temp_min = EXCLUDED.temp_min,
+			temp_max = EXCLUDED.temp_max,
+			temp_avg = EXCLUDED.temp_avg,
+			precipitation_sum = EXCLUDED.precipitation_sum,
+			wind_speed_max = EXCLUDED.wind_speed_max,
+			wind_gusts_max = EXCLUDED.wind_gusts_max,
+			shortwave_radiation_sum = EXCLUDED.shortwave_radiation_sum,
+			fetched_at = EXCLUDED.fetched_at`
+
+	for _, d := range data {
+		batch.Queue(query,
+			d.Date, d.Source, d.TempMin, d.TempMax, d.TempAvg,
+			d.PrecipitationSum, d.WindSpeedMax, d.WindGustsMax, d.ShortwaveRadiationSum,
+			d.FetchedAt,
+		)
+	}
+
+	br := r.pool.SendBatch(ctx, batch)
+	defer br.Close()
+
+	for i := 0; i < len(data); i++ {
+		if _, err := br.Exec(); err != nil {
+			return fmt.Errorf("failed to execute batch item %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// GetRange returns reanalysis days in [from, to) for the given source, oldest first.
+// When months is not empty, only those calendar months (1..12) are returned.
+func (r *reanalysisRepository) GetRange(ctx context.Context, source string, from, to time.Time, months []int) ([]models.ReanalysisDay, error) {
+	query := `
+		SELECT date::timestamp, source, temp_min, temp_max, temp_avg,
+			precipitation_sum, wind_speed_max, wind_gusts_max, shortwave_radiation_sum,
+			fetched_at
+		FROM reanalysis_daily
+		WHERE source = $1 AND date >= $2::date AND date < $3::date
+			AND (cardinality($4::int[]) = 0 OR EXTRACT(MONTH FROM date)::int = ANY($4::int[]))
+		ORDER BY date ASC`
+
+	if months == nil {
+		months = []int{}
+	}
+	rows, err := r.pool.Query(ctx, query, source, from.Format("2006-01-02"), to.Format("2006-01-02"), months)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reanalysis days: %w", err)
+	}
+	defer rows.Close()
+
+	var result []models.ReanalysisDay
+	for rows.Next() {
+		var d models.ReanalysisDay
+		if err := rows.Scan(
+			&d.Date, &d.Source, &d.TempMin, &d.TempMax, &d.TempAvg,
+			&d.PrecipitationSum, &d.WindSpeedMax, &d.WindGustsMax, &d.ShortwaveRadiationSum,
+			&d.FetchedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan reanalysis day: %w", err)
+		}
+		result = append(result, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reanalysis rows error: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetLatestDate returns the last stored day for the source, or nil when the table is empty.
+func (r *reanalysisRepository) GetLatestDate(ctx context.Context, source string) (*time.Time, error) {
+	var latest *time.Time
+	err := r.pool.QueryRow(ctx, `SELECT MAX(date)::timestamp FROM reanalysis_daily WHERE source = $1`, source).Scan(&latest)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get latest reanalysis date: %w", err)
+	}
+	return latest, nil
+}
@@ -38,7 +38,7 @@ func (r *telegramUserRepository) Create(ctx context.Context, user *models.Telegr
 func (r *telegramUserRepository) GetByID(ctx context.Context, id int64) (*models.TelegramUser, error) {
 	query := `
 		SELECT id, chat_id, username, first_name, last_name, language_code,
-		       is_bot, is_active, created_at, updated_at
+		       is_bot, is_active, skin_type, created_at, updated_at
 		FROM telegram_users
 		WHERE id = $1
 	`
@@ -46,7 +46,7 @@ func (r *telegramUserRepository) GetByID(ctx context.Context, id int64) (*models
 	var user models.TelegramUser
 	err := r.pool.QueryRow(ctx, query, id).Scan(
 		&user.ID, &user.ChatID, &user.Username, &user.FirstName, &user.LastName,
-		&user.LanguageCode, &user.IsBot, &user.IsActive, &user.CreatedAt, &user.UpdatedAt,
+		&user.LanguageCode, &user.IsBot, &user.IsActive, &user.SkinType, &user.CreatedAt, &user.UpdatedAt,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user by id: %w", err)
@@ -58,7 +58,7 @@ func (r *telegramUserRepository) GetByID(ctx context.Context, id int64) (*models
 func (r *telegramUserRepository) GetByChatID(ctx context.Context, chatID int64) (*models.TelegramUser, error) {
 	query := `
 		SELECT id, chat_id, username, first_name, last_name, language_code,
-		       is_bot, is_active, created_at, updated_at
+		       is_bot, is_active, skin_type, created_at, updated_at
 		FROM telegram_users
 		WHERE chat_id = $1
 	`
@@ -66,7 +66,7 @@ func (r *telegramUserRepository) GetByChatID(ctx context.Context, chatID int64)
 	var user models.TelegramUser
 	err := r.pool.QueryRow(ctx, query, chatID).Scan(
 		&user.ID, &user.ChatID, &user.Username, &user.FirstName, &user.LastName,
-		&user.LanguageCode, &user.IsBot, &user.IsActive, &user.CreatedAt, &user.UpdatedAt,
+		&user.LanguageCode, &user.IsBot, &user.IsActive, &user.SkinType, &user.CreatedAt, &user.UpdatedAt,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user by chat_id: %w", err)
@@ -78,7 +78,7 @@ func (r *telegramUserRepository) GetByChatID(ctx context.Context, chatID int64)
 func (r *telegramUserRepository) GetAll(ctx context.Context) ([]models.TelegramUser, error) {
 	query := `
 		SELECT id, chat_id, username, first_name, last_name, language_code,
-		       is_bot, is_active, created_at, updated_at
+		       is_bot, is_active, skin_type, created_at, updated_at
 		FROM telegram_users
 		ORDER BY created_at DESC
 	`
@@ -94,7 +94,7 @@ func (r *telegramUserRepository) GetAll(ctx context.Context) ([]models.TelegramU
 		var user models.TelegramUser
 		err := rows.Scan(
 			&user.ID, &user.ChatID, &user.Username, &user.FirstName, &user.LastName,
-			&user.LanguageCode, &user.IsBot, &user.IsActive, &user.CreatedAt, &user.UpdatedAt,
+			&user.LanguageCode, &user.IsBot, &user.IsActive, &user.SkinType, &user.CreatedAt, &user.UpdatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
@@ -108,7 +108,7 @@ func (r *telegramUserRepository) GetAll(ctx context.Context) ([]models.TelegramU
 func (r *telegramUserRepository) GetAllActive(ctx context.Context) ([]models.TelegramUser, error) {
 	query := `
 		SELECT id, chat_id, username, first_name, last_name, language_code,
-		       is_bot, is_active, created_at, updated_at
+		       is_bot, is_active, skin_type, created_at, updated_at
 		FROM telegram_users
 		WHERE is_active = true
 		ORDER BY created_at DESC
@@ -125,7 +125,7 @@ func (r *telegramUserRepository) GetAllActive(ctx context.Context) ([]models.Tel
 		var user models.TelegramUser
 		err := rows.Scan(
 			&user.ID, &user.ChatID, &user.Username, &user.FirstName, &user.LastName,
-			&user.LanguageCode, &user.IsBot, &user.IsActive, &user.CreatedAt, &user.UpdatedAt,
+			&user.LanguageCode, &user.IsBot, &user.IsActive, &user.SkinType, &user.CreatedAt, &user.UpdatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
@@ -146,3 +146,14 @@ func (r *telegramUserRepository) UpdateActivity(ctx context.Context, chatID int6
 	_, err := r.pool.Exec(ctx, query, isActive, chatID)
 	return err
 }
+
+func (r *telegramUserRepository) UpdateSkinType(ctx context.Context, chatID int64, skinType int) error {
+	query := `
+		UPDATE telegram_users
+		SET skin_type = $1, updated_at = NOW()
+		WHERE chat_id = $2
+	`
+
+	_, err := r.pool.Exec(ctx, query, skinType, chatID)
+	return err
+}
@@ -543,3 +543,254 @@ func (r *weatherRepository) GetDailyInsights(ctx context.Context, from, to time.
 
 	return result, nil
 }
+
+// GetDailyInsightsOnDate returns temperature and rain aggregates of the same calendar date
+// (month and day in the specified timezone) for every year before the given time.
+func (r *weatherRepository) GetDailyInsightsOnDate(ctx context.Context, month time.Month, day int, before time.Time, timezone string) ([]models.DailyWeatherInsight, error) {
+	query := `
+		SELECT
+			((time AT TIME ZONE $3)::date)::timestamp AS day,
+			MIN(temp_outdoor) AS temp_min,
+			MAX(temp_outdoor) AS temp_max,
+			AVG(temp_outdoor) AS temp_avg,
+			MAX(rain_daily) AS rain_total
+		FROM weather_data
+		WHERE time < $4
+			AND EXTRACT(MONTH FROM time AT TIME ZONE $3) = $1
+			AND EXTRACT(DAY FROM time AT TIME ZONE $3) = $2
+		GROUP BY (time AT TIME ZONE $3)::date
+		ORDER BY day ASC`
+
+	rows, err := r.pool.Query(ctx, query, int(month), day, timezone, before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query daily weather on date: %w", err)
+	}
+	defer rows.Close()
+
+	var result []models.DailyWeatherInsight
+	for rows.Next() {
+		var d models.DailyWeatherInsight
+		if err := rows.Scan(&d.Date, &d.TempMin, &d.TempMax, &d.TempAvg, &d.RainTotal); err != nil {
+			return nil, fmt.Errorf("failed to scan daily weather on date: %w", err)
+		}
+		result = append(result, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("daily weather on date rows error: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetSolarRadiationSeries returns 10-minute mean solar radiation for sunshine duration estimates.
+func (r *weatherRepository) GetSolarRadiationSeries(ctx context.Context, from, to time.Time) ([]models.SolarRadiationSample, error) {
+	query := `
+		SELECT
+			time_bucket('10 minutes', time) AS bucket,
+			AVG(solar_radiation) AS solar_radiation
+		FROM weather_data
+		WHERE time >= $1 AND time < $2 AND solar_radiation IS NOT NULL
+		GROUP BY bucket
+		ORDER BY bucket ASC`
+
+	rows, err := r.pool.Query(ctx, query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query solar radiation series: %w", err)
+	}
+	defer rows.Close()
+
+	var result []models.SolarRadiationSample
+	for rows.Next() {
+		var sample models.SolarRadiationSample
+		if err := rows.Scan(&sample.Time, &sample.Radiation); err != nil {
+			return nil, fmt.Errorf("failed to scan solar radiation series: %w", err)
+		}
+		result = append(result, sample)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("solar radiation series rows error: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetPVInputs returns hourly mean solar radiation and outdoor temperature for photovoltaic yield estimates.
+func (r *weatherRepository) GetPVInputs(ctx context.Context, from, to time.Time) ([]models.PVHourInput, error) {
+	query := `
+		SELECT
+			time_bucket('1 hour', time) AS bucket,
+			AVG(solar_radiation) AS solar_radiation,
+			AVG(temp_outdoor) AS temp_outdoor
+		FROM weather_data
+		WHERE time >= $1 AND time < $2 AND solar_radiation IS NOT NULL
+		GROUP BY bucket
+		ORDER BY bucket ASC`
+
+	rows, err := r.pool.Query(ctx, query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pv inputs: %w", err)
+	}
+	defer rows.Close()
+
+	var result []models.PVHourInput
+	for rows.Next() {
+		var input models.PVHourInput
+		if err := rows.Scan(&input.Time, &input.Radiation, &input.TempOutdoor); err != nil {
+			return nil, fmt.Errorf("failed to scan pv inputs: %w", err)
+		}
+		result = append(result, input)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("pv inputs rows error: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetDailyAgro returns daily aggregates for agrometeorological calculations in the specified timezone.
+func (r *weatherRepository) GetDailyAgro(ctx context.Context, from, to time.Time, timezone string) ([]models.AgroDailyInput, error) {
+	query := `
+		SELECT
+			((time AT TIME ZONE $3)::date)::timestamp AS day,
+			MIN(temp_outdoor) AS temp_min,
+			MAX(temp_outdoor) AS temp_max,
+			AVG(temp_outdoor) AS temp_avg,
+			MIN(humidity_outdoor) AS humidity_min,
+			MAX(humidity_outdoor) AS humidity_max,
+			AVG(wind_speed) AS wind_avg,
+			AVG(solar_radiation) AS solar_avg,
+			AVG(pressure_absolute) AS pressure_avg,
+			MAX(rain_daily) AS rain_total,
+			COALESCE(
+				COUNT(*) FILTER (WHERE temp_outdoor >= 0 AND temp_outdoor <= 7.2)::real
+					/ NULLIF(COUNT(temp_outdoor), 0) * 24,
+				0
+			) AS chill_hours,
+			COUNT(DISTINCT date_trunc('hour', time))::real / 24 AS coverage
+		FROM weather_data
+		WHERE time >= $1 AND time < $2
+		GROUP BY (time AT TIME ZONE $3)::date
+		ORDER BY day ASC`
+
+	rows, err := r.pool.Query(ctx, query, from, to, timezone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query daily agro data: %w", err)
+	}
+	defer rows.Close()
+
+	var result []models.AgroDailyInput
+	for rows.Next() {
+		var day models.AgroDailyInput
+		if err := rows.Scan(
+			&day.Date,
+			&day.TempMin,
+			&day.TempMax,
+			&day.TempAvg,
+			&day.HumidityMin,
+			&day.HumidityMax,
+			&day.WindAvg,
+			&day.SolarAvg,
+			&day.PressureAvg,
+			&day.RainTotal,
+			&day.ChillHours,
+			&day.Coverage,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan daily agro data: %w", err)
+		}
+		result = append(result, day)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("daily agro data rows error: %w", err)
+	}
+
+	return result, nil
+}
+
+// climatologyExpressions maps climatology metrics to aggregate expressions over weather_data.
+var climatologyExpressions = map[string]string{
+	models.ClimatologyTemperature: "AVG(temp_outdoor)",
+	models.ClimatologyHumidity:    "AVG(humidity_outdoor)",
+	models.ClimatologyPressure:    "AVG(pressure_relative)",
+	models.ClimatologyTempMax:     "MAX(temp_outdoor)",
+	models.ClimatologyTempMin:     "MIN(temp_outdoor)",
+	models.ClimatologyRain:        "MAX(rain_daily)",
+}
+
+// GetClimatology returns the distribution of a metric for the same hour (hourly metrics) or
+// calendar day (daily metrics) within ±WindowDays of the date of q.Before in all years before it.
+// Each year's window is a time range, so the scan uses the weather_data time index instead of
+// evaluating the day of year over the whole history.
+func (r *weatherRepository) GetClimatology(ctx context.Context, q models.ClimatologyQuery) (*models.ClimatologyStats, error) {
+	expr, ok := climatologyExpressions[q.Metric]
+	if !ok {
+		return nil, fmt.Errorf("unknown climatology metric %q", q.Metric)
+	}
+
+	bucket := "date_trunc('day', time AT TIME ZONE $1)"
+	hourFilter := ""
+	args := []any{q.Timezone, q.Before, q.WindowDays, q.LowPercentile, q.HighPercentile, int(q.Month), q.Day}
+	if models.ClimatologyHourly(q.Metric) {
+		bucket = "date_trunc('hour', time AT TIME ZONE $1)"
+		hourFilter = "AND EXTRACT(HOUR FROM time AT TIME ZONE $1) = $8"
+		args = append(args, q.Hour)
+	}
+
+	query := fmt.Sprintf(`
+		WITH anchor AS (
+			SELECT ($2::timestamptz AT TIME ZONE $1)::date AS day
+		), windows AS (
+			SELECT
+				((anchor.day - make_interval(years => n))::date - $3::int)::timestamp AT TIME ZONE $1 AS from_time,
+				((anchor.day - make_interval(years => n))::date + $3::int + 1)::timestamp AT TIME ZONE $1 AS to_time
+			FROM anchor, generate_series(
+				0,
+				EXTRACT(YEAR FROM anchor.day)::int - EXTRACT(YEAR FROM (SELECT MIN(time) FROM weather_data) AT TIME ZONE $1)::int
+			) AS n
+		), samples AS (
+			SELECT
+				%s AS local_time,
+				(%s)::float8 AS value
+			FROM windows
+			JOIN weather_data ON weather_data.time >= windows.from_time AND weather_data.time < windows.to_time
+			WHERE weather_data.time < $2
+				%s
+			GROUP BY 1
+			HAVING %s IS NOT NULL
+		), dated AS (
+			SELECT value, EXTRACT(YEAR FROM local_time) AS year,
+				EXTRACT(MONTH FROM local_time) = $6 AND EXTRACT(DAY FROM local_time) = $7 AS same_date
+			FROM samples
+		)
+		SELECT
+			COUNT(*),
+			COUNT(DISTINCT year),
+			COALESCE(AVG(value), 0),
+			COALESCE(STDDEV_SAMP(value), 0),
+			COALESCE(percentile_cont($4::float8) WITHIN GROUP (ORDER BY value), 0),
+			COALESCE(percentile_cont($5::float8) WITHIN GROUP (ORDER BY value), 0),
+			COALESCE(MIN(value), 0),
+			COALESCE(MAX(value), 0),
+			COUNT(DISTINCT year) FILTER (WHERE same_date),
+			COALESCE(MIN(value) FILTER (WHERE same_date), 0),
+			COALESCE(MAX(value) FILTER (WHERE same_date), 0)
+		FROM dated`, bucket, expr, hourFilter, expr)
+
+	stats := &models.ClimatologyStats{Metric: q.Metric}
+	err := r.pool.QueryRow(ctx, query, args...).Scan(
+		&stats.Samples,
+		&stats.Years,
+		&stats.Mean,
+		&stats.StdDev,
+		&stats.Low,
+		&stats.High,
+		&stats.Min,
+		&stats.Max,
+		&stats.DateYears,
+		&stats.DateMin,
+		&stats.DateMax,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s climatology: %w", q.Metric, err)
+	}
+	return stats, nil
+}
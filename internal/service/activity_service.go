@@ -0,0 +1,402 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+// Параметры оценки активностей
+const (
+	ACTIVITY_DEFAULT_HOURS   = 12 // часов вперёд по умолчанию
+	ACTIVITY_MAX_HOURS       = 48
+	ACTIVITY_GOOD_SCORE      = 60 // от этой оценки час считается подходящим
+	ACTIVITY_WINDOW_SPREAD   = 10 // окно лучших условий — часы не хуже лучшего на столько баллов
+	ACTIVITY_STATION_MAX_AGE = 30 * time.Minute
+)
+
+// activityInfo — название и иконка активности
+var activityInfo = map[string]struct{ name, icon string }{
+	models.ActivityRunning:  {"Бег", "🏃"},
+	models.ActivityCycling:  {"Велопрогулка", "🚴"},
+	models.ActivityFishing:  {"Рыбалка", "🎣"},
+	models.ActivityBarbecue: {"Шашлыки", "🍖"},
+	models.ActivityLaundry:  {"Сушка белья", "👕"},
+}
+
+// ActivityKeys возвращает все поддерживаемые активности в порядке показа
+func ActivityKeys() []string {
+	return []string{models.ActivityRunning, models.ActivityCycling, models.ActivityFishing, models.ActivityBarbecue, models.ActivityLaundry}
+}
+
+// ActivitySettings — набор активностей и горизонт оценки
+type ActivitySettings struct {
+	Activities []string // ключи активностей; пусто — все
+	Hours      int      // на сколько часов вперёд оценивать
+}
+
+// ActivityService оценивает ближайшие часы для бега, велопрогулки, рыбалки, шашлыков
+// и сушки белья по почасовому прогнозу, текущим показаниям станции, изменению
+// давления за 3 часа и фазе луны.
+type ActivityService struct {
+	weatherSvc  *WeatherService
+	forecastSvc *ForecastService
+	moonSvc     *MoonService
+	settings    ActivitySettings
+}
+
+func NewActivityService(weatherSvc *WeatherService, forecastSvc *ForecastService, moonSvc *MoonService, settings ActivitySettings) *ActivityService {
+	var activities []string
+	for _, key := range settings.Activities {
+		key = strings.ToLower(strings.TrimSpace(key))
+		if _, ok := activityInfo[key]; ok {
+			activities = append(activities, key)
+		}
+	}
+	if len(activities) == 0 {
+		activities = ActivityKeys()
+	}
+	settings.Activities = activities
+	if settings.Hours <= 0 {
+		settings.Hours = ACTIVITY_DEFAULT_HOURS
+	}
+	settings.Hours = min(settings.Hours, ACTIVITY_MAX_HOURS)
+	return &ActivityService{weatherSvc: weatherSvc, forecastSvc: forecastSvc, moonSvc: moonSvc, settings: settings}
+}
+
+// Activities возвращает настроенные активности
+func (s *ActivityService) Activities() []string {
+	return s.settings.Activities
+}
+
+// ActivityName возвращает название и иконку активности
+func ActivityName(key string) (name, icon string, ok bool) {
+	info, ok := activityInfo[key]
+	return info.name, info.icon, ok
+}
+
+// activityConditions — погода на час для оценки активностей
+type activityConditions struct {
+	hour           int // местный час
+	temp           float64
+	feelsLike      float64
+	wind           float64 // м/с
+	humidity       float64
+	cloudCover     float64
+	precipProb     float64 // %
+	precip         float64 // мм/ч
+	thunder        bool
+	pressureChange *float64 // мм рт. ст. за 3 часа
+	moonAge        float64
+}
+
+// GetForecast оценивает активности на ближайшие часы. Текущий час берётся
+// по станции, если её данные свежие, остальные — по прогнозу.
+func (s *ActivityService) GetForecast(ctx context.Context) (*models.ActivityForecast, error) {
+	loc := s.weatherSvc.location
+	if loc == nil {
+		loc = time.Local
+	}
+	now := time.Now()
+
+	// Прогноз хранится в местном времени станции — берём с запасом и фильтруем по местным часам
+	hourly, err := s.forecastSvc.GetHourlyForecast(ctx, s.settings.Hours+ACTIVITY_MAX_HOURS/4)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hourly forecast: %w", err)
+	}
+	currentHour := wallClockUTC(now, loc).Truncate(time.Hour)
+	upcoming := make([]models.HourlyForecast, 0, s.settings.Hours)
+	for _, h := range hourly {
+		if h.Time.UTC().Before(currentHour) {
+			continue
+		}
+		if len(upcoming) == s.settings.Hours {
+			break
+		}
+		upcoming = append(upcoming, h)
+	}
+
+	forecast := &models.ActivityForecast{GeneratedAt: now, Hours: s.settings.Hours}
+	current, err := s.weatherSvc.repo.GetLatest(ctx)
+	if err != nil || current == nil || now.Sub(current.Time) > ACTIVITY_STATION_MAX_AGE {
+		current = nil
+	}
+	if current != nil && current.PressureRelative != nil {
+		if past, err := s.weatherSvc.repo.GetDataNearTime(ctx, now.Add(-3*time.Hour)); err == nil && past != nil && past.PressureRelative != nil {
+			change := roundTo(float64(*current.PressureRelative-*past.PressureRelative), 1)
+			forecast.PressureChange = &change
+		}
+	}
+	if s.moonSvc != nil {
+		forecast.MoonAge = s.moonSvc.calcMoonAge(now)
+		forecast.MoonPhase = s.moonSvc.getPhaseName(s.moonSvc.calcMoonPhase(forecast.MoonAge))
+	}
+
+	conditions := make([]activityConditions, 0, len(upcoming))
+	for i, h := range upcoming {
+		c := activityConditions{
+			hour:           h.Time.Hour(),
+			temp:           float64(h.Temperature),
+			feelsLike:      float64(h.FeelsLike),
+			wind:           float64(h.WindSpeed),
+			humidity:       float64(h.Humidity),
+			cloudCover:     float64(h.CloudCover),
+			precipProb:     float64(h.PrecipitationProbability),
+			precip:         float64(h.Precipitation),
+			thunder:        h.WeatherCode >= 95,
+			pressureChange: forecast.PressureChange,
+			moonAge:        forecast.MoonAge,
+		}
+		if i == 0 && current != nil {
+			applyStationConditions(&c, current)
+		}
+		conditions = append(conditions, c)
+	}
+
+	for _, key := range s.settings.Activities {
+		forecast.Activities = append(forecast.Activities, buildActivityScore(key, upcoming, conditions, current != nil))
+	}
+	return forecast, nil
+}
+
+// GetActivity возвращает оценку одной активности
+func (s *ActivityService) GetActivity(ctx context.Context, key string) (*models.ActivityScore, *models.ActivityForecast, error) {
+	forecast, err := s.GetForecast(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range forecast.Activities {
+		if forecast.Activities[i].Key == key {
+			return &forecast.Activities[i], forecast, nil
+		}
+	}
+	return nil, forecast, nil
+}
+
+// applyStationConditions заменяет прогноз текущего часа показаниями станции
+func applyStationConditions(c *activityConditions, current *models.WeatherData) {
+	if current.TempOutdoor != nil {
+		// Ощущаемая по прогнозу сдвигается вместе с фактической температурой
+		delta := float64(*current.TempOutdoor) - c.temp
+		c.temp += delta
+		c.feelsLike += delta
+	}
+	if current.WindSpeed != nil {
+		c.wind = float64(*current.WindSpeed)
+	}
+	if current.HumidityOutdoor != nil {
+		c.humidity = float64(*current.HumidityOutdoor)
+	}
+	if current.RainRate != nil {
+		c.precip = float64(*current.RainRate)
+		if c.precip >= 0.1 {
+			c.precipProb = 100
+		} else {
+			c.precipProb = math.Min(c.precipProb, 20)
+		}
+	}
+}
+
+func buildActivityScore(key string, hours []models.HourlyForecast, conditions []activityConditions, observed bool) models.ActivityScore {
+	info := activityInfo[key]
+	score := models.ActivityScore{Key: key, Name: info.name, Icon: info.icon, Hours: make([]models.ActivityHour, 0, len(hours))}
+	for i, c := range conditions {
+		value, reasons := scoreActivity(key, c)
+		score.Hours = append(score.Hours, models.ActivityHour{
+			Time:     hours[i].Time,
+			Score:    value,
+			Rating:   activityRating(value),
+			Reasons:  reasons,
+			Observed: i == 0 && observed,
+		})
+	}
+	if len(score.Hours) == 0 {
+		score.Summary = "нет прогноза на ближайшие часы"
+		return score
+	}
+
+	score.Now = score.Hours[0]
+	best := 0
+	for i, h := range score.Hours {
+		if h.Score > score.Hours[best].Score {
+			best = i
+		}
+	}
+	score.Best = score.Hours[best]
+
+	threshold := max(ACTIVITY_GOOD_SCORE, score.Best.Score-ACTIVITY_WINDOW_SPREAD)
+	from, to := best, best
+	for from > 0 && score.Hours[from-1].Score >= threshold {
+		from--
+	}
+	for to < len(score.Hours)-1 && score.Hours[to+1].Score >= threshold {
+		to++
+	}
+	score.BestFrom = score.Hours[from].Time
+	score.BestTo = score.Hours[to].Time.Add(time.Hour)
+
+	if score.Best.Score < ACTIVITY_GOOD_SCORE {
+		reason := "условия неподходящие"
+		if len(score.Best.Reasons) > 0 {
+			reason = strings.Join(score.Best.Reasons, ", ")
+		}
+		score.Summary = fmt.Sprintf("в ближайшие %d ч не лучшее время: %s", len(score.Hours), reason)
+		return score
+	}
+	score.Summary = fmt.Sprintf("%s с %s до %s", score.Best.Rating, score.BestFrom.Format("15:04"), score.BestTo.Format("15:04"))
+	return score
+}
+
+// activityPenalty накапливает штрафные баллы и их причины
+type activityPenalty struct {
+	score   float64
+	reasons []string
+}
+
+func (p *activityPenalty) add(points float64, reason string) {
+	if points <= 0 {
+		return
+	}
+	p.score -= points
+	p.reasons = append(p.reasons, reason)
+}
+
+// scoreActivity оценивает час для активности от 0 до 100 и перечисляет, что мешает
+func scoreActivity(key string, c activityConditions) (int, []string) {
+	p := &activityPenalty{score: 100}
+	if c.thunder {
+		p.add(100, "гроза")
+		return 0, p.reasons
+	}
+	rain := c.precip >= 0.3 || c.precipProb >= 60
+
+	switch key {
+	case models.ActivityRunning:
+		comfortPenalty(p, c.feelsLike, 8, 18, 3, 4)
+		if rain {
+			p.add(35, "дождь")
+		} else if c.precipProb >= 30 {
+			p.add(10, "возможен дождь")
+		}
+		p.add((c.wind-8)*5, "сильный ветер")
+		if c.humidity >= 80 && c.temp >= 20 {
+			p.add(15, "душно")
+		}
+		if c.hour < 5 || c.hour >= 23 {
+			p.add(20, "ночь")
+		}
+
+	case models.ActivityCycling:
+		comfortPenalty(p, c.feelsLike, 12, 24, 3, 4)
+		if rain {
+			p.add(50, "дождь")
+		} else if c.precipProb >= 30 {
+			p.add(15, "возможен дождь")
+		}
+		p.add((c.wind-5)*6, "встречный ветер")
+		if c.hour < 6 || c.hour >= 22 {
+			p.add(30, "темно")
+		}
+
+	case models.ActivityFishing:
+		// Утренний и вечерний клёв — лучшее время, днём и ночью чуть хуже
+		if c.hour < 5 || (c.hour >= 10 && c.hour < 17) || c.hour >= 22 {
+			p.add(10, "не время клёва")
+		}
+		if c.pressureChange != nil {
+			switch change := math.Abs(*c.pressureChange); {
+			case change >= 2:
+				p.add(30, "давление скачет")
+			case change >= 1:
+				p.add(15, "давление меняется")
+			}
+		}
+		p.add((c.wind-7)*6, "сильный ветер")
+		if c.precip >= 2 {
+			p.add(30, "ливень")
+		} else if rain {
+			p.add(10, "дождь")
+		}
+		p.add((2-c.temp)*3, "холодно")
+		p.add((c.temp-30)*3, "жарко")
+		if moonPhaseNear(c.moonAge, 0) {
+			p.add(10, "новолуние")
+		} else if moonPhaseNear(c.moonAge, synodicMonthDays/2) {
+			p.add(10, "полнолуние")
+		}
+
+	case models.ActivityBarbecue:
+		if c.wind >= 12 {
+			p.add(100, "опасно разводить огонь")
+			break
+		}
+		if rain {
+			p.add(50, "дождь")
+		} else if c.precipProb >= 40 {
+			p.add(20, "возможен дождь")
+		}
+		p.add((c.wind-8)*6, "ветрено")
+		comfortPenalty(p, c.temp, 12, 30, 3, 3)
+		if c.hour < 11 || c.hour >= 23 {
+			p.add(30, "не время для шашлыков")
+		}
+
+	case models.ActivityLaundry:
+		if c.hour < 8 || c.hour >= 19 {
+			p.add(50, "без солнца сохнет плохо")
+		}
+		if c.precip >= 0.1 || c.precipProb >= 50 {
+			p.add(60, "дождь")
+		} else if c.precipProb >= 30 {
+			p.add(20, "возможен дождь")
+		}
+		switch {
+		case c.humidity >= 85:
+			p.add(40, "высокая влажность")
+		case c.humidity >= 70:
+			p.add(20, "влажно")
+		}
+		if c.cloudCover >= 80 {
+			p.add(10, "пасмурно")
+		}
+		p.add((10-c.temp)*3, "прохладно")
+		if c.wind < 1 {
+			p.add(10, "безветрие")
+		}
+		p.add((c.wind-12)*5, "сорвёт бельё")
+	}
+
+	value := int(math.Round(math.Max(0, math.Min(100, p.score))))
+	return value, p.reasons
+}
+
+// comfortPenalty штрафует за температуру вне комфортного диапазона
+func comfortPenalty(p *activityPenalty, temp, low, high, coldRate, heatRate float64) {
+	p.add((low-temp)*coldRate, "холодно")
+	p.add((temp-high)*heatRate, "жарко")
+}
+
+const synodicMonthDays = 29.53058867
+
+// moonPhaseNear — возраст луны в пределах полутора суток от заданной фазы
+func moonPhaseNear(age, phaseAge float64) bool {
+	diff := math.Abs(age - phaseAge)
+	return math.Min(diff, synodicMonthDays-diff) <= 1.5
+}
+
+func activityRating(score int) string {
+	switch {
+	case score >= 80:
+		return "отлично"
+	case score >= ACTIVITY_GOOD_SCORE:
+		return "хорошо"
+	case score >= 40:
+		return "так себе"
+	default:
+		return "не стоит"
+	}
+}
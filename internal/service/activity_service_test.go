@@ -0,0 +1,119 @@
+package service
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+func TestScoreActivity(t *testing.T) {
+	tests := []struct {
+		name        string
+		key         string
+		c           activityConditions
+		wantScore   int
+		wantReasons []string
+	}{
+		{
+			name:      "бег прохладным утром",
+			key:       models.ActivityRunning,
+			c:         activityConditions{hour: 7, temp: 14, feelsLike: 14, wind: 3, humidity: 60, precipProb: 10},
+			wantScore: 100,
+		},
+		{
+			name:        "гроза обнуляет любую активность",
+			key:         models.ActivityCycling,
+			c:           activityConditions{hour: 15, temp: 20, feelsLike: 20, wind: 2, thunder: true},
+			wantScore:   0,
+			wantReasons: []string{"гроза"},
+		},
+		{
+			name:        "рыбалка днём в полнолуние при скачке давления",
+			key:         models.ActivityFishing,
+			c:           activityConditions{hour: 12, temp: 18, feelsLike: 18, wind: 3, pressureChange: f64(-2.4), moonAge: 14.9},
+			wantScore:   50,
+			wantReasons: []string{"не время клёва", "давление скачет", "полнолуние"},
+		},
+		{
+			name:        "шашлыки в штормовой ветер",
+			key:         models.ActivityBarbecue,
+			c:           activityConditions{hour: 18, temp: 22, feelsLike: 22, wind: 13},
+			wantScore:   0,
+			wantReasons: []string{"опасно разводить огонь"},
+		},
+		{
+			name:        "бельё вечером под дождём",
+			key:         models.ActivityLaundry,
+			c:           activityConditions{hour: 21, temp: 15, wind: 3, humidity: 90, precip: 0.5, precipProb: 80},
+			wantScore:   0,
+			wantReasons: []string{"без солнца сохнет плохо", "дождь", "высокая влажность"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, reasons := scoreActivity(tt.key, tt.c)
+			if score != tt.wantScore {
+				t.Fatalf("score = %d, want %d (reasons %v)", score, tt.wantScore, reasons)
+			}
+			if !slices.Equal(reasons, tt.wantReasons) {
+				t.Fatalf("reasons = %v, want %v", reasons, tt.wantReasons)
+			}
+		})
+	}
+}
+
+func TestBuildActivityScoreFindsBestWindow(t *testing.T) {
+	start := time.Date(2026, time.October, 19, 7, 0, 0, 0, time.UTC)
+	conditions := []activityConditions{
+		{hour: 7, temp: 14, feelsLike: 14, wind: 3, precipProb: 70},              // 65: дождь
+		{hour: 8, temp: 14, feelsLike: 14, wind: 3},                              // 100
+		{hour: 9, temp: 15, feelsLike: 15, wind: 9},                              // 95: ветер
+		{hour: 10, temp: 15, feelsLike: 15, wind: 3, precipProb: 30},             // 90: возможен дождь
+		{hour: 11, temp: 16, feelsLike: 16, wind: 10, precip: 1, precipProb: 90}, // 55
+	}
+	hours := make([]models.HourlyForecast, len(conditions))
+	for i := range hours {
+		hours[i].Time = start.Add(time.Duration(i) * time.Hour)
+	}
+
+	score := buildActivityScore(models.ActivityRunning, hours, conditions, true)
+	if !score.Now.Observed || score.Now.Score != 65 || score.Hours[1].Observed {
+		t.Fatalf("now = %+v", score.Now)
+	}
+	if score.Best.Score != 100 || !score.BestFrom.Equal(start.Add(time.Hour)) || !score.BestTo.Equal(start.Add(4*time.Hour)) {
+		t.Fatalf("best = %d, window %s–%s", score.Best.Score, score.BestFrom.Format("15:04"), score.BestTo.Format("15:04"))
+	}
+	if score.Summary != "отлично с 08:00 до 11:00" {
+		t.Fatalf("summary = %q", score.Summary)
+	}
+
+	bad := buildActivityScore(models.ActivityRunning, hours[4:], conditions[4:], false)
+	if bad.Now.Observed || !strings.HasPrefix(bad.Summary, "в ближайшие 1 ч не лучшее время: дождь") {
+		t.Fatalf("bad summary = %q", bad.Summary)
+	}
+}
+
+func TestNewActivityServiceSettings(t *testing.T) {
+	s := NewActivityService(nil, nil, nil, ActivitySettings{Activities: []string{" Fishing", "chess", "laundry"}, Hours: 100})
+	if !slices.Equal(s.Activities(), []string{models.ActivityFishing, models.ActivityLaundry}) || s.settings.Hours != ACTIVITY_MAX_HOURS {
+		t.Fatalf("settings = %+v", s.settings)
+	}
+
+	s = NewActivityService(nil, nil, nil, ActivitySettings{})
+	if !slices.Equal(s.Activities(), ActivityKeys()) || s.settings.Hours != ACTIVITY_DEFAULT_HOURS {
+		t.Fatalf("default settings = %+v", s.settings)
+	}
+}
+
+func TestMoonPhaseNearWrapsAround(t *testing.T) {
+	if !moonPhaseNear(29.0, 0) || !moonPhaseNear(0.8, 0) || moonPhaseNear(3, 0) {
+		t.Fatal("new moon window is wrong")
+	}
+	if !moonPhaseNear(15.5, synodicMonthDays/2) || moonPhaseNear(10, synodicMonthDays/2) {
+		t.Fatal("full moon window is wrong")
+	}
+}
@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+// Параметры агрометеорологических расчётов
+const (
+	AGRO_CHILL_SEASON_MONTH = time.October // часы охлаждения считаются с 1 октября
+	AGRO_BALANCE_SPINUP     = 60           // дней водного баланса до отображаемого периода — почва «забывает» стартовое состояние
+	AGRO_DAYS_SHOWN         = 14           // дней в таблице страницы «Огород»
+	AGRO_MIN_COVERAGE       = 0.75         // сутки с меньшим покрытием измерениями не учитываются
+	AGRO_RAW_FRACTION       = 0.5          // доля влагоёмкости, после расхода которой растения испытывают стресс (FAO-56 p)
+	AGRO_FORECAST_DAYS      = 3            // дней прогноза осадков в рекомендации
+	AGRO_DEFAULT_WIND       = 2.0          // м/с — значение FAO-56 при отсутствии данных о ветре
+)
+
+// AgroSettings — параметры участка и культуры
+type AgroSettings struct {
+	GDDBase         float64 // базовая температура для градусо-дней, °C
+	CropCoefficient float64 // Kc: ETc = ET0 × Kc
+	SoilWaterMM     float64 // доступная влагоёмкость корнеобитаемого слоя, мм
+	Latitude        float64 // градусы
+	Elevation       float64 // высота над уровнем моря, м
+	WindHeight      float64 // высота анемометра, м
+}
+
+// AgroService считает градусо-дни, часы охлаждения, эвапотранспирацию FAO-56
+// и водный баланс почвы по данным станции.
+type AgroService struct {
+	weatherSvc  *WeatherService
+	forecastSvc *ForecastService
+	settings    AgroSettings
+}
+
+func NewAgroService(weatherSvc *WeatherService, forecastSvc *ForecastService, settings AgroSettings) *AgroService {
+	if settings.CropCoefficient <= 0 {
+		settings.CropCoefficient = 1
+	}
+	if settings.SoilWaterMM <= 0 {
+		settings.SoilWaterMM = 60
+	}
+	if settings.WindHeight <= 0 {
+		settings.WindHeight = 2
+	}
+	return &AgroService{weatherSvc: weatherSvc, forecastSvc: forecastSvc, settings: settings}
+}
+
+// GetSummary возвращает сводку по полным суткам до вчерашнего дня включительно
+func (s *AgroService) GetSummary(ctx context.Context) (*models.AgroSummary, error) {
+	loc := s.weatherSvc.location
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	gddStart := time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, loc)
+	chillStart := time.Date(now.Year(), AGRO_CHILL_SEASON_MONTH, 1, 0, 0, 0, 0, loc)
+	if now.Month() < AGRO_CHILL_SEASON_MONTH {
+		chillStart = chillStart.AddDate(-1, 0, 0)
+	}
+	from := today.AddDate(0, 0, -(AGRO_BALANCE_SPINUP + AGRO_DAYS_SHOWN))
+	for _, start := range []time.Time{gddStart, chillStart} {
+		if start.Before(from) {
+			from = start
+		}
+	}
+
+	inputs, err := s.weatherSvc.repo.GetDailyAgro(ctx, from, today, s.weatherSvc.timezone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily agro data: %w", err)
+	}
+
+	var forecastRain float64
+	if s.forecastSvc != nil {
+		daily, err := s.forecastSvc.GetDailyForecast(ctx, AGRO_FORECAST_DAYS+1)
+		if err == nil {
+			forecastRain = expectedForecastRain(daily, today, AGRO_FORECAST_DAYS)
+		}
+	}
+
+	summary := buildAgroSummary(inputs, s.settings, today, gddStart, chillStart, forecastRain)
+	summary.GeneratedAt = time.Now()
+	return summary, nil
+}
+
+// expectedForecastRain суммирует осадки ближайших дней с учётом вероятности
+func expectedForecastRain(daily []models.DailyForecast, today time.Time, days int) float64 {
+	first := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 0, days)
+	var sum float64
+	for _, d := range daily {
+		date := d.Date.UTC()
+		if date.Before(first) || !date.Before(last) {
+			continue
+		}
+		sum += float64(d.PrecipitationSum) * float64(d.PrecipitationProbability) / 100
+	}
+	return sum
+}
+
+func buildAgroSummary(inputs []models.AgroDailyInput, settings AgroSettings, today, gddStart, chillStart time.Time, forecastRain float64) *models.AgroSummary {
+	summary := &models.AgroSummary{
+		GDDBase:           settings.GDDBase,
+		GDDSeasonStart:    gddStart,
+		ChillSeasonStart:  chillStart,
+		CropCoefficient:   settings.CropCoefficient,
+		SoilWaterCapacity: settings.SoilWaterMM,
+		SoilWater:         settings.SoilWaterMM,
+		ForecastRain:      forecastRain,
+	}
+
+	balanceStart := today.AddDate(0, 0, -(AGRO_BALANCE_SPINUP + AGRO_DAYS_SHOWN))
+	weekStart := today.AddDate(0, 0, -7)
+	shownStart := today.AddDate(0, 0, -AGRO_DAYS_SHOWN)
+	depletion := 0.0
+
+	for _, in := range inputs {
+		if in.TempMin == nil || in.TempMax == nil || in.Coverage < AGRO_MIN_COVERAGE {
+			continue
+		}
+		// Дата из SQL — полночь в часовом поясе станции, помеченная как UTC
+		date := time.Date(in.Date.Year(), in.Date.Month(), in.Date.Day(), 0, 0, 0, 0, today.Location())
+		if !date.Before(today) {
+			continue
+		}
+		tmin, tmax := float64(*in.TempMin), float64(*in.TempMax)
+
+		day := models.AgroDay{
+			Date:       date,
+			TempMin:    *in.TempMin,
+			TempMax:    *in.TempMax,
+			GDD:        growingDegreeDays(tmin, tmax, settings.GDDBase),
+			ChillHours: float64(in.ChillHours),
+		}
+		if in.RainTotal != nil {
+			day.Rain = float64(*in.RainTotal)
+		}
+		day.ET0, day.ET0Method = referenceET0(in, date.YearDay(), settings)
+		day.ETc = day.ET0 * settings.CropCoefficient
+
+		if !date.Before(gddStart) {
+			summary.GDDSeason += day.GDD
+		}
+		if !date.Before(chillStart) {
+			summary.ChillHours += day.ChillHours
+		}
+		if date.Before(balanceStart) {
+			continue
+		}
+
+		// Водный баланс FAO-56: дефицит растёт на ETc и уменьшается осадками
+		depletion = math.Max(0, math.Min(settings.SoilWaterMM, depletion+day.ETc-day.Rain))
+		day.Depletion = depletion
+		day.SoilWater = settings.SoilWaterMM - depletion
+
+		if !date.Before(weekStart) {
+			summary.GDDWeek += day.GDD
+			summary.ET0Week += day.ET0
+			summary.ETcWeek += day.ETc
+			summary.RainWeek += day.Rain
+		}
+		if !date.Before(shownStart) {
+			summary.Days = append(summary.Days, day)
+		}
+	}
+
+	summary.Depletion = depletion
+	summary.SoilWater = settings.SoilWaterMM - depletion
+	summary.DepletionPercent = int(math.Round(depletion / settings.SoilWaterMM * 100))
+	applyWateringAdvice(summary)
+	return summary
+}
+
+// applyWateringAdvice считает рекомендацию по поливу. 1 мм воды = 1 л/м².
+func applyWateringAdvice(s *models.AgroSummary) {
+	readily := AGRO_RAW_FRACTION * s.SoilWaterCapacity
+
+	// На неделю: восполнить дефицит и покрыть расход как за прошлую неделю за вычетом ожидаемого дождя
+	s.WaterWeekMM = math.Max(0, math.Round(s.Depletion+s.ETcWeek-s.ForecastRain))
+
+	need := s.Depletion - s.ForecastRain
+	switch {
+	case s.Depletion >= readily && need > 5:
+		s.WateringLevel = models.WateringNow
+		s.WaterNowMM = math.Round(need)
+		s.Advice = fmt.Sprintf("Пора поливать: почва отдала %d%% доступной влаги, нужно около %.0f л/м²", s.DepletionPercent, s.WaterNowMM)
+	case s.Depletion >= readily*0.6:
+		s.WateringLevel = models.WateringSoon
+		s.Advice = "Влаги пока хватает, полив понадобится через несколько дней"
+		if s.ForecastRain >= 5 {
+			s.Advice = fmt.Sprintf("Влаги пока хватает, а в ближайшие дни ожидается около %.0f мм дождя", s.ForecastRain)
+		}
+	default:
+		s.WateringLevel = models.WateringNotNeeded
+		s.Advice = "Полив не нужен: почва достаточно влажная"
+	}
+}
+
+// growingDegreeDays — сумма активных температур за сутки (метод средней температуры)
+func growingDegreeDays(tmin, tmax, base float64) float64 {
+	return math.Max(0, (tmin+tmax)/2-base)
+}
+
+// referenceET0 считает эталонную эвапотранспирацию FAO-56 Penman–Monteith.
+// Без данных о солнечной радиации используется формула Харгривса.
+func referenceET0(in models.AgroDailyInput, dayOfYear int, settings AgroSettings) (float64, string) {
+	tmin, tmax := float64(*in.TempMin), float64(*in.TempMax)
+	tmean := (tmin + tmax) / 2
+	ra := extraterrestrialRadiation(settings.Latitude, dayOfYear)
+
+	if in.SolarAvg == nil {
+		et0 := 0.0023 * (tmean + 17.8) * math.Sqrt(math.Max(0, tmax-tmin)) * 0.408 * ra
+		return math.Max(0, et0), "hargreaves"
+	}
+
+	// Давление: по станции (мм рт.ст. → кПа) или по высоте
+	pressure := 101.3 * math.Pow((293-0.0065*settings.Elevation)/293, 5.26)
+	if in.PressureAvg != nil && *in.PressureAvg > 0 {
+		pressure = float64(*in.PressureAvg) * 0.133322
+	}
+	gamma := 0.000665 * pressure
+
+	delta := 4098 * saturationVaporPressure(tmean) / math.Pow(tmean+237.3, 2)
+	es := (saturationVaporPressure(tmax) + saturationVaporPressure(tmin)) / 2
+	ea := saturationVaporPressure(tmin) // без влажности точка росы ≈ минимальной температуре
+	if in.HumidityMin != nil && in.HumidityMax != nil {
+		ea = (saturationVaporPressure(tmin)*float64(*in.HumidityMax)/100 + saturationVaporPressure(tmax)*float64(*in.HumidityMin)/100) / 2
+	}
+
+	u2 := AGRO_DEFAULT_WIND
+	if in.WindAvg != nil {
+		u2 = windAt2m(float64(*in.WindAvg), settings.WindHeight)
+	}
+
+	// Радиационный баланс, МДж/м²/сут
+	rs := float64(*in.SolarAvg) * 0.0864
+	rso := (0.75 + 2e-5*settings.Elevation) * ra
+	relative := 1.0
+	if rso > 0 {
+		relative = math.Min(1, rs/rso)
+	}
+	rns := (1 - 0.23) * rs
+	const sigma = 4.903e-9
+	tmaxK, tminK := tmax+273.16, tmin+273.16
+	rnl := sigma * (math.Pow(tmaxK, 4) + math.Pow(tminK, 4)) / 2 * (0.34 - 0.14*math.Sqrt(math.Max(0, ea))) * (1.35*relative - 0.35)
+	rn := rns - rnl
+
+	et0 := (0.408*delta*rn + gamma*900/(tmean+273)*u2*(es-ea)) / (delta + gamma*(1+0.34*u2))
+	return math.Max(0, et0), "penman-monteith"
+}
+
+// saturationVaporPressure — давление насыщенного пара, кПа
+func saturationVaporPressure(t float64) float64 {
+	return 0.6108 * math.Exp(17.27*t/(t+237.3))
+}
+
+// windAt2m приводит скорость ветра к стандартной высоте 2 м (логарифмический профиль FAO-56)
+func windAt2m(speed, height float64) float64 {
+	if height <= 0 || math.Abs(height-2) < 0.01 {
+		return speed
+	}
+	return speed * 4.87 / math.Log(67.8*height-5.42)
+}
+
+// extraterrestrialRadiation — радиация на верхней границе атмосферы, МДж/м²/сут
+func extraterrestrialRadiation(latitude float64, dayOfYear int) float64 {
+	phi := latitude * math.Pi / 180
+	j := float64(dayOfYear)
+	dr := 1 + 0.033*math.Cos(2*math.Pi/365*j)
+	decl := 0.409 * math.Sin(2*math.Pi/365*j-1.39)
+	ws := math.Acos(math.Max(-1, math.Min(1, -math.Tan(phi)*math.Tan(decl))))
+	return 24 * 60 / math.Pi * 0.0820 * dr * (ws*math.Sin(phi)*math.Sin(decl) + math.Cos(phi)*math.Cos(decl)*math.Sin(ws))
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+func agroInput(date time.Time, tmin, tmax, rain float32) models.AgroDailyInput {
+	solar := float32(250)
+	return models.AgroDailyInput{
+		Date:      date,
+		TempMin:   &tmin,
+		TempMax:   &tmax,
+		SolarAvg:  &solar,
+		RainTotal: &rain,
+		Coverage:  1,
+	}
+}
+
+func TestReferenceET0_FAOExample(t *testing.T) {
+	// FAO-56, пример 18: Брюссель, 6 июля, 100 м над уровнем моря, ET₀ ≈ 3.9 мм
+	tmin, tmax := float32(12.3), float32(21.5)
+	rhMin, rhMax := int16(63), int16(84)
+	wind := float32(2.78)
+	solar := float32(22.07 / 0.0864)
+	in := models.AgroDailyInput{
+		TempMin: &tmin, TempMax: &tmax,
+		HumidityMin: &rhMin, HumidityMax: &rhMax,
+		WindAvg: &wind, SolarAvg: &solar,
+	}
+	settings := AgroSettings{Latitude: 50.8, Elevation: 100, WindHeight: 10}
+
+	et0, method := referenceET0(in, 187, settings)
+	if method != "penman-monteith" {
+		t.Fatalf("method = %s, want penman-monteith", method)
+	}
+	if math.Abs(et0-3.9) > 0.2 {
+		t.Fatalf("ET0 = %.2f, want ≈3.9", et0)
+	}
+}
+
+func TestReferenceET0_HargreavesWithoutRadiation(t *testing.T) {
+	tmin, tmax := float32(15), float32(30)
+	et0, method := referenceET0(models.AgroDailyInput{TempMin: &tmin, TempMax: &tmax}, 190, AgroSettings{Latitude: 45})
+	if method != "hargreaves" {
+		t.Fatalf("method = %s, want hargreaves", method)
+	}
+	if et0 < 4 || et0 > 8 {
+		t.Fatalf("ET0 = %.2f, want летнее значение 4–8 мм", et0)
+	}
+}
+
+func TestGrowingDegreeDays(t *testing.T) {
+	if got := growingDegreeDays(14, 26, 10); got != 10 {
+		t.Fatalf("GDD = %.1f, want 10", got)
+	}
+	if got := growingDegreeDays(2, 12, 10); got != 0 {
+		t.Fatalf("GDD = %.1f, want 0 ниже базовой температуры", got)
+	}
+}
+
+func TestBuildAgroSummary_DryWeekNeedsWatering(t *testing.T) {
+	loc := time.UTC
+	today := time.Date(2026, time.July, 20, 0, 0, 0, 0, loc)
+	settings := AgroSettings{GDDBase: 10, CropCoefficient: 1, SoilWaterMM: 60, Latitude: 45, WindHeight: 2}
+
+	var inputs []models.AgroDailyInput
+	for d := 30; d >= 1; d-- {
+		inputs = append(inputs, agroInput(today.AddDate(0, 0, -d), 18, 32, 0))
+	}
+	// Неполные сутки не учитываются
+	partial := agroInput(today.AddDate(0, 0, -31), 18, 32, 50)
+	partial.Coverage = 0.3
+	inputs = append([]models.AgroDailyInput{partial}, inputs...)
+
+	summary := buildAgroSummary(inputs, settings, today, time.Date(2026, time.January, 1, 0, 0, 0, 0, loc), time.Date(2025, time.October, 1, 0, 0, 0, 0, loc), 0)
+
+	if len(summary.Days) != AGRO_DAYS_SHOWN {
+		t.Fatalf("days = %d, want %d", len(summary.Days), AGRO_DAYS_SHOWN)
+	}
+	if summary.GDDWeek != 7*15 {
+		t.Fatalf("GDD за неделю = %.1f, want 105", summary.GDDWeek)
+	}
+	if summary.Depletion != settings.SoilWaterMM {
+		t.Fatalf("дефицит = %.1f, want почва полностью иссушена (%.0f)", summary.Depletion, settings.SoilWaterMM)
+	}
+	if summary.WateringLevel != models.WateringNow || summary.WaterNowMM != 60 {
+		t.Fatalf("watering = %s %.0f мм, want now 60 мм", summary.WateringLevel, summary.WaterNowMM)
+	}
+}
+
+func TestBuildAgroSummary_RainRefillsSoil(t *testing.T) {
+	loc := time.UTC
+	today := time.Date(2026, time.July, 20, 0, 0, 0, 0, loc)
+	settings := AgroSettings{GDDBase: 10, CropCoefficient: 1, SoilWaterMM: 60, Latitude: 45, WindHeight: 2}
+
+	var inputs []models.AgroDailyInput
+	for d := 10; d >= 2; d-- {
+		inputs = append(inputs, agroInput(today.AddDate(0, 0, -d), 18, 32, 0))
+	}
+	inputs = append(inputs, agroInput(today.AddDate(0, 0, -1), 15, 20, 80))
+
+	summary := buildAgroSummary(inputs, settings, today, today.AddDate(0, -6, 0), today.AddDate(0, -9, 0), 0)
+
+	if summary.Depletion != 0 || summary.SoilWater != settings.SoilWaterMM {
+		t.Fatalf("влага = %.1f мм, want полная после ливня", summary.SoilWater)
+	}
+	if summary.WateringLevel != models.WateringNotNeeded {
+		t.Fatalf("watering = %s, want not_needed", summary.WateringLevel)
+	}
+}
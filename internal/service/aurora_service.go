@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+// Параметры модели видимости полярного сияния
+const (
+	AURORA_POLE_LATITUDE             = 80.8  // северный геомагнитный полюс (IGRF, эпоха 2025), °
+	AURORA_POLE_LONGITUDE            = -72.6 // °
+	AURORA_OVAL_KP0                  = 66.5  // экваториальная граница овала при Kp 0, геомагнитная широта
+	AURORA_OVAL_PER_KP               = 2.4   // смещение границы к экватору на единицу Kp, °
+	AURORA_HORIZON_REACH             = 8.0   // сияние на высоте 100–400 км видно над горизонтом на столько градусов южнее границы
+	AURORA_DARK_ELEVATION            = -12.0 // навигационные сумерки: небо достаточно тёмное
+	AURORA_MOON_PENALTY              = 0.3   // доля шанса, которую отнимает полная Луна
+	AURORA_UNKNOWN_CLOUD             = 50.0  // облачность, если прогноза на час нет, %
+	AURORA_NIGHTS                    = 3     // прогноз Kp — на трое суток
+	AURORA_DEFAULT_ALERT_PROBABILITY = 10
+)
+
+// GeomagneticLatitude переводит географические координаты в геомагнитную широту
+// по дипольной модели поля
+func GeomagneticLatitude(latitude, longitude float64) float64 {
+	rad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	sin := math.Sin(rad(latitude))*math.Sin(rad(AURORA_POLE_LATITUDE)) +
+		math.Cos(rad(latitude))*math.Cos(rad(AURORA_POLE_LATITUDE))*math.Cos(rad(longitude-AURORA_POLE_LONGITUDE))
+	return roundTo(math.Asin(sin)*180/math.Pi, 1)
+}
+
+// AuroraOvalBoundary — экваториальная граница аврорального овала (геомагнитная широта) при данном Kp
+func AuroraOvalBoundary(kp float64) float64 {
+	return AURORA_OVAL_KP0 - AURORA_OVAL_PER_KP*kp
+}
+
+// AuroraKpNeeded — Kp, с которого сияние может показаться над северным горизонтом
+func AuroraKpNeeded(geomagneticLatitude float64) float64 {
+	return roundTo(math.Max(0, (AURORA_OVAL_KP0-AURORA_HORIZON_REACH-geomagneticLatitude)/AURORA_OVAL_PER_KP), 1)
+}
+
+// auroraHourProbability — шанс увидеть сияние в тёмный час: геомагнитная часть растёт
+// от нуля на краю видимости до единицы под границей овала, затем её снижают облака и Луна
+func auroraHourProbability(geomagneticLatitude float64, kp float32, cloudCover *float64, moonIllumination float64) int {
+	reach := AuroraOvalBoundary(float64(kp)) - AURORA_HORIZON_REACH
+	geo := math.Min(1, math.Max(0, (geomagneticLatitude-reach)/AURORA_HORIZON_REACH))
+	cloud := AURORA_UNKNOWN_CLOUD
+	if cloudCover != nil {
+		cloud = *cloudCover
+	}
+	p := geo * (1 - cloud/100) * (1 - AURORA_MOON_PENALTY*moonIllumination/100)
+	return int(math.Round(p * 100))
+}
+
+// AuroraSettings — порог вероятности для уведомления
+type AuroraSettings struct {
+	AlertProbability int // %
+}
+
+// AuroraService оценивает видимость полярного сияния на ближайшие ночи: прогноз Kp
+// сравнивается с геомагнитной широтой станции, а тёмные часы, фаза Луны и прогноз
+// облачности снижают шанс.
+type AuroraService struct {
+	geomagSvc   *GeomagneticService
+	sunSvc      *SunService
+	moonSvc     *MoonService
+	forecastSvc *ForecastService
+	settings    AuroraSettings
+}
+
+func NewAuroraService(geomagSvc *GeomagneticService, sunSvc *SunService, moonSvc *MoonService, forecastSvc *ForecastService, settings AuroraSettings) *AuroraService {
+	if settings.AlertProbability <= 0 {
+		settings.AlertProbability = AURORA_DEFAULT_ALERT_PROBABILITY
+	}
+	return &AuroraService{geomagSvc: geomagSvc, sunSvc: sunSvc, moonSvc: moonSvc, forecastSvc: forecastSvc, settings: settings}
+}
+
+// GetForecast возвращает шанс увидеть сияние в текущую и две следующие ночи
+func (s *AuroraService) GetForecast(ctx context.Context) (*models.AuroraForecast, error) {
+	loc := s.sunSvc.timezone
+	now := time.Now()
+	local := now.In(loc)
+	// До полудня ещё идёт ночь, начавшаяся вчера вечером
+	first := dayStart(local, loc)
+	if local.Hour() < 12 {
+		first = first.AddDate(0, 0, -1)
+	}
+	last := first.AddDate(0, 0, AURORA_NIGHTS).Add(12 * time.Hour)
+
+	detail, err := s.geomagSvc.GetDetail(ctx, now.Add(-3*time.Hour), last)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kp forecast: %w", err)
+	}
+	slots := make(map[int64]models.GeomagneticKp, len(detail.Kp))
+	for _, k := range detail.Kp {
+		key := k.SlotTime.UTC().Truncate(3 * time.Hour).Unix()
+		if prev, ok := slots[key]; !ok || k.Kp > prev.Kp {
+			slots[key] = k
+		}
+	}
+
+	clouds := make(map[time.Time]float64)
+	if s.forecastSvc != nil {
+		hourly, err := s.forecastSvc.GetHourlyForecast(ctx, int(last.Sub(now).Hours())+1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get hourly forecast: %w", err)
+		}
+		for _, h := range hourly {
+			clouds[h.Time.UTC()] = float64(h.CloudCover)
+		}
+	}
+
+	mlat := GeomagneticLatitude(s.sunSvc.latitude, s.sunSvc.longitude)
+	forecast := &models.AuroraForecast{GeneratedAt: now, GeomagneticLatitude: mlat, KpNeeded: AuroraKpNeeded(mlat)}
+	for i := 0; i < AURORA_NIGHTS; i++ {
+		date := first.AddDate(0, 0, i)
+		night := models.AuroraNight{Date: date}
+		if s.moonSvc != nil {
+			night.MoonIllumination = roundTo(s.moonSvc.calcIllumination(s.moonSvc.calcMoonAge(date.AddDate(0, 0, 1))), 0)
+		}
+
+		for t := date.Add(12 * time.Hour); t.Before(date.AddDate(0, 0, 1).Add(12 * time.Hour)); t = t.Add(time.Hour) {
+			elevation, _ := solarPosition(t.Add(30*time.Minute), s.sunSvc.latitude, s.sunSvc.longitude)
+			if elevation >= AURORA_DARK_ELEVATION {
+				continue
+			}
+			if night.DarkFrom.IsZero() {
+				night.DarkFrom = t
+			}
+			night.DarkTo = t.Add(time.Hour)
+			if !t.Add(time.Hour).After(now) {
+				continue
+			}
+
+			slot, ok := slots[t.UTC().Truncate(3*time.Hour).Unix()]
+			if !ok {
+				continue
+			}
+			hour := models.AuroraHour{Time: t, Kp: slot.Kp, IsForecast: slot.IsForecast}
+			if cloud, ok := clouds[wallClockUTC(t, loc)]; ok {
+				hour.CloudCover = &cloud
+			}
+			night.Hours = append(night.Hours, hour)
+		}
+
+		fillAuroraNight(&night, mlat)
+		forecast.Nights = append(forecast.Nights, night)
+	}
+	return forecast, nil
+}
+
+// AlertNight возвращает текущую ночь, если шанс увидеть сияние не ниже порога уведомления
+func (s *AuroraService) AlertNight(forecast *models.AuroraForecast) (*models.AuroraNight, bool) {
+	if forecast == nil || len(forecast.Nights) == 0 {
+		return nil, false
+	}
+	night := &forecast.Nights[0]
+	return night, night.Probability >= s.settings.AlertProbability
+}
+
+// fillAuroraNight считает шанс по часам, лучший час (если шанс есть), максимум Kp и среднюю облачность
+func fillAuroraNight(night *models.AuroraNight, geomagneticLatitude float64) {
+	var cloudSum float64
+	cloudHours := 0
+	for i := range night.Hours {
+		h := &night.Hours[i]
+		h.Probability = auroraHourProbability(geomagneticLatitude, h.Kp, h.CloudCover, night.MoonIllumination)
+		if h.Kp > night.MaxKp {
+			night.MaxKp = h.Kp
+		}
+		if h.CloudCover != nil {
+			cloudSum += *h.CloudCover
+			cloudHours++
+		}
+		if h.Probability > night.Probability {
+			night.Probability = h.Probability
+			best := h.Time
+			night.BestTime = &best
+		}
+	}
+	if cloudHours > 0 {
+		cloud := roundTo(cloudSum/float64(cloudHours), 0)
+		night.CloudCover = &cloud
+	}
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+func TestGeomagneticLatitude(t *testing.T) {
+	tests := []struct {
+		name                string
+		latitude, longitude float64
+		want                float64
+	}{
+		{name: "station", latitude: 44.995574, longitude: 41.128354, want: 40.7},
+		{name: "Murmansk", latitude: 68.97, longitude: 33.09, want: 64.9},
+		{name: "pole", latitude: AURORA_POLE_LATITUDE, longitude: AURORA_POLE_LONGITUDE, want: 90},
+	}
+	for _, tt := range tests {
+		if got := GeomagneticLatitude(tt.latitude, tt.longitude); got != tt.want {
+			t.Fatalf("%s: GeomagneticLatitude() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAuroraKpNeeded(t *testing.T) {
+	if got := AuroraKpNeeded(40.7); got != 7.4 {
+		t.Fatalf("AuroraKpNeeded(40.7) = %v, want 7.4", got)
+	}
+	if got := AuroraKpNeeded(65); got != 0 {
+		t.Fatalf("AuroraKpNeeded(65) = %v, want 0 (inside the oval at any Kp)", got)
+	}
+}
+
+func TestAuroraHourProbability(t *testing.T) {
+	clear, overcast := 0.0, 100.0
+	tests := []struct {
+		name  string
+		kp    float32
+		cloud *float64
+		moon  float64
+		want  int
+	}{
+		{name: "quiet", kp: 4, cloud: &clear, want: 0},
+		{name: "horizon edge", kp: 7, cloud: &clear, want: 0},
+		{name: "G5 clear dark", kp: 9, cloud: &clear, want: 47},
+		{name: "G5 full moon", kp: 9, cloud: &clear, moon: 100, want: 33},
+		{name: "G5 unknown clouds", kp: 9, want: 24},
+		{name: "G5 overcast", kp: 9, cloud: &overcast, want: 0},
+	}
+	for _, tt := range tests {
+		if got := auroraHourProbability(40.7, tt.kp, tt.cloud, tt.moon); got != tt.want {
+			t.Fatalf("%s: auroraHourProbability() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFillAuroraNightPicksBestHour(t *testing.T) {
+	evening := time.Date(2026, time.May, 10, 0, 0, 0, 0, time.UTC)
+	clear, cloudy := 0.0, 80.0
+	night := models.AuroraNight{Date: evening, MoonIllumination: 20, Hours: []models.AuroraHour{
+		{Time: evening.Add(21 * time.Hour), Kp: 8, CloudCover: &cloudy},
+		{Time: evening.Add(23 * time.Hour), Kp: 8.7, CloudCover: &clear},
+		{Time: evening.Add(26 * time.Hour), Kp: 9, CloudCover: &cloudy},
+	}}
+	fillAuroraNight(&night, 40.7)
+
+	if night.MaxKp != 9 {
+		t.Fatalf("MaxKp = %v, want 9", night.MaxKp)
+	}
+	if night.BestTime == nil || !night.BestTime.Equal(evening.Add(23*time.Hour)) {
+		t.Fatalf("BestTime = %v, want clear hour 23:00", night.BestTime)
+	}
+	if night.Probability != night.Hours[1].Probability || night.Probability == 0 {
+		t.Fatalf("Probability = %d, want best hour %d", night.Probability, night.Hours[1].Probability)
+	}
+	if night.CloudCover == nil || *night.CloudCover != 53 {
+		t.Fatalf("CloudCover = %v, want mean 53", night.CloudCover)
+	}
+
+	quiet := models.AuroraNight{Hours: []models.AuroraHour{{Time: evening, Kp: 3, CloudCover: &clear}}}
+	fillAuroraNight(&quiet, 40.7)
+	if quiet.Probability != 0 || quiet.BestTime != nil {
+		t.Fatalf("quiet night = %d%% at %v, want 0%% without best time", quiet.Probability, quiet.BestTime)
+	}
+}
+
+func TestAuroraAlertNight(t *testing.T) {
+	svc := NewAuroraService(nil, nil, nil, nil, AuroraSettings{AlertProbability: 15})
+	forecast := &models.AuroraForecast{Nights: []models.AuroraNight{{Probability: 12}, {Probability: 40}}}
+	if _, alert := svc.AlertNight(forecast); alert {
+		t.Fatal("AlertNight() alerted below threshold for tonight")
+	}
+	forecast.Nights[0].Probability = 15
+	if night, alert := svc.AlertNight(forecast); !alert || night.Probability != 15 {
+		t.Fatalf("AlertNight() = %+v, %v; want tonight, true", night, alert)
+	}
+}
@@ -0,0 +1,310 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+	"github.com/iRootPro/weather/internal/repository"
+)
+
+var (
+	ErrClimateNormalsDisabled = errors.New("climate normals are not configured")
+	ErrInvalidClimatePeriod   = errors.New("invalid climate anomaly period")
+)
+
+// climateNormalTolerance is the deviation still reported as "около нормы", °C.
+const climateNormalTolerance = 0.5
+
+// SetClimateNormals enables comparison with official station normals imported by normals-import.
+func (s *WeatherService) SetClimateNormals(repo repository.ClimateNormalsRepository, station, period string) {
+	if period == "" {
+		period = models.ClimateNormalsPeriod1991
+	}
+	s.normalsRepo = repo
+	s.normalsStation = station
+	s.normalsPeriod = period
+}
+
+// GetClimateAnomaly compares a day (YYYY-MM-DD, yesterday by default), a month (YYYY-MM)
+// or a season (YYYY-winter) with the official normals.
+func (s *WeatherService) GetClimateAnomaly(ctx context.Context, kind, dateParam string) (*models.ClimateAnomaly, error) {
+	if s.normalsRepo == nil {
+		return nil, ErrClimateNormalsDisabled
+	}
+	loc := s.location
+	if loc == nil {
+		loc = time.Local
+	}
+	now := time.Now().In(loc)
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	if kind == "" {
+		kind = "day"
+	}
+
+	start, end, label, err := resolveClimatePeriod(kind, strings.TrimSpace(dateParam), now, loc)
+	if err != nil {
+		return nil, err
+	}
+	anomaly, err := s.getClimateAnomaly(ctx, kind, label, start, end, now, loc)
+	if err != nil {
+		return nil, err
+	}
+	return &anomaly, nil
+}
+
+// GetDailySummaryAnomalies returns the anomalies shown in the morning summary: yesterday
+// and the month so far. Unavailable ones are skipped; nil when normals are not configured.
+func (s *WeatherService) GetDailySummaryAnomalies(ctx context.Context) ([]models.ClimateAnomaly, error) {
+	if s.normalsRepo == nil {
+		return nil, nil
+	}
+	loc := s.location
+	if loc == nil {
+		loc = time.Local
+	}
+	now := time.Now().In(loc)
+	today := dayStart(now, loc)
+	monthStart := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, loc)
+
+	type summaryPeriod struct {
+		kind, label string
+		start, end  time.Time
+	}
+	periods := []summaryPeriod{{"day", "Вчера", today.AddDate(0, 0, -1), today}}
+	// Today is not over yet, so the month is counted through yesterday.
+	if monthStart.Before(today) {
+		periods = append(periods, summaryPeriod{"month", "С начала месяца", monthStart, today})
+	}
+
+	var result []models.ClimateAnomaly
+	for _, period := range periods {
+		anomaly, err := s.getClimateAnomaly(ctx, period.kind, period.label, period.start, period.end, now, loc)
+		if err != nil {
+			return nil, err
+		}
+		if anomaly.Available {
+			result = append(result, anomaly)
+		}
+	}
+	return result, nil
+}
+
+func (s *WeatherService) getClimateAnomaly(ctx context.Context, kind, label string, start, end, now time.Time, loc *time.Location) (models.ClimateAnomaly, error) {
+	if s.normalsRepo == nil {
+		return models.ClimateAnomaly{}, nil
+	}
+	calendarEnd := minTime(end, dayStart(now, loc).AddDate(0, 0, 1))
+	days, err := s.repo.GetDailyInsights(ctx, start, minTime(end, now), s.timezone)
+	if err != nil {
+		return models.ClimateAnomaly{}, err
+	}
+	normals, err := s.normalsRepo.GetByStation(ctx, s.normalsStation, s.normalsPeriod)
+	if err != nil {
+		return models.ClimateAnomaly{}, err
+	}
+
+	anomaly := buildClimateAnomaly(start, calendarEnd, days, normals, loc)
+	anomaly.Kind = kind
+	anomaly.Label = label
+	anomaly.StationCode = s.normalsStation
+	anomaly.NormalsPeriod = s.normalsPeriod
+	return anomaly, nil
+}
+
+func resolveClimatePeriod(kind, param string, now time.Time, loc *time.Location) (time.Time, time.Time, string, error) {
+	today := dayStart(now, loc)
+	switch kind {
+	case "day":
+		start := today.AddDate(0, 0, -1)
+		if param != "" {
+			parsed, err := time.ParseInLocation("2006-01-02", param, loc)
+			if err != nil {
+				return time.Time{}, time.Time{}, "", ErrInvalidClimatePeriod
+			}
+			start = parsed
+		}
+		if start.After(today) {
+			return time.Time{}, time.Time{}, "", ErrInvalidClimatePeriod
+		}
+		return start, start.AddDate(0, 0, 1), formatInsightDate(start), nil
+	case "month":
+		start := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, loc)
+		if param != "" {
+			parsed, err := time.ParseInLocation("2006-01", param, loc)
+			if err != nil || parsed.After(start) {
+				return time.Time{}, time.Time{}, "", ErrInvalidClimatePeriod
+			}
+			start = parsed
+		}
+		return start, start.AddDate(0, 1, 0), russianMonthYear(start), nil
+	case "season":
+		start, end := seasonBounds(now, loc)
+		year, code := seasonIDFromStart(start)
+		if param != "" {
+			parsedYear, parsedCode, err := parseSeasonParam(param)
+			if err != nil {
+				return time.Time{}, time.Time{}, "", ErrInvalidClimatePeriod
+			}
+			candidateStart, candidateEnd := seasonBoundsByID(parsedYear, parsedCode, loc)
+			if candidateStart.After(start) {
+				return time.Time{}, time.Time{}, "", ErrInvalidClimatePeriod
+			}
+			start, end, year, code = candidateStart, candidateEnd, parsedYear, parsedCode
+		}
+		return start, end, seasonLabel(year, code), nil
+	default:
+		return time.Time{}, time.Time{}, "", ErrInvalidClimatePeriod
+	}
+}
+
+// climateDayNormal is the normal of one calendar day: the daily normal when present, else the monthly one.
+type climateDayNormal struct {
+	tempAvg, tempMin, tempMax, precipitation *float64
+}
+
+type climateNormalsIndex struct {
+	monthly map[int]models.ClimateNormal
+	daily   map[[2]int]models.ClimateNormal
+}
+
+func newClimateNormalsIndex(normals []models.ClimateNormal) climateNormalsIndex {
+	index := climateNormalsIndex{
+		monthly: make(map[int]models.ClimateNormal),
+		daily:   make(map[[2]int]models.ClimateNormal),
+	}
+	for _, normal := range normals {
+		if normal.Day == 0 {
+			index.monthly[normal.Month] = normal
+		} else {
+			index.daily[[2]int{normal.Month, normal.Day}] = normal
+		}
+	}
+	return index
+}
+
+// forDate resolves the day normal field by field, falling back from the daily to the monthly
+// normal. Monthly precipitation is spread evenly over the days of the month.
+func (idx climateNormalsIndex) forDate(date time.Time) (climateDayNormal, bool) {
+	monthly, hasMonthly := idx.monthly[int(date.Month())]
+	daily, hasDaily := idx.daily[[2]int{int(date.Month()), date.Day()}]
+	if !hasMonthly && !hasDaily {
+		return climateDayNormal{}, false
+	}
+
+	pick := func(dailyValue, monthlyValue *float32) *float64 {
+		if hasDaily && dailyValue != nil {
+			v := float64(*dailyValue)
+			return &v
+		}
+		if hasMonthly && monthlyValue != nil {
+			v := float64(*monthlyValue)
+			return &v
+		}
+		return nil
+	}
+
+	normal := climateDayNormal{
+		tempAvg: pick(daily.TempAvg, monthly.TempAvg),
+		tempMin: pick(daily.TempMin, monthly.TempMin),
+		tempMax: pick(daily.TempMax, monthly.TempMax),
+	}
+	if hasDaily && daily.Precipitation != nil {
+		v := float64(*daily.Precipitation)
+		normal.precipitation = &v
+	} else if hasMonthly && monthly.Precipitation != nil {
+		v := float64(*monthly.Precipitation) / float64(daysInMonth(date.Year(), date.Month(), date.Location()))
+		normal.precipitation = &v
+	}
+	return normal, true
+}
+
+// buildClimateAnomaly compares station days in [start, end) with the normals of the same calendar
+// days, so gaps in station data do not bias the result.
+func buildClimateAnomaly(start, end time.Time, days []models.DailyWeatherInsight, normals []models.ClimateNormal, loc *time.Location) models.ClimateAnomaly {
+	anomaly := models.ClimateAnomaly{From: start, To: end, DaysInPeriod: daysBetween(start, end)}
+	index := newClimateNormalsIndex(normals)
+
+	type pair struct {
+		station, normal float64
+		count           int
+	}
+	var temp, tempMin, tempMax pair
+	var rain pair
+	add := func(p *pair, station *float32, normal *float64) {
+		if station == nil || normal == nil {
+			return
+		}
+		p.station += float64(*station)
+		p.normal += *normal
+		p.count++
+	}
+
+	for _, day := range days {
+		date := dayStart(day.Date, loc)
+		if date.Before(start) || !date.Before(end) {
+			continue
+		}
+		normal, ok := index.forDate(date)
+		if !ok {
+			continue
+		}
+		anomaly.DaysWithData++
+		add(&temp, day.TempAvg, normal.tempAvg)
+		add(&tempMin, day.TempMin, normal.tempMin)
+		add(&tempMax, day.TempMax, normal.tempMax)
+		add(&rain, day.RainTotal, normal.precipitation)
+	}
+
+	if temp.count > 0 {
+		anomaly.HasTemp = true
+		anomaly.TempAvg = roundTo(temp.station/float64(temp.count), 1)
+		anomaly.TempNormal = roundTo(temp.normal/float64(temp.count), 1)
+		anomaly.TempAnomaly = roundTo((temp.station-temp.normal)/float64(temp.count), 1)
+		anomaly.TempText = climateTempText(anomaly.TempAnomaly)
+	}
+	if tempMin.count > 0 {
+		anomaly.HasTempMin = true
+		anomaly.TempMin = roundTo(tempMin.station/float64(tempMin.count), 1)
+		anomaly.TempMinNormal = roundTo(tempMin.normal/float64(tempMin.count), 1)
+		anomaly.TempMinAnomaly = roundTo((tempMin.station-tempMin.normal)/float64(tempMin.count), 1)
+	}
+	if tempMax.count > 0 {
+		anomaly.HasTempMax = true
+		anomaly.TempMax = roundTo(tempMax.station/float64(tempMax.count), 1)
+		anomaly.TempMaxNormal = roundTo(tempMax.normal/float64(tempMax.count), 1)
+		anomaly.TempMaxAnomaly = roundTo((tempMax.station-tempMax.normal)/float64(tempMax.count), 1)
+	}
+	if rain.count > 0 {
+		anomaly.HasPrecipitation = true
+		anomaly.Precipitation = roundTo(rain.station, 1)
+		anomaly.PrecipitationNormal = roundTo(rain.normal, 1)
+		anomaly.PrecipitationPercent = int(math.Round(ratioPercent(rain.station, rain.normal)))
+		// A daily rain normal is a fraction of a millimetre; a percentage of it means nothing.
+		if rain.normal >= 1 {
+			anomaly.PrecipitationText = fmt.Sprintf("осадков %d%% нормы", anomaly.PrecipitationPercent)
+		}
+	}
+	anomaly.Available = anomaly.HasTemp || anomaly.HasPrecipitation
+	return anomaly
+}
+
+func climateTempText(delta float64) string {
+	switch {
+	case math.Abs(delta) < climateNormalTolerance:
+		return "около нормы"
+	case delta > 0:
+		return fmt.Sprintf("на %.1f° теплее нормы", delta)
+	default:
+		return fmt.Sprintf("на %.1f° холоднее нормы", -delta)
+	}
+}
+
+func roundTo(value float64, precision int) float64 {
+	factor := math.Pow(10, float64(precision))
+	return math.Round(value*factor) / factor
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+func f32(v float32) *float32 { return &v }
+
+func TestBuildClimateAnomalyUsesDailyNormalsWithMonthlyFallback(t *testing.T) {
+	loc := time.UTC
+	start := time.Date(2026, time.March, 1, 0, 0, 0, 0, loc)
+	normals := []models.ClimateNormal{
+		{Month: 3, TempAvg: f32(4), TempMin: f32(0), TempMax: f32(9), Precipitation: f32(31)},
+		{Month: 3, Day: 2, TempAvg: f32(6)},
+	}
+	days := []models.DailyWeatherInsight{
+		{Date: start, TempAvg: f32(8), TempMin: f32(3), TempMax: f32(13), RainTotal: f32(3)},
+		{Date: start.AddDate(0, 0, 1), TempAvg: f32(8), TempMin: f32(2), TempMax: f32(14), RainTotal: f32(0)},
+		// За пределами периода
+		{Date: start.AddDate(0, 1, 0), TempAvg: f32(30)},
+	}
+
+	anomaly := buildClimateAnomaly(start, start.AddDate(0, 1, 0), days, normals, loc)
+	if !anomaly.Available || anomaly.DaysWithData != 2 || anomaly.DaysInPeriod != 31 {
+		t.Fatalf("unexpected coverage: %#v", anomaly)
+	}
+	// Норма: 4° (месячная) и 6° (дневная) → 5°, станция 8°
+	if anomaly.TempNormal != 5 || anomaly.TempAnomaly != 3 || anomaly.TempText != "на 3.0° теплее нормы" {
+		t.Fatalf("unexpected temperature anomaly: %#v", anomaly)
+	}
+	if anomaly.TempMinAnomaly != 2.5 || anomaly.TempMaxAnomaly != 4.5 {
+		t.Fatalf("unexpected min/max anomaly: %#v", anomaly)
+	}
+	// Месячная норма осадков 31 мм делится на 31 день
+	if anomaly.PrecipitationNormal != 2 || anomaly.PrecipitationPercent != 150 || anomaly.PrecipitationText != "осадков 150% нормы" {
+		t.Fatalf("unexpected precipitation anomaly: %#v", anomaly)
+	}
+}
+
+func TestBuildClimateAnomalyWithoutNormals(t *testing.T) {
+	start := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	anomaly := buildClimateAnomaly(start, start.AddDate(0, 0, 1), []models.DailyWeatherInsight{{Date: start, TempAvg: f32(8)}}, nil, time.UTC)
+	if anomaly.Available {
+		t.Fatalf("anomaly must be unavailable without normals: %#v", anomaly)
+	}
+}
+
+func TestClimateTempText(t *testing.T) {
+	tests := map[float64]string{
+		4.2:  "на 4.2° теплее нормы",
+		-1.5: "на 1.5° холоднее нормы",
+		0.3:  "около нормы",
+		-0.4: "около нормы",
+	}
+	for delta, want := range tests {
+		if got := climateTempText(delta); got != want {
+			t.Errorf("climateTempText(%v) = %q, want %q", delta, got, want)
+		}
+	}
+}
+
+func TestResolveClimatePeriod(t *testing.T) {
+	loc := time.UTC
+	now := time.Date(2026, time.October, 18, 7, 0, 0, 0, loc)
+	tests := []struct {
+		kind, param        string
+		wantStart, wantEnd string
+	}{
+		{kind: "day", wantStart: "2026-10-17", wantEnd: "2026-10-18"},
+		{kind: "day", param: "2026-07-01", wantStart: "2026-07-01", wantEnd: "2026-07-02"},
+		{kind: "month", wantStart: "2026-10-01", wantEnd: "2026-11-01"},
+		{kind: "season", param: "2026-winter", wantStart: "2025-12-01", wantEnd: "2026-03-01"},
+	}
+	for _, tt := range tests {
+		start, end, _, err := resolveClimatePeriod(tt.kind, tt.param, now, loc)
+		if err != nil {
+			t.Fatalf("resolveClimatePeriod(%q, %q) error = %v", tt.kind, tt.param, err)
+		}
+		if start.Format("2006-01-02") != tt.wantStart || end.Format("2006-01-02") != tt.wantEnd {
+			t.Errorf("resolveClimatePeriod(%q, %q) = %s..%s", tt.kind, tt.param, start.Format("2006-01-02"), end.Format("2006-01-02"))
+		}
+	}
+	for _, bad := range [][2]string{{"week", ""}, {"day", "2026-10-20"}, {"month", "2026-12"}, {"season", "2027-spring"}} {
+		if _, _, _, err := resolveClimatePeriod(bad[0], bad[1], now, loc); err == nil {
+			t.Errorf("resolveClimatePeriod(%q, %q) expected error", bad[0], bad[1])
+		}
+	}
+}
@@ -0,0 +1,325 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+const (
+	// climatologyMinSamples — меньше часов (суток) в окне не хватает для перцентилей
+	climatologyMinSamples = 20
+	// climatologyCacheTTL — климатология часа почти не меняется, поэтому не пересчитываем её на каждый запрос событий
+	climatologyCacheTTL = time.Hour
+	// climatologyMaxWindowDays — окна соседних лет не должны пересекаться, иначе сутки попадут в выборку дважды
+	climatologyMaxWindowDays = 180
+)
+
+// ClimatologySettings — параметры поиска необычных значений по климатологии станции
+type ClimatologySettings struct {
+	WindowDays     int     // ± дней вокруг даты
+	LowPercentile  float64 // нижний порог, % (например 5)
+	HighPercentile float64 // верхний порог, % (например 95)
+	MinYears       int     // сколько лет истории нужно, чтобы говорить о необычности
+}
+
+type climatologyCacheEntry struct {
+	stats   *models.ClimatologyStats
+	expires time.Time
+}
+
+type climatologyState struct {
+	settings ClimatologySettings
+	mu       sync.Mutex
+	cache    map[string]climatologyCacheEntry
+}
+
+// SetClimatology enables "unusual" events built from day-of-year/hour-of-day station climatology.
+func (s *WeatherService) SetClimatology(settings ClimatologySettings) {
+	if settings.WindowDays <= 0 {
+		settings.WindowDays = 7
+	}
+	if settings.WindowDays > climatologyMaxWindowDays {
+		settings.WindowDays = climatologyMaxWindowDays
+	}
+	if settings.LowPercentile <= 0 || settings.LowPercentile >= 50 {
+		settings.LowPercentile = 5
+	}
+	if settings.HighPercentile <= 50 || settings.HighPercentile >= 100 {
+		settings.HighPercentile = 95
+	}
+	if settings.MinYears <= 0 {
+		settings.MinYears = 2
+	}
+	s.climatology = &climatologyState{settings: settings, cache: make(map[string]climatologyCacheEntry)}
+}
+
+// GetClimatology returns station climatology of a metric for the date (and hour for hourly metrics) of at.
+func (s *WeatherService) GetClimatology(ctx context.Context, metric string, at time.Time) (*models.ClimatologyStats, error) {
+	if s.climatology == nil {
+		return nil, nil
+	}
+	loc := s.location
+	if loc == nil {
+		loc = time.Local
+	}
+	at = at.In(loc)
+	settings := s.climatology.settings
+	// Суточные метрики от часа не зависят: ключ без часа, чтобы не пересчитывать их каждый час
+	key := metric + "/" + at.Format("2006-01-02")
+	if models.ClimatologyHourly(metric) {
+		key += fmt.Sprintf("/%02d", at.Hour())
+	}
+
+	s.climatology.mu.Lock()
+	entry, ok := s.climatology.cache[key]
+	s.climatology.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.stats, nil
+	}
+
+	stats, err := s.repo.GetClimatology(ctx, models.ClimatologyQuery{
+		Metric:         metric,
+		Month:          at.Month(),
+		Day:            at.Day(),
+		Hour:           at.Hour(),
+		WindowDays:     settings.WindowDays,
+		Before:         dayStart(at, loc),
+		Timezone:       s.timezone,
+		LowPercentile:  settings.LowPercentile / 100,
+		HighPercentile: settings.HighPercentile / 100,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.climatology.mu.Lock()
+	for k, e := range s.climatology.cache {
+		if time.Now().After(e.expires) {
+			delete(s.climatology.cache, k)
+		}
+	}
+	s.climatology.cache[key] = climatologyCacheEntry{stats: stats, expires: time.Now().Add(climatologyCacheTTL)}
+	s.climatology.mu.Unlock()
+	return stats, nil
+}
+
+// getUnusualEvents compares the last hour and today's running extremes with the station climatology.
+func (s *WeatherService) getUnusualEvents(ctx context.Context, data []models.WeatherData) ([]models.WeatherEvent, error) {
+	if s.climatology == nil || len(data) == 0 {
+		return nil, nil
+	}
+	loc := s.location
+	if loc == nil {
+		loc = time.Local
+	}
+	latest := data[len(data)-1].Time.In(loc)
+	settings := s.climatology.settings
+
+	var events []models.WeatherEvent
+	hourly := lastHourMeans(data, latest)
+	for _, metric := range []string{models.ClimatologyTemperature, models.ClimatologyHumidity, models.ClimatologyPressure} {
+		value, ok := hourly[metric]
+		if !ok {
+			continue
+		}
+		stats, err := s.GetClimatology(ctx, metric, latest)
+		if err != nil {
+			return nil, err
+		}
+		if event := unusualReadingEvent(metric, value, stats, settings, latest); event != nil {
+			events = append(events, *event)
+		}
+	}
+
+	today, err := s.repo.GetDailyInsights(ctx, dayStart(latest, loc), latest.Add(time.Minute), s.timezone)
+	if err != nil {
+		return nil, err
+	}
+	if len(today) == 0 {
+		return events, nil
+	}
+	day := today[len(today)-1]
+	daily := []struct {
+		metric string
+		value  *float32
+	}{
+		{models.ClimatologyTempMax, day.TempMax},
+		{models.ClimatologyTempMin, day.TempMin},
+		{models.ClimatologyRain, day.RainTotal},
+	}
+	for _, item := range daily {
+		if item.value == nil {
+			continue
+		}
+		stats, err := s.GetClimatology(ctx, item.metric, latest)
+		if err != nil {
+			return nil, err
+		}
+		if event := unusualDayEvent(item.metric, float64(*item.value), stats, settings, latest); event != nil {
+			events = append(events, *event)
+		}
+	}
+	return events, nil
+}
+
+// lastHourMeans усредняет часовые метрики за последний час перед latest.
+func lastHourMeans(data []models.WeatherData, latest time.Time) map[string]float64 {
+	var temp, humidity, pressure []float64
+	for _, d := range data {
+		if latest.Sub(d.Time) >= time.Hour {
+			continue
+		}
+		if d.TempOutdoor != nil {
+			temp = append(temp, float64(*d.TempOutdoor))
+		}
+		if d.HumidityOutdoor != nil {
+			humidity = append(humidity, float64(*d.HumidityOutdoor))
+		}
+		if d.PressureRelative != nil {
+			pressure = append(pressure, float64(*d.PressureRelative))
+		}
+	}
+	result := make(map[string]float64, 3)
+	for metric, values := range map[string][]float64{
+		models.ClimatologyTemperature: temp,
+		models.ClimatologyHumidity:    humidity,
+		models.ClimatologyPressure:    pressure,
+	} {
+		if len(values) == 0 {
+			continue
+		}
+		sum := 0.0
+		for _, v := range values {
+			sum += v
+		}
+		result[metric] = sum / float64(len(values))
+	}
+	return result
+}
+
+func climatologyReliable(stats *models.ClimatologyStats, settings ClimatologySettings) bool {
+	return stats != nil && stats.Years >= settings.MinYears && stats.Samples >= climatologyMinSamples
+}
+
+// unusualReadingEvent сообщает о часовом значении за пределами перцентилей этого часа и даты.
+func unusualReadingEvent(metric string, value float64, stats *models.ClimatologyStats, settings ClimatologySettings, at time.Time) *models.WeatherEvent {
+	if !climatologyReliable(stats, settings) {
+		return nil
+	}
+	high := value > stats.High
+	if !high && value >= stats.Low {
+		return nil
+	}
+
+	var description, details string
+	switch metric {
+	case models.ClimatologyTemperature:
+		word := "холодно"
+		if high {
+			word = "тепло"
+		}
+		description = fmt.Sprintf("Необычно %s для %s в %02d:00", word, formatInsightDate(at), at.Hour())
+		details = fmt.Sprintf("сейчас %.1f°C, обычно %.1f…%.1f°C", value, stats.Low, stats.High)
+	case models.ClimatologyHumidity:
+		word := "сухо"
+		if high {
+			word = "влажно"
+		}
+		description = fmt.Sprintf("Необычно %s для %s в %02d:00", word, formatInsightDate(at), at.Hour())
+		details = fmt.Sprintf("сейчас %.0f%%, обычно %.0f–%.0f%%", value, stats.Low, stats.High)
+	case models.ClimatologyPressure:
+		word := "низкое"
+		if high {
+			word = "высокое"
+		}
+		description = fmt.Sprintf("Необычно %s давление для %s", word, formatInsightDate(at))
+		details = fmt.Sprintf("сейчас %.0f мм, обычно %.0f–%.0f мм", value, stats.Low, stats.High)
+	default:
+		return nil
+	}
+	details += fmt.Sprintf(" (%.0f–%.0f-й перцентиль за %d лет, %+.1fσ)", settings.LowPercentile, settings.HighPercentile, stats.Years, stats.ZScore(value))
+
+	icon := "📉"
+	if high {
+		icon = "📈"
+	}
+	return &models.WeatherEvent{
+		Type:        "unusual",
+		Time:        at,
+		Value:       value,
+		ValueFrom:   stats.Mean,
+		Change:      math.Round((value-stats.Mean)*10) / 10,
+		Period:      "за час",
+		Description: description,
+		Details:     details,
+		Icon:        icon,
+	}
+}
+
+// unusualDayEvent сообщает о рекорде даты или о сутках за пределами перцентилей.
+// Текущие сутки не закончились: максимум и осадки могут только расти, минимум — только падать,
+// поэтому проверяется лишь та сторона распределения, которая уже не изменится.
+func unusualDayEvent(metric string, value float64, stats *models.ClimatologyStats, settings ClimatologySettings, at time.Time) *models.WeatherEvent {
+	if !climatologyReliable(stats, settings) {
+		return nil
+	}
+	date := formatInsightDate(at)
+	years := stats.DateYears >= settings.MinYears
+
+	var description, details, icon string
+	switch metric {
+	case models.ClimatologyTempMax:
+		switch {
+		case years && value > stats.DateMax:
+			description = fmt.Sprintf("Самый тёплый %s за всю историю станции", date)
+			details = fmt.Sprintf("максимум %.1f°C, прежний рекорд даты %.1f°C", value, stats.DateMax)
+		case value > stats.High:
+			description = fmt.Sprintf("Необычно тёплый день для %s", date)
+			details = fmt.Sprintf("максимум %.1f°C, обычно не выше %.1f°C", value, stats.High)
+		}
+		icon = "🔥"
+	case models.ClimatologyTempMin:
+		switch {
+		case years && value < stats.DateMin:
+			description = fmt.Sprintf("Самая холодная ночь %s за всю историю станции", date)
+			details = fmt.Sprintf("минимум %.1f°C, прежний рекорд даты %.1f°C", value, stats.DateMin)
+		case value < stats.Low:
+			description = fmt.Sprintf("Необычно холодная ночь для %s", date)
+			details = fmt.Sprintf("минимум %.1f°C, обычно не ниже %.1f°C", value, stats.Low)
+		}
+		icon = "🥶"
+	case models.ClimatologyRain:
+		// Сухие дни составляют большую часть выборки — без заметного дождя рекордом это не считаем
+		if value < 1 {
+			return nil
+		}
+		switch {
+		case years && value > stats.DateMax:
+			description = fmt.Sprintf("Самый дождливый %s за всю историю станции", date)
+			details = fmt.Sprintf("%.1f мм, прежний рекорд даты %.1f мм", value, stats.DateMax)
+		case value > stats.High:
+			description = fmt.Sprintf("Необычно дождливый день для %s", date)
+			details = fmt.Sprintf("%.1f мм, обычно не больше %.1f мм", value, stats.High)
+		}
+		icon = "🌧️"
+	}
+	if description == "" {
+		return nil
+	}
+	return &models.WeatherEvent{
+		Type:        "unusual",
+		Time:        at,
+		Value:       value,
+		ValueFrom:   stats.Mean,
+		Change:      math.Round((value-stats.Mean)*10) / 10,
+		Period:      "за сутки",
+		Description: description,
+		Details:     details,
+		Icon:        icon,
+	}
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+	"github.com/iRootPro/weather/internal/repository"
+)
+
+var testClimatologySettings = ClimatologySettings{WindowDays: 7, LowPercentile: 5, HighPercentile: 95, MinYears: 2}
+
+func TestUnusualReadingEventFlagsValuesOutsidePercentiles(t *testing.T) {
+	at := time.Date(2026, time.October, 15, 14, 20, 0, 0, time.UTC)
+	stats := &models.ClimatologyStats{Samples: 45, Years: 3, Mean: 15, StdDev: 2.5, Low: 11, High: 19}
+
+	warm := unusualReadingEvent(models.ClimatologyTemperature, 22.4, stats, testClimatologySettings, at)
+	if warm == nil || warm.Type != "unusual" || warm.Description != "Необычно тепло для 15 октября в 14:00" {
+		t.Fatalf("unexpected warm event: %#v", warm)
+	}
+	if !strings.Contains(warm.Details, "+3.0σ") {
+		t.Errorf("details must contain z-score: %q", warm.Details)
+	}
+	if cold := unusualReadingEvent(models.ClimatologyTemperature, 9, stats, testClimatologySettings, at); cold == nil || cold.Icon != "📉" {
+		t.Fatalf("unexpected cold event: %#v", cold)
+	}
+	if usual := unusualReadingEvent(models.ClimatologyTemperature, 16, stats, testClimatologySettings, at); usual != nil {
+		t.Fatalf("usual value must not be flagged: %#v", usual)
+	}
+}
+
+func TestUnusualReadingEventNeedsEnoughHistory(t *testing.T) {
+	at := time.Date(2026, time.October, 15, 14, 0, 0, 0, time.UTC)
+	oneYear := &models.ClimatologyStats{Samples: 15, Years: 1, Mean: 15, StdDev: 2, Low: 12, High: 18}
+	if event := unusualReadingEvent(models.ClimatologyTemperature, 30, oneYear, testClimatologySettings, at); event != nil {
+		t.Fatalf("one year of history is not a climatology: %#v", event)
+	}
+	if event := unusualReadingEvent(models.ClimatologyTemperature, 30, nil, testClimatologySettings, at); event != nil {
+		t.Fatalf("nil stats must not produce events: %#v", event)
+	}
+}
+
+func TestUnusualDayEventRecordsForDate(t *testing.T) {
+	at := time.Date(2026, time.October, 15, 16, 0, 0, 0, time.UTC)
+	stats := &models.ClimatologyStats{Samples: 40, Years: 3, Mean: 17, StdDev: 3, Low: 12, High: 23, DateYears: 3, DateMin: 4, DateMax: 24.5}
+
+	record := unusualDayEvent(models.ClimatologyTempMax, 25.1, stats, testClimatologySettings, at)
+	if record == nil || record.Description != "Самый тёплый 15 октября за всю историю станции" {
+		t.Fatalf("unexpected record event: %#v", record)
+	}
+	warm := unusualDayEvent(models.ClimatologyTempMax, 23.5, stats, testClimatologySettings, at)
+	if warm == nil || warm.Description != "Необычно тёплый день для 15 октября" {
+		t.Fatalf("unexpected warm day event: %#v", warm)
+	}
+	// Минимум текущих суток ещё может опуститься, но не подняться: высокий минимум не флагуем
+	if event := unusualDayEvent(models.ClimatologyTempMin, 20, stats, testClimatologySettings, at); event != nil {
+		t.Fatalf("running minimum must only be checked against the low side: %#v", event)
+	}
+	cold := unusualDayEvent(models.ClimatologyTempMin, 3, stats, testClimatologySettings, at)
+	if cold == nil || cold.Description != "Самая холодная ночь 15 октября за всю историю станции" {
+		t.Fatalf("unexpected cold record: %#v", cold)
+	}
+}
+
+func TestUnusualDayEventIgnoresDryDays(t *testing.T) {
+	at := time.Date(2026, time.October, 15, 16, 0, 0, 0, time.UTC)
+	stats := &models.ClimatologyStats{Samples: 40, Years: 3, Mean: 0.2, Low: 0, High: 0.1, DateYears: 3, DateMax: 0}
+	if event := unusualDayEvent(models.ClimatologyRain, 0.4, stats, testClimatologySettings, at); event != nil {
+		t.Fatalf("drizzle must not become a rain record: %#v", event)
+	}
+}
+
+func TestLastHourMeans(t *testing.T) {
+	latest := time.Date(2026, time.October, 15, 14, 0, 0, 0, time.UTC)
+	t1, t2, old := float32(10), float32(12), float32(30)
+	means := lastHourMeans([]models.WeatherData{
+		{Time: latest.Add(-2 * time.Hour), TempOutdoor: &old},
+		{Time: latest.Add(-30 * time.Minute), TempOutdoor: &t1},
+		{Time: latest, TempOutdoor: &t2},
+	}, latest)
+	if means[models.ClimatologyTemperature] != 11 {
+		t.Fatalf("unexpected hourly mean: %v", means)
+	}
+	if _, ok := means[models.ClimatologyHumidity]; ok {
+		t.Fatalf("metric without data must be absent: %v", means)
+	}
+}
+
+func TestBuildEventCardsPromotesUnusualDay(t *testing.T) {
+	now := time.Date(2026, time.October, 15, 16, 0, 0, 0, time.UTC)
+	cards := buildEventCards([]models.WeatherEvent{{Type: "unusual", Time: now, Period: "за сутки", Description: "Самый тёплый 15 октября за всю историю станции", Value: 25}}, now)
+	if len(cards) != 1 || cards[0].Priority < 70 {
+		t.Fatalf("unusual day must be headline-worthy: %#v", cards)
+	}
+	if headline := buildHeadline(cards); !strings.Contains(headline.Title, "Самый тёплый 15 октября") {
+		t.Fatalf("unexpected headline: %#v", headline)
+	}
+}
+
+// countingClimatologyRepo считает запросы климатологии по метрикам
+type countingClimatologyRepo struct {
+	repository.WeatherRepository
+	calls map[string]int
+}
+
+func (r *countingClimatologyRepo) GetClimatology(_ context.Context, q models.ClimatologyQuery) (*models.ClimatologyStats, error) {
+	r.calls[q.Metric]++
+	return &models.ClimatologyStats{Metric: q.Metric}, nil
+}
+
+func TestGetClimatologyCachesDailyMetricsForTheWholeDay(t *testing.T) {
+	repo := &countingClimatologyRepo{calls: map[string]int{}}
+	svc := &WeatherService{repo: repo, timezone: "UTC", location: time.UTC}
+	svc.SetClimatology(testClimatologySettings)
+
+	morning := time.Date(2026, time.October, 15, 9, 10, 0, 0, time.UTC)
+	for _, at := range []time.Time{morning, morning.Add(3 * time.Hour), morning.Add(6 * time.Hour)} {
+		for _, metric := range []string{models.ClimatologyTempMax, models.ClimatologyTemperature} {
+			if _, err := svc.GetClimatology(context.Background(), metric, at); err != nil {
+				t.Fatalf("GetClimatology(%s) error = %v", metric, err)
+			}
+		}
+	}
+	if repo.calls[models.ClimatologyTempMax] != 1 || repo.calls[models.ClimatologyTemperature] != 3 {
+		t.Fatalf("queries = %v, want daily metric once and hourly metric once per hour", repo.calls)
+	}
+}
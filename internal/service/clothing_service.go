@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+// Правила по умолчанию; свои можно положить в файл и указать CLOTHING_RULES_FILE
+//
+//go:embed clothing_rules.json
+var defaultClothingRules []byte
+
+// Показатели, по которым срабатывают дополнительные советы
+const (
+	ClothingMetricPrecipitation            = "precipitation"             // максимум осадков, мм/ч
+	ClothingMetricPrecipitationProbability = "precipitation_probability" // максимум вероятности осадков, %
+	ClothingMetricWind                     = "wind"                      // максимум ветра, м/с
+	ClothingMetricUV                       = "uv"                        // максимум UV-индекса
+	ClothingMetricFeelsLikeMin             = "feels_like_min"            // минимум ощущаемой температуры, °C
+	ClothingMetricFeelsLikeMax             = "feels_like_max"            // максимум ощущаемой температуры, °C
+)
+
+// ClothingRules — настраиваемые правила советов по одежде
+type ClothingRules struct {
+	Windows []ClothingRuleWindow `json:"windows"`
+	Layers  []ClothingLayerRule  `json:"layers"`
+	Extras  []ClothingExtraRule  `json:"extras"`
+}
+
+// ClothingRuleWindow — отрезок дня, для которого даётся совет (местные часы, To не включительно)
+type ClothingRuleWindow struct {
+	Name   string `json:"name"`   // «Утро»
+	Phrase string `json:"phrase"` // «утром»
+	From   int    `json:"from"`
+	To     int    `json:"to"`
+}
+
+// ClothingLayerRule — основной слой одежды для ощущаемой температуры ниже MaxFeelsLike.
+// Правила проверяются по порядку, последнее без порога подходит всегда.
+type ClothingLayerRule struct {
+	MaxFeelsLike *float64 `json:"max_feels_like"`
+	Text         string   `json:"text"`
+}
+
+// ClothingExtraRule — что взять с собой, если показатель в отрезке не ниже Min и не выше Max.
+// Из правил одной группы срабатывает первое подходящее.
+type ClothingExtraRule struct {
+	Metric string   `json:"metric"`
+	Min    *float64 `json:"min"`
+	Max    *float64 `json:"max"`
+	Group  string   `json:"group"`
+	Icon   string   `json:"icon"`
+	Text   string   `json:"text"`
+}
+
+// LoadClothingRules читает правила из файла; пустой путь — встроенные правила
+func LoadClothingRules(path string) (ClothingRules, error) {
+	data := defaultClothingRules
+	if path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return ClothingRules{}, fmt.Errorf("failed to read clothing rules: %w", err)
+		}
+	}
+	return ParseClothingRules(data)
+}
+
+// ParseClothingRules разбирает и проверяет правила советов по одежде
+func ParseClothingRules(data []byte) (ClothingRules, error) {
+	var rules ClothingRules
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return ClothingRules{}, fmt.Errorf("failed to parse clothing rules: %w", err)
+	}
+	if len(rules.Windows) == 0 {
+		return ClothingRules{}, fmt.Errorf("clothing rules: no windows")
+	}
+	for _, w := range rules.Windows {
+		if w.Name == "" || w.From < 0 || w.To > 24 || w.From >= w.To {
+			return ClothingRules{}, fmt.Errorf("clothing rules: invalid window %q %d-%d", w.Name, w.From, w.To)
+		}
+	}
+	if len(rules.Layers) == 0 || rules.Layers[len(rules.Layers)-1].MaxFeelsLike != nil {
+		return ClothingRules{}, fmt.Errorf("clothing rules: last layer must have no max_feels_like")
+	}
+	for _, e := range rules.Extras {
+		switch e.Metric {
+		case ClothingMetricPrecipitation, ClothingMetricPrecipitationProbability, ClothingMetricWind,
+			ClothingMetricUV, ClothingMetricFeelsLikeMin, ClothingMetricFeelsLikeMax:
+		default:
+			return ClothingRules{}, fmt.Errorf("clothing rules: unknown metric %q", e.Metric)
+		}
+		if e.Text == "" || (e.Min == nil && e.Max == nil) {
+			return ClothingRules{}, fmt.Errorf("clothing rules: extra %q needs text and min or max", e.Metric)
+		}
+	}
+	return rules, nil
+}
+
+// ClothingService подбирает одежду на утро, день и вечер по почасовому прогнозу:
+// ощущаемая температура задаёт основной слой, осадки, ветер и UV — что взять с собой.
+type ClothingService struct {
+	weatherSvc  *WeatherService
+	forecastSvc *ForecastService
+	rules       ClothingRules
+}
+
+func NewClothingService(weatherSvc *WeatherService, forecastSvc *ForecastService, rules ClothingRules) *ClothingService {
+	return &ClothingService{weatherSvc: weatherSvc, forecastSvc: forecastSvc, rules: rules}
+}
+
+// GetAdvice возвращает совет на оставшиеся отрезки сегодняшнего дня,
+// а после последнего отрезка — на завтра
+func (s *ClothingService) GetAdvice(ctx context.Context) (*models.ClothingAdvice, error) {
+	loc := time.Local
+	if s.weatherSvc != nil && s.weatherSvc.location != nil {
+		loc = s.weatherSvc.location
+	}
+	now := wallClockUTC(time.Now(), loc)
+	currentHour := now.Truncate(time.Hour)
+	day := dayStart(now, time.UTC)
+	if now.Hour() >= s.rules.Windows[len(s.rules.Windows)-1].To {
+		day = day.AddDate(0, 0, 1)
+	}
+
+	hourly, err := s.forecastSvc.GetHourlyForecast(ctx, 48)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hourly forecast: %w", err)
+	}
+	upcoming := make([]models.HourlyForecast, 0, len(hourly))
+	for _, h := range hourly {
+		if !h.Time.UTC().Before(currentHour) {
+			upcoming = append(upcoming, h)
+		}
+	}
+	return buildClothingAdvice(s.rules, day, upcoming), nil
+}
+
+// buildClothingAdvice раскладывает прогноз по отрезкам дня; отрезки без прогноза пропускаются
+func buildClothingAdvice(rules ClothingRules, day time.Time, hourly []models.HourlyForecast) *models.ClothingAdvice {
+	advice := &models.ClothingAdvice{Date: day}
+	matched := make(map[int]bool)
+	var phrases []string
+	for _, rw := range rules.Windows {
+		from := day.Add(time.Duration(rw.From) * time.Hour)
+		to := day.Add(time.Duration(rw.To) * time.Hour)
+
+		window := models.ClothingWindow{Name: rw.Name, From: from, To: to, FeelsLikeMin: math.Inf(1), FeelsLikeMax: math.Inf(-1)}
+		var precipMax float64
+		hours := 0
+		for _, h := range hourly {
+			t := h.Time.UTC()
+			if t.Before(from) || !t.Before(to) {
+				continue
+			}
+			hours++
+			window.FeelsLikeMin = math.Min(window.FeelsLikeMin, float64(h.FeelsLike))
+			window.FeelsLikeMax = math.Max(window.FeelsLikeMax, float64(h.FeelsLike))
+			window.WindMax = math.Max(window.WindMax, float64(h.WindSpeed))
+			window.UVMax = math.Max(window.UVMax, float64(h.UVIndex))
+			window.PrecipProbability = max(window.PrecipProbability, int(h.PrecipitationProbability))
+			precipMax = math.Max(precipMax, float64(h.Precipitation))
+		}
+		if hours == 0 {
+			continue
+		}
+		window.FeelsLikeMin = roundTo(window.FeelsLikeMin, 1)
+		window.FeelsLikeMax = roundTo(window.FeelsLikeMax, 1)
+		window.WindMax = roundTo(window.WindMax, 1)
+		window.UVMax = roundTo(window.UVMax, 1)
+		window.Clothes = clothingLayer(rules.Layers, window.FeelsLikeMin)
+
+		metrics := map[string]float64{
+			ClothingMetricPrecipitation:            precipMax,
+			ClothingMetricPrecipitationProbability: float64(window.PrecipProbability),
+			ClothingMetricWind:                     window.WindMax,
+			ClothingMetricUV:                       window.UVMax,
+			ClothingMetricFeelsLikeMin:             window.FeelsLikeMin,
+			ClothingMetricFeelsLikeMax:             window.FeelsLikeMax,
+		}
+		for _, i := range clothingExtras(rules.Extras, metrics) {
+			window.Extras = append(window.Extras, clothingExtraText(rules.Extras[i]))
+			matched[i] = true
+		}
+		advice.Windows = append(advice.Windows, window)
+		phrases = append(phrases, rw.Phrase)
+	}
+
+	// За день из каждой группы остаётся самый строгий совет
+	groups := make(map[string]bool)
+	for i, e := range rules.Extras {
+		if !matched[i] {
+			continue
+		}
+		if e.Group != "" {
+			if groups[e.Group] {
+				continue
+			}
+			groups[e.Group] = true
+		}
+		advice.Extras = append(advice.Extras, clothingExtraText(e))
+	}
+
+	if len(advice.Windows) == 0 {
+		advice.Summary = "нет прогноза для совета по одежде"
+		return advice
+	}
+	// Соседние отрезки с той же одеждой объединяются: «утром и вечером куртка»
+	var parts []string
+	for i := 0; i < len(advice.Windows); {
+		j := i
+		for j+1 < len(advice.Windows) && advice.Windows[j+1].Clothes == advice.Windows[i].Clothes {
+			j++
+		}
+		parts = append(parts, strings.Join(phrases[i:j+1], " и ")+" "+advice.Windows[i].Clothes)
+		i = j + 1
+	}
+	if len(parts) == 1 {
+		parts[0] = advice.Windows[0].Clothes
+	}
+	advice.Summary = strings.Join(parts, ", ")
+	return advice
+}
+
+// clothingLayer выбирает основной слой по самой низкой ощущаемой температуре отрезка
+func clothingLayer(layers []ClothingLayerRule, feelsLike float64) string {
+	for _, l := range layers {
+		if l.MaxFeelsLike == nil || feelsLike < *l.MaxFeelsLike {
+			return l.Text
+		}
+	}
+	return ""
+}
+
+// clothingExtras возвращает индексы сработавших дополнительных советов
+func clothingExtras(extras []ClothingExtraRule, metrics map[string]float64) []int {
+	var result []int
+	groups := make(map[string]bool)
+	for i, e := range extras {
+		if e.Group != "" && groups[e.Group] {
+			continue
+		}
+		value := metrics[e.Metric]
+		if (e.Min != nil && value < *e.Min) || (e.Max != nil && value > *e.Max) {
+			continue
+		}
+		if e.Group != "" {
+			groups[e.Group] = true
+		}
+		result = append(result, i)
+	}
+	return result
+}
+
+func clothingExtraText(e ClothingExtraRule) string {
+	if e.Icon == "" {
+		return e.Text
+	}
+	return e.Icon + " " + e.Text
+}
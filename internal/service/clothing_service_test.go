@@ -0,0 +1,87 @@
+package service
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+func TestDefaultClothingRulesParse(t *testing.T) {
+	rules, err := LoadClothingRules("")
+	if err != nil {
+		t.Fatalf("LoadClothingRules() error = %v", err)
+	}
+	if len(rules.Windows) != 3 || len(rules.Layers) == 0 || len(rules.Extras) == 0 {
+		t.Fatalf("unexpected default rules: %+v", rules)
+	}
+}
+
+func TestParseClothingRulesRejectsInvalid(t *testing.T) {
+	tests := map[string]string{
+		"no windows":      `{"layers": [{"text": "куртка"}]}`,
+		"bad window":      `{"windows": [{"name": "Утро", "from": 10, "to": 7}], "layers": [{"text": "куртка"}]}`,
+		"no catch-all":    `{"windows": [{"name": "Утро", "from": 7, "to": 10}], "layers": [{"max_feels_like": 5, "text": "куртка"}]}`,
+		"unknown metric":  `{"windows": [{"name": "Утро", "from": 7, "to": 10}], "layers": [{"text": "куртка"}], "extras": [{"metric": "snow", "min": 1, "text": "лыжи"}]}`,
+		"extra threshold": `{"windows": [{"name": "Утро", "from": 7, "to": 10}], "layers": [{"text": "куртка"}], "extras": [{"metric": "uv", "text": "крем"}]}`,
+	}
+	for name, data := range tests {
+		if _, err := ParseClothingRules([]byte(data)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestBuildClothingAdvice(t *testing.T) {
+	rules, err := LoadClothingRules("")
+	if err != nil {
+		t.Fatalf("LoadClothingRules() error = %v", err)
+	}
+	day := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+	var hourly []models.HourlyForecast
+	for hour := 6; hour < 21; hour++ {
+		h := models.HourlyForecast{Time: day.Add(time.Duration(hour) * time.Hour), FeelsLike: 2, WindSpeed: 3}
+		switch {
+		case hour >= 12 && hour < 16:
+			h.FeelsLike, h.UVIndex = 12, 4.2
+		case hour >= 17:
+			h.FeelsLike, h.PrecipitationProbability = 6, 60
+		}
+		hourly = append(hourly, h)
+	}
+
+	advice := buildClothingAdvice(rules, day, hourly)
+	if len(advice.Windows) != 3 {
+		t.Fatalf("windows = %d, want 3", len(advice.Windows))
+	}
+	morning, midday, evening := advice.Windows[0], advice.Windows[1], advice.Windows[2]
+	if morning.Clothes != "тёплая куртка, свитер и шапка" || morning.FeelsLikeMin != 2 || len(morning.Extras) != 0 {
+		t.Fatalf("morning = %+v", morning)
+	}
+	if midday.Clothes != "лёгкая куртка или ветровка" || !slices.Equal(midday.Extras, []string{"🧴 солнцезащитный крем"}) {
+		t.Fatalf("midday = %+v", midday)
+	}
+	if evening.Clothes != "демисезонная куртка или пальто" || !slices.Equal(evening.Extras, []string{"☂️ зонт"}) {
+		t.Fatalf("evening = %+v", evening)
+	}
+	if !slices.Equal(advice.Extras, []string{"☂️ зонт", "🧴 солнцезащитный крем"}) {
+		t.Fatalf("extras = %v", advice.Extras)
+	}
+	if advice.Summary != "утром тёплая куртка, свитер и шапка, днём лёгкая куртка или ветровка, вечером демисезонная куртка или пальто" {
+		t.Fatalf("summary = %q", advice.Summary)
+	}
+
+	// Утро уже прошло и весь остаток дня одинаковый — без перечисления отрезков
+	for i := range hourly {
+		hourly[i].FeelsLike, hourly[i].UVIndex, hourly[i].PrecipitationProbability = 14, 0, 0
+	}
+	later := buildClothingAdvice(rules, day, hourly[7:])
+	if len(later.Windows) != 2 || later.Windows[0].Name != "День" || later.Summary != "лёгкая куртка или ветровка" || later.Extras != nil {
+		t.Fatalf("later advice = %+v", later)
+	}
+	if !strings.HasPrefix(later.Windows[0].From.Format("15:04"), "12") {
+		t.Fatalf("later window from = %s", later.Windows[0].From)
+	}
+}
@@ -20,15 +20,30 @@ type DashboardService struct {
 	forecastService    *ForecastService
 	geomagneticService *GeomagneticService
 	hydroService       *HydroService
+	frostRiskService   *FrostRiskService
+	meteoService       *MeteoSensitivityService
+	clothingService    *ClothingService
 }
 
 func NewDashboardService(weatherService *WeatherService, forecastService *ForecastService, geomagneticService *GeomagneticService, hydroService *HydroService) *DashboardService {
-	return &DashboardService{
+	s := &DashboardService{
 		weatherService:     weatherService,
 		forecastService:    forecastService,
 		geomagneticService: geomagneticService,
 		hydroService:       hydroService,
 	}
+	if weatherService != nil && forecastService != nil {
+		s.frostRiskService = NewFrostRiskService(weatherService, forecastService)
+	}
+	if weatherService != nil {
+		s.meteoService = NewMeteoSensitivityService(weatherService, forecastService, geomagneticService)
+	}
+	return s
+}
+
+// SetClothingService включает карточку «Что надеть» (nil — выключить)
+func (s *DashboardService) SetClothingService(clothingService *ClothingService) {
+	s.clothingService = clothingService
 }
 
 func (s *DashboardService) GetSnapshot(ctx context.Context) (*models.DashboardSnapshot, error) {
@@ -97,6 +112,24 @@ func (s *DashboardService) GetSnapshot(ctx context.Context) (*models.DashboardSn
 		}
 	}
 
+	if s.frostRiskService != nil {
+		if risk, err := s.frostRiskService.GetFrostRisk(ctx); err == nil && risk != nil {
+			allCards = append(allCards, buildFrostRiskCards(risk)...)
+		}
+	}
+
+	if s.meteoService != nil {
+		if meteo, err := s.meteoService.GetMeteoSensitivity(ctx); err == nil {
+			allCards = append(allCards, buildMeteoSensitivityCard(meteo))
+		}
+	}
+
+	if s.clothingService != nil {
+		if advice, err := s.clothingService.GetAdvice(ctx); err == nil && len(advice.Windows) > 0 {
+			allCards = append(allCards, buildClothingCard(advice))
+		}
+	}
+
 	if s.geomagneticService != nil {
 		if card := s.buildGeomagneticAttentionCard(ctx, now); card != nil {
 			allCards = append(allCards, *card)
@@ -511,11 +544,15 @@ func buildUVCard(current *models.WeatherData, now time.Time) *models.AttentionCa
 	if *current.UVIndex >= 8 {
 		priority = 78
 	}
+	subtitle := "Лучше избегать прямого солнца"
+	if minutes := UVBurnMinutes(float64(*current.UVIndex), UV_DEFAULT_SKIN_TYPE); minutes != nil {
+		subtitle = fmt.Sprintf("Светлая кожа сгорит за ~%d мин", *minutes)
+	}
 	return &models.AttentionCard{
 		ID:        "uv-high",
 		Domain:    "solar",
 		Title:     "Высокий UV",
-		Subtitle:  "Лучше избегать прямого солнца",
+		Subtitle:  subtitle,
 		Value:     fmt.Sprintf("%.0f", *current.UVIndex),
 		Unit:      "UV",
 		Severity:  string(severity),
@@ -527,6 +564,127 @@ func buildUVCard(current *models.WeatherData, now time.Time) *models.AttentionCa
 	}
 }
 
+// buildFrostRiskCards строит карточки заморозка и гололёда на ближайшую ночь
+func buildFrostRiskCards(risk *models.FrostRisk) []models.AttentionCard {
+	cards := make([]models.AttentionCard, 0, 2)
+	if risk.Frost != models.RiskNone {
+		priority, severity := frostRiskPriority(risk.Frost)
+		cards = append(cards, models.AttentionCard{
+			ID:        "frost-risk",
+			Domain:    "frost",
+			Title:     "Заморозок ночью: риск " + risk.Frost.Label(),
+			Subtitle:  strings.Join(risk.FrostReasons, " · "),
+			Value:     fmt.Sprintf("%+.0f", risk.SurfaceMin),
+			Unit:      "° у земли",
+			Severity:  string(severity),
+			Priority:  priority,
+			Reason:    "минимум прогноза, облачность, ветер и точка росы на станции",
+			Action:    "Укрой рассаду и теплолюбивые растения, закрой теплицу",
+			Icon:      "❄️",
+			DetailURL: "/detail/temperature",
+		})
+	}
+	if risk.Ice != models.RiskNone {
+		priority, severity := frostRiskPriority(risk.Ice)
+		cards = append(cards, models.AttentionCard{
+			ID:        "ice-risk",
+			Domain:    "frost",
+			Title:     "Гололёд ночью: риск " + risk.Ice.Label(),
+			Subtitle:  strings.Join(risk.IceReasons, " · "),
+			Value:     fmt.Sprintf("%+.0f", risk.SurfaceMin),
+			Unit:      "° на дорогах",
+			Severity:  string(severity),
+			Priority:  priority,
+			Reason:    "мокрые дороги и переход температуры поверхности через ноль",
+			Action:    "Утром выезжай заранее и держи дистанцию, на тротуарах осторожно",
+			Icon:      "🧊",
+			DetailURL: "/detail/rain",
+		})
+	}
+	return cards
+}
+
+// buildMeteoSensitivityCard строит карточку индекса метеочувствительности на сегодня
+func buildMeteoSensitivityCard(meteo *models.MeteoSensitivity) models.AttentionCard {
+	priority, severity := 10, models.DashboardSeverityCalm
+	switch meteo.Today.Level {
+	case models.RiskHigh:
+		priority, severity = 74, models.DashboardSeverityWarning
+	case models.RiskMedium:
+		priority, severity = 56, models.DashboardSeverityWarning
+	case models.RiskLow:
+		priority, severity = 32, models.DashboardSeverityInfo
+	}
+	reasons := make([]string, 0, len(meteo.Today.Factors))
+	for _, f := range meteo.Today.Factors {
+		if f.Text != "" {
+			reasons = append(reasons, f.Text)
+		}
+	}
+	action := "Действий не требуется"
+	if len(meteo.Advice) > 0 {
+		action = meteo.Advice[0]
+	}
+	return models.AttentionCard{
+		ID:        "meteo-sensitivity",
+		Domain:    "meteo",
+		Title:     meteo.Title,
+		Subtitle:  strings.Join(reasons, " · "),
+		Value:     fmt.Sprintf("%.1f", meteo.Today.Index),
+		Unit:      "из 10",
+		Severity:  string(severity),
+		Priority:  priority,
+		Reason:    "давление, перепад температуры, влажность и Kp для метеочувствительных",
+		Action:    action,
+		Icon:      "🩺",
+		DetailURL: "/insights#meteo",
+	}
+}
+
+// buildClothingCard строит карточку совета по одежде на оставшуюся часть дня
+func buildClothingCard(advice *models.ClothingAdvice) models.AttentionCard {
+	minFeels, maxFeels := advice.Windows[0].FeelsLikeMin, advice.Windows[0].FeelsLikeMax
+	for _, w := range advice.Windows[1:] {
+		minFeels = math.Min(minFeels, w.FeelsLikeMin)
+		maxFeels = math.Max(maxFeels, w.FeelsLikeMax)
+	}
+	priority, severity := 24, models.DashboardSeverityCalm
+	action := "Ничего дополнительно брать не нужно"
+	if len(advice.Extras) > 0 {
+		priority, severity = 38, models.DashboardSeverityInfo
+		action = "С собой: " + strings.Join(advice.Extras, ", ")
+	}
+	value := fmt.Sprintf("%+.0f…%+.0f", minFeels, maxFeels)
+	if math.Round(minFeels) == math.Round(maxFeels) {
+		value = fmt.Sprintf("%+.0f", minFeels)
+	}
+	return models.AttentionCard{
+		ID:        "clothing",
+		Domain:    "clothing",
+		Title:     "Что надеть",
+		Subtitle:  advice.Summary,
+		Value:     value,
+		Unit:      "°C ощущается",
+		Severity:  string(severity),
+		Priority:  priority,
+		Reason:    "ощущаемая температура, ветер, осадки и UV по прогнозу на утро, день и вечер",
+		Action:    action,
+		Icon:      "👕",
+		DetailURL: "/detail/temperature",
+	}
+}
+
+func frostRiskPriority(level models.RiskLevel) (int, models.DashboardSeverity) {
+	switch level {
+	case models.RiskHigh:
+		return 82, models.DashboardSeverityDanger
+	case models.RiskMedium:
+		return 68, models.DashboardSeverityWarning
+	default:
+		return 40, models.DashboardSeverityInfo
+	}
+}
+
 func buildEventCards(events []models.WeatherEvent, now time.Time) []models.AttentionCard {
 	cards := make([]models.AttentionCard, 0, 3)
 	for _, event := range events {
@@ -561,6 +719,13 @@ func buildEventCards(events []models.WeatherEvent, now time.Time) []models.Atten
 			priority = 62
 			domain = "rain"
 			detailURL = "/detail/rain"
+		case "unusual":
+			// Необычное для даты значение достойно заголовка, рекорд суток — тем более
+			priority = 72
+			detailURL = "/insights"
+			if event.Period == "за сутки" {
+				priority = 78
+			}
 		default:
 			continue
 		}
@@ -857,6 +1022,12 @@ func quietLabel(card models.AttentionCard) string {
 		return "геомагнитка"
 	case "solar":
 		return "UV"
+	case "frost":
+		return "заморозки"
+	case "meteo":
+		return "самочувствие"
+	case "clothing":
+		return "одежда"
 	}
 	return ""
 }
@@ -62,3 +62,45 @@ func TestBuildRainCardKeepsNoRainQuiet(t *testing.T) {
 		t.Fatalf("ожидался calm, получено %s", card.Severity)
 	}
 }
+
+func TestBuildMeteoSensitivityCardStaysQuietOnCalmDays(t *testing.T) {
+	calm := &models.MeteoSensitivity{Title: "Благоприятный день", Today: models.MeteoSensitivityDay{Level: models.RiskNone}}
+	card := buildMeteoSensitivityCard(calm)
+	if card.Priority > quietPriorityThreshold || quietLabel(card) != "самочувствие" {
+		t.Fatalf("calm day card should be quiet, got priority %d", card.Priority)
+	}
+
+	pressure := -9.0
+	bad := &models.MeteoSensitivity{
+		Title:  "Тяжёлый день для метеочувствительных",
+		Advice: []string{"Давление падает"},
+		Today: models.MeteoSensitivityDay{Index: 6.5, Level: models.RiskHigh, Factors: []models.MeteoFactor{
+			{Key: models.MeteoFactorPressure, Value: &pressure, Score: 3, Text: "давление упало на 9 мм за сутки"},
+			{Key: models.MeteoFactorHumidity},
+		}},
+	}
+	card = buildMeteoSensitivityCard(bad)
+	if card.Priority < 70 || card.Value != "6.5" || card.Subtitle != "давление упало на 9 мм за сутки" || card.Action != "Давление падает" {
+		t.Fatalf("unexpected card: %+v", card)
+	}
+}
+
+func TestBuildClothingCardShowsExtras(t *testing.T) {
+	advice := &models.ClothingAdvice{
+		Summary: "утром тёплая куртка, свитер и шапка, днём демисезонная куртка или пальто",
+		Windows: []models.ClothingWindow{
+			{Name: "Утро", FeelsLikeMin: 1.4, FeelsLikeMax: 2.8},
+			{Name: "День", FeelsLikeMin: 6.2, FeelsLikeMax: 9.6},
+		},
+	}
+	card := buildClothingCard(advice)
+	if card.Priority <= quietPriorityThreshold || card.Value != "+1…+10" || card.Subtitle != advice.Summary {
+		t.Fatalf("unexpected card: %+v", card)
+	}
+
+	advice.Extras = []string{"☂️ зонт"}
+	card = buildClothingCard(advice)
+	if card.Action != "С собой: ☂️ зонт" || card.Severity != string(models.DashboardSeverityInfo) {
+		t.Fatalf("unexpected card with extras: %+v", card)
+	}
+}
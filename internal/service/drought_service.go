@@ -0,0 +1,610 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+// Параметры мониторинга засухи
+const (
+	DROUGHT_TREND_DAYS         = 90         // суток в графике тренда
+	DROUGHT_SPI_SHORT_DAYS     = 30         // короткое окно SPI и дефицита осадков
+	DROUGHT_SPI_LONG_DAYS      = 90         // длинное окно SPI и дефицита осадков
+	DROUGHT_SPI_SHIFT_DAYS     = 14         // окна прошлых лет сдвигаются на ±14 суток с шагом 7 — выборка в 5 раз больше
+	DROUGHT_SPI_MIN_SAMPLES    = 30         // меньше сумм прошлых лет не хватает для гамма-распределения
+	DROUGHT_MIN_COVERAGE       = 0.9        // доля суток с данными в окне, ниже — значение не считается
+	DROUGHT_GTK_BASE_TEMP      = 10.0       // ГТК считается по суткам со средней выше +10 °C
+	DROUGHT_GTK_MIN_DAYS       = 15         // тёплых суток в окне, меньше — вне вегетационного периода
+	DROUGHT_SEASON_START_MONTH = time.April // сезонный ГТК считается с 1 апреля
+	DROUGHT_ALERT_FROM_HOUR    = 9          // уведомление о засухе отправляется с 09:00
+	DROUGHT_ALERT_TO_HOUR      = 21         // и до 21:00
+	droughtCacheTTL            = time.Hour  // индексы по суточным суммам за день почти не меняются
+	droughtSPIMaxSigma         = 3.0        // SPI ограничивается ±3
+	droughtSPIStepDays         = 7          // шаг сдвига окон прошлых лет
+	droughtSPIMinNonzeroShare  = 0.5        // доля ненулевых сумм, без которой гамма-распределение не подобрать
+)
+
+// DroughtSettings — пороги предупреждений о засухе
+type DroughtSettings struct {
+	SPIThreshold float64 // SPI, при переходе ниже которого приходит предупреждение (−1.5 — сильно сухо)
+	GTKThreshold float64 // ГТК за 30 суток, при переходе ниже которого приходит предупреждение
+	DrySpellDays int     // суток подряд без дождя для предупреждения о сухом периоде
+}
+
+// DroughtService считает дефицит осадков к норме, стандартизированный индекс осадков
+// SPI за 30 и 90 суток (гамма-распределение сумм того же окна в прошлые годы по
+// реанализу) и гидротермический коэффициент Селянинова по суточным данным станции.
+type DroughtService struct {
+	weatherSvc *WeatherService
+	settings   DroughtSettings
+
+	mu      sync.Mutex
+	cached  *models.DroughtIndex
+	expires time.Time
+}
+
+func NewDroughtService(weatherSvc *WeatherService, settings DroughtSettings) *DroughtService {
+	if settings.SPIThreshold >= 0 {
+		settings.SPIThreshold = -1.5
+	}
+	if settings.GTKThreshold <= 0 {
+		settings.GTKThreshold = 0.7
+	}
+	if settings.DrySpellDays <= 0 {
+		settings.DrySpellDays = 20
+	}
+	return &DroughtService{weatherSvc: weatherSvc, settings: settings}
+}
+
+// GetDroughtIndex возвращает индексы засухи на вчерашние сутки и тренд за 90 суток.
+// Норма осадков берётся из реанализа, без него — из официальных норм; SPI — только по реанализу.
+func (s *DroughtService) GetDroughtIndex(ctx context.Context) (*models.DroughtIndex, error) {
+	loc := s.weatherSvc.location
+	if loc == nil {
+		loc = time.Local
+	}
+	now := time.Now()
+	today := dayStart(now, loc)
+
+	s.mu.Lock()
+	if s.cached != nil && s.cached.Date.Equal(today.AddDate(0, 0, -1)) && now.Before(s.expires) {
+		cached := s.cached
+		s.mu.Unlock()
+		return cached, nil
+	}
+	s.mu.Unlock()
+
+	from := minTime(today.AddDate(0, 0, -(DROUGHT_TREND_DAYS+DROUGHT_SPI_LONG_DAYS)), droughtSeasonStart(today.AddDate(0, 0, -1)))
+	station, err := s.weatherSvc.repo.GetDailyInsights(ctx, from, today, s.weatherSvc.timezone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily insights: %w", err)
+	}
+
+	input := droughtInput{station: station, from: from, today: today}
+	switch {
+	case s.weatherSvc.reanalysisRepo != nil:
+		input.baselineFrom = from.AddDate(-s.weatherSvc.reanalysisYears, 0, -DROUGHT_SPI_SHIFT_DAYS-DROUGHT_SPI_LONG_DAYS)
+		input.baselineTo = today.AddDate(-1, 0, DROUGHT_SPI_SHIFT_DAYS)
+		input.baseline, err = s.weatherSvc.getReanalysisDays(ctx, input.baselineFrom, input.baselineTo, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get reanalysis days: %w", err)
+		}
+		input.years = s.weatherSvc.reanalysisYears
+		input.kind = models.DroughtBaselineReanalysis
+		input.label = reanalysisSourceLabel(s.weatherSvc.reanalysisSource)
+	case s.weatherSvc.normalsRepo != nil:
+		input.normals, err = s.weatherSvc.normalsRepo.GetByStation(ctx, s.weatherSvc.normalsStation, s.weatherSvc.normalsPeriod)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get climate normals: %w", err)
+		}
+		input.kind = models.DroughtBaselineNormals
+		input.label = "нормы " + s.weatherSvc.normalsPeriod
+	}
+
+	index := buildDroughtIndex(input, s.settings, loc)
+
+	s.mu.Lock()
+	s.cached = index
+	s.expires = now.Add(droughtCacheTTL)
+	s.mu.Unlock()
+	return index, nil
+}
+
+// GetDroughtEvents возвращает предупреждение drought, если на вчерашних сутках SPI или ГТК
+// перешли ниже порога или сухой период достиг заданной длины. Вне дневного окна — пустой список.
+func (s *DroughtService) GetDroughtEvents(ctx context.Context) ([]models.WeatherEvent, error) {
+	loc := s.weatherSvc.location
+	if loc == nil {
+		loc = time.Local
+	}
+	now := time.Now()
+	if hour := now.In(loc).Hour(); hour < DROUGHT_ALERT_FROM_HOUR || hour >= DROUGHT_ALERT_TO_HOUR {
+		return nil, nil
+	}
+
+	index, err := s.GetDroughtIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+	event := droughtEvent(index, s.settings, now)
+	if event == nil {
+		return nil, nil
+	}
+	return []models.WeatherEvent{*event}, nil
+}
+
+type droughtInput struct {
+	station      []models.DailyWeatherInsight
+	from, today  time.Time
+	baseline     []models.DailyWeatherInsight
+	baselineFrom time.Time
+	baselineTo   time.Time
+	years        int
+	normals      []models.ClimateNormal
+	kind, label  string
+}
+
+// droughtSeries — суточные осадки и средняя температура с префиксными суммами осадков
+type droughtSeries struct {
+	start   time.Time
+	rain    []float64
+	hasRain []bool
+	temp    []float64
+	hasTemp []bool
+	cumRain []float64 // cumRain[i] — осадки за сутки [0, i)
+	cumHave []int
+}
+
+func newDroughtSeries(days []models.DailyWeatherInsight, start, end time.Time) *droughtSeries {
+	loc := start.Location()
+	n := maxInt(0, dayIndex(start, end))
+	series := &droughtSeries{
+		start:   start,
+		rain:    make([]float64, n),
+		hasRain: make([]bool, n),
+		temp:    make([]float64, n),
+		hasTemp: make([]bool, n),
+		cumRain: make([]float64, n+1),
+		cumHave: make([]int, n+1),
+	}
+	for _, day := range days {
+		// Дата из SQL — полночь в часовом поясе станции, помеченная как UTC
+		date := time.Date(day.Date.Year(), day.Date.Month(), day.Date.Day(), 0, 0, 0, 0, loc)
+		i := dayIndex(start, date)
+		if i < 0 || i >= n {
+			continue
+		}
+		if day.RainTotal != nil {
+			series.rain[i] = math.Max(0, float64(*day.RainTotal))
+			series.hasRain[i] = true
+		}
+		if day.TempAvg != nil {
+			series.temp[i] = float64(*day.TempAvg)
+			series.hasTemp[i] = true
+		}
+	}
+	for i := 0; i < n; i++ {
+		series.cumRain[i+1] = series.cumRain[i] + series.rain[i]
+		series.cumHave[i+1] = series.cumHave[i]
+		if series.hasRain[i] {
+			series.cumHave[i+1]++
+		}
+	}
+	return series
+}
+
+// dayIndex — номер суток date от start; округление защищает от переходов на летнее время
+func dayIndex(start, date time.Time) int {
+	return int(math.Round(date.Sub(start).Hours() / 24))
+}
+
+// window возвращает осадки за days суток по last включительно и долю суток с данными
+func (s *droughtSeries) window(last time.Time, days int) (float64, float64) {
+	hi := dayIndex(s.start, last) + 1
+	lo := hi - days
+	if hi <= 0 || lo >= len(s.rain) {
+		return 0, 0
+	}
+	lo = maxInt(lo, 0)
+	if hi > len(s.rain) {
+		hi = len(s.rain)
+	}
+	return s.cumRain[hi] - s.cumRain[lo], float64(s.cumHave[hi]-s.cumHave[lo]) / float64(days)
+}
+
+// gtk считает ГТК Селянинова по суткам [from, to]: осадки тёплых суток к 0.1 суммы их температур
+func (s *droughtSeries) gtk(from, to time.Time) models.HydrothermalCoefficient {
+	result := models.HydrothermalCoefficient{From: from, To: to}
+	for i := maxInt(0, dayIndex(s.start, from)); i <= dayIndex(s.start, to) && i < len(s.rain); i++ {
+		if !s.hasTemp[i] || s.temp[i] <= DROUGHT_GTK_BASE_TEMP {
+			continue
+		}
+		result.WarmDays++
+		result.TempSum += s.temp[i]
+		result.Rain += s.rain[i]
+	}
+	result.Rain = roundTo(result.Rain, 1)
+	result.TempSum = roundTo(result.TempSum, 0)
+	if result.WarmDays >= DROUGHT_GTK_MIN_DAYS && result.TempSum > 0 {
+		value := roundTo(result.Rain/(0.1*result.TempSum), 2)
+		result.Value = &value
+		result.Category, result.Label = gtkCategory(value)
+	}
+	return result
+}
+
+func droughtSeasonStart(date time.Time) time.Time {
+	start := time.Date(date.Year(), DROUGHT_SEASON_START_MONTH, 1, 0, 0, 0, 0, date.Location())
+	if date.Before(start) {
+		start = start.AddDate(-1, 0, 0)
+	}
+	return start
+}
+
+func buildDroughtIndex(input droughtInput, settings DroughtSettings, loc *time.Location) *models.DroughtIndex {
+	yesterday := input.today.AddDate(0, 0, -1)
+	station := newDroughtSeries(input.station, input.from, input.today)
+	var baseline *droughtSeries
+	if input.kind == models.DroughtBaselineReanalysis {
+		baseline = newDroughtSeries(input.baseline, dayStart(input.baselineFrom, loc), dayStart(input.baselineTo, loc))
+	}
+	normals := newClimateNormalsIndex(input.normals)
+
+	normalFor := func(last time.Time, days int) *float64 {
+		switch input.kind {
+		case models.DroughtBaselineReanalysis:
+			samples := spiSamples(baseline, last, days, input.years)
+			if len(samples) == 0 {
+				return nil
+			}
+			var sum float64
+			for _, sample := range samples {
+				sum += sample
+			}
+			normal := roundTo(sum/float64(len(samples)), 1)
+			return &normal
+		case models.DroughtBaselineNormals:
+			var sum float64
+			for d := last.AddDate(0, 0, -days+1); !d.After(last); d = d.AddDate(0, 0, 1) {
+				normal, ok := normals.forDate(d)
+				if !ok || normal.precipitation == nil {
+					return nil
+				}
+				sum += *normal.precipitation
+			}
+			normal := roundTo(sum, 1)
+			return &normal
+		}
+		return nil
+	}
+	observedFor := func(last time.Time, days int) *float64 {
+		sum, coverage := station.window(last, days)
+		if coverage < DROUGHT_MIN_COVERAGE {
+			return nil
+		}
+		observed := roundTo(sum, 1)
+		return &observed
+	}
+	spiFor := func(last time.Time, days int, observed *float64) models.SPIValue {
+		spi := models.SPIValue{Days: days}
+		if baseline == nil {
+			return spi
+		}
+		samples := spiSamples(baseline, last, days, input.years)
+		spi.Samples = len(samples)
+		if observed == nil {
+			return spi
+		}
+		if value, ok := standardizedPrecipitationIndex(*observed, samples); ok {
+			value = roundTo(value, 2)
+			spi.Value = &value
+			spi.Category, spi.Label = spiCategory(value)
+		}
+		return spi
+	}
+
+	index := &models.DroughtIndex{
+		Date:          yesterday,
+		Baseline:      input.kind,
+		BaselineLabel: input.label,
+		Deficits:      []models.PrecipitationDeficit{},
+		SPI:           []models.SPIValue{},
+		Trend:         []models.DroughtPoint{},
+	}
+
+	// Сухой период — по суткам станции, которые есть в данных
+	var recent []models.DailyWeatherInsight
+	for _, day := range input.station {
+		if day.RainTotal != nil {
+			recent = append(recent, day)
+		}
+	}
+	streak, lastRain, ok := calculateRainRecency(recent, time.UTC)
+	index.DryStreak = streak
+	if ok {
+		date := time.Date(lastRain.Year(), lastRain.Month(), lastRain.Day(), 0, 0, 0, 0, loc)
+		index.LastRain = &date
+	}
+
+	for _, days := range []int{DROUGHT_SPI_SHORT_DAYS, DROUGHT_SPI_LONG_DAYS} {
+		observed := observedFor(yesterday, days)
+		deficit := models.PrecipitationDeficit{Days: days, Observed: observed, Normal: normalFor(yesterday, days)}
+		if observed != nil && deficit.Normal != nil {
+			value := roundTo(*deficit.Normal-*observed, 1)
+			deficit.Deficit = &value
+			if *deficit.Normal > 0 {
+				percent := roundTo(ratioPercent(*observed, *deficit.Normal), 0)
+				deficit.PercentOfNormal = &percent
+			}
+		}
+		index.Deficits = append(index.Deficits, deficit)
+		index.SPI = append(index.SPI, spiFor(yesterday, days, observed))
+	}
+	index.GTK = station.gtk(yesterday.AddDate(0, 0, -DROUGHT_SPI_SHORT_DAYS+1), yesterday)
+	index.GTKSeason = station.gtk(droughtSeasonStart(yesterday), yesterday)
+
+	for d := yesterday.AddDate(0, 0, -DROUGHT_TREND_DAYS+1); !d.After(yesterday); d = d.AddDate(0, 0, 1) {
+		point := models.DroughtPoint{Date: d, Rain30: observedFor(d, DROUGHT_SPI_SHORT_DAYS), Normal30: normalFor(d, DROUGHT_SPI_SHORT_DAYS)}
+		point.SPI30 = spiFor(d, DROUGHT_SPI_SHORT_DAYS, point.Rain30).Value
+		point.SPI90 = spiFor(d, DROUGHT_SPI_LONG_DAYS, observedFor(d, DROUGHT_SPI_LONG_DAYS)).Value
+		point.GTK30 = station.gtk(d.AddDate(0, 0, -DROUGHT_SPI_SHORT_DAYS+1), d).Value
+		index.Trend = append(index.Trend, point)
+	}
+
+	index.Level, index.Summary = droughtLevel(index, settings)
+	return index
+}
+
+// spiSamples собирает суммы осадков окна days, заканчивающегося той же датой в прошлые годы
+// (со сдвигами ±DROUGHT_SPI_SHIFT_DAYS), по суткам реанализа
+func spiSamples(baseline *droughtSeries, last time.Time, days, years int) []float64 {
+	if baseline == nil {
+		return nil
+	}
+	var samples []float64
+	for year := 1; year <= years; year++ {
+		for shift := -DROUGHT_SPI_SHIFT_DAYS; shift <= DROUGHT_SPI_SHIFT_DAYS; shift += droughtSPIStepDays {
+			sum, coverage := baseline.window(last.AddDate(-year, 0, shift), days)
+			if coverage >= DROUGHT_MIN_COVERAGE {
+				samples = append(samples, sum)
+			}
+		}
+	}
+	return samples
+}
+
+// standardizedPrecipitationIndex переводит сумму осадков в SPI: гамма-распределение по выборке
+// прошлых лет (оценка Тома), поправка на долю сухих окон и переход к стандартной нормальной величине.
+func standardizedPrecipitationIndex(value float64, samples []float64) (float64, bool) {
+	if len(samples) < DROUGHT_SPI_MIN_SAMPLES {
+		return 0, false
+	}
+	var zeros int
+	var sum, logSum float64
+	for _, sample := range samples {
+		if sample <= 0 {
+			zeros++
+			continue
+		}
+		sum += sample
+		logSum += math.Log(sample)
+	}
+	nonzero := len(samples) - zeros
+	if float64(nonzero) < float64(len(samples))*droughtSPIMinNonzeroShare {
+		return 0, false
+	}
+	mean := sum / float64(nonzero)
+	a := math.Log(mean) - logSum/float64(nonzero)
+	if a <= 0 {
+		return 0, false
+	}
+	alpha := (1 + math.Sqrt(1+4*a/3)) / (4 * a)
+	beta := mean / alpha
+
+	zeroShare := float64(zeros) / float64(len(samples))
+	probability := zeroShare
+	if value > 0 {
+		probability = zeroShare + (1-zeroShare)*regularizedGammaP(alpha, value/beta)
+	}
+	limit := 0.5 * math.Erfc(droughtSPIMaxSigma/math.Sqrt2)
+	probability = math.Min(math.Max(probability, limit), 1-limit)
+	return math.Sqrt2 * math.Erfinv(2*probability-1), true
+}
+
+// regularizedGammaP — регуляризованная нижняя неполная гамма-функция P(a, x):
+// ряд при x < a+1, иначе цепная дробь Лентца для Q(a, x)
+func regularizedGammaP(a, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	const (
+		maxIterations = 500
+		epsilon       = 1e-12
+		tiny          = 1e-300
+	)
+	lgamma, _ := math.Lgamma(a)
+	prefix := math.Exp(-x + a*math.Log(x) - lgamma)
+
+	if x < a+1 {
+		term := 1 / a
+		sum := term
+		for n := 1; n < maxIterations; n++ {
+			term *= x / (a + float64(n))
+			sum += term
+			if math.Abs(term) < math.Abs(sum)*epsilon {
+				break
+			}
+		}
+		return math.Min(1, sum*prefix)
+	}
+
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for i := 1; i < maxIterations; i++ {
+		an := -float64(i) * (float64(i) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < epsilon {
+			break
+		}
+	}
+	return math.Max(0, 1-prefix*h)
+}
+
+// spiCategory — градации SPI по McKee
+func spiCategory(value float64) (string, string) {
+	switch {
+	case value >= 2:
+		return "extremely_wet", "экстремально влажно"
+	case value >= 1.5:
+		return "very_wet", "очень влажно"
+	case value >= 1:
+		return "moderately_wet", "умеренно влажно"
+	case value > -1:
+		return "near_normal", "около нормы"
+	case value > -1.5:
+		return "moderately_dry", "умеренно сухо"
+	case value > -2:
+		return "severely_dry", "сильно сухо"
+	default:
+		return "extremely_dry", "экстремально сухо"
+	}
+}
+
+// gtkCategory — градации ГТК Селянинова
+func gtkCategory(value float64) (string, string) {
+	switch {
+	case value > 1.6:
+		return "excessive", "избыточное увлажнение"
+	case value >= 1:
+		return "sufficient", "достаточное увлажнение"
+	case value >= 0.7:
+		return "weak_drought", "слабая засуха"
+	case value >= 0.5:
+		return "moderate_drought", "средняя засуха"
+	case value >= 0.4:
+		return "severe_drought", "сильная засуха"
+	default:
+		return "extreme_drought", "очень сильная засуха"
+	}
+}
+
+// droughtLevel сводит индексы в уровень засухи и короткое объяснение
+func droughtLevel(index *models.DroughtIndex, settings DroughtSettings) (models.RiskLevel, string) {
+	level := models.RiskNone
+	raise := func(candidate models.RiskLevel) {
+		if candidate.Rank() > level.Rank() {
+			level = candidate
+		}
+	}
+	var reasons []string
+
+	for _, spi := range index.SPI {
+		if spi.Value == nil {
+			continue
+		}
+		switch {
+		case *spi.Value <= -2:
+			raise(models.RiskHigh)
+		case *spi.Value <= -1.5:
+			raise(models.RiskMedium)
+		case *spi.Value <= -1:
+			raise(models.RiskLow)
+		default:
+			continue
+		}
+		reasons = append(reasons, fmt.Sprintf("SPI за %d суток %.1f — %s", spi.Days, *spi.Value, spi.Label))
+	}
+	if index.GTK.Value != nil && *index.GTK.Value < 1 {
+		switch {
+		case *index.GTK.Value < 0.5:
+			raise(models.RiskHigh)
+		case *index.GTK.Value < 0.7:
+			raise(models.RiskMedium)
+		default:
+			raise(models.RiskLow)
+		}
+		reasons = append(reasons, fmt.Sprintf("ГТК за 30 суток %.2f — %s", *index.GTK.Value, index.GTK.Label))
+	}
+	for _, deficit := range index.Deficits {
+		if deficit.PercentOfNormal != nil && *deficit.PercentOfNormal < 50 {
+			raise(models.RiskLow)
+			reasons = append(reasons, fmt.Sprintf("за %d суток выпало %.0f мм — %.0f%% нормы", deficit.Days, *deficit.Observed, *deficit.PercentOfNormal))
+		}
+	}
+	if index.DryStreak >= settings.DrySpellDays {
+		raise(models.RiskLow)
+		reasons = append(reasons, fmt.Sprintf("%d суток без дождя", index.DryStreak))
+	}
+
+	if level == models.RiskNone {
+		return level, "Признаков засухи нет"
+	}
+	return level, "Засуха — " + level.Label() + ": " + strings.Join(reasons, "; ")
+}
+
+// droughtEvent сообщает о переходе индексов через пороги на вчерашних сутках
+func droughtEvent(index *models.DroughtIndex, settings DroughtSettings, now time.Time) *models.WeatherEvent {
+	if index == nil || len(index.Trend) < 2 {
+		return nil
+	}
+	last, prev := index.Trend[len(index.Trend)-1], index.Trend[len(index.Trend)-2]
+	crossed := func(before, after *float64, threshold float64) bool {
+		return before != nil && after != nil && *before > threshold && *after <= threshold
+	}
+
+	var details []string
+	value := 0.0
+	if crossed(prev.SPI30, last.SPI30, settings.SPIThreshold) {
+		details = append(details, fmt.Sprintf("SPI за 30 суток опустился до %.1f", *last.SPI30))
+		value = *last.SPI30
+	}
+	if crossed(prev.SPI90, last.SPI90, settings.SPIThreshold) {
+		details = append(details, fmt.Sprintf("SPI за 90 суток опустился до %.1f", *last.SPI90))
+		if len(details) == 1 {
+			value = *last.SPI90
+		}
+	}
+	if crossed(prev.GTK30, last.GTK30, settings.GTKThreshold) {
+		_, label := gtkCategory(*last.GTK30)
+		details = append(details, fmt.Sprintf("ГТК за 30 суток %.2f — %s", *last.GTK30, label))
+	}
+	if index.DryStreak == settings.DrySpellDays {
+		details = append(details, fmt.Sprintf("%d суток без дождя", index.DryStreak))
+	}
+	if len(details) == 0 {
+		return nil
+	}
+
+	for _, deficit := range index.Deficits {
+		if deficit.Days == DROUGHT_SPI_SHORT_DAYS && deficit.Observed != nil && deficit.PercentOfNormal != nil {
+			details = append(details, fmt.Sprintf("за 30 суток %.0f мм — %.0f%% нормы", *deficit.Observed, *deficit.PercentOfNormal))
+		}
+	}
+	return &models.WeatherEvent{
+		Type:        "drought",
+		Time:        now,
+		Value:       value,
+		Description: "Засуха усиливается",
+		Details:     strings.Join(details, "\n"),
+		Icon:        "🏜️",
+	}
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+func TestRegularizedGammaPMatchesClosedForms(t *testing.T) {
+	for _, x := range []float64{0.3, 1, 2.5, 8} {
+		// P(1, x) = 1 − e^−x, P(2, x) = 1 − e^−x·(1 + x)
+		if got, want := regularizedGammaP(1, x), 1-math.Exp(-x); math.Abs(got-want) > 1e-9 {
+			t.Fatalf("P(1, %.1f) = %.10f, want %.10f", x, got, want)
+		}
+		if got, want := regularizedGammaP(2, x), 1-math.Exp(-x)*(1+x); math.Abs(got-want) > 1e-9 {
+			t.Fatalf("P(2, %.1f) = %.10f, want %.10f", x, got, want)
+		}
+	}
+}
+
+func TestStandardizedPrecipitationIndex(t *testing.T) {
+	// Квантили экспоненциального распределения со средним 50 мм — частный случай гамма
+	samples := make([]float64, 150)
+	for i := range samples {
+		samples[i] = -50 * math.Log(1-(float64(i)+0.5)/float64(len(samples)))
+	}
+
+	median, ok := standardizedPrecipitationIndex(50*math.Ln2, samples)
+	if !ok || math.Abs(median) > 0.1 {
+		t.Fatalf("median sum should give SPI about 0, got %.2f (ok=%v)", median, ok)
+	}
+	dry, _ := standardizedPrecipitationIndex(1, samples)
+	if dry > -2 {
+		t.Fatalf("1 mm against a 50 mm mean should be extremely dry, got %.2f", dry)
+	}
+	wet, _ := standardizedPrecipitationIndex(200, samples)
+	if wet < 1.5 {
+		t.Fatalf("four times the mean should be very wet, got %.2f", wet)
+	}
+	if zero, _ := standardizedPrecipitationIndex(0, samples); math.Abs(zero+droughtSPIMaxSigma) > 1e-6 {
+		t.Fatalf("no rain without dry windows in the past should clamp to −3, got %.2f", zero)
+	}
+	if _, ok := standardizedPrecipitationIndex(10, samples[:DROUGHT_SPI_MIN_SAMPLES-1]); ok {
+		t.Fatal("SPI must not be computed from too few samples")
+	}
+}
+
+func TestDroughtSeriesGTK(t *testing.T) {
+	start := time.Date(2026, time.July, 1, 0, 0, 0, 0, time.UTC)
+	var days []models.DailyWeatherInsight
+	for i := 0; i < 30; i++ {
+		temp, rain := float32(20), float32(0)
+		if i%10 == 0 {
+			rain = 10
+		}
+		days = append(days, models.DailyWeatherInsight{Date: start.AddDate(0, 0, i), TempAvg: &temp, RainTotal: &rain})
+	}
+	series := newDroughtSeries(days, start, start.AddDate(0, 0, 30))
+
+	// 30 мм на сумму активных температур 600 °C
+	gtk := series.gtk(start, start.AddDate(0, 0, 29))
+	if gtk.Value == nil || *gtk.Value != 0.5 || gtk.Category != "moderate_drought" || gtk.WarmDays != 30 {
+		t.Fatalf("unexpected GTK: %#v", gtk)
+	}
+	if sum, coverage := series.window(start.AddDate(0, 0, 29), 30); sum != 30 || coverage != 1 {
+		t.Fatalf("window = %.1f mm, coverage %.2f", sum, coverage)
+	}
+	if sum, coverage := series.window(start.AddDate(0, 0, 9), 20); sum != 10 || coverage != 0.5 {
+		t.Fatalf("window before the series start = %.1f mm, coverage %.2f", sum, coverage)
+	}
+
+	cold := float32(5)
+	for i := range days {
+		days[i].TempAvg = &cold
+	}
+	if gtk := newDroughtSeries(days, start, start.AddDate(0, 0, 30)).gtk(start, start.AddDate(0, 0, 29)); gtk.Value != nil {
+		t.Fatalf("GTK outside the growing season should be empty, got %.2f", *gtk.Value)
+	}
+}
+
+func TestBuildDroughtIndexDetectsDrySpell(t *testing.T) {
+	loc := time.UTC
+	today := time.Date(2026, time.August, 1, 0, 0, 0, 0, loc)
+	from := today.AddDate(0, 0, -(DROUGHT_TREND_DAYS + DROUGHT_SPI_LONG_DAYS))
+	years := 30
+	input := droughtInput{
+		from:         from,
+		today:        today,
+		baselineFrom: from.AddDate(-years, 0, -DROUGHT_SPI_SHIFT_DAYS-DROUGHT_SPI_LONG_DAYS),
+		baselineTo:   today.AddDate(-1, 0, DROUGHT_SPI_SHIFT_DAYS),
+		years:        years,
+		kind:         models.DroughtBaselineReanalysis,
+		label:        "реанализ ERA5",
+	}
+
+	// Прошлые годы: дождь каждые трое суток, в разные годы от 3 до 9 мм
+	for d := input.baselineFrom; d.Before(input.baselineTo); d = d.AddDate(0, 0, 1) {
+		rain := float32(0)
+		if d.YearDay()%3 == 0 {
+			rain = 6 * (0.5 + float32(d.Year()%5)/4)
+		}
+		input.baseline = append(input.baseline, models.DailyWeatherInsight{Date: d, RainTotal: &rain})
+	}
+	// Станция: обычные дожди, затем 25 жарких суток без осадков
+	dryFrom := today.AddDate(0, 0, -25)
+	for d := from; d.Before(today); d = d.AddDate(0, 0, 1) {
+		rain, temp := float32(0), float32(24)
+		if d.Before(dryFrom) && (d.YearDay()%3 == 0 || d.Equal(dryFrom.AddDate(0, 0, -1))) {
+			rain = 6
+		}
+		input.station = append(input.station, models.DailyWeatherInsight{Date: d, RainTotal: &rain, TempAvg: &temp})
+	}
+
+	index := buildDroughtIndex(input, DroughtSettings{SPIThreshold: -1.5, GTKThreshold: 0.7, DrySpellDays: 20}, loc)
+
+	if index.DryStreak != 25 || index.LastRain == nil {
+		t.Fatalf("dry streak = %d, last rain %v", index.DryStreak, index.LastRain)
+	}
+	short := index.SPI[0]
+	if short.Days != 30 || short.Value == nil || *short.Value > -1.5 || short.Samples != years*5 {
+		t.Fatalf("unexpected SPI-30: %#v", short)
+	}
+	deficit := index.Deficits[0]
+	if deficit.Normal == nil || math.Abs(*deficit.Normal-60) > 6 || deficit.PercentOfNormal == nil || *deficit.PercentOfNormal > 35 {
+		t.Fatalf("unexpected 30-day deficit: normal %v, %v%% of normal", deref64(deficit.Normal), deref64(deficit.PercentOfNormal))
+	}
+	if index.GTK.Value == nil || *index.GTK.Value > 0.4 {
+		t.Fatalf("unexpected GTK: %#v", index.GTK)
+	}
+	if index.Level != models.RiskHigh || !strings.Contains(index.Summary, "25 суток без дождя") {
+		t.Fatalf("level %q, summary %q", index.Level, index.Summary)
+	}
+	if len(index.Trend) != DROUGHT_TREND_DAYS || index.Trend[0].SPI90 == nil {
+		t.Fatalf("trend must cover %d days with SPI-90 from the first point", DROUGHT_TREND_DAYS)
+	}
+}
+
+func TestDroughtEventFiresOnThresholdCrossing(t *testing.T) {
+	settings := DroughtSettings{SPIThreshold: -1.5, GTKThreshold: 0.7, DrySpellDays: 20}
+	now := time.Date(2026, time.August, 1, 10, 0, 0, 0, time.UTC)
+	index := &models.DroughtIndex{
+		DryStreak: 12,
+		Trend: []models.DroughtPoint{
+			{SPI30: f64(-1.2), GTK30: f64(0.9)},
+			{SPI30: f64(-1.6), GTK30: f64(0.8)},
+		},
+	}
+
+	event := droughtEvent(index, settings, now)
+	if event == nil || event.Type != "drought" || event.Value != -1.6 || !strings.Contains(event.Details, "SPI за 30 суток") {
+		t.Fatalf("unexpected event: %#v", event)
+	}
+
+	// Индексы уже ниже порога — повторного предупреждения нет
+	index.Trend[0].SPI30 = f64(-1.55)
+	if event := droughtEvent(index, settings, now); event != nil {
+		t.Fatalf("no crossing, got %#v", event)
+	}
+
+	index.DryStreak = settings.DrySpellDays
+	if event := droughtEvent(index, settings, now); event == nil || !strings.Contains(event.Details, "20 суток без дождя") {
+		t.Fatalf("dry spell reaching the limit should alert, got %#v", event)
+	}
+}
+
+func f64(v float64) *float64 {
+	return &v
+}
+
+func deref64(v *float64) float64 {
+	if v == nil {
+		return math.NaN()
+	}
+	return *v
+}
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+	"github.com/iRootPro/weather/internal/repository"
+)
+
+const (
+	biasRefitInterval   = 6 * time.Hour // как часто переобучать поправки
+	biasLookbackYears   = 3             // сколько лет пар «прогноз — наблюдение» учитывать
+	biasHourWindow      = 1             // соседние часы (±) попадают в выборку часа
+	biasMinForecastVar  = 0.25          // при меньшей дисперсии прогноза берём среднюю ошибку
+	biasDailyMinHour    = 5             // типичный час суточного минимума
+	biasDailyMaxHour    = 14            // типичный час суточного максимума
+	defaultBiasMinPairs = 30
+)
+
+// biasModel — линейная поправка: наблюдение ≈ Intercept + Slope × прогноз
+type biasModel struct {
+	Intercept float64
+	Slope     float64
+	Samples   int
+}
+
+func (m biasModel) apply(value float64) float64 {
+	return m.Intercept + m.Slope*value
+}
+
+type biasKey struct {
+	season string
+	hour   int
+}
+
+// biasModelSet — поправки по сезону и часу суток для каждой величины
+type biasModelSet struct {
+	temperature map[biasKey]biasModel
+	humidity    map[biasKey]biasModel
+	windSpeed   map[biasKey]biasModel
+}
+
+// ForecastBiasCorrector корректирует прогноз Open-Meteo под микроклимат станции
+// по накопленным парам «прогноз — наблюдение» (отдельно для сезона и часа суток).
+type ForecastBiasCorrector struct {
+	repo       repository.ForecastRepository
+	minSamples int
+
+	mu       sync.Mutex
+	models   *biasModelSet
+	fittedAt time.Time
+}
+
+func NewForecastBiasCorrector(repo repository.ForecastRepository, minSamples int) *ForecastBiasCorrector {
+	if minSamples <= 0 {
+		minSamples = defaultBiasMinPairs
+	}
+	return &ForecastBiasCorrector{repo: repo, minSamples: minSamples}
+}
+
+// CorrectHourly применяет поправки к почасовому прогнозу на месте
+func (c *ForecastBiasCorrector) CorrectHourly(ctx context.Context, forecasts []models.HourlyForecast) error {
+	set, err := c.load(ctx)
+	if err != nil {
+		return err
+	}
+	for i := range forecasts {
+		set.correctHourly(&forecasts[i])
+	}
+	return nil
+}
+
+// CorrectDaily применяет поправки к дневному прогнозу. Если на день есть
+// скорректированный почасовой прогноз целиком, экстремумы берутся из него.
+func (c *ForecastBiasCorrector) CorrectDaily(ctx context.Context, forecasts []models.DailyForecast, hourly []models.HourlyForecast) error {
+	set, err := c.load(ctx)
+	if err != nil {
+		return err
+	}
+	for i := range forecasts {
+		set.correctDaily(&forecasts[i], hourly)
+	}
+	return nil
+}
+
+func (c *ForecastBiasCorrector) load(ctx context.Context) (*biasModelSet, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.models != nil && time.Since(c.fittedAt) < biasRefitInterval {
+		return c.models, nil
+	}
+
+	now := time.Now()
+	pairs, err := c.repo.GetVerificationPairs(ctx, now.AddDate(-biasLookbackYears, 0, 0), now)
+	if err != nil {
+		return nil, err
+	}
+
+	c.models = fitBiasModels(pairs, c.minSamples)
+	c.fittedAt = now
+	return c.models, nil
+}
+
+// fitBiasModels строит поправки для каждого сезона и часа суток
+func fitBiasModels(pairs []models.ForecastVerification, minSamples int) *biasModelSet {
+	type sample struct{ forecast, observed float64 }
+	type buckets map[biasKey][]sample
+
+	temperature := buckets{}
+	humidity := buckets{}
+	windSpeed := buckets{}
+
+	for _, p := range pairs {
+		// forecast_time хранит локальное время Open-Meteo, помеченное как UTC
+		local := p.ForecastTime.UTC()
+		_, season, _ := seasonName(local.Month())
+		key := biasKey{season: season, hour: local.Hour()}
+
+		if p.ForecastTemperature != nil && p.ObservedTemperature != nil {
+			temperature[key] = append(temperature[key], sample{float64(*p.ForecastTemperature), float64(*p.ObservedTemperature)})
+		}
+		if p.ForecastHumidity != nil && p.ObservedHumidity != nil {
+			humidity[key] = append(humidity[key], sample{float64(*p.ForecastHumidity), float64(*p.ObservedHumidity)})
+		}
+		if p.ForecastWindSpeed != nil && p.ObservedWindSpeed != nil {
+			windSpeed[key] = append(windSpeed[key], sample{float64(*p.ForecastWindSpeed), float64(*p.ObservedWindSpeed)})
+		}
+	}
+
+	fit := func(src buckets) map[biasKey]biasModel {
+		result := make(map[biasKey]biasModel)
+		for _, season := range []string{"winter", "spring", "summer", "autumn"} {
+			for hour := 0; hour < 24; hour++ {
+				var xs, ys []float64
+				for d := -biasHourWindow; d <= biasHourWindow; d++ {
+					for _, s := range src[biasKey{season: season, hour: (hour + d + 24) % 24}] {
+						xs = append(xs, s.forecast)
+						ys = append(ys, s.observed)
+					}
+				}
+				if len(xs) < minSamples {
+					continue
+				}
+				result[biasKey{season: season, hour: hour}] = fitLinearBias(xs, ys)
+			}
+		}
+		return result
+	}
+
+	return &biasModelSet{
+		temperature: fit(temperature),
+		humidity:    fit(humidity),
+		windSpeed:   fit(windSpeed),
+	}
+}
+
+// fitLinearBias — МНК-регрессия наблюдения на прогноз. При вырожденной выборке
+// (почти постоянный прогноз или неположительный наклон) остаётся сдвиг на среднюю ошибку.
+func fitLinearBias(xs, ys []float64) biasModel {
+	n := float64(len(xs))
+	var sumX, sumY float64
+	for i := range xs {
+		sumX += xs[i]
+		sumY += ys[i]
+	}
+	meanX, meanY := sumX/n, sumY/n
+
+	var sxx, sxy float64
+	for i := range xs {
+		dx := xs[i] - meanX
+		sxx += dx * dx
+		sxy += dx * (ys[i] - meanY)
+	}
+
+	if sxx/n >= biasMinForecastVar {
+		slope := sxy / sxx
+		if slope > 0 {
+			return biasModel{Intercept: meanY - slope*meanX, Slope: slope, Samples: len(xs)}
+		}
+	}
+	return biasModel{Intercept: meanY - meanX, Slope: 1, Samples: len(xs)}
+}
+
+func lookupBias(byKey map[biasKey]biasModel, t time.Time, hour int) (biasModel, bool) {
+	_, season, _ := seasonName(t.UTC().Month())
+	m, ok := byKey[biasKey{season: season, hour: hour}]
+	return m, ok
+}
+
+func (s *biasModelSet) correctHourly(f *models.HourlyForecast) {
+	hour := f.Time.UTC().Hour()
+
+	if m, ok := lookupBias(s.temperature, f.Time, hour); ok {
+		corrected := float32(m.apply(float64(f.RawTemperature)))
+		// Ощущаемую температуру сдвигаем на ту же величину
+		f.FeelsLike += corrected - f.RawTemperature
+		f.Temperature = corrected
+		f.BiasCorrected = true
+	}
+	if m, ok := lookupBias(s.humidity, f.Time, hour); ok {
+		corrected := math.Round(m.apply(float64(f.RawHumidity)))
+		f.Humidity = int16(math.Max(0, math.Min(100, corrected)))
+		f.BiasCorrected = true
+	}
+	if m, ok := lookupBias(s.windSpeed, f.Time, hour); ok {
+		f.WindSpeed = float32(math.Max(0, m.apply(float64(f.RawWindSpeed))))
+		f.BiasCorrected = true
+	}
+}
+
+func (s *biasModelSet) correctDaily(f *models.DailyForecast, hourly []models.HourlyForecast) {
+	day := f.Date.UTC().Format("2006-01-02")
+	var dayHours []models.HourlyForecast
+	for _, h := range hourly {
+		if h.BiasCorrected && h.Time.UTC().Format("2006-01-02") == day {
+			dayHours = append(dayHours, h)
+		}
+	}
+
+	if len(dayHours) == 24 {
+		minT, maxT := dayHours[0].Temperature, dayHours[0].Temperature
+		for _, h := range dayHours[1:] {
+			minT = min(minT, h.Temperature)
+			maxT = max(maxT, h.Temperature)
+		}
+		// Почасовой ряд сглаживает пики, поэтому переносим только смещение экстремумов
+		rawMin, rawMax := dayHours[0].RawTemperature, dayHours[0].RawTemperature
+		for _, h := range dayHours[1:] {
+			rawMin = min(rawMin, h.RawTemperature)
+			rawMax = max(rawMax, h.RawTemperature)
+		}
+		f.TemperatureMin = f.RawTemperatureMin + (minT - rawMin)
+		f.TemperatureMax = f.RawTemperatureMax + (maxT - rawMax)
+		f.BiasCorrected = true
+	} else {
+		if m, ok := lookupBias(s.temperature, f.Date, biasDailyMinHour); ok {
+			f.TemperatureMin = float32(m.apply(float64(f.RawTemperatureMin)))
+			f.BiasCorrected = true
+		}
+		if m, ok := lookupBias(s.temperature, f.Date, biasDailyMaxHour); ok {
+			f.TemperatureMax = float32(m.apply(float64(f.RawTemperatureMax)))
+			f.BiasCorrected = true
+		}
+	}
+
+	if m, ok := lookupBias(s.windSpeed, f.Date, biasDailyMaxHour); ok {
+		f.WindSpeedMax = float32(math.Max(0, m.apply(float64(f.RawWindSpeedMax))))
+		f.BiasCorrected = true
+	}
+
+	if f.TemperatureMin > f.TemperatureMax {
+		f.TemperatureMin, f.TemperatureMax = f.TemperatureMax, f.TemperatureMin
+	}
+}
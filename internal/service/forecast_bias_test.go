@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+	"github.com/iRootPro/weather/internal/repository"
+)
+
+func TestFitLinearBias(t *testing.T) {
+	xs := []float64{0, 2, 4, 6, 8}
+	ys := []float64{-3, -1.4, 0.2, 1.8, 3.4} // obs = 0.8*fc - 3
+	m := fitLinearBias(xs, ys)
+	if math.Abs(m.Slope-0.8) > 1e-9 || math.Abs(m.Intercept+3) > 1e-9 {
+		t.Fatalf("модель = %+v, want slope 0.8 intercept -3", m)
+	}
+
+	// Почти постоянный прогноз — только сдвиг на среднюю ошибку
+	m = fitLinearBias([]float64{5, 5, 5}, []float64{2, 3, 4})
+	if m.Slope != 1 || math.Abs(m.Intercept+2) > 1e-9 {
+		t.Fatalf("вырожденная модель = %+v, want slope 1 intercept -2", m)
+	}
+}
+
+func TestFitBiasModelsNightInversion(t *testing.T) {
+	pairs := make([]models.ForecastVerification, 0)
+	start := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for day := 0; day < 20; day++ {
+		for hour := 0; hour < 24; hour++ {
+			fc := float32(day%5) - 2
+			obs := fc
+			if hour < 6 {
+				obs -= 3 // ночью в долине холоднее
+			}
+			pairs = append(pairs, models.ForecastVerification{
+				ForecastTime:        start.AddDate(0, 0, day).Add(time.Duration(hour) * time.Hour),
+				ForecastTemperature: &fc,
+				ObservedTemperature: &obs,
+			})
+		}
+	}
+
+	set := fitBiasModels(pairs, 30)
+
+	night := models.HourlyForecast{Time: time.Date(2026, time.February, 3, 3, 0, 0, 0, time.UTC), RawTemperature: 0, Temperature: 0, FeelsLike: -2}
+	set.correctHourly(&night)
+	if !night.BiasCorrected || math.Abs(float64(night.Temperature)+3) > 0.01 {
+		t.Fatalf("ночная температура = %.2f, want -3", night.Temperature)
+	}
+	if math.Abs(float64(night.FeelsLike)+5) > 0.01 {
+		t.Fatalf("ощущаемая = %.2f, want -5", night.FeelsLike)
+	}
+	if night.RawTemperature != 0 {
+		t.Fatalf("сырое значение изменилось: %.2f", night.RawTemperature)
+	}
+
+	day := models.HourlyForecast{Time: time.Date(2026, time.February, 3, 13, 0, 0, 0, time.UTC), RawTemperature: 1, Temperature: 1}
+	set.correctHourly(&day)
+	if math.Abs(float64(day.Temperature)-1) > 0.01 {
+		t.Fatalf("дневная температура = %.2f, want 1", day.Temperature)
+	}
+
+	// Летом пар нет — прогноз остаётся как есть
+	summer := models.HourlyForecast{Time: time.Date(2026, time.July, 3, 3, 0, 0, 0, time.UTC), RawTemperature: 20, Temperature: 20}
+	set.correctHourly(&summer)
+	if summer.BiasCorrected || summer.Temperature != 20 {
+		t.Fatalf("летний прогноз скорректирован без данных: %+v", summer)
+	}
+}
+
+// brokenBiasRepo отдаёт прогноз, но не может прочитать пары «прогноз — наблюдение»
+type brokenBiasRepo struct {
+	repository.ForecastRepository
+	hourly []models.ForecastData
+	daily  []models.ForecastData
+}
+
+func (r *brokenBiasRepo) GetHourlyForecast(context.Context, time.Time, time.Time) ([]models.ForecastData, error) {
+	return r.hourly, nil
+}
+
+func (r *brokenBiasRepo) GetLatestHourly(context.Context, int) ([]models.ForecastData, error) {
+	return r.hourly, nil
+}
+
+func (r *brokenBiasRepo) GetLatestDaily(context.Context, int) ([]models.ForecastData, error) {
+	return r.daily, nil
+}
+
+func (r *brokenBiasRepo) GetModelForecasts(context.Context, string, time.Time, time.Time) ([]models.ForecastData, error) {
+	return nil, nil
+}
+
+func (r *brokenBiasRepo) GetVerificationPairs(context.Context, time.Time, time.Time) ([]models.ForecastVerification, error) {
+	return nil, errors.New("relation forecast_verification does not exist")
+}
+
+func TestForecastServiceFallsBackWhenBiasFails(t *testing.T) {
+	temp := float32(12.5)
+	tempMin, tempMax := float32(4), float32(15)
+	now := time.Now().UTC().Truncate(time.Hour)
+	repo := &brokenBiasRepo{
+		hourly: []models.ForecastData{{ForecastTime: now.Add(time.Hour), ForecastType: "hourly", Temperature: &temp}},
+		daily:  []models.ForecastData{{ForecastTime: now.Truncate(24 * time.Hour), ForecastType: "daily", TemperatureMin: &tempMin, TemperatureMax: &tempMax}},
+	}
+	svc := NewForecastService(repo)
+	svc.SetBiasCorrector(NewForecastBiasCorrector(repo, 0))
+	ctx := context.Background()
+
+	hourly, err := svc.GetHourlyForecast(ctx, 24)
+	if err != nil || len(hourly) != 1 || hourly[0].Temperature != temp {
+		t.Fatalf("GetHourlyForecast() = %+v, %v; want raw forecast", hourly, err)
+	}
+	today, err := svc.GetTodayForecast(ctx)
+	if err != nil || len(today) != 1 {
+		t.Fatalf("GetTodayForecast() = %+v, %v; want raw forecast", today, err)
+	}
+	current, err := svc.GetCurrentConditions(ctx)
+	if err != nil || current == nil || current.Temperature != temp {
+		t.Fatalf("GetCurrentConditions() = %+v, %v; want raw forecast", current, err)
+	}
+	daily, err := svc.GetDailyForecast(ctx, 7)
+	if err != nil || len(daily) != 1 || daily[0].TemperatureMin != tempMin || daily[0].TemperatureMax != tempMax {
+		t.Fatalf("GetDailyForecast() = %+v, %v; want raw forecast", daily, err)
+	}
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+const (
+	ensembleMinMembers = 2 // ансамбль имеет смысл хотя бы из двух моделей
+
+	// Пороги разброса (стандартное отклонение между моделями)
+	ensembleTempSpreadMedium = 1.5
+	ensembleTempSpreadHigh   = 3.0
+	ensembleHourTempMedium   = 1.2
+	ensembleHourTempHigh     = 2.5
+
+	ensembleRainyDayMM  = 1.0 // модель «за дождь» на день
+	ensembleRainyHourMM = 0.3 // модель «за дождь» на час
+	ensembleHeavyRainMM = 5.0 // если хоть одна модель даёт столько, а другие сухо — высокая неопределённость
+)
+
+// attachHourlyEnsemble добавляет к почасовому прогнозу среднее и разброс по моделям
+func (s *ForecastService) attachHourlyEnsemble(ctx context.Context, forecasts []models.HourlyForecast) error {
+	if len(forecasts) == 0 {
+		return nil
+	}
+	rows, err := s.repo.GetModelForecasts(ctx, "hourly", forecasts[0].Time, forecasts[len(forecasts)-1].Time)
+	if err != nil {
+		return err
+	}
+	ensembles := buildHourlyEnsembles(rows)
+	for i := range forecasts {
+		forecasts[i].Ensemble = ensembles[forecasts[i].Time.UTC()]
+	}
+	return nil
+}
+
+// attachDailyEnsemble добавляет к дневному прогнозу среднее и разброс по моделям
+func (s *ForecastService) attachDailyEnsemble(ctx context.Context, forecasts []models.DailyForecast) error {
+	if len(forecasts) == 0 {
+		return nil
+	}
+	rows, err := s.repo.GetModelForecasts(ctx, "daily", forecasts[0].Date, forecasts[len(forecasts)-1].Date)
+	if err != nil {
+		return err
+	}
+	ensembles := buildDailyEnsembles(rows)
+	for i := range forecasts {
+		forecasts[i].Ensemble = ensembles[forecasts[i].Date.UTC()]
+	}
+	return nil
+}
+
+func buildHourlyEnsembles(rows []models.ForecastData) map[time.Time]*models.HourlyEnsemble {
+	groups := groupByForecastTime(rows)
+	result := make(map[time.Time]*models.HourlyEnsemble, len(groups))
+
+	for t, members := range groups {
+		var names []string
+		var temps, precips, winds []float32
+		for _, m := range members {
+			if m.Temperature == nil {
+				continue
+			}
+			names = append(names, m.Provider+"/"+m.Model)
+			temps = append(temps, *m.Temperature)
+			if m.Precipitation != nil {
+				precips = append(precips, *m.Precipitation)
+			}
+			if m.WindSpeed != nil {
+				winds = append(winds, *m.WindSpeed)
+			}
+		}
+		if len(names) < ensembleMinMembers {
+			continue
+		}
+
+		e := &models.HourlyEnsemble{
+			Members:       names,
+			Temperature:   ensembleStat(temps),
+			Precipitation: ensembleStat(precips),
+			WindSpeed:     ensembleStat(winds),
+		}
+		e.Uncertainty = hourlyUncertainty(e, countAtLeast(precips, ensembleRainyHourMM), len(precips))
+		result[t] = e
+	}
+	return result
+}
+
+func buildDailyEnsembles(rows []models.ForecastData) map[time.Time]*models.DailyEnsemble {
+	groups := groupByForecastTime(rows)
+	result := make(map[time.Time]*models.DailyEnsemble, len(groups))
+
+	for t, members := range groups {
+		var names []string
+		var mins, maxs, precips, winds []float32
+		for _, m := range members {
+			if m.TemperatureMin == nil || m.TemperatureMax == nil {
+				continue
+			}
+			names = append(names, m.Provider+"/"+m.Model)
+			mins = append(mins, *m.TemperatureMin)
+			maxs = append(maxs, *m.TemperatureMax)
+			if m.Precipitation != nil {
+				precips = append(precips, *m.Precipitation)
+			}
+			if m.WindSpeed != nil {
+				winds = append(winds, *m.WindSpeed)
+			}
+		}
+		if len(names) < ensembleMinMembers {
+			continue
+		}
+
+		e := &models.DailyEnsemble{
+			Members:          names,
+			TemperatureMin:   ensembleStat(mins),
+			TemperatureMax:   ensembleStat(maxs),
+			PrecipitationSum: ensembleStat(precips),
+			WindSpeedMax:     ensembleStat(winds),
+			RainyMembers:     countAtLeast(precips, ensembleRainyDayMM),
+		}
+		e.Uncertainty = dailyUncertainty(e, len(precips))
+		result[t] = e
+	}
+	return result
+}
+
+// groupByForecastTime раскладывает прогнозы моделей по времени. best_match в ансамбль
+// не входит: Open-Meteo подставляет под него одну из моделей (здесь ICON), и она
+// учитывалась бы в среднем и разбросе дважды.
+func groupByForecastTime(rows []models.ForecastData) map[time.Time][]models.ForecastData {
+	groups := make(map[time.Time][]models.ForecastData)
+	for _, r := range rows {
+		if r.Provider == models.ForecastProviderOpenMeteo && r.Model == models.ForecastModelBestMatch {
+			continue
+		}
+		t := r.ForecastTime.UTC()
+		groups[t] = append(groups[t], r)
+	}
+	for _, members := range groups {
+		sort.SliceStable(members, func(i, j int) bool {
+			if members[i].Provider != members[j].Provider {
+				return members[i].Provider < members[j].Provider
+			}
+			return members[i].Model < members[j].Model
+		})
+	}
+	return groups
+}
+
+// ensembleStat считает среднее, стандартное отклонение и крайние значения
+func ensembleStat(values []float32) models.EnsembleStat {
+	if len(values) == 0 {
+		return models.EnsembleStat{}
+	}
+	stat := models.EnsembleStat{Min: values[0], Max: values[0]}
+	var sum float64
+	for _, v := range values {
+		sum += float64(v)
+		stat.Min = min(stat.Min, v)
+		stat.Max = max(stat.Max, v)
+	}
+	mean := sum / float64(len(values))
+
+	var sq float64
+	for _, v := range values {
+		d := float64(v) - mean
+		sq += d * d
+	}
+	stat.Mean = float32(mean)
+	stat.Spread = float32(math.Sqrt(sq / float64(len(values))))
+	return stat
+}
+
+func countAtLeast(values []float32, threshold float32) int {
+	n := 0
+	for _, v := range values {
+		if v >= threshold {
+			n++
+		}
+	}
+	return n
+}
+
+// rainDisagreement — часть моделей даёт осадки, часть нет
+func rainDisagreement(rainy, total int) bool {
+	return rainy > 0 && rainy < total
+}
+
+func dailyUncertainty(e *models.DailyEnsemble, precipMembers int) string {
+	tempSpread := max(e.TemperatureMin.Spread, e.TemperatureMax.Spread)
+	disagree := rainDisagreement(e.RainyMembers, precipMembers)
+
+	switch {
+	case tempSpread >= ensembleTempSpreadHigh,
+		disagree && e.PrecipitationSum.Max >= ensembleHeavyRainMM:
+		return models.ForecastUncertaintyHigh
+	case tempSpread >= ensembleTempSpreadMedium, disagree:
+		return models.ForecastUncertaintyMedium
+	default:
+		return models.ForecastUncertaintyLow
+	}
+}
+
+func hourlyUncertainty(e *models.HourlyEnsemble, rainyMembers, precipMembers int) string {
+	switch {
+	case e.Temperature.Spread >= ensembleHourTempHigh:
+		return models.ForecastUncertaintyHigh
+	case e.Temperature.Spread >= ensembleHourTempMedium, rainDisagreement(rainyMembers, precipMembers):
+		return models.ForecastUncertaintyMedium
+	default:
+		return models.ForecastUncertaintyLow
+	}
+}
@@ -0,0 +1,377 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+	"github.com/iRootPro/weather/pkg/metno"
+	"github.com/iRootPro/weather/pkg/openmeteo"
+)
+
+// ForecastProvider — источник прогноза одной модели. Прогноз возвращается в формате
+// forecast_data: время — локальное время станции, помеченное как UTC (как отдаёт Open-Meteo).
+type ForecastProvider interface {
+	Provider() string
+	Model() string
+	Fetch(ctx context.Context) ([]models.ForecastData, error)
+}
+
+// ForecastLocation — точка и часовой пояс, для которых запрашивается прогноз
+type ForecastLocation struct {
+	Latitude  float64
+	Longitude float64
+	Timezone  string
+}
+
+// OpenMeteoProvider — прогноз Open-Meteo для выбранной модели (пусто — best_match)
+type OpenMeteoProvider struct {
+	client      *openmeteo.Client
+	model       string
+	location    ForecastLocation
+	hourlyHours int
+	dailyDays   int
+}
+
+func NewOpenMeteoProvider(client *openmeteo.Client, model string, location ForecastLocation, hourlyHours, dailyDays int) *OpenMeteoProvider {
+	return &OpenMeteoProvider{
+		client:      client,
+		model:       model,
+		location:    location,
+		hourlyHours: hourlyHours,
+		dailyDays:   dailyDays,
+	}
+}
+
+func (p *OpenMeteoProvider) Provider() string {
+	return models.ForecastProviderOpenMeteo
+}
+
+func (p *OpenMeteoProvider) Model() string {
+	if p.model == "" {
+		return models.ForecastModelBestMatch
+	}
+	return p.model
+}
+
+func (p *OpenMeteoProvider) Fetch(ctx context.Context) ([]models.ForecastData, error) {
+	req := openmeteo.ForecastRequest{
+		Latitude:  p.location.Latitude,
+		Longitude: p.location.Longitude,
+		Timezone:  p.location.Timezone,
+		Hourly:    openmeteo.GetDefaultHourlyParams(),
+		Daily:     openmeteo.GetDefaultDailyParams(),
+		WindUnit:  "ms",
+	}
+	if p.model != "" && p.model != models.ForecastModelBestMatch {
+		req.Model = p.model
+	}
+
+	resp, err := p.client.GetForecast(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	fetchedAt := time.Now()
+	result := make([]models.ForecastData, 0, p.hourlyHours+p.dailyDays)
+
+	// Конвертируем почасовой прогноз
+	h := resp.Hourly
+	for i := 0; i < len(h.Time) && i < p.hourlyHours; i++ {
+		// Open-Meteo возвращает время в формате "2025-12-29T00:00"
+		forecastTime, err := time.Parse("2006-01-02T15:04", h.Time[i])
+		if err != nil {
+			continue
+		}
+
+		data := models.ForecastData{
+			ForecastTime:             forecastTime,
+			Temperature:              float32At(h.Temperature, i),
+			FeelsLike:                float32At(h.FeelsLike, i),
+			PrecipitationProbability: int16At(h.PrecipitationProbability, i),
+			Precipitation:            float32At(h.Precipitation, i),
+			WindSpeed:                float32At(h.WindSpeed, i),
+			WindDirection:            int16At(h.WindDirection, i),
+			WindGusts:                float32At(h.WindGusts, i),
+			CloudCover:               int16At(h.CloudCover, i),
+			Pressure:                 float32At(h.Pressure, i),
+			Humidity:                 int16At(h.Humidity, i),
+			UVIndex:                  float32At(h.UVIndex, i),
+			WeatherCode:              int16At(h.WeatherCode, i),
+			ForecastType:             "hourly",
+			Provider:                 p.Provider(),
+			Model:                    p.Model(),
+			FetchedAt:                fetchedAt,
+		}
+		if data.Temperature == nil {
+			continue
+		}
+		data.WeatherDescription = weatherDescriptionFor(data.WeatherCode)
+		result = append(result, data)
+	}
+
+	// Конвертируем дневной прогноз
+	d := resp.Daily
+	for i := 0; i < len(d.Time) && i < p.dailyDays; i++ {
+		forecastTime, err := time.Parse("2006-01-02", d.Time[i])
+		if err != nil {
+			continue
+		}
+
+		data := models.ForecastData{
+			ForecastTime:             forecastTime,
+			TemperatureMin:           float32At(d.TemperatureMin, i),
+			TemperatureMax:           float32At(d.TemperatureMax, i),
+			PrecipitationProbability: int16At(d.PrecipitationProbability, i),
+			Precipitation:            float32At(d.PrecipitationSum, i),
+			WindSpeed:                float32At(d.WindSpeedMax, i),
+			WindDirection:            int16At(d.WindDirection, i),
+			WindGusts:                float32At(d.WindGustsMax, i),
+			UVIndex:                  float32At(d.UVIndexMax, i),
+			WeatherCode:              int16At(d.WeatherCode, i),
+			ForecastType:             "daily",
+			Provider:                 p.Provider(),
+			Model:                    p.Model(),
+			FetchedAt:                fetchedAt,
+		}
+		if data.TemperatureMin == nil || data.TemperatureMax == nil {
+			continue
+		}
+		data.WeatherDescription = weatherDescriptionFor(data.WeatherCode)
+		result = append(result, data)
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("empty forecast for model %s", p.Model())
+	}
+
+	return result, nil
+}
+
+// MetNorwayProvider — прогноз MET Norway Locationforecast
+type MetNorwayProvider struct {
+	client      *metno.Client
+	location    ForecastLocation
+	tz          *time.Location
+	hourlyHours int
+	dailyDays   int
+}
+
+func NewMetNorwayProvider(client *metno.Client, location ForecastLocation, hourlyHours, dailyDays int) (*MetNorwayProvider, error) {
+	tz, err := time.LoadLocation(location.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load timezone: %w", err)
+	}
+	return &MetNorwayProvider{
+		client:      client,
+		location:    location,
+		tz:          tz,
+		hourlyHours: hourlyHours,
+		dailyDays:   dailyDays,
+	}, nil
+}
+
+func (p *MetNorwayProvider) Provider() string {
+	return models.ForecastProviderMetNorway
+}
+
+func (p *MetNorwayProvider) Model() string {
+	return models.ForecastModelMetNorway
+}
+
+func (p *MetNorwayProvider) Fetch(ctx context.Context) ([]models.ForecastData, error) {
+	resp, err := p.client.GetForecast(ctx, p.location.Latitude, p.location.Longitude)
+	if err != nil {
+		return nil, err
+	}
+
+	result := convertMetNorway(resp, p.tz, p.hourlyHours, p.dailyDays, time.Now())
+	if len(result) == 0 {
+		return nil, fmt.Errorf("empty forecast from MET Norway")
+	}
+	for i := range result {
+		result[i].Provider = p.Provider()
+		result[i].Model = p.Model()
+	}
+	return result, nil
+}
+
+// metNorwayDay накапливает шаги прогноза одного локального дня
+type metNorwayDay struct {
+	date      time.Time
+	tempMin   float32
+	tempMax   float32
+	windMax   float32
+	precip    float32
+	code      int16
+	samples   int
+	hasPrecip bool
+}
+
+// convertMetNorway переводит ответ MET Norway в строки forecast_data. Почасовые строки
+// строятся по шагам с next_1_hours; дневные — агрегатом всех шагов локального дня
+// (дальше ~60 часов шаг 6 часов, поэтому экстремумы там грубее, чем у Open-Meteo).
+func convertMetNorway(resp *metno.Response, tz *time.Location, hourlyHours, dailyDays int, fetchedAt time.Time) []models.ForecastData {
+	result := make([]models.ForecastData, 0, hourlyHours+dailyDays)
+	days := make([]*metNorwayDay, 0, dailyDays+1)
+	byDate := make(map[string]*metNorwayDay)
+
+	var precipCoveredUntil time.Time
+	hourly := 0
+
+	for _, step := range resp.Properties.Timeseries {
+		details := step.Data.Instant.Details
+		if details.AirTemperature == nil {
+			continue
+		}
+		local := wallClockUTC(step.Time, tz)
+		temp := float32(*details.AirTemperature)
+
+		var code int16 = -1
+		var precip *float32
+		switch {
+		case step.Data.Next1Hours != nil:
+			code = metno.SymbolToWMO(step.Data.Next1Hours.Summary.SymbolCode)
+			precip = float64PtrTo32(step.Data.Next1Hours.Details.PrecipitationAmount)
+		case step.Data.Next6Hours != nil:
+			code = metno.SymbolToWMO(step.Data.Next6Hours.Summary.SymbolCode)
+		}
+
+		if step.Data.Next1Hours != nil && hourly < hourlyHours {
+			data := models.ForecastData{
+				ForecastTime:  local,
+				Temperature:   &temp,
+				Precipitation: precip,
+				WindSpeed:     float64PtrTo32(details.WindSpeed),
+				WindDirection: float64PtrToInt16(details.WindFromDirection),
+				CloudCover:    float64PtrToInt16(details.CloudAreaFraction),
+				Pressure:      float64PtrTo32(details.AirPressureAtSeaLevel),
+				Humidity:      float64PtrToInt16(details.RelativeHumidity),
+				ForecastType:  "hourly",
+				FetchedAt:     fetchedAt,
+			}
+			if code >= 0 {
+				data.WeatherCode = &code
+				data.WeatherDescription = weatherDescriptionFor(&code)
+			}
+			result = append(result, data)
+			hourly++
+		}
+
+		// Дневной агрегат
+		key := local.Format("2006-01-02")
+		day, ok := byDate[key]
+		if !ok {
+			if len(days) >= dailyDays {
+				continue
+			}
+			day = &metNorwayDay{
+				date:    time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC),
+				tempMin: temp,
+				tempMax: temp,
+				code:    -1,
+			}
+			byDate[key] = day
+			days = append(days, day)
+		}
+		day.samples++
+		day.tempMin = min(day.tempMin, temp)
+		day.tempMax = max(day.tempMax, temp)
+		if details.WindSpeed != nil {
+			day.windMax = max(day.windMax, float32(*details.WindSpeed))
+		}
+		if code > day.code {
+			day.code = code
+		}
+
+		// Осадки: часовые суммы, а где их нет — 6-часовые, без двойного учёта
+		switch {
+		case step.Data.Next1Hours != nil && precip != nil:
+			day.precip += *precip
+			day.hasPrecip = true
+			precipCoveredUntil = step.Time.Add(time.Hour)
+		case step.Data.Next6Hours != nil && !step.Time.Before(precipCoveredUntil):
+			if amount := step.Data.Next6Hours.Details.PrecipitationAmount; amount != nil {
+				day.precip += float32(*amount)
+				day.hasPrecip = true
+			}
+			precipCoveredUntil = step.Time.Add(6 * time.Hour)
+		}
+	}
+
+	for _, day := range days {
+		// Меньше 4 шагов — день покрыт частично, экстремумы будут недостоверны
+		if day.samples < 4 {
+			continue
+		}
+		tempMin, tempMax, windMax := day.tempMin, day.tempMax, day.windMax
+		data := models.ForecastData{
+			ForecastTime:   day.date,
+			TemperatureMin: &tempMin,
+			TemperatureMax: &tempMax,
+			WindSpeed:      &windMax,
+			ForecastType:   "daily",
+			FetchedAt:      fetchedAt,
+		}
+		if day.hasPrecip {
+			precip := day.precip
+			data.Precipitation = &precip
+		}
+		if day.code >= 0 {
+			code := day.code
+			data.WeatherCode = &code
+			data.WeatherDescription = weatherDescriptionFor(&code)
+		}
+		result = append(result, data)
+	}
+
+	return result
+}
+
+// wallClockUTC переводит момент времени в локальное время станции, помеченное как UTC,
+// — так хранятся строки forecast_data от Open-Meteo.
+func wallClockUTC(t time.Time, tz *time.Location) time.Time {
+	local := t.In(tz)
+	return time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), local.Minute(), 0, 0, time.UTC)
+}
+
+func weatherDescriptionFor(code *int16) *string {
+	if code == nil {
+		return nil
+	}
+	description := models.GetWeatherDescription(*code)
+	return &description
+}
+
+func float32At(values []*float64, i int) *float32 {
+	if i >= len(values) {
+		return nil
+	}
+	return float64PtrTo32(values[i])
+}
+
+func int16At(values []*int, i int) *int16 {
+	if i >= len(values) || values[i] == nil {
+		return nil
+	}
+	v := int16(*values[i])
+	return &v
+}
+
+func float64PtrTo32(value *float64) *float32 {
+	if value == nil {
+		return nil
+	}
+	v := float32(*value)
+	return &v
+}
+
+func float64PtrToInt16(value *float64) *int16 {
+	if value == nil {
+		return nil
+	}
+	v := int16(math.Round(*value))
+	return &v
+}
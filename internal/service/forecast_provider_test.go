@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+	"github.com/iRootPro/weather/pkg/metno"
+	"github.com/iRootPro/weather/pkg/openmeteo"
+)
+
+// Ответ Open-Meteo с models=ecmwf_ifs025: у модели нет вероятности осадков — null
+const openMeteoECMWFJSON = `{
+  "latitude": 45.0, "longitude": 41.125, "timezone": "Europe/Moscow",
+  "hourly": {
+    "time": ["2026-04-13T00:00", "2026-04-13T01:00", "2026-04-13T02:00"],
+    "temperature_2m": [8.1, 7.4, null],
+    "apparent_temperature": [6.0, 5.2, null],
+    "precipitation_probability": [null, null, null],
+    "precipitation": [0.0, 0.4, null],
+    "wind_speed_10m": [2.5, 3.1, null],
+    "wind_direction_10m": [200, 210, null],
+    "wind_gusts_10m": [5.0, 6.2, null],
+    "cloud_cover": [90, 100, null],
+    "pressure_msl": [1011.2, 1010.8, null],
+    "relative_humidity_2m": [81, 86, null],
+    "uv_index": [0, 0, null],
+    "weather_code": [3, 61, null]
+  },
+  "daily": {
+    "time": ["2026-04-13"],
+    "temperature_2m_min": [5.2],
+    "temperature_2m_max": [15.8],
+    "precipitation_probability_max": [null],
+    "precipitation_sum": [3.4],
+    "wind_speed_10m_max": [6.1],
+    "wind_direction_10m_dominant": [215],
+    "wind_gusts_10m_max": [12.4],
+    "uv_index_max": [4.1],
+    "weather_code": [63]
+  }
+}`
+
+func TestOpenMeteoProviderFetch(t *testing.T) {
+	var gotModel, gotUnit string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotModel = r.URL.Query().Get("models")
+		gotUnit = r.URL.Query().Get("wind_speed_unit")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(openMeteoECMWFJSON))
+	}))
+	defer srv.Close()
+
+	client := openmeteo.NewClientWithBaseURL(5*time.Second, srv.URL)
+	provider := NewOpenMeteoProvider(client, "ecmwf_ifs025", ForecastLocation{Latitude: 45, Longitude: 41.1, Timezone: "Europe/Moscow"}, 48, 7)
+
+	data, err := provider.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if gotModel != "ecmwf_ifs025" || gotUnit != "ms" {
+		t.Fatalf("параметры запроса: models=%q wind_speed_unit=%q", gotModel, gotUnit)
+	}
+
+	// Третий час без температуры пропускается
+	if len(data) != 3 {
+		t.Fatalf("строк = %d, want 3 (2 часа + 1 день)", len(data))
+	}
+	first := data[0]
+	if first.Provider != models.ForecastProviderOpenMeteo || first.Model != "ecmwf_ifs025" {
+		t.Fatalf("источник = %s/%s", first.Provider, first.Model)
+	}
+	if first.PrecipitationProbability != nil {
+		t.Fatalf("null вероятность осадков должна остаться nil, got %d", *first.PrecipitationProbability)
+	}
+	if first.ForecastTime != time.Date(2026, time.April, 13, 0, 0, 0, 0, time.UTC) {
+		t.Fatalf("forecast_time = %s", first.ForecastTime)
+	}
+	daily := data[2]
+	if daily.ForecastType != "daily" || daily.TemperatureMax == nil || *daily.TemperatureMax != 15.8 {
+		t.Fatalf("дневная строка разобрана неверно: %+v", daily)
+	}
+}
+
+const metNorwayJSON = `{
+  "properties": {
+    "meta": {"updated_at": "2026-04-13T08:00:00Z"},
+    "timeseries": [
+      {"time": "2026-04-13T09:00:00Z", "data": {"instant": {"details": {"air_temperature": 12.0, "relative_humidity": 60.4, "wind_speed": 3.0}},
+        "next_1_hours": {"summary": {"symbol_code": "cloudy"}, "details": {"precipitation_amount": 0.0}},
+        "next_6_hours": {"summary": {"symbol_code": "rain"}, "details": {"precipitation_amount": 4.0}}}},
+      {"time": "2026-04-13T10:00:00Z", "data": {"instant": {"details": {"air_temperature": 14.0, "wind_speed": 4.0}},
+        "next_1_hours": {"summary": {"symbol_code": "lightrain"}, "details": {"precipitation_amount": 1.5}},
+        "next_6_hours": {"summary": {"symbol_code": "rain"}, "details": {"precipitation_amount": 4.0}}}},
+      {"time": "2026-04-13T11:00:00Z", "data": {"instant": {"details": {"air_temperature": 15.0, "wind_speed": 5.0}},
+        "next_6_hours": {"summary": {"symbol_code": "rain"}, "details": {"precipitation_amount": 2.0}}}},
+      {"time": "2026-04-13T18:00:00Z", "data": {"instant": {"details": {"air_temperature": 9.0, "wind_speed": 2.0}},
+        "next_6_hours": {"summary": {"symbol_code": "partlycloudy_night"}, "details": {"precipitation_amount": 0.0}}}}
+    ]
+  }
+}`
+
+func TestMetNorwayProviderFetch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(metNorwayJSON))
+	}))
+	defer srv.Close()
+
+	client := metno.NewClient(5*time.Second, srv.URL, "test")
+	provider, err := NewMetNorwayProvider(client, ForecastLocation{Latitude: 45, Longitude: 41.1, Timezone: "Europe/Moscow"}, 48, 7)
+	if err != nil {
+		t.Fatalf("NewMetNorwayProvider: %v", err)
+	}
+
+	data, err := provider.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+
+	var hourly []models.ForecastData
+	var daily []models.ForecastData
+	for _, d := range data {
+		if d.Provider != models.ForecastProviderMetNorway {
+			t.Fatalf("provider = %q", d.Provider)
+		}
+		if d.ForecastType == "hourly" {
+			hourly = append(hourly, d)
+		} else {
+			daily = append(daily, d)
+		}
+	}
+
+	if len(hourly) != 2 {
+		t.Fatalf("почасовых строк = %d, want 2", len(hourly))
+	}
+	// 09:00Z → 12:00 по Москве, хранится как локальное время с пометкой UTC
+	if want := time.Date(2026, time.April, 13, 12, 0, 0, 0, time.UTC); !hourly[0].ForecastTime.Equal(want) {
+		t.Fatalf("время = %s, want %s", hourly[0].ForecastTime, want)
+	}
+	if hourly[0].Humidity == nil || *hourly[0].Humidity != 60 {
+		t.Fatalf("влажность = %v, want 60", hourly[0].Humidity)
+	}
+
+	if len(daily) != 1 {
+		t.Fatalf("дневных строк = %d, want 1", len(daily))
+	}
+	day := daily[0]
+	if *day.TemperatureMin != 9 || *day.TemperatureMax != 15 {
+		t.Fatalf("min/max = %.1f/%.1f, want 9/15", *day.TemperatureMin, *day.TemperatureMax)
+	}
+	// 0 + 1.5 по часам, затем 6-часовые 2.0 и 0.0 — 6-часовые суммы первых шагов не учитываются
+	if day.Precipitation == nil || math.Abs(float64(*day.Precipitation)-3.5) > 1e-6 {
+		t.Fatalf("осадки = %v, want 3.5", day.Precipitation)
+	}
+	if *day.WeatherCode != 63 {
+		t.Fatalf("код погоды = %d, want 63 (самый значимый за день)", *day.WeatherCode)
+	}
+}
+
+func TestBuildDailyEnsembles(t *testing.T) {
+	date := time.Date(2026, time.April, 14, 0, 0, 0, 0, time.UTC)
+	row := func(model string, tmin, tmax, precip float32) models.ForecastData {
+		return models.ForecastData{
+			ForecastTime:   date,
+			TemperatureMin: &tmin,
+			TemperatureMax: &tmax,
+			Precipitation:  &precip,
+			ForecastType:   "daily",
+			Provider:       models.ForecastProviderOpenMeteo,
+			Model:          model,
+		}
+	}
+
+	ensembles := buildDailyEnsembles([]models.ForecastData{
+		row("ecmwf_ifs025", 5, 15, 0),
+		row("icon_seamless", 6, 16, 0.2),
+		row("gfs_seamless", 4, 14, 8),
+	})
+
+	e := ensembles[date]
+	if e == nil {
+		t.Fatal("ансамбль не построен")
+	}
+	if len(e.Members) != 3 || e.RainyMembers != 1 {
+		t.Fatalf("members=%d rainy=%d, want 3/1", len(e.Members), e.RainyMembers)
+	}
+	if math.Abs(float64(e.TemperatureMax.Mean)-15) > 1e-6 {
+		t.Fatalf("среднее max = %.2f, want 15", e.TemperatureMax.Mean)
+	}
+	if math.Abs(float64(e.TemperatureMax.Spread)-math.Sqrt(2.0/3.0)) > 1e-5 {
+		t.Fatalf("разброс max = %.3f", e.TemperatureMax.Spread)
+	}
+	// Одна модель даёт ливень, остальные сухо — высокая неопределённость
+	if e.Uncertainty != models.ForecastUncertaintyHigh {
+		t.Fatalf("uncertainty = %s, want high", e.Uncertainty)
+	}
+
+	single := buildDailyEnsembles([]models.ForecastData{row("icon_seamless", 6, 16, 0.2)})
+	if len(single) != 0 {
+		t.Fatal("из одной модели ансамбль строиться не должен")
+	}
+}
+
+func TestBuildDailyEnsemblesSkipsBestMatch(t *testing.T) {
+	date := time.Date(2026, time.April, 14, 0, 0, 0, 0, time.UTC)
+	row := func(model string, tmin, tmax float32) models.ForecastData {
+		return models.ForecastData{ForecastTime: date, TemperatureMin: &tmin, TemperatureMax: &tmax, ForecastType: "daily", Provider: models.ForecastProviderOpenMeteo, Model: model}
+	}
+
+	// best_match — та же ICON, в среднем и разбросе она не должна считаться дважды
+	e := buildDailyEnsembles([]models.ForecastData{
+		row(models.ForecastModelBestMatch, 6, 16),
+		row("icon_seamless", 6, 16),
+		row("gfs_seamless", 4, 14),
+	})[date]
+	if e == nil || len(e.Members) != 2 {
+		t.Fatalf("ensemble = %+v, want icon_seamless and gfs_seamless only", e)
+	}
+	if e.TemperatureMax.Mean != 15 || e.TemperatureMax.Spread != 1 {
+		t.Fatalf("max = %+v, want mean 15 spread 1", e.TemperatureMax)
+	}
+}
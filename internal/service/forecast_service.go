@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"log/slog"
 	"time"
 
 	"github.com/iRootPro/weather/internal/models"
@@ -10,12 +11,18 @@ import (
 
 type ForecastService struct {
 	repo repository.ForecastRepository
+	bias *ForecastBiasCorrector
 }
 
 func NewForecastService(repo repository.ForecastRepository) *ForecastService {
 	return &ForecastService{repo: repo}
 }
 
+// SetBiasCorrector включает коррекцию прогноза под станцию (nil — выключить)
+func (s *ForecastService) SetBiasCorrector(bias *ForecastBiasCorrector) {
+	s.bias = bias
+}
+
 // GetTodayForecast возвращает почасовой прогноз на сегодня
 func (s *ForecastService) GetTodayForecast(ctx context.Context) ([]models.HourlyForecast, error) {
 	now := time.Now()
@@ -27,7 +34,9 @@ func (s *ForecastService) GetTodayForecast(ctx context.Context) ([]models.Hourly
 		return nil, err
 	}
 
-	return convertToHourlyForecast(data), nil
+	forecasts := convertToHourlyForecast(data)
+	s.correctHourly(ctx, forecasts)
+	return forecasts, nil
 }
 
 // GetHourlyForecast возвращает почасовой прогноз на N часов вперед
@@ -40,7 +49,14 @@ func (s *ForecastService) GetHourlyForecast(ctx context.Context, hours int) ([]m
 		return nil, err
 	}
 
-	return convertToHourlyForecast(data), nil
+	forecasts := convertToHourlyForecast(data)
+	s.correctHourly(ctx, forecasts)
+	// Разброс моделей — дополнительная информация: без него прогноз остаётся полезным
+	if err := s.attachHourlyEnsemble(ctx, forecasts); err != nil {
+		slog.Warn("failed to attach hourly forecast ensemble", "error", err)
+	}
+
+	return forecasts, nil
 }
 
 // GetDailyForecast возвращает дневной прогноз на N дней вперед
@@ -50,7 +66,43 @@ func (s *ForecastService) GetDailyForecast(ctx context.Context, days int) ([]mod
 		return nil, err
 	}
 
-	return convertToDailyForecast(data), nil
+	forecasts := convertToDailyForecast(data)
+	if err := s.attachDailyEnsemble(ctx, forecasts); err != nil {
+		slog.Warn("failed to attach daily forecast ensemble", "error", err)
+	}
+	if s.bias == nil || len(forecasts) == 0 {
+		return forecasts, nil
+	}
+
+	// Почасовой прогноз на те же дни — из него берутся скорректированные экстремумы
+	from := forecasts[0].Date
+	to := forecasts[len(forecasts)-1].Date.Add(24 * time.Hour)
+	hourlyData, err := s.repo.GetHourlyForecast(ctx, from, to)
+	if err != nil {
+		slog.Warn("failed to load hourly forecast for daily bias correction, using raw forecast", "error", err)
+		return forecasts, nil
+	}
+	hourly := convertToHourlyForecast(hourlyData)
+	if err := s.bias.CorrectHourly(ctx, hourly); err != nil {
+		slog.Warn("forecast bias correction failed, using raw forecast", "error", err)
+		return forecasts, nil
+	}
+	if err := s.bias.CorrectDaily(ctx, forecasts, hourly); err != nil {
+		slog.Warn("forecast bias correction failed, using raw forecast", "error", err)
+	}
+
+	return forecasts, nil
+}
+
+// correctHourly применяет поправки станции на месте. Поправки — уточнение, поэтому
+// ошибка таблицы пар или запроса не скрывает прогноз: остаются исходные значения.
+func (s *ForecastService) correctHourly(ctx context.Context, forecasts []models.HourlyForecast) {
+	if s.bias == nil {
+		return
+	}
+	if err := s.bias.CorrectHourly(ctx, forecasts); err != nil {
+		slog.Warn("forecast bias correction failed, using raw forecast", "error", err)
+	}
 }
 
 // GetCurrentConditions возвращает текущие условия из прогноза (первый час)
@@ -65,6 +117,7 @@ func (s *ForecastService) GetCurrentConditions(ctx context.Context) (*models.Hou
 	}
 
 	forecasts := convertToHourlyForecast(data)
+	s.correctHourly(ctx, forecasts)
 	return &forecasts[0], nil
 }
 
@@ -104,8 +157,20 @@ func convertToHourlyForecast(data []models.ForecastData) []models.HourlyForecast
 		if d.WeatherDescription != nil {
 			forecast.WeatherDescription = *d.WeatherDescription
 		}
+		if d.Humidity != nil {
+			forecast.Humidity = *d.Humidity
+		}
+		if d.CloudCover != nil {
+			forecast.CloudCover = *d.CloudCover
+		}
+		if d.UVIndex != nil {
+			forecast.UVIndex = *d.UVIndex
+		}
 
 		forecast.Icon = models.GetWeatherIcon(forecast.WeatherCode)
+		forecast.RawTemperature = forecast.Temperature
+		forecast.RawHumidity = forecast.Humidity
+		forecast.RawWindSpeed = forecast.WindSpeed
 
 		result = append(result, forecast)
 	}
@@ -145,6 +210,9 @@ func convertToDailyForecast(data []models.ForecastData) []models.DailyForecast {
 		}
 
 		forecast.Icon = models.GetWeatherIcon(forecast.WeatherCode)
+		forecast.RawTemperatureMin = forecast.TemperatureMin
+		forecast.RawTemperatureMax = forecast.TemperatureMax
+		forecast.RawWindSpeedMax = forecast.WindSpeedMax
 
 		result = append(result, forecast)
 	}
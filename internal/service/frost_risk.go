@@ -0,0 +1,265 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+// Параметры оценки заморозков и гололёда
+const (
+	FROST_NIGHT_START_HOUR  = 18  // начало оцениваемой ночи, местное время
+	FROST_NIGHT_END_HOUR    = 9   // конец оцениваемой ночи (утро следующего дня)
+	FROST_ALERT_FROM_HOUR   = 18  // вечернее предупреждение отправляется с 18:00
+	FROST_ALERT_TO_HOUR     = 22  // и до 22:00, один раз за вечер
+	FROST_RADIATIVE_COOLING = 3.0 // °C — на сколько поверхность холоднее воздуха на 2 м в ясную тихую ночь
+	FROST_RAIN_LOOKBACK     = 12  // часов — осадки на станции, после которых дороги считаются мокрыми
+	ICE_WET_RAIN_MM         = 0.2 // мм — столько осадков за FROST_RAIN_LOOKBACK часов делает дороги мокрыми
+	ICE_WET_FORECAST_MM     = 0.1 // мм — осадки ночью по прогнозу
+)
+
+// FrostRiskService оценивает риск ночного заморозка и гололёда по прогнозу на ночь
+// (минимум, облачность, ветер, осадки) и данным станции (точка росы, недавний дождь).
+type FrostRiskService struct {
+	weatherSvc  *WeatherService
+	forecastSvc *ForecastService
+}
+
+func NewFrostRiskService(weatherSvc *WeatherService, forecastSvc *ForecastService) *FrostRiskService {
+	return &FrostRiskService{weatherSvc: weatherSvc, forecastSvc: forecastSvc}
+}
+
+// GetFrostRisk возвращает оценку на ближайшую ночь. nil — прогноза на ночь нет.
+func (s *FrostRiskService) GetFrostRisk(ctx context.Context) (*models.FrostRisk, error) {
+	now := time.Now()
+
+	data, err := s.weatherSvc.repo.GetDataForEventDetection(ctx, now.Add(-FROST_RAIN_LOOKBACK*time.Hour), now)
+	if err != nil {
+		return nil, err
+	}
+
+	// Прогноз хранится в локальном времени станции — берём с запасом и фильтруем по местным часам
+	hourly, err := s.forecastSvc.GetHourlyForecast(ctx, 30)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := frostNightWindow(now, s.weatherSvc.location)
+	night := make([]models.HourlyForecast, 0, 16)
+	for _, h := range hourly {
+		t := h.Time.UTC()
+		if !t.Before(start) && !t.After(end) {
+			night = append(night, h)
+		}
+	}
+	if len(night) == 0 {
+		return nil, nil
+	}
+
+	var dewPoint *float32
+	for i := len(data) - 1; i >= 0; i-- {
+		if data[i].DewPoint != nil {
+			dewPoint = data[i].DewPoint
+			break
+		}
+	}
+
+	risk := assessFrostRisk(night, dewPoint, stationRainAmount(data))
+	risk.NightStart = start
+	risk.NightEnd = end
+	return &risk, nil
+}
+
+// GetFrostRiskEvents возвращает вечерние предупреждения frost_risk и ice_risk
+// (уровень не ниже умеренного). Вне вечернего окна — пустой список.
+func (s *FrostRiskService) GetFrostRiskEvents(ctx context.Context) ([]models.WeatherEvent, error) {
+	loc := s.weatherSvc.location
+	if loc == nil {
+		loc = time.Local
+	}
+	now := time.Now()
+	if hour := now.In(loc).Hour(); hour < FROST_ALERT_FROM_HOUR || hour >= FROST_ALERT_TO_HOUR {
+		return nil, nil
+	}
+
+	risk, err := s.GetFrostRisk(ctx)
+	if err != nil || risk == nil {
+		return nil, err
+	}
+	return frostRiskEvents(risk, now), nil
+}
+
+func frostRiskEvents(risk *models.FrostRisk, now time.Time) []models.WeatherEvent {
+	var events []models.WeatherEvent
+	if risk.Frost.Rank() >= models.RiskMedium.Rank() {
+		events = append(events, models.WeatherEvent{
+			Type:        "frost_risk",
+			Time:        now,
+			Value:       float64(risk.MinTemperature),
+			Description: "Ночью заморозки — риск " + risk.Frost.Label(),
+			Details:     "• " + strings.Join(risk.FrostReasons, "\n• ") + "\nУкройте рассаду и теплолюбивые растения",
+			Icon:        "❄️",
+		})
+	}
+	if risk.Ice.Rank() >= models.RiskMedium.Rank() {
+		events = append(events, models.WeatherEvent{
+			Type:        "ice_risk",
+			Time:        now,
+			Value:       float64(risk.SurfaceMin),
+			Description: "Гололёд на дорогах — риск " + risk.Ice.Label(),
+			Details:     "• " + strings.Join(risk.IceReasons, "\n• ") + "\nУтром будьте осторожны за рулём и на тротуарах",
+			Icon:        "🧊",
+		})
+	}
+	return events
+}
+
+// frostNightWindow возвращает ближайшую ночь в локальном времени с пометкой UTC.
+// До 09:00 — текущая ночь, позже — предстоящая.
+func frostNightWindow(now time.Time, loc *time.Location) (time.Time, time.Time) {
+	if loc == nil {
+		loc = time.Local
+	}
+	local := wallClockUTC(now, loc)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+	current := local.Truncate(time.Hour)
+
+	if local.Hour() < FROST_NIGHT_END_HOUR {
+		return current, day.Add(FROST_NIGHT_END_HOUR * time.Hour)
+	}
+	start := day.Add(FROST_NIGHT_START_HOUR * time.Hour)
+	if current.After(start) {
+		start = current
+	}
+	return start, day.Add((24 + FROST_NIGHT_END_HOUR) * time.Hour)
+}
+
+// assessFrostRisk оценивает риски по почасовому прогнозу на ночь, текущей точке росы
+// и осадкам на станции. В ясную тихую ночь поверхность выхолаживается сильнее воздуха
+// на 2 м, поэтому заморозок на почве возможен и при плюсовом прогнозе.
+func assessFrostRisk(night []models.HourlyForecast, dewPoint *float32, recentRain float32) models.FrostRisk {
+	risk := models.FrostRisk{
+		Frost:      models.RiskNone,
+		Ice:        models.RiskNone,
+		DewPoint:   dewPoint,
+		RecentRain: recentRain,
+	}
+	if len(night) == 0 {
+		return risk
+	}
+
+	minHour := night[0]
+	var cloudSum, windSum float64
+	for _, h := range night {
+		if h.Temperature < minHour.Temperature {
+			minHour = h
+		}
+		cloudSum += float64(h.CloudCover)
+		windSum += float64(h.WindSpeed)
+		risk.NightPrecipitation += h.Precipitation
+	}
+	risk.MinTemperature = minHour.Temperature
+	risk.MinTemperatureTime = minHour.Time
+	risk.CloudCover = int16(math.Round(cloudSum / float64(len(night))))
+	risk.WindSpeed = float32(windSum / float64(len(night)))
+
+	// Выхолаживание: максимально при ясном небе и штиле
+	clearSky := 1 - float64(risk.CloudCover)/100
+	calm := clamp01((4 - float64(risk.WindSpeed)) / 3)
+	cooling := FROST_RADIATIVE_COOLING * clearSky * calm
+
+	var airNote string
+	if dewPoint != nil {
+		switch {
+		case *dewPoint <= risk.MinTemperature-5:
+			cooling += 1
+			airNote = fmt.Sprintf("сухой воздух (точка росы %+.0f°) — остывание сильнее", *dewPoint)
+		case *dewPoint >= risk.MinTemperature:
+			cooling /= 2
+			airNote = fmt.Sprintf("влажный воздух (точка росы %+.0f°) сдерживает остывание", *dewPoint)
+		}
+	}
+	risk.SurfaceMin = risk.MinTemperature - float32(cooling)
+
+	minText := fmt.Sprintf("минимум ночью %+.0f° около %s", risk.MinTemperature, minHour.Time.UTC().Format("15:04"))
+	var skyNote string
+	switch {
+	case clearSky*calm >= 0.5:
+		skyNote = fmt.Sprintf("ясно (облачность %d%%) и тихо (%.1f м/с) — земля остынет сильнее воздуха", risk.CloudCover, risk.WindSpeed)
+	case risk.CloudCover >= 80:
+		skyNote = fmt.Sprintf("облачность %d%% удерживает тепло", risk.CloudCover)
+	case risk.WindSpeed >= 4:
+		skyNote = fmt.Sprintf("ветер %.1f м/с перемешивает воздух", risk.WindSpeed)
+	}
+	surfaceText := fmt.Sprintf("у поверхности до %+.0f°", risk.SurfaceMin)
+
+	// Заморозок
+	switch {
+	case risk.MinTemperature <= 0 || risk.SurfaceMin <= -2:
+		risk.Frost = models.RiskHigh
+	case risk.SurfaceMin <= 0:
+		risk.Frost = models.RiskMedium
+	case risk.SurfaceMin <= 2:
+		risk.Frost = models.RiskLow
+	}
+	if risk.Frost != models.RiskNone {
+		risk.FrostReasons = appendNonEmpty(nil, minText, surfaceText, skyNote, airNote)
+	}
+
+	// Гололёд: нужна вода на дорогах и переход поверхности через ноль
+	rained := recentRain >= ICE_WET_RAIN_MM
+	precipAhead := risk.NightPrecipitation >= ICE_WET_FORECAST_MM
+	saturated := dewPoint != nil && *dewPoint >= risk.MinTemperature-1
+	switch {
+	case (rained || precipAhead) && risk.SurfaceMin <= -1:
+		risk.Ice = models.RiskHigh
+	case (rained || precipAhead) && risk.SurfaceMin <= 1:
+		risk.Ice = models.RiskMedium
+	case saturated && risk.SurfaceMin <= 0:
+		risk.Ice = models.RiskLow
+	}
+	if risk.Ice != models.RiskNone {
+		var rainNote, precipNote, fogNote string
+		if rained {
+			rainNote = fmt.Sprintf("на станции выпало %.1f мм за %d ч — дороги мокрые", recentRain, FROST_RAIN_LOOKBACK)
+		}
+		if precipAhead {
+			precipNote = fmt.Sprintf("ночью в прогнозе осадки %.1f мм", risk.NightPrecipitation)
+		}
+		if saturated {
+			fogNote = "воздух близок к насыщению — возможны изморозь и туман"
+		}
+		risk.IceReasons = appendNonEmpty(nil, fmt.Sprintf("дороги до %+.0f°", risk.SurfaceMin), rainNote, precipNote, fogNote, minText)
+	}
+
+	return risk
+}
+
+// stationRainAmount считает осадки по интенсивности дождя в 5-минутных данных
+func stationRainAmount(data []models.WeatherData) float32 {
+	var total float64
+	for i := 0; i+1 < len(data); i++ {
+		if data[i].RainRate == nil || *data[i].RainRate <= 0 {
+			continue
+		}
+		step := data[i+1].Time.Sub(data[i].Time)
+		if step > 10*time.Minute {
+			step = 10 * time.Minute
+		}
+		total += float64(*data[i].RainRate) * step.Hours()
+	}
+	return float32(total)
+}
+
+func appendNonEmpty(list []string, values ...string) []string {
+	for _, v := range values {
+		if v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
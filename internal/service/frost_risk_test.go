@@ -0,0 +1,98 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+func frostNight(temps []float32, cloud int16, wind, precip float32) []models.HourlyForecast {
+	start := time.Date(2026, time.April, 20, 21, 0, 0, 0, time.UTC)
+	night := make([]models.HourlyForecast, 0, len(temps))
+	for i, temp := range temps {
+		night = append(night, models.HourlyForecast{
+			Time:          start.Add(time.Duration(i) * time.Hour),
+			Temperature:   temp,
+			CloudCover:    cloud,
+			WindSpeed:     wind,
+			Precipitation: precip,
+		})
+	}
+	return night
+}
+
+func TestAssessFrostRisk_ClearCalmNight(t *testing.T) {
+	// Воздух +2°, но ясно и тихо — у поверхности заморозок
+	dew := float32(-4)
+	risk := assessFrostRisk(frostNight([]float32{6, 4, 3, 2, 2.5}, 5, 0.5, 0), &dew, 0)
+
+	if risk.MinTemperature != 2 {
+		t.Fatalf("минимум = %.1f, want 2", risk.MinTemperature)
+	}
+	if risk.Frost != models.RiskMedium || risk.SurfaceMin >= 0 {
+		t.Fatalf("frost = %s, want medium (поверхность %.1f°)", risk.Frost, risk.SurfaceMin)
+	}
+	if len(risk.FrostReasons) == 0 {
+		t.Fatal("нет пояснений к риску заморозка")
+	}
+	// Дороги сухие — гололёда нет
+	if risk.Ice != models.RiskNone {
+		t.Fatalf("ice = %s, want none без влаги", risk.Ice)
+	}
+}
+
+func TestAssessFrostRisk_CloudyWindyNight(t *testing.T) {
+	// Тот же минимум, но облачно и ветрено — выхолаживания нет
+	risk := assessFrostRisk(frostNight([]float32{6, 4, 3, 2, 2.5}, 95, 5, 0), nil, 0)
+
+	if risk.Frost != models.RiskLow {
+		t.Fatalf("frost = %s, want low (поверхность %.1f°)", risk.Frost, risk.SurfaceMin)
+	}
+}
+
+func TestAssessFrostRisk_IceAfterRain(t *testing.T) {
+	dew := float32(0.5)
+	risk := assessFrostRisk(frostNight([]float32{3, 1, 0, -1, -1.5}, 20, 1, 0), &dew, 2.4)
+
+	if risk.Ice != models.RiskHigh {
+		t.Fatalf("ice = %s, want high после дождя и минуса", risk.Ice)
+	}
+
+	events := frostRiskEvents(&risk, time.Now())
+	if len(events) != 2 || events[0].Type != "frost_risk" || events[1].Type != "ice_risk" {
+		t.Fatalf("события = %+v", events)
+	}
+}
+
+func TestFrostNightWindow(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Skipf("нет данных часового пояса: %v", err)
+	}
+
+	// 16:30 по Москве — предстоящая ночь с 18:00 до 09:00
+	start, end := frostNightWindow(time.Date(2026, time.April, 20, 13, 30, 0, 0, time.UTC), loc)
+	if start != time.Date(2026, time.April, 20, 18, 0, 0, 0, time.UTC) || end != time.Date(2026, time.April, 21, 9, 0, 0, 0, time.UTC) {
+		t.Fatalf("окно = %s – %s", start, end)
+	}
+
+	// 03:10 по Москве — текущая ночь до 09:00
+	start, end = frostNightWindow(time.Date(2026, time.April, 21, 0, 10, 0, 0, time.UTC), loc)
+	if start != time.Date(2026, time.April, 21, 3, 0, 0, 0, time.UTC) || end != time.Date(2026, time.April, 21, 9, 0, 0, 0, time.UTC) {
+		t.Fatalf("окно = %s – %s", start, end)
+	}
+}
+
+func TestStationRainAmount(t *testing.T) {
+	base := time.Date(2026, time.April, 20, 12, 0, 0, 0, time.UTC)
+	rate := float32(6) // мм/ч
+	data := []models.WeatherData{
+		{Time: base, RainRate: &rate},
+		{Time: base.Add(5 * time.Minute), RainRate: &rate},
+		{Time: base.Add(10 * time.Minute)},
+	}
+	if got := stationRainAmount(data); got < 0.99 || got > 1.01 {
+		t.Fatalf("осадки = %.2f мм, want 1.0", got)
+	}
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+// Стратегии объединения прогноза Kp из нескольких источников
+const (
+	KpForecastAverage = "average" // среднее по всем источникам, где есть слот
+	KpForecastPrefer  = "prefer"  // первый источник из списка, остальные — запасные
+)
+
+// ValidKpForecastMerge проверяет название стратегии объединения прогноза
+func ValidKpForecastMerge(strategy string) bool {
+	return strategy == KpForecastAverage || strategy == KpForecastPrefer
+}
+
+// MergeKpSources объединяет слоты Kp нескольких источников (в порядке приоритета) в одну
+// серию, по записи на слот. Наблюдение берётся из источника с самым свежим наблюдённым
+// слотом — он раньше других получил обновление; при равенстве — из более приоритетного.
+// Наблюдение всегда важнее прогноза. Прогноз по стратегии усредняется (источник
+// записывается через «+») или берётся из первого источника, где он есть.
+func MergeKpSources(sources [][]models.GeomagneticKp, strategy string) []models.GeomagneticKp {
+	type candidate struct {
+		rank int
+		kp   models.GeomagneticKp
+	}
+
+	// Ранг источника для наблюдений: по убыванию последнего наблюдённого слота
+	latest := make([]time.Time, len(sources))
+	for i, src := range sources {
+		for _, k := range src {
+			if !k.IsForecast && k.SlotTime.After(latest[i]) {
+				latest[i] = k.SlotTime
+			}
+		}
+	}
+	order := make([]int, len(sources))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return latest[order[a]].After(latest[order[b]]) })
+	freshness := make([]int, len(sources))
+	for rank, i := range order {
+		freshness[i] = rank
+	}
+
+	slots := make(map[int64][]candidate)
+	for i, src := range sources {
+		for _, k := range src {
+			key := k.SlotTime.UTC().Unix()
+			slots[key] = append(slots[key], candidate{rank: i, kp: k})
+		}
+	}
+
+	merged := make([]models.GeomagneticKp, 0, len(slots))
+	for _, cands := range slots {
+		var observed *models.GeomagneticKp
+		bestRank := len(sources)
+		for _, c := range cands {
+			if !c.kp.IsForecast && freshness[c.rank] < bestRank {
+				kp := c.kp
+				observed, bestRank = &kp, freshness[c.rank]
+			}
+		}
+		if observed != nil {
+			merged = append(merged, *observed)
+			continue
+		}
+
+		sort.SliceStable(cands, func(a, b int) bool { return cands[a].rank < cands[b].rank })
+		if strategy == KpForecastPrefer || len(cands) == 1 {
+			merged = append(merged, cands[0].kp)
+			continue
+		}
+
+		slot := cands[0].kp
+		var sum float64
+		names := make([]string, 0, len(cands))
+		for _, c := range cands {
+			sum += float64(c.kp.Kp)
+			names = append(names, c.kp.Source)
+			if c.kp.FetchedAt.After(slot.FetchedAt) {
+				slot.FetchedAt = c.kp.FetchedAt
+			}
+		}
+		slot.Kp = float32(math.Round(sum/float64(len(cands))*100) / 100)
+		slot.Source = strings.Join(names, "+")
+		merged = append(merged, slot)
+	}
+
+	sort.Slice(merged, func(a, b int) bool { return merged[a].SlotTime.Before(merged[b].SlotTime) })
+	return merged
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+func kpSlot(t time.Time, kp float32, source string, forecast bool) models.GeomagneticKp {
+	return models.GeomagneticKp{SlotTime: t, Kp: kp, Source: source, IsForecast: forecast, FetchedAt: t}
+}
+
+func TestMergeKpSources(t *testing.T) {
+	base := time.Date(2026, time.April, 12, 0, 0, 0, 0, time.UTC)
+	slot := func(i int) time.Time { return base.Add(time.Duration(i*3) * time.Hour) }
+
+	// xras.ru отстаёт на слот, SWPC уже получил наблюдение за slot(1)
+	xras := []models.GeomagneticKp{
+		kpSlot(slot(0), 2, models.KpSourceXras, false),
+		kpSlot(slot(1), 3, models.KpSourceXras, true),
+		kpSlot(slot(2), 4, models.KpSourceXras, true),
+		kpSlot(slot(3), 5, models.KpSourceXras, true),
+	}
+	swpc := []models.GeomagneticKp{
+		kpSlot(slot(0), 2.33, models.KpSourceSWPC, false),
+		kpSlot(slot(1), 2.67, models.KpSourceSWPC, false),
+		kpSlot(slot(2), 5, models.KpSourceSWPC, true),
+		kpSlot(slot(4), 6, models.KpSourceSWPC, true),
+	}
+
+	tests := []struct {
+		name     string
+		sources  [][]models.GeomagneticKp
+		strategy string
+		want     []models.GeomagneticKp
+	}{
+		{
+			name:     "average",
+			sources:  [][]models.GeomagneticKp{xras, swpc},
+			strategy: KpForecastAverage,
+			want: []models.GeomagneticKp{
+				{SlotTime: slot(0), Kp: 2.33, Source: models.KpSourceSWPC},
+				{SlotTime: slot(1), Kp: 2.67, Source: models.KpSourceSWPC},
+				{SlotTime: slot(2), Kp: 4.5, Source: models.KpSourceXras + "+" + models.KpSourceSWPC, IsForecast: true},
+				{SlotTime: slot(3), Kp: 5, Source: models.KpSourceXras, IsForecast: true},
+				{SlotTime: slot(4), Kp: 6, Source: models.KpSourceSWPC, IsForecast: true},
+			},
+		},
+		{
+			name:     "prefer",
+			sources:  [][]models.GeomagneticKp{xras, swpc},
+			strategy: KpForecastPrefer,
+			want: []models.GeomagneticKp{
+				{SlotTime: slot(0), Kp: 2.33, Source: models.KpSourceSWPC},
+				{SlotTime: slot(1), Kp: 2.67, Source: models.KpSourceSWPC},
+				{SlotTime: slot(2), Kp: 4, Source: models.KpSourceXras, IsForecast: true},
+				{SlotTime: slot(3), Kp: 5, Source: models.KpSourceXras, IsForecast: true},
+				{SlotTime: slot(4), Kp: 6, Source: models.KpSourceSWPC, IsForecast: true},
+			},
+		},
+		{
+			name:     "equal freshness keeps priority",
+			sources:  [][]models.GeomagneticKp{xras[:1], swpc[:1]},
+			strategy: KpForecastAverage,
+			want:     []models.GeomagneticKp{{SlotTime: slot(0), Kp: 2, Source: models.KpSourceXras}},
+		},
+		{
+			name:     "single source fallback",
+			sources:  [][]models.GeomagneticKp{swpc},
+			strategy: KpForecastAverage,
+			want:     []models.GeomagneticKp{swpc[0], swpc[1], swpc[2], swpc[3]},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MergeKpSources(tt.sources, tt.strategy)
+			if len(got) != len(tt.want) {
+				t.Fatalf("MergeKpSources() returned %d slots, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i, w := range tt.want {
+				g := got[i]
+				if !g.SlotTime.Equal(w.SlotTime) || g.Kp != w.Kp || g.Source != w.Source || g.IsForecast != w.IsForecast {
+					t.Errorf("slot %d = {%s %v %s %v}, want {%s %v %s %v}", i,
+						g.SlotTime.Format(time.RFC3339), g.Kp, g.Source, g.IsForecast,
+						w.SlotTime.Format(time.RFC3339), w.Kp, w.Source, w.IsForecast)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,322 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+// Параметры отопительного сезона
+const (
+	HEATING_YEAR_START_MONTH = time.July // отопительный год считается с 1 июля — летом отопления нет
+	HEATING_DAYS_SHOWN       = 14        // суток наблюдений в таблице градусо-суток
+	HEATING_FORECAST_DAYS    = 7         // суток прогноза для предсказания смены режима
+	HEATING_ALERT_FROM_HOUR  = 9         // уведомление о выполнении критерия отправляется с 09:00
+	HEATING_ALERT_TO_HOUR    = 21        // и до 21:00
+)
+
+// HeatingSettings — базы градусо-суток и критерий отопительного сезона.
+// Температуры заданы указателями: nil — значение по умолчанию, а 0 °C — допустимая база.
+type HeatingSettings struct {
+	BaseTemp        *float64 // база градусо-суток отопления, °C (по умолчанию 18)
+	CoolingBaseTemp *float64 // база градусо-суток охлаждения, °C (по умолчанию 18)
+	ThresholdTemp   *float64 // порог среднесуточной температуры начала/окончания отопления, °C (по умолчанию 8)
+	ThresholdDays   int      // сколько суток подряд температура должна быть ниже (выше) порога
+}
+
+// heatingParams — настройки с подставленными значениями по умолчанию
+type heatingParams struct {
+	BaseTemp        float64
+	CoolingBaseTemp float64
+	ThresholdTemp   float64
+	ThresholdDays   int
+}
+
+// HeatingService считает градусо-сутки отопления и охлаждения и определяет начало
+// и окончание отопительного сезона по правилу «N суток подряд ниже (выше) +8 °C»
+// по наблюдениям станции, достраивая серию суточным прогнозом.
+type HeatingService struct {
+	weatherSvc  *WeatherService
+	forecastSvc *ForecastService
+	settings    heatingParams
+}
+
+func NewHeatingService(weatherSvc *WeatherService, forecastSvc *ForecastService, settings HeatingSettings) *HeatingService {
+	params := heatingParams{BaseTemp: 18, CoolingBaseTemp: 18, ThresholdTemp: 8, ThresholdDays: settings.ThresholdDays}
+	if settings.BaseTemp != nil {
+		params.BaseTemp = *settings.BaseTemp
+	}
+	if settings.CoolingBaseTemp != nil {
+		params.CoolingBaseTemp = *settings.CoolingBaseTemp
+	}
+	if settings.ThresholdTemp != nil {
+		params.ThresholdTemp = *settings.ThresholdTemp
+	}
+	if params.ThresholdDays <= 0 {
+		params.ThresholdDays = 5
+	}
+	return &HeatingService{weatherSvc: weatherSvc, forecastSvc: forecastSvc, settings: params}
+}
+
+// GetSummary возвращает градусо-сутки отопительного года и состояние сезона.
+// Наблюдения берутся по вчерашний день включительно, с сегодняшнего дня — прогноз.
+func (s *HeatingService) GetSummary(ctx context.Context) (*models.HeatingSummary, error) {
+	loc := s.weatherSvc.location
+	if loc == nil {
+		loc = time.Local
+	}
+	now := time.Now().In(loc)
+	today := dayStart(now, loc)
+	yearStart := heatingYearStart(today)
+
+	insights, err := s.weatherSvc.repo.GetDailyInsights(ctx, yearStart, today, s.weatherSvc.timezone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily insights: %w", err)
+	}
+
+	var forecast []models.DailyForecast
+	if s.forecastSvc != nil {
+		forecast, err = s.forecastSvc.GetDailyForecast(ctx, HEATING_FORECAST_DAYS+1)
+		if err != nil {
+			// Без прогноза пропадает только предсказание смены режима, градусо-сутки остаются
+			slog.Warn("failed to get daily forecast for heating season", "error", err)
+			forecast = nil
+		}
+	}
+
+	summary := buildHeatingSummary(insights, forecast, s.settings, today, yearStart)
+	summary.GeneratedAt = time.Now()
+	return summary, nil
+}
+
+// GetHeatingEvents возвращает уведомление heating_start/heating_end, если критерий
+// выполнился на вчерашних сутках. Вне дневного окна — пустой список.
+func (s *HeatingService) GetHeatingEvents(ctx context.Context) ([]models.WeatherEvent, error) {
+	loc := s.weatherSvc.location
+	if loc == nil {
+		loc = time.Local
+	}
+	now := time.Now()
+	if hour := now.In(loc).Hour(); hour < HEATING_ALERT_FROM_HOUR || hour >= HEATING_ALERT_TO_HOUR {
+		return nil, nil
+	}
+
+	summary, err := s.GetSummary(ctx)
+	if err != nil {
+		return nil, err
+	}
+	event := heatingTransitionEvent(summary, dayStart(now, loc), now)
+	if event == nil {
+		return nil, nil
+	}
+	return []models.WeatherEvent{*event}, nil
+}
+
+func heatingYearStart(today time.Time) time.Time {
+	start := time.Date(today.Year(), HEATING_YEAR_START_MONTH, 1, 0, 0, 0, 0, today.Location())
+	if today.Before(start) {
+		start = start.AddDate(-1, 0, 0)
+	}
+	return start
+}
+
+func buildHeatingSummary(insights []models.DailyWeatherInsight, forecast []models.DailyForecast, settings heatingParams, today, yearStart time.Time) *models.HeatingSummary {
+	loc := today.Location()
+	summary := &models.HeatingSummary{
+		BaseTemp:        settings.BaseTemp,
+		CoolingBaseTemp: settings.CoolingBaseTemp,
+		ThresholdTemp:   settings.ThresholdTemp,
+		ThresholdDays:   settings.ThresholdDays,
+		Days:            []models.DegreeDay{},
+		Months:          []models.DegreeDaysTotal{},
+	}
+
+	var observed []models.DegreeDay
+	for _, in := range insights {
+		if in.TempAvg == nil {
+			continue
+		}
+		// Дата из SQL — полночь в часовом поясе станции, помеченная как UTC
+		date := time.Date(in.Date.Year(), in.Date.Month(), in.Date.Day(), 0, 0, 0, 0, loc)
+		if date.Before(yearStart) || !date.Before(today) {
+			continue
+		}
+		observed = append(observed, newDegreeDay(date, float64(*in.TempAvg), settings, false))
+	}
+
+	var predicted []models.DegreeDay
+	for _, f := range forecast {
+		date := time.Date(f.Date.Year(), f.Date.Month(), f.Date.Day(), 0, 0, 0, 0, loc)
+		if date.Before(today) {
+			continue
+		}
+		// Среднесуточная по прогнозу — полусумма экстремумов
+		predicted = append(predicted, newDegreeDay(date, float64(f.TemperatureMin+f.TemperatureMax)/2, settings, true))
+	}
+
+	// Отопительный год начинается летом — стартуем без отопления
+	on, transitions, streak := detectHeatingTransitions(observed, false, settings)
+	summary.HeatingOn = on
+	summary.Streak = streak
+	if len(transitions) > 0 {
+		last := transitions[len(transitions)-1]
+		summary.Last = &last
+	}
+	_, all, _ := detectHeatingTransitions(append(append([]models.DegreeDay{}, observed...), predicted...), false, settings)
+	for _, t := range all {
+		if t.Forecast {
+			next := t
+			summary.Next = &next
+			break
+		}
+	}
+	summary.StatusText = heatingStatusText(summary)
+
+	shownFrom := today.AddDate(0, 0, -HEATING_DAYS_SHOWN)
+	for _, d := range observed {
+		if !d.Date.Before(shownFrom) {
+			summary.Days = append(summary.Days, d)
+		}
+	}
+	summary.Days = append(summary.Days, predicted...)
+
+	summary.Season = degreeDaysTotal(fmt.Sprintf("Отопительный год %d/%02d", yearStart.Year(), (yearStart.Year()+1)%100), yearStart, today, observed)
+	for month := yearStart; month.Before(today); month = month.AddDate(0, 1, 0) {
+		total := degreeDaysTotal(russianMonthYear(month), month, minTime(month.AddDate(0, 1, 0), today), observed)
+		if total.Days > 0 {
+			summary.Months = append(summary.Months, total)
+		}
+	}
+	return summary
+}
+
+func newDegreeDay(date time.Time, tempAvg float64, settings heatingParams, forecast bool) models.DegreeDay {
+	return models.DegreeDay{
+		Date:     date,
+		TempAvg:  roundTo(tempAvg, 1),
+		HDD:      roundTo(math.Max(0, settings.BaseTemp-tempAvg), 1),
+		CDD:      roundTo(math.Max(0, tempAvg-settings.CoolingBaseTemp), 1),
+		Forecast: forecast,
+	}
+}
+
+// detectHeatingTransitions проходит сутки по порядку и фиксирует смену режима, когда
+// ThresholdDays суток подряд средняя ниже порога (начало) или выше порога (окончание).
+// Пропуск суток в данных прерывает серию. Возвращает режим после последних суток
+// и длину незавершённой серии к следующей смене.
+func detectHeatingTransitions(days []models.DegreeDay, on bool, settings heatingParams) (bool, []models.HeatingTransition, int) {
+	var transitions []models.HeatingTransition
+	run := 0
+	var runFrom, prev time.Time
+	for _, d := range days {
+		if run > 0 && !d.Date.Equal(prev.AddDate(0, 0, 1)) {
+			run = 0
+		}
+		prev = d.Date
+
+		meets := (!on && d.TempAvg < settings.ThresholdTemp) || (on && d.TempAvg > settings.ThresholdTemp)
+		if !meets {
+			run = 0
+			continue
+		}
+		if run == 0 {
+			runFrom = d.Date
+		}
+		run++
+		if run < settings.ThresholdDays {
+			continue
+		}
+
+		kind := models.HeatingTransitionStart
+		if on {
+			kind = models.HeatingTransitionEnd
+		}
+		transitions = append(transitions, models.HeatingTransition{
+			Kind:     kind,
+			RunFrom:  runFrom,
+			MetOn:    d.Date,
+			Date:     d.Date.AddDate(0, 0, 1),
+			Forecast: d.Forecast,
+		})
+		on = !on
+		run = 0
+	}
+	return on, transitions, run
+}
+
+func heatingStatusText(s *models.HeatingSummary) string {
+	var text string
+	switch {
+	case s.HeatingOn && s.Last != nil:
+		text = fmt.Sprintf("Отопительный сезон идёт с %s", formatInsightDate(s.Last.Date))
+	case s.HeatingOn:
+		text = "Отопительный сезон идёт"
+	case s.Last != nil:
+		text = fmt.Sprintf("Отопительный сезон завершён %s", formatInsightDate(s.Last.Date))
+	default:
+		text = "Отопление пока не требуется"
+	}
+
+	if s.Next != nil {
+		if s.Next.Kind == models.HeatingTransitionStart {
+			return text + fmt.Sprintf(". По прогнозу критерий начала выполнится %s — отопление с %s", formatInsightDate(s.Next.MetOn), formatInsightDate(s.Next.Date))
+		}
+		return text + fmt.Sprintf(". По прогнозу критерий окончания выполнится %s — отопление можно отключать с %s", formatInsightDate(s.Next.MetOn), formatInsightDate(s.Next.Date))
+	}
+	if s.Streak > 0 {
+		side := "ниже"
+		if s.HeatingOn {
+			side = "выше"
+		}
+		return text + fmt.Sprintf(". %d из %d суток подряд %s %+.0f °C", s.Streak, s.ThresholdDays, side, s.ThresholdTemp)
+	}
+	return text
+}
+
+func degreeDaysTotal(label string, from, to time.Time, days []models.DegreeDay) models.DegreeDaysTotal {
+	total := models.DegreeDaysTotal{Label: label, From: from, To: to}
+	var tempSum float64
+	for _, d := range days {
+		if d.Date.Before(from) || !d.Date.Before(to) {
+			continue
+		}
+		total.Days++
+		total.HDD += d.HDD
+		total.CDD += d.CDD
+		tempSum += d.TempAvg
+	}
+	if total.Days > 0 {
+		total.TempAvg = roundTo(tempSum/float64(total.Days), 1)
+	}
+	total.HDD = roundTo(total.HDD, 1)
+	total.CDD = roundTo(total.CDD, 1)
+	return total
+}
+
+// heatingTransitionEvent сообщает о смене режима, критерий которой выполнился на вчерашних сутках.
+func heatingTransitionEvent(s *models.HeatingSummary, today, now time.Time) *models.WeatherEvent {
+	if s == nil || s.Last == nil || !s.Last.MetOn.Equal(today.AddDate(0, 0, -1)) {
+		return nil
+	}
+	event := &models.WeatherEvent{
+		Time:    now,
+		Value:   s.ThresholdTemp,
+		Details: fmt.Sprintf("%d суток подряд, с %s по %s", s.ThresholdDays, formatInsightDate(s.Last.RunFrom), formatInsightDate(s.Last.MetOn)),
+	}
+	if s.Last.Kind == models.HeatingTransitionStart {
+		event.Type = "heating_start"
+		event.Icon = "🔥"
+		event.Description = "Пора включать отопление"
+		event.Details = fmt.Sprintf("Среднесуточная температура ниже %+.0f °C %s", s.ThresholdTemp, event.Details)
+	} else {
+		event.Type = "heating_end"
+		event.Icon = "🌷"
+		event.Description = "Отопительный сезон можно завершать"
+		event.Details = fmt.Sprintf("Среднесуточная температура выше %+.0f °C %s", s.ThresholdTemp, event.Details)
+	}
+	return event
+}
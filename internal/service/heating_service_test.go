@@ -0,0 +1,122 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+var testHeatingSettings = heatingParams{BaseTemp: 18, CoolingBaseTemp: 18, ThresholdTemp: 8, ThresholdDays: 5}
+
+func heatingInsights(from time.Time, temps ...float32) []models.DailyWeatherInsight {
+	days := make([]models.DailyWeatherInsight, 0, len(temps))
+	for i, temp := range temps {
+		date := from.AddDate(0, 0, i)
+		days = append(days, models.DailyWeatherInsight{
+			Date:    time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC),
+			TempAvg: f32(temp),
+		})
+	}
+	return days
+}
+
+func TestDetectHeatingTransitionsNeedsConsecutiveDays(t *testing.T) {
+	from := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+	var days []models.DegreeDay
+	for i, temp := range []float64{7, 6, 7, 9, 7, 6, 5, 7, 6, 10} {
+		days = append(days, newDegreeDay(from.AddDate(0, 0, i), temp, testHeatingSettings, false))
+	}
+
+	on, transitions, streak := detectHeatingTransitions(days, false, testHeatingSettings)
+
+	if !on || len(transitions) != 1 {
+		t.Fatalf("expected one start transition, got on=%v %#v", on, transitions)
+	}
+	start := transitions[0]
+	if start.Kind != models.HeatingTransitionStart || start.RunFrom.Day() != 5 || start.MetOn.Day() != 9 || start.Date.Day() != 10 {
+		t.Fatalf("unexpected transition: %#v", start)
+	}
+	if streak != 1 {
+		t.Fatalf("warm day after start begins the end streak, got %d", streak)
+	}
+}
+
+func TestDetectHeatingTransitionsGapBreaksRun(t *testing.T) {
+	from := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+	var days []models.DegreeDay
+	for _, offset := range []int{0, 1, 2, 4, 5} {
+		days = append(days, newDegreeDay(from.AddDate(0, 0, offset), 5, testHeatingSettings, false))
+	}
+	if on, transitions, streak := detectHeatingTransitions(days, false, testHeatingSettings); on || len(transitions) != 0 || streak != 2 {
+		t.Fatalf("missing day must break the run: on=%v %#v streak=%d", on, transitions, streak)
+	}
+}
+
+func TestBuildHeatingSummaryPredictsStartFromForecast(t *testing.T) {
+	today := time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+	yearStart := heatingYearStart(today)
+	insights := heatingInsights(time.Date(2026, time.October, 13, 0, 0, 0, 0, time.UTC), 12, 11, 9, 7.5, 6)
+	forecast := []models.DailyForecast{
+		{Date: today, TemperatureMin: 2, TemperatureMax: 10},
+		{Date: today.AddDate(0, 0, 1), TemperatureMin: 1, TemperatureMax: 9},
+		{Date: today.AddDate(0, 0, 2), TemperatureMin: 3, TemperatureMax: 9},
+	}
+
+	summary := buildHeatingSummary(insights, forecast, testHeatingSettings, today, yearStart)
+
+	if summary.HeatingOn || summary.Streak != 2 || summary.Last != nil {
+		t.Fatalf("heating must not be on yet: %#v", summary)
+	}
+	if summary.Next == nil || !summary.Next.Forecast || summary.Next.MetOn.Day() != 20 || summary.Next.Date.Day() != 21 {
+		t.Fatalf("unexpected predicted start: %#v", summary.Next)
+	}
+	if !strings.Contains(summary.StatusText, "отопление с 21 октября") {
+		t.Fatalf("unexpected status: %q", summary.StatusText)
+	}
+	if len(summary.Days) != 8 || !summary.Days[5].Forecast {
+		t.Fatalf("days must include observed and forecast days: %#v", summary.Days)
+	}
+	if len(summary.Months) != 1 || summary.Months[0].Label != "Октябрь 2026" || summary.Season.HDD != 6+7+9+10.5+12 {
+		t.Fatalf("unexpected totals: months %#v, season %#v", summary.Months, summary.Season)
+	}
+	if summary.Season.Label != "Отопительный год 2026/27" {
+		t.Fatalf("unexpected season label: %q", summary.Season.Label)
+	}
+}
+
+func TestHeatingTransitionEventOnlyForYesterday(t *testing.T) {
+	today := time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+	summary := &models.HeatingSummary{ThresholdTemp: 8, ThresholdDays: 5, HeatingOn: true, Last: &models.HeatingTransition{
+		Kind:    models.HeatingTransitionStart,
+		RunFrom: today.AddDate(0, 0, -5),
+		MetOn:   today.AddDate(0, 0, -1),
+		Date:    today,
+	}}
+
+	event := heatingTransitionEvent(summary, today, today.Add(10*time.Hour))
+	if event == nil || event.Type != "heating_start" || !strings.Contains(event.Details, "с 13 октября по 17 октября") {
+		t.Fatalf("unexpected event: %#v", event)
+	}
+	if event := heatingTransitionEvent(summary, today.AddDate(0, 0, 1), today.Add(34*time.Hour)); event != nil {
+		t.Fatalf("criterion met two days ago must not be announced again: %#v", event)
+	}
+}
+
+func TestHeatingYearStart(t *testing.T) {
+	if got := heatingYearStart(time.Date(2027, time.March, 3, 0, 0, 0, 0, time.UTC)); !got.Equal(time.Date(2026, time.July, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("spring belongs to the previous heating year, got %v", got)
+	}
+}
+
+func TestNewHeatingServiceKeepsExplicitZero(t *testing.T) {
+	zero := 0.0
+	svc := NewHeatingService(nil, nil, HeatingSettings{BaseTemp: &zero, ThresholdTemp: &zero})
+	if svc.settings.BaseTemp != 0 || svc.settings.ThresholdTemp != 0 {
+		t.Fatalf("явный 0 °C заменён: %+v", svc.settings)
+	}
+	if svc.settings.CoolingBaseTemp != 18 || svc.settings.ThresholdDays != 5 {
+		t.Fatalf("незаданные значения = %+v, want база охлаждения 18 и 5 суток", svc.settings)
+	}
+}
@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+// Пороги уведомлений об уровне воды
+const (
+	HYDRO_ALERT_DEFAULT_RISE_CM_PER_HOUR      = 5
+	HYDRO_ALERT_DEFAULT_UPSTREAM_RISE_CM      = 30
+	HYDRO_ALERT_DEFAULT_UPSTREAM_WINDOW_HOURS = 6
+	HYDRO_ALERT_DEFAULT_HYSTERESIS_CM         = 5
+	HYDRO_ALERT_HISTORY_HOURS                 = 48 // по этой истории восстанавливается состояние эпизодов
+	HYDRO_ALERT_FRESH_HOURS                   = 3  // о более старых событиях не сообщаем: данные поста устарели
+	HYDRO_ALERT_CHART_HOURS                   = 48
+)
+
+// HydroAlertSettings — пороги уведомлений и гистерезис
+type HydroAlertSettings struct {
+	RiseCmPerHour       float32 // быстрый подъём на основном посту
+	UpstreamRiseCm      float32 // подъём верхнего поста за окно
+	UpstreamWindowHours int
+	HysteresisCm        float32 // статус снижается, только когда уровень ушёл ниже порога на столько
+}
+
+// HydroAlertService находит эпизоды для уведомлений об уровне воды: смену статуса
+// основного поста, быстрый подъём и подъём на верхних постах. Состояние эпизодов
+// каждый раз восстанавливается по истории измерений, поэтому перезапуск бота не даёт
+// повторов, а гистерезис не даёт сообщениям дребезжать у порога: статус повышается
+// сразу, а снижается, только когда уровень ушёл ниже порога на HysteresisCm; эпизод
+// подъёма заканчивается, когда скорость или подъём опускаются ниже половины порога.
+type HydroAlertService struct {
+	hydroSvc *HydroService
+	settings HydroAlertSettings
+}
+
+func NewHydroAlertService(hydroSvc *HydroService, settings HydroAlertSettings) *HydroAlertService {
+	if settings.RiseCmPerHour <= 0 {
+		settings.RiseCmPerHour = HYDRO_ALERT_DEFAULT_RISE_CM_PER_HOUR
+	}
+	if settings.UpstreamRiseCm <= 0 {
+		settings.UpstreamRiseCm = HYDRO_ALERT_DEFAULT_UPSTREAM_RISE_CM
+	}
+	if settings.UpstreamWindowHours <= 0 {
+		settings.UpstreamWindowHours = HYDRO_ALERT_DEFAULT_UPSTREAM_WINDOW_HOURS
+	}
+	if settings.HysteresisCm <= 0 {
+		settings.HysteresisCm = HYDRO_ALERT_DEFAULT_HYSTERESIS_CM
+	}
+	return &HydroAlertService{hydroSvc: hydroSvc, settings: settings}
+}
+
+// GetAlerts возвращает активные эпизоды по основному и верхним постам
+func (s *HydroAlertService) GetAlerts(ctx context.Context, now time.Time) ([]models.HydroAlert, error) {
+	repo := s.hydroSvc.repo
+	from := now.Add(-HYDRO_ALERT_HISTORY_HOURS * time.Hour)
+	fresh := now.Add(-HYDRO_ALERT_FRESH_HOURS * time.Hour)
+
+	readings, err := repo.GetRange(ctx, s.hydroSvc.stationUUID, from, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hydro readings: %w", err)
+	}
+	gauge, err := repo.GetGauge(ctx, s.hydroSvc.stationUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hydro gauge: %w", err)
+	}
+	readings = dropHydroSpikes(readings)
+
+	var alerts []models.HydroAlert
+	name := s.hydroSvc.stationUUID
+	var prevention, danger *float32
+	if gauge != nil {
+		name = hydroGaugeName(gauge)
+		prevention, danger = gauge.FloodingPreventionBM, gauge.FloodingDangerBSM
+	}
+	if len(readings) > 0 && !readings[len(readings)-1].ObservedAt.Before(fresh) {
+		last := readings[len(readings)-1]
+		if change := lastHydroStatusChange(readings, prevention, danger, s.settings.HysteresisCm/100); change != nil && !readings[change.index].ObservedAt.Before(fresh) {
+			at := readings[change.index].ObservedAt
+			alerts = append(alerts, models.HydroAlert{
+				Kind:        models.HydroAlertStatus,
+				Key:         fmt.Sprintf("hydro_status_%s_%d", change.to, at.Unix()),
+				StationUUID: s.hydroSvc.stationUUID,
+				StationName: name,
+				StartedAt:   at,
+				ObservedAt:  last.ObservedAt,
+				LevelBSM:    last.LevelBSM,
+				From:        change.from,
+				To:          change.to,
+			})
+		}
+
+		rates := hydroRatesCmPerHour(readings)
+		if start := activeHydroEpisode(rates, float64(s.settings.RiseCmPerHour)); start >= 0 {
+			alerts = append(alerts, models.HydroAlert{
+				Kind:            models.HydroAlertRapidRise,
+				Key:             fmt.Sprintf("hydro_rise_%d", readings[start].ObservedAt.Unix()),
+				StationUUID:     s.hydroSvc.stationUUID,
+				StationName:     name,
+				StartedAt:       readings[start].ObservedAt,
+				ObservedAt:      last.ObservedAt,
+				LevelBSM:        last.LevelBSM,
+				ChangeCmPerHour: float32(roundTo(rates[len(rates)-1], 0)),
+				To:              models.ClassifyHydroLevel(last.LevelBSM, prevention, danger),
+			})
+		}
+	}
+
+	window := time.Duration(s.settings.UpstreamWindowHours) * time.Hour
+	for _, stationUUID := range s.hydroSvc.upstreamStationUUIDs {
+		upstream, err := repo.GetRange(ctx, stationUUID, from, now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get upstream hydro readings: %w", err)
+		}
+		upstream = dropHydroSpikes(upstream)
+		if len(upstream) == 0 || upstream[len(upstream)-1].ObservedAt.Before(fresh) {
+			continue
+		}
+		rises := hydroRisesCm(upstream, window)
+		start := activeHydroEpisode(rises, float64(s.settings.UpstreamRiseCm))
+		if start < 0 {
+			continue
+		}
+		upstreamGauge, err := repo.GetGauge(ctx, stationUUID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get upstream hydro gauge: %w", err)
+		}
+		upstreamName := stationUUID
+		if upstreamGauge != nil {
+			upstreamName = hydroGaugeName(upstreamGauge)
+		}
+		last := upstream[len(upstream)-1]
+		alerts = append(alerts, models.HydroAlert{
+			Kind:        models.HydroAlertUpstreamRise,
+			Key:         fmt.Sprintf("hydro_up_%.8s_%d", stationUUID, upstream[start].ObservedAt.Unix()),
+			StationUUID: stationUUID,
+			StationName: upstreamName,
+			StartedAt:   upstream[start].ObservedAt,
+			ObservedAt:  last.ObservedAt,
+			LevelBSM:    last.LevelBSM,
+			RiseCm:      float32(roundTo(rises[len(rises)-1], 0)),
+			WindowHours: s.settings.UpstreamWindowHours,
+		})
+	}
+	return alerts, nil
+}
+
+// ChartData возвращает измерения поста для графика к уведомлению
+func (s *HydroAlertService) ChartData(ctx context.Context, stationUUID string, now time.Time) ([]models.HydroLevelReading, *models.HydroGauge, error) {
+	readings, err := s.hydroSvc.repo.GetRange(ctx, stationUUID, now.Add(-HYDRO_ALERT_CHART_HOURS*time.Hour), now)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get hydro chart readings: %w", err)
+	}
+	gauge, err := s.hydroSvc.repo.GetGauge(ctx, stationUUID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get hydro gauge: %w", err)
+	}
+	return dropHydroSpikes(readings), gauge, nil
+}
+
+// hydroStatusChange — последняя смена статуса с учётом гистерезиса
+type hydroStatusChange struct {
+	index    int
+	from, to models.HydroStatus
+}
+
+// lastHydroStatusChange проходит измерения по порядку: статус повышается сразу,
+// а понижается, только когда и уровень плюс гистерезис даёт более низкий статус
+func lastHydroStatusChange(readings []models.HydroLevelReading, prevention, danger *float32, hysteresisM float32) *hydroStatusChange {
+	if len(readings) == 0 {
+		return nil
+	}
+	var change *hydroStatusChange
+	status := models.ClassifyHydroLevel(readings[0].LevelBSM, prevention, danger)
+	for i := 1; i < len(readings); i++ {
+		level := readings[i].LevelBSM
+		next := status
+		if raw := models.ClassifyHydroLevel(level, prevention, danger); hydroStatusRank(raw) > hydroStatusRank(status) {
+			next = raw
+		} else if lowered := models.ClassifyHydroLevel(level+hysteresisM, prevention, danger); hydroStatusRank(lowered) < hydroStatusRank(status) {
+			next = lowered
+		}
+		if next != status {
+			change = &hydroStatusChange{index: i, from: status, to: next}
+			status = next
+		}
+	}
+	return change
+}
+
+// activeHydroEpisode возвращает индекс начала эпизода, если он не закончился к последнему
+// измерению: эпизод начинается при значении не ниже порога и заканчивается ниже половины порога
+func activeHydroEpisode(values []float64, threshold float64) int {
+	start := -1
+	for i, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		switch {
+		case start < 0 && v >= threshold:
+			start = i
+		case start >= 0 && v < threshold/2:
+			start = -1
+		}
+	}
+	return start
+}
+
+// hydroRatesCmPerHour — скорость изменения уровня: значение источника, а без него —
+// по измерению не меньше часа назад
+func hydroRatesCmPerHour(readings []models.HydroLevelReading) []float64 {
+	rates := make([]float64, len(readings))
+	j := 0
+	for i, r := range readings {
+		rates[i] = math.NaN()
+		if r.ChangeCmPerHour != nil {
+			rates[i] = float64(*r.ChangeCmPerHour)
+			continue
+		}
+		for j+1 < i && r.ObservedAt.Sub(readings[j+1].ObservedAt) >= time.Hour {
+			j++
+		}
+		if hours := r.ObservedAt.Sub(readings[j].ObservedAt).Hours(); j < i && hours >= 1 && hours <= 3 {
+			rates[i] = float64(r.LevelBSM-readings[j].LevelBSM) * 100 / hours
+		}
+	}
+	return rates
+}
+
+// hydroRisesCm — подъём уровня над минимумом за предшествующее окно, см
+func hydroRisesCm(readings []models.HydroLevelReading, window time.Duration) []float64 {
+	rises := make([]float64, len(readings))
+	for i, r := range readings {
+		minLevel := r.LevelBSM
+		for j := i - 1; j >= 0 && r.ObservedAt.Sub(readings[j].ObservedAt) <= window; j-- {
+			minLevel = min(minLevel, readings[j].LevelBSM)
+		}
+		rises[i] = float64(r.LevelBSM-minLevel) * 100
+	}
+	return rises
+}
+
+// dropHydroSpikes убирает одиночные выбросы источника: измерение, отличающееся больше
+// чем на HYDRO_FORECAST_MAX_STEP и от предыдущего принятого, и от следующего
+func dropHydroSpikes(readings []models.HydroLevelReading) []models.HydroLevelReading {
+	out := make([]models.HydroLevelReading, 0, len(readings))
+	for i, r := range readings {
+		if len(out) > 0 && math.Abs(float64(r.LevelBSM-out[len(out)-1].LevelBSM)) > HYDRO_FORECAST_MAX_STEP &&
+			(i+1 == len(readings) || math.Abs(float64(r.LevelBSM-readings[i+1].LevelBSM)) > HYDRO_FORECAST_MAX_STEP) {
+			continue
+		}
+		out = append(out, r)
+	}
+	return out
+}
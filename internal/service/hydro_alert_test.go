@@ -0,0 +1,92 @@
+package service
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+func hydroReadings(start time.Time, step time.Duration, levels ...float32) []models.HydroLevelReading {
+	readings := make([]models.HydroLevelReading, 0, len(levels))
+	for i, level := range levels {
+		readings = append(readings, models.HydroLevelReading{ObservedAt: start.Add(time.Duration(i) * step), LevelBSM: level})
+	}
+	return readings
+}
+
+func TestLastHydroStatusChangeHysteresis(t *testing.T) {
+	start := time.Date(2026, 4, 20, 0, 0, 0, 0, time.UTC)
+	prevention, danger := float32(251), float32(252)
+
+	// Уровень колеблется у порога НЯ: повышение — сразу, снижение — только на 5 см ниже порога
+	readings := hydroReadings(start, 10*time.Minute, 250.95, 251.01, 250.98, 251.02, 250.97)
+	change := lastHydroStatusChange(readings, &prevention, &danger, 0.05)
+	if change == nil || change.index != 1 || change.from != models.HydroStatusNear || change.to != models.HydroStatusPrevention {
+		t.Fatalf("change = %+v, want single near→prevention at 1", change)
+	}
+
+	readings = append(readings, hydroReadings(start.Add(time.Hour), 10*time.Minute, 250.94)...)
+	change = lastHydroStatusChange(readings, &prevention, &danger, 0.05)
+	if change == nil || change.index != 5 || change.to != models.HydroStatusNear {
+		t.Fatalf("change = %+v, want prevention→near at 5", change)
+	}
+
+	if change := lastHydroStatusChange(hydroReadings(start, time.Hour, 250, 250.1, 250.05), &prevention, &danger, 0.05); change != nil {
+		t.Fatalf("change = %+v, want none for normal level", change)
+	}
+}
+
+func TestActiveHydroEpisode(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		want   int
+	}{
+		{name: "rising", values: []float64{1, 2, 6, 4, 3}, want: 2},
+		{name: "ended below half", values: []float64{1, 6, 4, 2, 1}, want: -1},
+		{name: "restarted", values: []float64{6, 1, 5}, want: 2},
+		{name: "never", values: []float64{1, 2, 4.9}, want: -1},
+	}
+	for _, tt := range tests {
+		if got := activeHydroEpisode(tt.values, 5); got != tt.want {
+			t.Fatalf("%s: activeHydroEpisode() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestHydroRatesAndRises(t *testing.T) {
+	start := time.Date(2026, 4, 20, 0, 0, 0, 0, time.UTC)
+	readings := hydroReadings(start, 30*time.Minute, 250, 250.02, 250.1, 250.2, 250.15)
+	source := float32(12)
+	readings[4].ChangeCmPerHour = &source
+
+	rates := hydroRatesCmPerHour(readings)
+	if !math.IsNaN(rates[1]) {
+		t.Fatalf("rates[1] = %v, want NaN before an hour of history", rates[1])
+	}
+	if got := roundTo(rates[2], 1); got != 10 {
+		t.Fatalf("rates[2] = %v, want 10 cm/h", got)
+	}
+	if rates[4] != 12 {
+		t.Fatalf("rates[4] = %v, want source value 12", rates[4])
+	}
+
+	rises := hydroRisesCm(readings, time.Hour)
+	if got := roundTo(rises[3], 0); got != 18 {
+		t.Fatalf("rises[3] = %v, want 18 cm over the hour window", got)
+	}
+}
+
+func TestDropHydroSpikes(t *testing.T) {
+	start := time.Date(2026, 4, 20, 0, 0, 0, 0, time.UTC)
+	readings := dropHydroSpikes(hydroReadings(start, 10*time.Minute, 250, 250.01, 180, 250.02, 250.03))
+	if len(readings) != 4 {
+		t.Fatalf("len = %d, want spike dropped", len(readings))
+	}
+	readings = dropHydroSpikes(hydroReadings(start, 10*time.Minute, 180, 250, 250.01))
+	if len(readings) != 3 {
+		t.Fatalf("len = %d, want series after a leading spike kept", len(readings))
+	}
+}
@@ -0,0 +1,493 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+// Параметры прогноза уровня по верхним постам
+const (
+	HYDRO_FORECAST_DEFAULT_TRAINING_DAYS   = 30
+	HYDRO_FORECAST_DEFAULT_MAX_LAG_HOURS   = 48
+	HYDRO_FORECAST_DEFAULT_HOURS           = 24
+	HYDRO_FORECAST_MIN_HOURS               = 6
+	HYDRO_FORECAST_DEFAULT_MIN_CORRELATION = 0.3
+	HYDRO_FORECAST_MIN_SAMPLES             = 72   // часов с изменениями уровня на обоих постах
+	HYDRO_FORECAST_MIN_BACKTEST            = 10   // проверок на истории для оценки ошибки горизонта
+	HYDRO_FORECAST_BAND_Z                  = 1.28 // ±z·RMSE — 80% интервал
+	HYDRO_FORECAST_MAX_STEP                = 1.0  // м/ч: больший скачок часового уровня — выброс источника
+	HYDRO_FORECAST_DEFAULT_ERROR           = 0.02 // м за час, если ошибку на истории оценить не по чему
+	hydroForecastCacheTTL                  = 10 * time.Minute
+)
+
+// HydroForecastSettings — окно обучения, поиск времени добегания и горизонт прогноза
+type HydroForecastSettings struct {
+	TrainingDays   int     // сколько суток истории учитывать
+	MaxLagHours    int     // максимальное время добегания
+	Hours          int     // горизонт прогноза
+	MinCorrelation float64 // пост с меньшей корреляцией в прогнозе не участвует
+}
+
+// HydroForecastService прогнозирует уровень основного поста на 6–24 часа по верхним
+// постам: по истории часовых изменений уровня взаимной корреляцией подбирается время
+// добегания от каждого поста, регрессией — доля подъёма, доходящая до основного поста,
+// а ошибка прогноза на каждом горизонте оценивается прогоном модели по той же истории.
+type HydroForecastService struct {
+	hydroSvc *HydroService
+	settings HydroForecastSettings
+
+	mu      sync.Mutex
+	cached  *models.HydroForecast
+	expires time.Time
+}
+
+func NewHydroForecastService(hydroSvc *HydroService, settings HydroForecastSettings) *HydroForecastService {
+	if settings.TrainingDays <= 0 {
+		settings.TrainingDays = HYDRO_FORECAST_DEFAULT_TRAINING_DAYS
+	}
+	if settings.MaxLagHours <= 0 {
+		settings.MaxLagHours = HYDRO_FORECAST_DEFAULT_MAX_LAG_HOURS
+	}
+	if settings.Hours <= 0 {
+		settings.Hours = HYDRO_FORECAST_DEFAULT_HOURS
+	}
+	settings.Hours = max(settings.Hours, HYDRO_FORECAST_MIN_HOURS)
+	if settings.MinCorrelation <= 0 {
+		settings.MinCorrelation = HYDRO_FORECAST_DEFAULT_MIN_CORRELATION
+	}
+	return &HydroForecastService{hydroSvc: hydroSvc, settings: settings}
+}
+
+// hydroUpstreamSeries — история верхнего поста для обучения
+type hydroUpstreamSeries struct {
+	stationUUID string
+	name        string
+	readings    []models.HydroLevelReading
+}
+
+// GetForecast возвращает прогноз уровня основного поста. Без связанных верхних постов
+// прогноз недоступен, причина — в Reason.
+func (s *HydroForecastService) GetForecast(ctx context.Context, now time.Time) (*models.HydroForecast, error) {
+	s.mu.Lock()
+	if s.cached != nil && now.Before(s.expires) {
+		cached := s.cached
+		s.mu.Unlock()
+		return cached, nil
+	}
+	s.mu.Unlock()
+
+	repo := s.hydroSvc.repo
+	from := now.AddDate(0, 0, -s.settings.TrainingDays)
+	primary, err := repo.GetRange(ctx, s.hydroSvc.stationUUID, from, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get primary hydro readings: %w", err)
+	}
+	gauge, err := repo.GetGauge(ctx, s.hydroSvc.stationUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get primary hydro gauge: %w", err)
+	}
+
+	upstream := make([]hydroUpstreamSeries, 0, len(s.hydroSvc.upstreamStationUUIDs))
+	for _, stationUUID := range s.hydroSvc.upstreamStationUUIDs {
+		readings, err := repo.GetRange(ctx, stationUUID, from, now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get upstream hydro readings: %w", err)
+		}
+		series := hydroUpstreamSeries{stationUUID: stationUUID, name: stationUUID, readings: readings}
+		upstreamGauge, err := repo.GetGauge(ctx, stationUUID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get upstream hydro gauge: %w", err)
+		}
+		if upstreamGauge != nil {
+			series.name = hydroGaugeName(upstreamGauge)
+		}
+		upstream = append(upstream, series)
+	}
+
+	forecast := buildHydroForecast(primary, upstream, from, now, s.settings)
+	fillHydroForecastStatus(forecast, gauge)
+
+	s.mu.Lock()
+	s.cached = forecast
+	s.expires = now.Add(hydroForecastCacheTTL)
+	s.mu.Unlock()
+	return forecast, nil
+}
+
+// Alert сообщает, что прогноз выводит уровень к неблагоприятной или опасной отметке,
+// которой сейчас нет
+func (s *HydroForecastService) Alert(forecast *models.HydroForecast) bool {
+	if forecast == nil || !forecast.Available {
+		return false
+	}
+	return hydroStatusRank(forecast.PeakStatus) >= hydroStatusRank(models.HydroStatusPrevention) &&
+		hydroStatusRank(forecast.PeakStatus) > hydroStatusRank(forecast.CurrentStatus)
+}
+
+func hydroStatusRank(status models.HydroStatus) int {
+	switch status {
+	case models.HydroStatusNear:
+		return 1
+	case models.HydroStatusPrevention:
+		return 2
+	case models.HydroStatusDanger:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// hydroGaugeName — название поста для подписей: населённый пункт и река
+func hydroGaugeName(gauge *models.HydroGauge) string {
+	name := gauge.Name
+	if gauge.Locality != nil && *gauge.Locality != "" {
+		name = *gauge.Locality
+	}
+	if gauge.MonitoringObject != "" {
+		name = gauge.MonitoringObject + ", " + name
+	}
+	return name
+}
+
+// buildHydroForecast обучает связи верхних постов с основным на часовых рядах [from, now]
+// и строит прогноз от последнего часа с измерением основного поста
+func buildHydroForecast(primary []models.HydroLevelReading, upstream []hydroUpstreamSeries, from, now time.Time, settings HydroForecastSettings) *models.HydroForecast {
+	forecast := &models.HydroForecast{GeneratedAt: now, TrainingDays: settings.TrainingDays, Links: []models.HydroUpstreamLink{}, Points: []models.HydroForecastPoint{}}
+	start := from.Truncate(time.Hour)
+	n := int(now.Sub(start)/time.Hour) + 1
+
+	p := hourlyHydroLevels(primary, start, n)
+	base := -1
+	for i := n - 1; i >= 0; i-- {
+		if !math.IsNaN(p[i]) {
+			base = i
+			break
+		}
+	}
+	if base < 0 {
+		forecast.Reason = "нет измерений основного поста"
+		return forecast
+	}
+	forecast.BaseTime = start.Add(time.Duration(base) * time.Hour)
+	forecast.BaseLevelBSM = p[base]
+	dp := hourlyHydroChanges(p)
+
+	// Связи по отдельности: время добегания — сдвиг с наибольшей корреляцией изменений
+	var links []hydroLink
+	for _, series := range upstream {
+		u := hourlyHydroLevels(series.readings, start, n)
+		du := hourlyHydroChanges(u)
+		lag, r, samples := learnHydroLag(du, dp, settings.MaxLagHours)
+		link := models.HydroUpstreamLink{StationUUID: series.stationUUID, StationName: series.name, LagHours: lag, Correlation: roundTo(r, 2), Samples: samples}
+		if samples >= HYDRO_FORECAST_MIN_SAMPLES && r >= settings.MinCorrelation {
+			link.Used = true
+			links = append(links, hydroLink{index: len(forecast.Links), lag: lag, r: r, level: fillForwardHydro(u), change: du})
+		}
+		forecast.Links = append(forecast.Links, link)
+	}
+	if len(links) == 0 {
+		forecast.Reason = "по истории уровней верхние посты не связаны с основным"
+		return forecast
+	}
+
+	// Доли подъёма — совместной регрессией, чтобы посты одной реки не учитывались дважды
+	links = fitHydroGains(links, dp)
+	for _, l := range links {
+		forecast.Links[l.index].Gain = roundTo(l.gain, 2)
+	}
+	for i := range forecast.Links {
+		forecast.Links[i].Used = false
+	}
+	for _, l := range links {
+		forecast.Links[l.index].Used = true
+	}
+	if len(links) == 0 {
+		forecast.Reason = "доли подъёма верхних постов не положительны — прогноз невозможен"
+		return forecast
+	}
+
+	errors := hydroForecastErrors(p, links, settings)
+	for h := 1; h <= settings.Hours; h++ {
+		change, _ := predictHydroChange(links, base, h, n-1)
+		level := p[base] + change
+		band := HYDRO_FORECAST_BAND_Z * errors[h]
+		extrapolated := false
+		for _, l := range links {
+			if base+h-l.lag > n-1 {
+				extrapolated = true
+			}
+		}
+		forecast.Points = append(forecast.Points, models.HydroForecastPoint{
+			Time:         forecast.BaseTime.Add(time.Duration(h) * time.Hour),
+			LevelBSM:     roundTo(level, 3),
+			LowerBSM:     roundTo(level-band, 3),
+			UpperBSM:     roundTo(level+band, 3),
+			Extrapolated: extrapolated,
+		})
+	}
+	forecast.Available = true
+	last := forecast.Points[len(forecast.Points)-1]
+	forecast.ChangeCm = roundTo((last.LevelBSM-forecast.BaseLevelBSM)*100, 0)
+	return forecast
+}
+
+// fillHydroForecastStatus сравнивает прогноз с отметками поста
+func fillHydroForecastStatus(forecast *models.HydroForecast, gauge *models.HydroGauge) {
+	forecast.CurrentStatus, forecast.PeakStatus = models.HydroStatusUnknown, models.HydroStatusUnknown
+	if !forecast.Available {
+		return
+	}
+	var prevention, danger *float32
+	if gauge != nil {
+		prevention, danger = gauge.FloodingPreventionBM, gauge.FloodingDangerBSM
+	}
+	forecast.CurrentStatus = models.ClassifyHydroLevel(float32(forecast.BaseLevelBSM), prevention, danger)
+	for i := range forecast.Points {
+		point := forecast.Points[i]
+		if forecast.Peak == nil || point.LevelBSM > forecast.Peak.LevelBSM {
+			forecast.Peak = &point
+		}
+		if prevention != nil && forecast.PreventionAt == nil && point.LevelBSM >= float64(*prevention) {
+			t := point.Time
+			forecast.PreventionAt = &t
+		}
+		if danger != nil && forecast.DangerAt == nil && point.LevelBSM >= float64(*danger) {
+			t := point.Time
+			forecast.DangerAt = &t
+		}
+	}
+	forecast.PeakStatus = models.ClassifyHydroLevel(float32(forecast.Peak.LevelBSM), prevention, danger)
+}
+
+// hydroLink — связь верхнего поста, участвующая в прогнозе
+type hydroLink struct {
+	index  int // в HydroForecast.Links
+	lag    int
+	r      float64
+	gain   float64
+	level  []float64 // часовой уровень с протянутыми пропусками
+	change []float64 // часовые изменения
+}
+
+// hourlyHydroLevels — медиана измерений за каждый час: одиночные выбросы источника не сдвигают час
+func hourlyHydroLevels(readings []models.HydroLevelReading, start time.Time, n int) []float64 {
+	buckets := make([][]float64, n)
+	for _, r := range readings {
+		i := int(r.ObservedAt.Sub(start) / time.Hour)
+		if r.ObservedAt.Before(start) || i >= n {
+			continue
+		}
+		buckets[i] = append(buckets[i], float64(r.LevelBSM))
+	}
+	levels := make([]float64, n)
+	for i, b := range buckets {
+		if len(b) == 0 {
+			levels[i] = math.NaN()
+			continue
+		}
+		sort.Float64s(b)
+		levels[i] = b[len(b)/2]
+	}
+	return levels
+}
+
+// hourlyHydroChanges — изменение уровня за час; пропуски и скачки больше HYDRO_FORECAST_MAX_STEP — NaN
+func hourlyHydroChanges(levels []float64) []float64 {
+	changes := make([]float64, len(levels))
+	changes[0] = math.NaN()
+	for i := 1; i < len(levels); i++ {
+		d := levels[i] - levels[i-1]
+		if math.IsNaN(d) || math.Abs(d) > HYDRO_FORECAST_MAX_STEP {
+			d = math.NaN()
+		}
+		changes[i] = d
+	}
+	return changes
+}
+
+func fillForwardHydro(levels []float64) []float64 {
+	filled := make([]float64, len(levels))
+	last := math.NaN()
+	for i, v := range levels {
+		if !math.IsNaN(v) {
+			last = v
+		}
+		filled[i] = last
+	}
+	return filled
+}
+
+// learnHydroLag ищет сдвиг 0..maxLag часов с наибольшей корреляцией изменений
+// верхнего поста и последующих изменений основного
+func learnHydroLag(upstream, primary []float64, maxLag int) (lag int, r float64, samples int) {
+	r = math.Inf(-1)
+	for l := 0; l <= maxLag; l++ {
+		var sx, sy, sxx, syy, sxy float64
+		count := 0
+		for i := l; i < len(primary); i++ {
+			x, y := upstream[i-l], primary[i]
+			if math.IsNaN(x) || math.IsNaN(y) {
+				continue
+			}
+			sx, sy, sxx, syy, sxy = sx+x, sy+y, sxx+x*x, syy+y*y, sxy+x*y
+			count++
+		}
+		if count < HYDRO_FORECAST_MIN_SAMPLES {
+			continue
+		}
+		cnt := float64(count)
+		den := math.Sqrt((sxx - sx*sx/cnt) * (syy - sy*sy/cnt))
+		if den == 0 {
+			continue
+		}
+		if c := (sxy - sx*sy/cnt) / den; c > r {
+			lag, r, samples = l, c, count
+		}
+	}
+	if math.IsInf(r, -1) {
+		return 0, 0, 0
+	}
+	return lag, r, samples
+}
+
+// fitHydroGains подбирает доли подъёма всех постов регрессией без свободного члена.
+// Если совместная регрессия вырождена или даёт отрицательную долю, остаётся один
+// пост с наибольшей корреляцией.
+func fitHydroGains(links []hydroLink, primary []float64) []hydroLink {
+	k := len(links)
+	a := make([][]float64, k)
+	for i := range a {
+		a[i] = make([]float64, k+1)
+	}
+	for t := range primary {
+		if math.IsNaN(primary[t]) {
+			continue
+		}
+		x := make([]float64, k)
+		ok := true
+		for j, l := range links {
+			if t-l.lag < 0 || math.IsNaN(l.change[t-l.lag]) {
+				ok = false
+				break
+			}
+			x[j] = l.change[t-l.lag]
+		}
+		if !ok {
+			continue
+		}
+		for i := 0; i < k; i++ {
+			for j := 0; j < k; j++ {
+				a[i][j] += x[i] * x[j]
+			}
+			a[i][k] += x[i] * primary[t]
+		}
+	}
+
+	if gains, ok := solveLinearSystem(a); ok {
+		positive := true
+		for _, g := range gains {
+			positive = positive && g > 0
+		}
+		if positive {
+			for j := range links {
+				links[j].gain = gains[j]
+			}
+			return links
+		}
+	}
+	if k == 1 {
+		return nil
+	}
+	best := 0
+	for j, l := range links {
+		if l.r > links[best].r {
+			best = j
+		}
+	}
+	return fitHydroGains([]hydroLink{links[best]}, primary)
+}
+
+// solveLinearSystem решает систему по расширенной матрице методом Гаусса с выбором главного элемента
+func solveLinearSystem(a [][]float64) ([]float64, bool) {
+	k := len(a)
+	for col := 0; col < k; col++ {
+		pivot := col
+		for row := col + 1; row < k; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-12 {
+			return nil, false
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		for row := 0; row < k; row++ {
+			if row == col {
+				continue
+			}
+			f := a[row][col] / a[col][col]
+			for c := col; c <= k; c++ {
+				a[row][c] -= f * a[col][c]
+			}
+		}
+	}
+	x := make([]float64, k)
+	for i := range x {
+		x[i] = a[i][k] / a[i][i]
+	}
+	return x, true
+}
+
+// predictHydroChange — изменение уровня основного поста за h часов от часа base. Уровень
+// верхнего поста известен до часа known, дальше считается неизменным.
+func predictHydroChange(links []hydroLink, base, h, known int) (float64, bool) {
+	var change float64
+	ok := false
+	for _, l := range links {
+		from, to := base-l.lag, min(base+h-l.lag, known)
+		if from < 0 || to < from {
+			continue
+		}
+		if math.IsNaN(l.level[from]) || math.IsNaN(l.level[to]) {
+			continue
+		}
+		change += l.gain * (l.level[to] - l.level[from])
+		ok = true
+	}
+	return change, ok
+}
+
+// hydroForecastErrors — RMSE прогноза изменения уровня на каждом горизонте по истории.
+// Ошибка не убывает с горизонтом; без проверок — растёт как корень из числа часов.
+func hydroForecastErrors(primary []float64, links []hydroLink, settings HydroForecastSettings) []float64 {
+	errors := make([]float64, settings.Hours+1)
+	for h := 1; h <= settings.Hours; h++ {
+		var sum float64
+		count := 0
+		for b := 0; b+h < len(primary); b++ {
+			if math.IsNaN(primary[b]) || math.IsNaN(primary[b+h]) {
+				continue
+			}
+			predicted, ok := predictHydroChange(links, b, h, b)
+			if !ok {
+				continue
+			}
+			e := primary[b+h] - primary[b] - predicted
+			sum += e * e
+			count++
+		}
+		if count >= HYDRO_FORECAST_MIN_BACKTEST {
+			errors[h] = math.Sqrt(sum / float64(count))
+		} else {
+			errors[h] = HYDRO_FORECAST_DEFAULT_ERROR * math.Sqrt(float64(h))
+		}
+		errors[h] = math.Max(errors[h], errors[h-1])
+	}
+	return errors
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+// syntheticHydro — часовые измерения: верхний пост с паводочными волнами и основной,
+// куда через lag часов доходит доля gain подъёма
+func syntheticHydro(start time.Time, hours, lag int, gain float64) (primary, upstream, noise []models.HydroLevelReading) {
+	rng := rand.New(rand.NewSource(1))
+	u := make([]float64, hours)
+	level := 300.0
+	for i := range u {
+		level += 0.05*math.Sin(float64(i)/9) + 0.03*rng.NormFloat64()
+		u[i] = level
+	}
+	for i := 0; i < hours; i++ {
+		at := start.Add(time.Duration(i)*time.Hour + 10*time.Minute)
+		upstream = append(upstream, models.HydroLevelReading{ObservedAt: at, LevelBSM: float32(u[i])})
+		noise = append(noise, models.HydroLevelReading{ObservedAt: at, LevelBSM: float32(200 + 0.05*rng.NormFloat64())})
+		if i >= lag {
+			p := 250 + gain*(u[i-lag]-u[0])
+			primary = append(primary, models.HydroLevelReading{ObservedAt: at, LevelBSM: float32(p)})
+		}
+	}
+	return primary, upstream, noise
+}
+
+func TestBuildHydroForecastLearnsLagAndGain(t *testing.T) {
+	now := time.Date(2026, 4, 20, 12, 30, 0, 0, time.UTC)
+	from := now.AddDate(0, 0, -30)
+	hours := int(now.Sub(from.Truncate(time.Hour))/time.Hour) + 1
+	primary, upstream, noise := syntheticHydro(from.Truncate(time.Hour), hours, 6, 0.5)
+	settings := HydroForecastSettings{TrainingDays: 30, MaxLagHours: 24, Hours: 12, MinCorrelation: 0.3}
+
+	forecast := buildHydroForecast(primary, []hydroUpstreamSeries{
+		{stationUUID: "up", name: "Кубань, Невинномысск", readings: upstream},
+		{stationUUID: "noise", name: "Соседняя река", readings: noise},
+	}, from, now, settings)
+
+	if !forecast.Available {
+		t.Fatalf("forecast unavailable: %s", forecast.Reason)
+	}
+	if len(forecast.Links) != 2 {
+		t.Fatalf("links = %d, want 2", len(forecast.Links))
+	}
+	link := forecast.Links[0]
+	if !link.Used || link.LagHours != 6 || math.Abs(link.Gain-0.5) > 0.02 || link.Correlation < 0.95 {
+		t.Fatalf("upstream link = %+v, want used lag 6 gain 0.5", link)
+	}
+	if forecast.Links[1].Used {
+		t.Fatalf("unrelated station should not be used: %+v", forecast.Links[1])
+	}
+	if len(forecast.Points) != 12 {
+		t.Fatalf("points = %d, want 12", len(forecast.Points))
+	}
+
+	// Первые 6 часов прогноза уже известны по верхнему посту
+	for h := 1; h <= 12; h++ {
+		point := forecast.Points[h-1]
+		if point.Extrapolated != (h > 6) {
+			t.Fatalf("h=%d extrapolated = %v", h, point.Extrapolated)
+		}
+		if point.LowerBSM > point.LevelBSM || point.UpperBSM < point.LevelBSM {
+			t.Fatalf("h=%d band %v..%v does not contain %v", h, point.LowerBSM, point.UpperBSM, point.LevelBSM)
+		}
+		if h > 6 {
+			continue
+		}
+		want := 250 + 0.5*(float64(upstream[hours-1-6+h].LevelBSM)-float64(upstream[0].LevelBSM))
+		if math.Abs(point.LevelBSM-want) > 0.01 {
+			t.Fatalf("h=%d level = %v, want %v", h, point.LevelBSM, want)
+		}
+	}
+}
+
+func TestBuildHydroForecastUnavailable(t *testing.T) {
+	now := time.Date(2026, 4, 20, 12, 0, 0, 0, time.UTC)
+	from := now.AddDate(0, 0, -30)
+	settings := HydroForecastSettings{TrainingDays: 30, MaxLagHours: 24, Hours: 12, MinCorrelation: 0.3}
+
+	forecast := buildHydroForecast(nil, nil, from, now, settings)
+	if forecast.Available || forecast.Reason == "" {
+		t.Fatalf("forecast without primary readings = %+v, want unavailable with reason", forecast)
+	}
+
+	hours := int(now.Sub(from)/time.Hour) + 1
+	primary, _, noise := syntheticHydro(from, hours, 6, 0.5)
+	forecast = buildHydroForecast(primary, []hydroUpstreamSeries{{stationUUID: "noise", readings: noise}}, from, now, settings)
+	if forecast.Available || len(forecast.Links) != 1 || forecast.Links[0].Used {
+		t.Fatalf("forecast with unrelated upstream = %+v, want unavailable", forecast)
+	}
+}
+
+func TestBuildHydroForecastAntiCorrelatedUpstream(t *testing.T) {
+	now := time.Date(2026, 4, 20, 12, 0, 0, 0, time.UTC)
+	from := now.AddDate(0, 0, -30)
+	hours := int(now.Sub(from)/time.Hour) + 1
+	// Основной пост опускается, когда верхний поднимается: порог корреляции снят,
+	// связь проходит отбор, но доля подъёма выходит отрицательной
+	primary, upstream, _ := syntheticHydro(from, hours, 6, -0.5)
+	settings := HydroForecastSettings{TrainingDays: 30, MaxLagHours: 12, Hours: 12, MinCorrelation: -1}
+
+	forecast := buildHydroForecast(primary, []hydroUpstreamSeries{{stationUUID: "up", readings: upstream}}, from, now, settings)
+	if forecast.Available || forecast.Reason == "" || len(forecast.Points) != 0 {
+		t.Fatalf("forecast with anti-correlated upstream = %+v, want unavailable with reason", forecast)
+	}
+	if len(forecast.Links) != 1 || forecast.Links[0].Used {
+		t.Fatalf("anti-correlated link = %+v, want not used", forecast.Links)
+	}
+}
+
+func TestFillHydroForecastStatus(t *testing.T) {
+	base := time.Date(2026, 4, 20, 12, 0, 0, 0, time.UTC)
+	prevention, danger := float32(251), float32(252)
+	forecast := &models.HydroForecast{Available: true, BaseLevelBSM: 250.5}
+	for h, level := range []float64{250.7, 251.2, 252.1, 251.8} {
+		forecast.Points = append(forecast.Points, models.HydroForecastPoint{Time: base.Add(time.Duration(h+1) * time.Hour), LevelBSM: level})
+	}
+
+	fillHydroForecastStatus(forecast, &models.HydroGauge{FloodingPreventionBM: &prevention, FloodingDangerBSM: &danger})
+	if forecast.Peak == nil || forecast.Peak.LevelBSM != 252.1 || forecast.PeakStatus != models.HydroStatusDanger {
+		t.Fatalf("peak = %+v status %s, want 252.1 danger", forecast.Peak, forecast.PeakStatus)
+	}
+	if forecast.PreventionAt == nil || !forecast.PreventionAt.Equal(base.Add(2*time.Hour)) {
+		t.Fatalf("PreventionAt = %v, want +2h", forecast.PreventionAt)
+	}
+	if forecast.DangerAt == nil || !forecast.DangerAt.Equal(base.Add(3*time.Hour)) {
+		t.Fatalf("DangerAt = %v, want +3h", forecast.DangerAt)
+	}
+	if !(&HydroForecastService{}).Alert(forecast) {
+		t.Fatal("Alert() = false for forecast reaching danger")
+	}
+	forecast.CurrentStatus = models.HydroStatusDanger
+	if (&HydroForecastService{}).Alert(forecast) {
+		t.Fatal("Alert() = true when danger is already current")
+	}
+}
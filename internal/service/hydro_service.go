@@ -2,18 +2,27 @@ package service
 
 import (
 	"context"
+	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/iRootPro/weather/internal/models"
 	"github.com/iRootPro/weather/internal/repository"
 )
 
+// Обзор всех постов строится из нескольких запросов на пост, а источник обновляется раз в 10 минут
+const hydroOverviewCacheTTL = 5 * time.Minute
+
 type HydroService struct {
 	repo                 repository.HydroRepository
 	stationUUID          string
 	upstreamStationUUIDs []string
 	zeroPostBSM          float32
 	hasZeroPostBSM       bool
+
+	mu              sync.Mutex
+	overview        []*models.HydroSnapshot
+	overviewExpires time.Time
 }
 
 func NewHydroService(repo repository.HydroRepository, stationUUID string, zeroPostBSM float32, upstreamStationUUIDs ...string) *HydroService {
@@ -39,13 +48,16 @@ func (s *HydroService) GetUpstreamSnapshots(ctx context.Context, now time.Time)
 }
 
 func (s *HydroService) getSnapshotForStation(ctx context.Context, stationUUID string, calculateRelative bool, now time.Time) (*models.HydroSnapshot, error) {
-	snap := &models.HydroSnapshot{}
 	gauge, err := s.repo.GetGauge(ctx, stationUUID)
 	if err != nil {
 		return nil, err
 	}
-	snap.Gauge = gauge
+	return s.snapshotForGauge(ctx, stationUUID, gauge, calculateRelative)
+}
 
+// snapshotForGauge собирает состояние поста по уже загруженному паспорту (gauge может быть nil)
+func (s *HydroService) snapshotForGauge(ctx context.Context, stationUUID string, gauge *models.HydroGauge, calculateRelative bool) (*models.HydroSnapshot, error) {
+	snap := &models.HydroSnapshot{Gauge: gauge}
 	current, err := s.repo.GetLatest(ctx, stationUUID)
 	if err != nil {
 		return nil, err
@@ -111,3 +123,46 @@ func (s *HydroService) GetRangeForStation(ctx context.Context, stationUUID strin
 func (s *HydroService) GetGauge(ctx context.Context) (*models.HydroGauge, error) {
 	return s.repo.GetGauge(ctx, s.stationUUID)
 }
+
+// GetOverview возвращает состояние всех постов, сохранённых hydro-fetcher: основного, верхних
+// и отслеживаемых для обзорной карты. Основной пост идёт первым, посты без данных тоже попадают в список.
+// Пост, показания которого не удалось прочитать, пропускается с предупреждением.
+func (s *HydroService) GetOverview(ctx context.Context, now time.Time) ([]*models.HydroSnapshot, error) {
+	s.mu.Lock()
+	if s.overview != nil && now.Before(s.overviewExpires) {
+		cached := s.overview
+		s.mu.Unlock()
+		return cached, nil
+	}
+	s.mu.Unlock()
+
+	gauges, err := s.repo.ListGauges(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*models.HydroSnapshot, 0, len(gauges))
+	for i := range gauges {
+		gauge := &gauges[i]
+		primary := gauge.StationUUID == s.stationUUID
+		snap, err := s.snapshotForGauge(ctx, gauge.StationUUID, gauge, primary)
+		if err != nil {
+			slog.Warn("failed to get hydro snapshot for overview", "station_uuid", gauge.StationUUID, "error", err)
+			continue
+		}
+		if primary {
+			out = append([]*models.HydroSnapshot{snap}, out...)
+		} else {
+			out = append(out, snap)
+		}
+	}
+
+	s.mu.Lock()
+	s.overview = out
+	s.overviewExpires = now.Add(hydroOverviewCacheTTL)
+	s.mu.Unlock()
+	return out, nil
+}
+
+func (s *HydroService) PrimaryStationUUID() string {
+	return s.stationUUID
+}
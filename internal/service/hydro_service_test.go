@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+	"github.com/iRootPro/weather/internal/repository"
+)
+
+// overviewHydroRepo отдаёт три поста; показания поста "broken" не читаются
+type overviewHydroRepo struct {
+	repository.HydroRepository
+	listCalls int
+}
+
+func (r *overviewHydroRepo) ListGauges(context.Context) ([]models.HydroGauge, error) {
+	r.listCalls++
+	return []models.HydroGauge{{StationUUID: "up"}, {StationUUID: "broken"}, {StationUUID: "main"}}, nil
+}
+
+func (r *overviewHydroRepo) GetLatest(_ context.Context, stationUUID string) (*models.HydroLevelReading, error) {
+	if stationUUID == "broken" {
+		return nil, errors.New("connection reset")
+	}
+	return &models.HydroLevelReading{StationUUID: stationUUID, ObservedAt: time.Date(2026, 4, 20, 12, 0, 0, 0, time.UTC), LevelBSM: 250}, nil
+}
+
+func (r *overviewHydroRepo) GetPreviousBefore(context.Context, string, time.Time) (*models.HydroLevelReading, error) {
+	return nil, nil
+}
+
+func (r *overviewHydroRepo) GetNearBefore(context.Context, string, time.Time, time.Duration) (*models.HydroLevelReading, error) {
+	return nil, nil
+}
+
+func TestHydroServiceGetOverviewSkipsFailingStation(t *testing.T) {
+	repo := &overviewHydroRepo{}
+	svc := NewHydroService(repo, "main", 0)
+	now := time.Date(2026, 4, 20, 12, 5, 0, 0, time.UTC)
+
+	overview, err := svc.GetOverview(context.Background(), now)
+	if err != nil {
+		t.Fatalf("GetOverview() error = %v", err)
+	}
+	if len(overview) != 2 || overview[0].Gauge.StationUUID != "main" || overview[1].Gauge.StationUUID != "up" {
+		t.Fatalf("overview = %+v, want main first and broken station skipped", overview)
+	}
+
+	if _, err := svc.GetOverview(context.Background(), now.Add(time.Minute)); err != nil {
+		t.Fatalf("GetOverview() cached error = %v", err)
+	}
+	if repo.listCalls != 1 {
+		t.Fatalf("ListGauges calls = %d, want overview served from cache", repo.listCalls)
+	}
+}
@@ -0,0 +1,318 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+// Параметры анализа микроклимата в доме
+const (
+	INDOOR_ANALYSIS_DAYS     = 7    // суток часовых данных для долей комфорта и риска плесени
+	INDOOR_SURFACE_MOLD_RH   = 80.0 // % — влажность у поверхности, при которой растёт плесень (ISO 13788)
+	INDOOR_VENTILATION_DELTA = 1.0  // г/м³ — уличный воздух должен быть суше хотя бы на столько, чтобы проветривание осушало
+	INDOOR_ALERT_FROM_HOUR   = 9    // уведомления о микроклимате отправляются с 09:00
+	INDOOR_ALERT_TO_HOUR     = 21   // и до 21:00
+)
+
+// IndoorSettings — границы зоны комфорта и пороги предупреждений
+type IndoorSettings struct {
+	ComfortTempMin     float64 // °C
+	ComfortTempMax     float64 // °C
+	ComfortHumidityMin float64 // %
+	ComfortHumidityMax float64 // %
+	MoldHumidity       float64 // %, выше — благоприятно для плесени
+	DryHumidity        float64 // %, ниже в отопительный сезон — сухой воздух
+	WallFactor         float64 // температурный фактор внутренней поверхности стены fRsi (0..1)
+}
+
+// IndoorClimateService анализирует комнатный датчик станции: доли времени в зоне комфорта,
+// риск плесени у холодной стены, советы по проветриванию и сухой воздух при отоплении.
+type IndoorClimateService struct {
+	weatherSvc *WeatherService
+	heatingSvc *HeatingService
+	settings   IndoorSettings
+}
+
+func NewIndoorClimateService(weatherSvc *WeatherService, heatingSvc *HeatingService, settings IndoorSettings) *IndoorClimateService {
+	if settings.ComfortTempMax <= settings.ComfortTempMin {
+		settings.ComfortTempMin, settings.ComfortTempMax = 20, 24
+	}
+	if settings.ComfortHumidityMax <= settings.ComfortHumidityMin {
+		settings.ComfortHumidityMin, settings.ComfortHumidityMax = 40, 60
+	}
+	if settings.MoldHumidity <= 0 {
+		settings.MoldHumidity = 70
+	}
+	if settings.DryHumidity <= 0 {
+		settings.DryHumidity = 30
+	}
+	if settings.WallFactor <= 0 || settings.WallFactor > 1 {
+		settings.WallFactor = 0.7
+	}
+	return &IndoorClimateService{weatherSvc: weatherSvc, heatingSvc: heatingSvc, settings: settings}
+}
+
+// GetIndoorClimate возвращает аналитику по часовым средним за последние INDOOR_ANALYSIS_DAYS суток
+func (s *IndoorClimateService) GetIndoorClimate(ctx context.Context) (*models.IndoorClimate, error) {
+	now := time.Now()
+	from := now.AddDate(0, 0, -INDOOR_ANALYSIS_DAYS)
+	data, err := s.weatherSvc.GetHistory(ctx, from, now, "1h")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get indoor history: %w", err)
+	}
+
+	heatingOn := false
+	if s.heatingSvc != nil {
+		heating, err := s.heatingSvc.GetSummary(ctx)
+		if err != nil {
+			return nil, err
+		}
+		heatingOn = heating.HeatingOn
+	}
+
+	climate := buildIndoorClimate(data, s.settings, heatingOn)
+	climate.From = from
+	climate.To = now
+	climate.GeneratedAt = now
+	return climate, nil
+}
+
+// GetIndoorEvents возвращает предупреждения indoor_mold и indoor_dry для подписки
+// indoor_climate. Вне дневного окна — пустой список.
+func (s *IndoorClimateService) GetIndoorEvents(ctx context.Context) ([]models.WeatherEvent, error) {
+	loc := s.weatherSvc.location
+	if loc == nil {
+		loc = time.Local
+	}
+	now := time.Now()
+	if hour := now.In(loc).Hour(); hour < INDOOR_ALERT_FROM_HOUR || hour >= INDOOR_ALERT_TO_HOUR {
+		return nil, nil
+	}
+
+	climate, err := s.GetIndoorClimate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return indoorEvents(climate, now), nil
+}
+
+func buildIndoorClimate(data []models.WeatherData, settings IndoorSettings, heatingOn bool) *models.IndoorClimate {
+	climate := &models.IndoorClimate{
+		HeatingSeason: heatingOn,
+		Hours:         []models.IndoorHour{},
+		Comfort: models.IndoorComfort{
+			TempMin:     settings.ComfortTempMin,
+			TempMax:     settings.ComfortTempMax,
+			HumidityMin: settings.ComfortHumidityMin,
+			HumidityMax: settings.ComfortHumidityMax,
+		},
+		Mold: models.IndoorMoldRisk{Level: models.RiskNone},
+	}
+
+	for _, d := range data {
+		if hour, ok := newIndoorHour(d, settings.WallFactor); ok {
+			climate.Hours = append(climate.Hours, hour)
+		}
+	}
+	if len(climate.Hours) == 0 {
+		return climate
+	}
+	climate.HasData = true
+	current := climate.Hours[len(climate.Hours)-1]
+	climate.Current = &current
+
+	climate.Comfort = indoorComfort(climate.Hours, settings)
+	climate.Mold = indoorMoldRisk(climate.Hours, settings)
+	climate.Ventilation = indoorVentilation(current, settings)
+
+	if heatingOn && current.HumidityIndoor < settings.DryHumidity {
+		climate.DryAir = true
+		climate.DryAirText = fmt.Sprintf("Сухой воздух: %.0f%% в комнате при отоплении. Увлажнитель или сушка белья в комнате поднимут влажность до %.0f–%.0f%%",
+			current.HumidityIndoor, settings.ComfortHumidityMin, settings.ComfortHumidityMax)
+	}
+	return climate
+}
+
+// newIndoorHour считает абсолютную влажность и состояние поверхности наружной стены.
+// Поверхность оценивается по температурному фактору: Tsi = Tout + fRsi·(Tin − Tout).
+func newIndoorHour(d models.WeatherData, wallFactor float64) (models.IndoorHour, bool) {
+	if d.TempIndoor == nil || d.HumidityIndoor == nil {
+		return models.IndoorHour{}, false
+	}
+	hour := models.IndoorHour{
+		Time:           d.Time,
+		TempIndoor:     roundTo(float64(*d.TempIndoor), 1),
+		HumidityIndoor: float64(*d.HumidityIndoor),
+	}
+	tin, rhin := float64(*d.TempIndoor), float64(*d.HumidityIndoor)
+	hour.AbsoluteHumidity = roundTo(absoluteHumidity(tin, rhin), 1)
+
+	if d.TempOutdoor != nil {
+		tout := float64(*d.TempOutdoor)
+		hour.TempOutdoor = &tout
+		surface := tin
+		if tout < tin {
+			surface = tout + wallFactor*(tin-tout)
+		}
+		surfaceRH := math.Min(100, rhin*saturationVaporPressure(tin)/saturationVaporPressure(surface))
+		surface, surfaceRH = roundTo(surface, 1), roundTo(surfaceRH, 0)
+		hour.SurfaceTemp = &surface
+		hour.SurfaceHumidity = &surfaceRH
+		if d.HumidityOutdoor != nil {
+			outdoor := roundTo(absoluteHumidity(tout, float64(*d.HumidityOutdoor)), 1)
+			hour.OutdoorAbsolute = &outdoor
+		}
+	}
+	return hour, true
+}
+
+// absoluteHumidity — абсолютная влажность воздуха, г/м³
+func absoluteHumidity(t, rh float64) float64 {
+	vaporPressure := rh / 100 * saturationVaporPressure(t) * 1000 // Па
+	return vaporPressure / (461.5 * (t + 273.15)) * 1000
+}
+
+func indoorComfort(hours []models.IndoorHour, settings IndoorSettings) models.IndoorComfort {
+	comfort := models.IndoorComfort{
+		Hours:       len(hours),
+		TempMin:     settings.ComfortTempMin,
+		TempMax:     settings.ComfortTempMax,
+		HumidityMin: settings.ComfortHumidityMin,
+		HumidityMax: settings.ComfortHumidityMax,
+	}
+	var inZone, cold, warm, dry, humid int
+	for _, h := range hours {
+		tempOK := h.TempIndoor >= settings.ComfortTempMin && h.TempIndoor <= settings.ComfortTempMax
+		humidityOK := h.HumidityIndoor >= settings.ComfortHumidityMin && h.HumidityIndoor <= settings.ComfortHumidityMax
+		if tempOK && humidityOK {
+			inZone++
+		}
+		switch {
+		case h.TempIndoor < settings.ComfortTempMin:
+			cold++
+		case h.TempIndoor > settings.ComfortTempMax:
+			warm++
+		}
+		switch {
+		case h.HumidityIndoor < settings.ComfortHumidityMin:
+			dry++
+		case h.HumidityIndoor > settings.ComfortHumidityMax:
+			humid++
+		}
+	}
+	share := func(n int) float64 { return roundTo(float64(n)/float64(len(hours))*100, 0) }
+	comfort.ComfortPct = share(inZone)
+	comfort.TooColdPct = share(cold)
+	comfort.TooWarmPct = share(warm)
+	comfort.TooDryPct = share(dry)
+	comfort.TooHumidPct = share(humid)
+	return comfort
+}
+
+// indoorMoldRisk оценивает риск по длительности: плесени нужны многие часы подряд
+// высокой влажности в комнате или у холодной поверхности стены.
+func indoorMoldRisk(hours []models.IndoorHour, settings IndoorSettings) models.IndoorMoldRisk {
+	risk := models.IndoorMoldRisk{Level: models.RiskNone, Reasons: []string{}}
+	humidRun, surfaceRun := 0, 0
+	for _, h := range hours {
+		if h.HumidityIndoor > settings.MoldHumidity {
+			risk.HumidHours++
+			humidRun++
+			risk.LongestHumid = maxInt(risk.LongestHumid, humidRun)
+		} else {
+			humidRun = 0
+		}
+		if h.SurfaceHumidity != nil && *h.SurfaceHumidity >= INDOOR_SURFACE_MOLD_RH {
+			risk.SurfaceHours++
+			surfaceRun++
+			risk.LongestSurface = maxInt(risk.LongestSurface, surfaceRun)
+		} else {
+			surfaceRun = 0
+		}
+	}
+
+	if risk.LongestHumid > 0 {
+		risk.Reasons = append(risk.Reasons, fmt.Sprintf("влажность выше %.0f%% — %d ч за %d сут, до %d ч подряд", settings.MoldHumidity, risk.HumidHours, INDOOR_ANALYSIS_DAYS, risk.LongestHumid))
+	}
+	if risk.LongestSurface > 0 {
+		risk.Reasons = append(risk.Reasons, fmt.Sprintf("у наружной стены влажность ≥ %.0f%% — %d ч, до %d ч подряд", INDOOR_SURFACE_MOLD_RH, risk.SurfaceHours, risk.LongestSurface))
+	}
+
+	switch {
+	case risk.LongestSurface >= 12 || risk.LongestHumid >= 24:
+		risk.Level = models.RiskHigh
+	case risk.LongestSurface >= 6 || risk.LongestHumid >= 12 || risk.HumidHours*4 >= len(hours):
+		risk.Level = models.RiskMedium
+	case risk.HumidHours > 0 || risk.SurfaceHours > 0:
+		risk.Level = models.RiskLow
+	}
+	return risk
+}
+
+// indoorVentilation сравнивает абсолютную влажность дома и улицы: уличный воздух,
+// нагретый до комнатной температуры, осушает комнату, только если в нём меньше пара.
+func indoorVentilation(h models.IndoorHour, settings IndoorSettings) models.IndoorVentilation {
+	v := models.IndoorVentilation{Advice: models.VentilationNeutral, IndoorAbsolute: h.AbsoluteHumidity}
+	if h.OutdoorAbsolute == nil {
+		v.Text = "Нет данных уличного датчика для сравнения влажности"
+		return v
+	}
+	v.OutdoorAbsolute = *h.OutdoorAbsolute
+	saturation := absoluteHumidity(h.TempIndoor, 100)
+	v.IndoorAfterAiring = roundTo(math.Min(100, v.OutdoorAbsolute/saturation*100), 0)
+	drier := v.OutdoorAbsolute <= v.IndoorAbsolute-INDOOR_VENTILATION_DELTA
+
+	switch {
+	case h.HumidityIndoor > settings.ComfortHumidityMax && drier:
+		v.Advice = models.VentilationAdvised
+		v.Text = fmt.Sprintf("Проветрите: на улице %.1f г/м³ против %.1f г/м³ дома — после нагрева уличный воздух даст около %.0f%% влажности",
+			v.OutdoorAbsolute, v.IndoorAbsolute, v.IndoorAfterAiring)
+	case h.HumidityIndoor > settings.ComfortHumidityMax:
+		v.Advice = models.VentilationAvoid
+		v.Text = fmt.Sprintf("Проветривание не осушит комнату: на улице %.1f г/м³, дома %.1f г/м³. Помогут осушитель или кондиционер",
+			v.OutdoorAbsolute, v.IndoorAbsolute)
+	case h.HumidityIndoor < settings.ComfortHumidityMin && drier:
+		v.Advice = models.VentilationAvoid
+		v.Text = fmt.Sprintf("Проветривайте коротко: уличный воздух суше, влажность упадёт примерно до %.0f%%", v.IndoorAfterAiring)
+	default:
+		v.Text = fmt.Sprintf("Влажность в норме. На улице %.1f г/м³, дома %.1f г/м³", v.OutdoorAbsolute, v.IndoorAbsolute)
+	}
+	return v
+}
+
+func indoorEvents(climate *models.IndoorClimate, now time.Time) []models.WeatherEvent {
+	if climate == nil || !climate.HasData {
+		return nil
+	}
+	var events []models.WeatherEvent
+	if climate.Mold.Level.Rank() >= models.RiskMedium.Rank() {
+		details := "• " + strings.Join(climate.Mold.Reasons, "\n• ")
+		if climate.Ventilation.Text != "" {
+			details += "\n" + climate.Ventilation.Text
+		}
+		events = append(events, models.WeatherEvent{
+			Type:        "indoor_mold",
+			Time:        now,
+			Value:       climate.Current.HumidityIndoor,
+			Description: "Риск плесени в доме — " + climate.Mold.Level.Label(),
+			Details:     details,
+			Icon:        "🍄",
+		})
+	}
+	if climate.DryAir {
+		events = append(events, models.WeatherEvent{
+			Type:        "indoor_dry",
+			Time:        now,
+			Value:       climate.Current.HumidityIndoor,
+			Description: "Сухой воздух в доме",
+			Details:     climate.DryAirText,
+			Icon:        "🏜️",
+		})
+	}
+	return events
+}
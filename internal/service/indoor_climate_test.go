@@ -0,0 +1,92 @@
+package service
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+var testIndoorSettings = IndoorSettings{
+	ComfortTempMin: 20, ComfortTempMax: 24,
+	ComfortHumidityMin: 40, ComfortHumidityMax: 60,
+	MoldHumidity: 70, DryHumidity: 30, WallFactor: 0.7,
+}
+
+func i16(v int16) *int16 { return &v }
+
+func indoorData(hours int, tin float32, rhin int16, tout float32, rhout int16) []models.WeatherData {
+	from := time.Date(2026, time.January, 10, 0, 0, 0, 0, time.UTC)
+	data := make([]models.WeatherData, 0, hours)
+	for i := 0; i < hours; i++ {
+		data = append(data, models.WeatherData{
+			Time:            from.Add(time.Duration(i) * time.Hour),
+			TempIndoor:      f32(tin),
+			HumidityIndoor:  i16(rhin),
+			TempOutdoor:     f32(tout),
+			HumidityOutdoor: i16(rhout),
+		})
+	}
+	return data
+}
+
+func TestAbsoluteHumidity(t *testing.T) {
+	if got := absoluteHumidity(20, 50); math.Abs(got-8.6) > 0.1 {
+		t.Fatalf("absoluteHumidity(20, 50) = %.2f, want about 8.6 g/m³", got)
+	}
+}
+
+func TestBuildIndoorClimateDetectsMoldAtColdWall(t *testing.T) {
+	climate := buildIndoorClimate(indoorData(30, 22, 65, -5, 85), testIndoorSettings, true)
+
+	if !climate.HasData || climate.Comfort.Hours != 30 {
+		t.Fatalf("unexpected climate: %#v", climate)
+	}
+	// Стена при -5 °C на улице холоднее комнаты примерно на 8 °C — у неё конденсация
+	if climate.Current.SurfaceTemp == nil || math.Abs(*climate.Current.SurfaceTemp-13.9) > 0.1 {
+		t.Fatalf("unexpected surface temperature: %v", climate.Current.SurfaceTemp)
+	}
+	if climate.Mold.Level != models.RiskHigh || climate.Mold.LongestSurface != 30 || climate.Mold.HumidHours != 0 {
+		t.Fatalf("expected high mold risk from the cold wall, got %#v", climate.Mold)
+	}
+	if climate.Comfort.TooHumidPct != 100 || climate.Comfort.ComfortPct != 0 {
+		t.Fatalf("unexpected comfort shares: %#v", climate.Comfort)
+	}
+	if climate.Ventilation.Advice != models.VentilationAdvised {
+		t.Fatalf("cold outdoor air should dry the room, got %#v", climate.Ventilation)
+	}
+
+	events := indoorEvents(climate, time.Now())
+	if len(events) != 1 || events[0].Type != "indoor_mold" || !strings.Contains(events[0].Details, "у наружной стены") {
+		t.Fatalf("unexpected events: %#v", events)
+	}
+}
+
+func TestIndoorVentilationAvoidsHumidOutdoorAir(t *testing.T) {
+	climate := buildIndoorClimate(indoorData(3, 24, 68, 28, 70), testIndoorSettings, false)
+
+	if climate.Ventilation.Advice != models.VentilationAvoid {
+		t.Fatalf("warm humid outdoor air should not be advised, got %#v", climate.Ventilation)
+	}
+	if climate.Mold.Level != models.RiskNone {
+		t.Fatalf("wall warmer than room must not add mold risk, got %#v", climate.Mold)
+	}
+}
+
+func TestIndoorDryAirOnlyInHeatingSeason(t *testing.T) {
+	data := indoorData(5, 23, 25, -10, 80)
+
+	if climate := buildIndoorClimate(data, testIndoorSettings, false); climate.DryAir {
+		t.Fatal("dry air warning outside heating season")
+	}
+	climate := buildIndoorClimate(data, testIndoorSettings, true)
+	if !climate.DryAir || !strings.Contains(climate.DryAirText, "25%") {
+		t.Fatalf("expected dry air warning, got %#v", climate)
+	}
+	events := indoorEvents(climate, time.Now())
+	if len(events) != 1 || events[0].Type != "indoor_dry" {
+		t.Fatalf("unexpected events: %#v", events)
+	}
+}
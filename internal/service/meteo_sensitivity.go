@@ -0,0 +1,419 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+// Параметры индекса метеочувствительности
+const (
+	METEO_HISTORY_DAYS     = 30 // суток истории для графика
+	METEO_DAY_FROM_HOUR    = 9  // влажность и духота оцениваются по прогнозу дневных часов
+	METEO_DAY_TO_HOUR      = 21
+	METEO_WEIGHT_PRESSURE  = 0.35
+	METEO_WEIGHT_TEMP      = 0.25
+	METEO_WEIGHT_HUMIDITY  = 0.15
+	METEO_WEIGHT_KP        = 0.25
+	METEO_MAX_FACTOR_SCORE = 3
+)
+
+// MeteoSensitivityService считает суточный индекс метеочувствительности 0–10:
+// взвешенную сумму баллов за изменение давления за сутки, перепад среднесуточной
+// температуры, влажность (духота, сырость, сухость) и геомагнитный индекс Kp.
+type MeteoSensitivityService struct {
+	weatherSvc  *WeatherService
+	forecastSvc *ForecastService
+	geomagSvc   *GeomagneticService
+
+	mu           sync.Mutex
+	history      []models.MeteoSensitivityDay // прошедшие сутки не меняются — пересчёт раз в сутки
+	yesterday    models.DailyWeatherInsight
+	historyToday time.Time
+}
+
+func NewMeteoSensitivityService(weatherSvc *WeatherService, forecastSvc *ForecastService, geomagSvc *GeomagneticService) *MeteoSensitivityService {
+	return &MeteoSensitivityService{weatherSvc: weatherSvc, forecastSvc: forecastSvc, geomagSvc: geomagSvc}
+}
+
+// meteoInput — значения факторов за сутки; nil — нет данных
+type meteoInput struct {
+	pressureChange *float64 // изменение давления за сутки, мм рт. ст.
+	tempChange     *float64 // перепад среднесуточной температуры к предыдущим суткам, °C
+	humidity       *float64 // средняя относительная влажность, %
+	temp           *float64 // максимальная температура для оценки духоты, °C
+	kp             *float64 // максимальный Kp за сутки
+}
+
+// GetMeteoSensitivity возвращает индекс на сегодня с советами и историю за месяц.
+// Сегодняшние сутки считаются по изменению давления за последние 24 часа,
+// прогнозу температуры и влажности и прогнозу Kp.
+func (s *MeteoSensitivityService) GetMeteoSensitivity(ctx context.Context) (*models.MeteoSensitivity, error) {
+	loc := s.weatherSvc.location
+	if loc == nil {
+		loc = time.Local
+	}
+	now := time.Now()
+	today := dayStart(now, loc)
+
+	s.mu.Lock()
+	history, yesterday := s.history, s.yesterday
+	cached := s.historyToday.Equal(today)
+	s.mu.Unlock()
+	if !cached {
+		var err error
+		history, yesterday, err = s.loadHistory(ctx, today, loc)
+		if err != nil {
+			return nil, err
+		}
+		s.mu.Lock()
+		s.history, s.yesterday, s.historyToday = history, yesterday, today
+		s.mu.Unlock()
+	}
+
+	input := s.todayInput(ctx, now, today, yesterday)
+	if s.geomagSvc != nil {
+		slots, err := s.geomagSvc.repo.GetKpRange(ctx, today, today.AddDate(0, 0, 1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get kp range: %w", err)
+		}
+		if value, ok := dailyMaxKp(slots, loc)[today]; ok {
+			input.kp = &value
+		}
+	}
+	todayIndex := buildMeteoDay(today, input, true)
+
+	month := make([]models.MeteoSensitivityDay, 0, len(history)+1)
+	month = append(month, history...)
+	month = append(month, todayIndex)
+	return &models.MeteoSensitivity{
+		GeneratedAt: now,
+		Today:       todayIndex,
+		Title:       meteoTitle(todayIndex.Level),
+		Advice:      meteoAdvice(todayIndex),
+		Month:       month,
+	}, nil
+}
+
+// loadHistory считает индекс за прошедшие METEO_HISTORY_DAYS суток и возвращает
+// суточные средние вчерашнего дня для оценки перепада температуры сегодня.
+func (s *MeteoSensitivityService) loadHistory(ctx context.Context, today time.Time, loc *time.Location) ([]models.MeteoSensitivityDay, models.DailyWeatherInsight, error) {
+	from := today.AddDate(0, 0, -(METEO_HISTORY_DAYS + 1))
+	insights, err := s.weatherSvc.repo.GetDailyInsights(ctx, from, today, s.weatherSvc.timezone)
+	if err != nil {
+		return nil, models.DailyWeatherInsight{}, fmt.Errorf("failed to get daily insights: %w", err)
+	}
+	days := make(map[time.Time]models.DailyWeatherInsight, len(insights))
+	for _, in := range insights {
+		// Дата из SQL — полночь в часовом поясе станции, помеченная как UTC
+		days[time.Date(in.Date.Year(), in.Date.Month(), in.Date.Day(), 0, 0, 0, 0, loc)] = in
+	}
+
+	kp := map[time.Time]float64{}
+	if s.geomagSvc != nil {
+		slots, err := s.geomagSvc.repo.GetKpRange(ctx, from, today)
+		if err != nil {
+			return nil, models.DailyWeatherInsight{}, fmt.Errorf("failed to get kp range: %w", err)
+		}
+		kp = dailyMaxKp(slots, loc)
+	}
+	return buildMeteoHistory(days, kp, today), days[today.AddDate(0, 0, -1)], nil
+}
+
+// todayInput собирает факторы сегодняшних суток: давление — по станции за 24 часа,
+// температура и влажность — по прогнозу, без него — по станции.
+func (s *MeteoSensitivityService) todayInput(ctx context.Context, now, today time.Time, yesterday models.DailyWeatherInsight) meteoInput {
+	var input meteoInput
+
+	current, err := s.weatherSvc.repo.GetLatest(ctx)
+	if err != nil || current == nil {
+		current = &models.WeatherData{}
+	}
+	dayAgo, err := s.weatherSvc.repo.GetDataNearTime(ctx, now.Add(-24*time.Hour))
+	if err != nil || dayAgo == nil {
+		dayAgo = &models.WeatherData{}
+	}
+	input.pressureChange = float32Diff(current.PressureRelative, dayAgo.PressureRelative)
+	input.tempChange = float32Diff(current.TempOutdoor, dayAgo.TempOutdoor)
+	if current.HumidityOutdoor != nil {
+		value := float64(*current.HumidityOutdoor)
+		input.humidity = &value
+	}
+	if current.TempOutdoor != nil {
+		value := float64(*current.TempOutdoor)
+		input.temp = &value
+	}
+
+	if s.forecastSvc == nil {
+		return input
+	}
+	if daily, err := s.forecastSvc.GetDailyForecast(ctx, 2); err == nil && yesterday.TempAvg != nil {
+		for _, f := range daily {
+			if f.Date.Year() == today.Year() && f.Date.YearDay() == today.YearDay() {
+				// Среднесуточная по прогнозу — полусумма экстремумов
+				value := float64(f.TemperatureMin+f.TemperatureMax)/2 - float64(*yesterday.TempAvg)
+				input.tempChange = &value
+			}
+		}
+	}
+	if hourly, err := s.forecastSvc.GetTodayForecast(ctx); err == nil {
+		var humiditySum float64
+		var count int
+		var maxTemp *float64
+		for _, h := range hourly {
+			// Прогноз хранится в местном времени станции
+			if hour := h.Time.Hour(); hour < METEO_DAY_FROM_HOUR || hour >= METEO_DAY_TO_HOUR {
+				continue
+			}
+			humiditySum += float64(h.Humidity)
+			count++
+			if temp := float64(h.Temperature); maxTemp == nil || temp > *maxTemp {
+				maxTemp = &temp
+			}
+		}
+		if count > 0 {
+			value := humiditySum / float64(count)
+			input.humidity = &value
+			input.temp = maxTemp
+		}
+	}
+	return input
+}
+
+// buildMeteoHistory считает индекс за прошедшие сутки по суточным средним станции:
+// изменение давления и температуры — разность средних с предыдущими сутками.
+func buildMeteoHistory(days map[time.Time]models.DailyWeatherInsight, kp map[time.Time]float64, today time.Time) []models.MeteoSensitivityDay {
+	history := make([]models.MeteoSensitivityDay, 0, METEO_HISTORY_DAYS)
+	for i := METEO_HISTORY_DAYS; i >= 1; i-- {
+		date := today.AddDate(0, 0, -i)
+		day, ok := days[date]
+		if !ok {
+			continue
+		}
+		prev := days[date.AddDate(0, 0, -1)]
+
+		input := meteoInput{
+			pressureChange: float32Diff(day.PressureAvg, prev.PressureAvg),
+			tempChange:     float32Diff(day.TempAvg, prev.TempAvg),
+		}
+		if day.HumidityAvg != nil {
+			value := float64(*day.HumidityAvg)
+			input.humidity = &value
+		}
+		if day.TempMax != nil {
+			value := float64(*day.TempMax)
+			input.temp = &value
+		}
+		if value, ok := kp[date]; ok {
+			input.kp = &value
+		}
+		history = append(history, buildMeteoDay(date, input, false))
+	}
+	return history
+}
+
+// buildMeteoDay переводит факторы в баллы 0–3 и индекс 0–10.
+// Фактор без данных даёт ноль баллов: индекс не завышается за счёт остальных.
+func buildMeteoDay(date time.Time, input meteoInput, forecast bool) models.MeteoSensitivityDay {
+	factors := []models.MeteoFactor{
+		pressureFactor(input.pressureChange),
+		temperatureFactor(input.tempChange),
+		humidityFactor(input.humidity, input.temp),
+		geomagneticFactor(input.kp),
+	}
+	var index float64
+	for _, f := range factors {
+		index += f.Weight * float64(f.Score) / METEO_MAX_FACTOR_SCORE * 10
+	}
+	index = roundTo(index, 1)
+	return models.MeteoSensitivityDay{
+		Date:     date,
+		Index:    index,
+		Level:    meteoLevel(index),
+		Forecast: forecast,
+		Factors:  factors,
+	}
+}
+
+func pressureFactor(change *float64) models.MeteoFactor {
+	f := models.MeteoFactor{Key: models.MeteoFactorPressure, Label: "Давление", Unit: "мм/сут", Weight: METEO_WEIGHT_PRESSURE}
+	if change == nil {
+		return f
+	}
+	value := roundTo(*change, 1)
+	f.Value = &value
+	// Перепад больше 5 мм рт. ст. за сутки ощущают большинство метеочувствительных людей
+	switch abs := math.Abs(value); {
+	case abs >= 8:
+		f.Score = 3
+	case abs >= 5:
+		f.Score = 2
+	case abs >= 3:
+		f.Score = 1
+	}
+	direction := "выросло"
+	if value < 0 {
+		direction = "упало"
+	}
+	if f.Score > 0 {
+		f.Text = fmt.Sprintf("давление %s на %.0f мм за сутки", direction, math.Abs(value))
+	}
+	return f
+}
+
+func temperatureFactor(change *float64) models.MeteoFactor {
+	f := models.MeteoFactor{Key: models.MeteoFactorTemperature, Label: "Температура", Unit: "°C", Weight: METEO_WEIGHT_TEMP}
+	if change == nil {
+		return f
+	}
+	value := roundTo(*change, 1)
+	f.Value = &value
+	switch abs := math.Abs(value); {
+	case abs >= 10:
+		f.Score = 3
+	case abs >= 7:
+		f.Score = 2
+	case abs >= 4:
+		f.Score = 1
+	}
+	if f.Score > 0 {
+		direction := "потепление"
+		if value < 0 {
+			direction = "похолодание"
+		}
+		f.Text = fmt.Sprintf("%s на %.0f° к вчерашнему дню", direction, math.Abs(value))
+	}
+	return f
+}
+
+func humidityFactor(humidity, temp *float64) models.MeteoFactor {
+	f := models.MeteoFactor{Key: models.MeteoFactorHumidity, Label: "Влажность", Unit: "%", Weight: METEO_WEIGHT_HUMIDITY}
+	if humidity == nil {
+		return f
+	}
+	value := math.Round(*humidity)
+	f.Value = &value
+	hot := temp != nil && *temp >= 28
+	warm := temp != nil && *temp >= 22
+	switch {
+	case value >= 70 && hot:
+		f.Score, f.Text = 3, "душно: жара при высокой влажности"
+	case value >= 80 && warm:
+		f.Score, f.Text = 2, "влажно и тепло — ощущается духота"
+	case value >= 90:
+		f.Score, f.Text = 1, "сыро, влажность около 90%"
+	case value <= 30:
+		f.Score, f.Text = 1, "сухой воздух"
+	}
+	return f
+}
+
+func geomagneticFactor(kp *float64) models.MeteoFactor {
+	f := models.MeteoFactor{Key: models.MeteoFactorGeomagnetic, Label: "Геомагнитка", Unit: "Kp", Weight: METEO_WEIGHT_KP}
+	if kp == nil {
+		return f
+	}
+	value := roundTo(*kp, 1)
+	f.Value = &value
+	switch {
+	case value >= 6:
+		f.Score = 3
+	case value >= 5:
+		f.Score = 2
+	case value >= 4:
+		f.Score = 1
+	}
+	switch {
+	case f.Score >= 2:
+		gLevel, _, _ := models.StormLevel(float32(value))
+		f.Text = fmt.Sprintf("магнитная буря %s (Kp %.0f)", gLevel, value)
+	case f.Score == 1:
+		f.Text = "геомагнитное возмущение (Kp 4)"
+	}
+	return f
+}
+
+func meteoLevel(index float64) models.RiskLevel {
+	switch {
+	case index >= 6:
+		return models.RiskHigh
+	case index >= 4:
+		return models.RiskMedium
+	case index >= 2:
+		return models.RiskLow
+	default:
+		return models.RiskNone
+	}
+}
+
+func meteoTitle(level models.RiskLevel) string {
+	switch level {
+	case models.RiskHigh:
+		return "Тяжёлый день для метеочувствительных"
+	case models.RiskMedium:
+		return "Неблагоприятный день"
+	case models.RiskLow:
+		return "Погода может слегка сказаться на самочувствии"
+	default:
+		return "Благоприятный день"
+	}
+}
+
+// meteoAdvice подбирает советы по факторам, набравшим баллы
+func meteoAdvice(day models.MeteoSensitivityDay) []string {
+	advice := make([]string, 0, 4)
+	for _, f := range day.Factors {
+		if f.Score == 0 || f.Value == nil {
+			continue
+		}
+		switch f.Key {
+		case models.MeteoFactorPressure:
+			if *f.Value < 0 {
+				advice = append(advice, "Давление падает: возможны слабость и головная боль, особенно у гипотоников — больше воды, меньше нагрузок")
+			} else {
+				advice = append(advice, "Давление растёт: гипертоникам стоит чаще измерять давление и держать под рукой привычные лекарства")
+			}
+		case models.MeteoFactorTemperature:
+			advice = append(advice, "Резкая смена температуры: одевайтесь по погоде и избегайте переохлаждения и перегрева")
+		case models.MeteoFactorHumidity:
+			if *f.Value <= 30 {
+				advice = append(advice, "Сухой воздух: пейте больше воды, увлажняйте воздух в комнате")
+			} else {
+				advice = append(advice, "Высокая влажность: в духоту снизьте нагрузку и проветривайте помещение")
+			}
+		case models.MeteoFactorGeomagnetic:
+			advice = append(advice, "Геомагнитная активность: выспитесь, ограничьте кофе и не планируйте перегрузок")
+		}
+	}
+	switch day.Level {
+	case models.RiskNone:
+		advice = append(advice, "Погода спокойная — особых мер не нужно")
+	case models.RiskHigh:
+		advice = append(advice, "При хронических заболеваниях следуйте рекомендациям врача и не откладывайте приём лекарств")
+	}
+	return advice
+}
+
+// dailyMaxKp возвращает максимальный Kp по местным суткам
+func dailyMaxKp(slots []models.GeomagneticKp, loc *time.Location) map[time.Time]float64 {
+	out := make(map[time.Time]float64)
+	for _, slot := range slots {
+		day := dayStart(slot.SlotTime, loc)
+		if value, ok := out[day]; !ok || float64(slot.Kp) > value {
+			out[day] = float64(slot.Kp)
+		}
+	}
+	return out
+}
+
+func float32Diff(a, b *float32) *float64 {
+	if a == nil || b == nil {
+		return nil
+	}
+	value := float64(*a - *b)
+	return &value
+}
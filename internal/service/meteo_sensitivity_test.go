@@ -0,0 +1,95 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+func TestBuildMeteoDayCombinesFactors(t *testing.T) {
+	date := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+	calm := buildMeteoDay(date, meteoInput{pressureChange: f64(1.2), tempChange: f64(-2), humidity: f64(60), temp: f64(15), kp: f64(2.3)}, false)
+	if calm.Index != 0 || calm.Level != models.RiskNone {
+		t.Fatalf("calm day: index %.1f, level %q", calm.Index, calm.Level)
+	}
+
+	// Падение давления на 9 мм и буря G1: 0.35·10 + 0.25·2/3·10
+	stormy := buildMeteoDay(date, meteoInput{pressureChange: f64(-9.4), tempChange: f64(-3), humidity: f64(65), temp: f64(12), kp: f64(5.3)}, true)
+	if stormy.Index != 5.2 || stormy.Level != models.RiskMedium || !stormy.Forecast {
+		t.Fatalf("stormy day: index %.1f, level %q", stormy.Index, stormy.Level)
+	}
+	if text := stormy.Factors[0].Text; text != "давление упало на 9 мм за сутки" {
+		t.Fatalf("pressure text = %q", text)
+	}
+	if text := stormy.Factors[3].Text; !strings.Contains(text, "G1") {
+		t.Fatalf("geomagnetic text = %q", text)
+	}
+
+	// Без данных фактор не набирает баллов, а не пересчитывает вес на остальные
+	missing := buildMeteoDay(date, meteoInput{pressureChange: f64(9)}, false)
+	if missing.Index != 3.5 || missing.Factors[3].Value != nil {
+		t.Fatalf("missing factors: index %.1f", missing.Index)
+	}
+}
+
+func TestHumidityFactorDetectsStuffiness(t *testing.T) {
+	tests := []struct {
+		humidity, temp float64
+		score          int
+	}{
+		{humidity: 75, temp: 30, score: 3},
+		{humidity: 85, temp: 24, score: 2},
+		{humidity: 95, temp: 10, score: 1},
+		{humidity: 25, temp: 20, score: 1},
+		{humidity: 60, temp: 30, score: 0},
+	}
+	for _, tt := range tests {
+		if got := humidityFactor(f64(tt.humidity), f64(tt.temp)).Score; got != tt.score {
+			t.Errorf("humidity %.0f%% at %.0f° scored %d, want %d", tt.humidity, tt.temp, got, tt.score)
+		}
+	}
+}
+
+func TestBuildMeteoHistoryUsesDailyDifferences(t *testing.T) {
+	today := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+	days := map[time.Time]models.DailyWeatherInsight{
+		today.AddDate(0, 0, -3): {PressureAvg: f32(752), TempAvg: f32(14)},
+		today.AddDate(0, 0, -2): {PressureAvg: f32(744), TempAvg: f32(6), TempMax: f32(9)},
+		today.AddDate(0, 0, -1): {PressureAvg: f32(745), TempAvg: f32(7)},
+	}
+	kp := dailyMaxKp([]models.GeomagneticKp{
+		{SlotTime: today.AddDate(0, 0, -2).Add(3 * time.Hour), Kp: 3.3},
+		{SlotTime: today.AddDate(0, 0, -2).Add(15 * time.Hour), Kp: 6},
+	}, time.UTC)
+
+	history := buildMeteoHistory(days, kp, today)
+	if len(history) != 3 {
+		t.Fatalf("history covers %d days, want 3", len(history))
+	}
+	first, storm := history[0], history[1]
+	if first.Factors[0].Value != nil {
+		t.Fatal("the first day has no previous day to compare with")
+	}
+	if *storm.Factors[0].Value != -8 || storm.Factors[0].Score != 3 || *storm.Factors[1].Value != -8 || storm.Factors[3].Score != 3 {
+		t.Fatalf("unexpected storm day factors: %+v", storm.Factors)
+	}
+	if storm.Level != models.RiskHigh {
+		t.Fatalf("storm day level = %q", storm.Level)
+	}
+}
+
+func TestMeteoAdviceFollowsFactors(t *testing.T) {
+	day := buildMeteoDay(time.Now(), meteoInput{pressureChange: f64(6), kp: f64(4)}, true)
+	advice := strings.Join(meteoAdvice(day), "\n")
+	if !strings.Contains(advice, "гипертоникам") || !strings.Contains(advice, "Геомагнитная активность") {
+		t.Fatalf("unexpected advice: %s", advice)
+	}
+
+	calm := buildMeteoDay(time.Now(), meteoInput{}, true)
+	if advice := meteoAdvice(calm); len(advice) != 1 || !strings.Contains(advice[0], "особых мер не нужно") {
+		t.Fatalf("calm day advice: %v", advice)
+	}
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+var ErrInvalidOnThisDayDate = errors.New("invalid on-this-day date")
+
+// GetOnThisDay returns the weather observed on the same calendar date in previous years
+// and the place of that date's values among them. dateParam is YYYY-MM-DD, today by default.
+func (s *WeatherService) GetOnThisDay(ctx context.Context, dateParam string) (*models.OnThisDay, error) {
+	loc := s.location
+	if loc == nil {
+		loc = time.Local
+	}
+	now := time.Now().In(loc)
+	date := dayStart(now, loc)
+	if dateParam = strings.TrimSpace(dateParam); dateParam != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", dateParam, loc)
+		if err != nil || parsed.After(date) {
+			return nil, ErrInvalidOnThisDayDate
+		}
+		date = parsed
+	}
+
+	days, err := s.repo.GetDailyInsightsOnDate(ctx, date.Month(), date.Day(), minTime(date.AddDate(0, 0, 1), now), s.timezone)
+	if err != nil {
+		return nil, err
+	}
+	result := buildOnThisDay(date, days, now, loc)
+	return &result, nil
+}
+
+// buildOnThisDay раскладывает суточные агрегаты той же даты по годам и считает места даты среди них.
+func buildOnThisDay(date time.Time, days []models.DailyWeatherInsight, now time.Time, loc *time.Location) models.OnThisDay {
+	result := models.OnThisDay{Date: date, Label: formatInsightDate(date), Years: []models.OnThisDayYear{}}
+	for _, day := range days {
+		local := dayStart(day.Date, loc)
+		if local.Year() > date.Year() {
+			continue
+		}
+		year := models.OnThisDayYear{
+			Year:      local.Year(),
+			Date:      local,
+			TempMin:   day.TempMin,
+			TempMax:   day.TempMax,
+			RainTotal: day.RainTotal,
+			Partial:   sameDay(local, now),
+		}
+		if local.Year() == date.Year() {
+			result.Current = &year
+			continue
+		}
+		result.Years = append(result.Years, year)
+	}
+	sort.Slice(result.Years, func(i, j int) bool { return result.Years[i].Year > result.Years[j].Year })
+
+	for i := range result.Years {
+		year := &result.Years[i]
+		if year.TempMax != nil && (result.Warmest == nil || *year.TempMax > *result.Warmest.TempMax) {
+			result.Warmest = year
+		}
+		if year.TempMin != nil && (result.Coldest == nil || *year.TempMin < *result.Coldest.TempMin) {
+			result.Coldest = year
+		}
+		if year.RainTotal != nil && *year.RainTotal > 0 && (result.Wettest == nil || *year.RainTotal > *result.Wettest.RainTotal) {
+			result.Wettest = year
+		}
+	}
+
+	if result.Current == nil || len(result.Years) == 0 {
+		return result
+	}
+	current := result.Current
+	// Незаконченные сутки: максимум и осадки ещё могут вырасти, минимум — опуститься
+	prefix := ""
+	if current.Partial {
+		prefix = "пока "
+	}
+	if current.TempMax != nil {
+		result.TempMaxRank = onThisDayRank(*current.TempMax, result.Years, func(y models.OnThisDayYear) *float32 { return y.TempMax }, true)
+		result.TempMaxRank.Text = prefix + onThisDayRankText(result.TempMaxRank, "самый тёплый", "по теплу")
+	}
+	if current.TempMin != nil {
+		result.TempMinRank = onThisDayRank(*current.TempMin, result.Years, func(y models.OnThisDayYear) *float32 { return y.TempMin }, false)
+		result.TempMinRank.Text = prefix + onThisDayRankText(result.TempMinRank, "самый холодный", "по холоду")
+	}
+	if current.RainTotal != nil && *current.RainTotal > 0 {
+		result.RainRank = onThisDayRank(*current.RainTotal, result.Years, func(y models.OnThisDayYear) *float32 { return y.RainTotal }, true)
+		result.RainRank.Text = prefix + onThisDayRankText(result.RainRank, "самый дождливый", "по осадкам")
+	}
+	return result
+}
+
+// onThisDayRank считает место значения среди прошлых лет: 1 — самое высокое (desc) или самое низкое значение.
+func onThisDayRank(value float32, years []models.OnThisDayYear, field func(models.OnThisDayYear) *float32, desc bool) *models.OnThisDayRank {
+	rank := &models.OnThisDayRank{Place: 1, Of: 1}
+	for _, year := range years {
+		v := field(year)
+		if v == nil {
+			continue
+		}
+		rank.Of++
+		if (desc && *v > value) || (!desc && *v < value) {
+			rank.Place++
+		}
+	}
+	return rank
+}
+
+func onThisDayRankText(rank *models.OnThisDayRank, superlative, criterion string) string {
+	if rank.Place == 1 {
+		return fmt.Sprintf("%s за %d %s", superlative, rank.Of, russianYears(rank.Of))
+	}
+	return fmt.Sprintf("%d-й %s из %d", rank.Place, criterion, rank.Of)
+}
+
+// russianYears согласует слово «год» с числом: 1 год, 2 года, 5 лет.
+func russianYears(n int) string {
+	switch {
+	case n%100 >= 11 && n%100 <= 14:
+		return "лет"
+	case n%10 == 1:
+		return "год"
+	case n%10 >= 2 && n%10 <= 4:
+		return "года"
+	default:
+		return "лет"
+	}
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+func onThisDayInsight(year int, tempMin, tempMax, rain float32) models.DailyWeatherInsight {
+	return models.DailyWeatherInsight{
+		Date:      time.Date(year, time.October, 18, 0, 0, 0, 0, time.UTC),
+		TempMin:   f32(tempMin),
+		TempMax:   f32(tempMax),
+		RainTotal: f32(rain),
+	}
+}
+
+func TestBuildOnThisDayRanksCurrentDay(t *testing.T) {
+	date := time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+	now := date.Add(15 * time.Hour)
+	days := []models.DailyWeatherInsight{
+		onThisDayInsight(2023, 9, 24, 0),
+		onThisDayInsight(2024, 2, 15, 12.5),
+		onThisDayInsight(2025, 6, 19, 3),
+		onThisDayInsight(2026, 4, 21, 0),
+	}
+
+	result := buildOnThisDay(date, days, now, time.UTC)
+
+	if result.Label != "18 октября" || result.Current == nil || !result.Current.Partial {
+		t.Fatalf("unexpected current day: %#v", result.Current)
+	}
+	if len(result.Years) != 3 || result.Years[0].Year != 2025 || result.Years[2].Year != 2023 {
+		t.Fatalf("previous years must be newest first: %#v", result.Years)
+	}
+	if result.TempMaxRank == nil || result.TempMaxRank.Place != 2 || result.TempMaxRank.Of != 4 || result.TempMaxRank.Text != "пока 2-й по теплу из 4" {
+		t.Fatalf("unexpected max rank: %#v", result.TempMaxRank)
+	}
+	if result.TempMinRank == nil || result.TempMinRank.Place != 2 {
+		t.Fatalf("unexpected min rank: %#v", result.TempMinRank)
+	}
+	if result.RainRank != nil {
+		t.Fatalf("dry day must not be ranked by rain: %#v", result.RainRank)
+	}
+	if result.Warmest.Year != 2023 || result.Coldest.Year != 2024 || result.Wettest.Year != 2024 {
+		t.Fatalf("unexpected extremes: warmest %d, coldest %d, wettest %d", result.Warmest.Year, result.Coldest.Year, result.Wettest.Year)
+	}
+}
+
+func TestBuildOnThisDayPastDateIgnoresLaterYears(t *testing.T) {
+	date := time.Date(2024, time.October, 18, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2026, time.October, 18, 15, 0, 0, 0, time.UTC)
+	days := []models.DailyWeatherInsight{
+		onThisDayInsight(2023, 9, 24, 0),
+		onThisDayInsight(2024, 2, 25, 12.5),
+		onThisDayInsight(2025, 6, 19, 3),
+	}
+
+	result := buildOnThisDay(date, days, now, time.UTC)
+
+	if result.Current == nil || result.Current.Partial || len(result.Years) != 1 {
+		t.Fatalf("unexpected years: current %#v, previous %#v", result.Current, result.Years)
+	}
+	if result.TempMaxRank.Text != "самый тёплый за 2 года" || result.RainRank.Text != "самый дождливый за 2 года" {
+		t.Fatalf("unexpected rank texts: %q, %q", result.TempMaxRank.Text, result.RainRank.Text)
+	}
+}
+
+func TestBuildOnThisDayWithoutHistory(t *testing.T) {
+	date := time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+	result := buildOnThisDay(date, []models.DailyWeatherInsight{onThisDayInsight(2026, 4, 21, 0)}, date.Add(time.Hour), time.UTC)
+	if len(result.Years) != 0 || result.TempMaxRank != nil || result.Warmest != nil {
+		t.Fatalf("first year of observations has nothing to compare with: %#v", result)
+	}
+}
+
+func TestRussianYears(t *testing.T) {
+	for n, want := range map[int]string{1: "год", 2: "года", 4: "года", 5: "лет", 11: "лет", 12: "лет", 21: "год", 22: "года"} {
+		if got := russianYears(n); got != want {
+			t.Errorf("russianYears(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,337 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+// ErrInvalidPVDate возвращается, когда дата отчёта о выработке не разобрана или в будущем
+var ErrInvalidPVDate = errors.New("invalid pv date")
+
+// Параметры модели солнечной электростанции
+const (
+	PV_DAYS_SHOWN         = 30     // суток в графике выработки
+	PV_MONTHS_SHOWN       = 12     // месяцев в сравнении
+	PV_ALBEDO             = 0.2    // отражательная способность поверхности перед модулями (трава, грунт)
+	PV_NOCT               = 45.0   // номинальная рабочая температура модуля при 800 Вт/м² и +20 °C, °C
+	PV_MIN_BEAM_ELEVATION = 3.0    // °, ниже всю радиацию считаем рассеянной
+	SOLAR_CONSTANT        = 1367.0 // солнечная постоянная, Вт/м²
+)
+
+// PVSettings — параметры солнечной электростанции
+type PVSettings struct {
+	CapacityKWp     float64  // пиковая мощность модулей, кВт
+	Tilt            float64  // наклон модулей от горизонта, °
+	Azimuth         float64  // ориентация от севера по часовой стрелке, ° (180 — юг)
+	LossesPct       float64  // суммарные потери системы, %
+	TempCoefficient *float64 // температурный коэффициент мощности, %/°C (отрицательный); nil — −0.4, 0 — без поправки на нагрев
+}
+
+func (s PVSettings) tempCoefficient() float64 {
+	if s.TempCoefficient == nil {
+		return -0.4
+	}
+	return *s.TempCoefficient
+}
+
+// PVService оценивает выработку солнечных панелей по датчику радиации станции:
+// горизонтальная радиация раскладывается на прямую и рассеянную (модель Erbs),
+// пересчитывается в плоскость модулей (изотропное небо) и поправляется на нагрев модулей.
+type PVService struct {
+	weatherSvc *WeatherService
+	sun        *SunService
+	settings   PVSettings
+}
+
+func NewPVService(weatherSvc *WeatherService, sun *SunService, settings PVSettings) *PVService {
+	if settings.LossesPct < 0 || settings.LossesPct >= 100 {
+		settings.LossesPct = 14
+	}
+	return &PVService{weatherSvc: weatherSvc, sun: sun, settings: settings}
+}
+
+// GetReport returns the estimated PV yield for the given day (YYYY-MM-DD, empty for today)
+// together with daily totals for the last 30 days and monthly totals for the last 12 months.
+func (s *PVService) GetReport(ctx context.Context, date string) (*models.PVReport, error) {
+	loc := s.location()
+	now := time.Now()
+	today := dayStart(now, loc)
+
+	day := today
+	if date != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", date, loc)
+		if err != nil || parsed.After(today) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidPVDate, date)
+		}
+		day = parsed
+	}
+
+	from := time.Date(today.Year(), today.Month()-PV_MONTHS_SHOWN+1, 1, 0, 0, 0, 0, loc)
+	inputs, err := s.weatherSvc.repo.GetPVInputs(ctx, from, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pv inputs: %w", err)
+	}
+	hours := pvHours(inputs, s.settings, s.sun, now)
+
+	dayHours := hours
+	if day.Before(from) {
+		dayInputs, err := s.weatherSvc.repo.GetPVInputs(ctx, day, day.AddDate(0, 0, 1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pv inputs: %w", err)
+		}
+		dayHours = pvHours(dayInputs, s.settings, s.sun, now)
+	}
+
+	days := pvDays(hours, s.settings, now, loc)
+	recent := make([]models.PVDay, 0, PV_DAYS_SHOWN)
+	for _, d := range days {
+		if !d.Date.Before(today.AddDate(0, 0, -PV_DAYS_SHOWN+1)) {
+			d.Hours = nil
+			recent = append(recent, d)
+		}
+	}
+
+	return &models.PVReport{
+		GeneratedAt: now,
+		System:      s.System(),
+		Day:         pvDay(day, dayHours, s.settings, now),
+		Days:        recent,
+		Months:      pvMonths(days, from, today, s.settings, now),
+	}, nil
+}
+
+// GetMonthlyReport returns the PV yield of the calendar month containing month
+// compared with the previous month, the same month a year ago and the last 12 months.
+func (s *PVService) GetMonthlyReport(ctx context.Context, month time.Time) (*models.PVMonthlyReport, error) {
+	loc := s.location()
+	now := time.Now()
+	month = month.In(loc)
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, loc)
+	end := minTime(start.AddDate(0, 1, 0), now)
+	from := start.AddDate(-1, 0, 0)
+
+	inputs, err := s.weatherSvc.repo.GetPVInputs(ctx, from, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pv inputs: %w", err)
+	}
+	days := pvDays(pvHours(inputs, s.settings, s.sun, now), s.settings, now, loc)
+	months := pvMonths(days, from, dayStart(end.Add(-time.Nanosecond), loc), s.settings, now)
+
+	report := buildPVMonthlyReport(months, days, start)
+	report.System = s.System()
+	return report, nil
+}
+
+// System возвращает параметры станции, по которым считается выработка
+func (s *PVService) System() models.PVSystem {
+	return models.PVSystem{
+		CapacityKWp:     s.settings.CapacityKWp,
+		Tilt:            s.settings.Tilt,
+		Azimuth:         s.settings.Azimuth,
+		LossesPct:       s.settings.LossesPct,
+		TempCoefficient: s.settings.tempCoefficient(),
+	}
+}
+
+func (s *PVService) location() *time.Location {
+	if s.weatherSvc.location != nil {
+		return s.weatherSvc.location
+	}
+	return time.Local
+}
+
+// pvHours пересчитывает часовые средние радиации в мощность и выработку станции.
+// Положение Солнца берётся на середину часа; текущий час учитывается по прошедшей доле.
+func pvHours(inputs []models.PVHourInput, settings PVSettings, sun *SunService, now time.Time) []models.PVHour {
+	hours := make([]models.PVHour, 0, len(inputs))
+	for _, input := range inputs {
+		if !input.Time.Before(now) {
+			continue
+		}
+		mid := input.Time.Add(30 * time.Minute)
+		elevation, azimuth := solarPosition(mid, sun.latitude, sun.longitude)
+		ghi := math.Max(0, input.Radiation)
+		poa := planeOfArrayIrradiance(ghi, elevation, azimuth, settings.Tilt, settings.Azimuth, mid.YearDay())
+
+		hour := models.PVHour{Time: input.Time, GHI: roundTo(ghi, 0), POA: roundTo(poa, 0)}
+		factor := 1.0
+		if input.TempOutdoor != nil {
+			cellTemp := cellTemperature(*input.TempOutdoor, poa)
+			factor += settings.tempCoefficient() / 100 * (cellTemp - 25)
+			rounded := roundTo(cellTemp, 1)
+			hour.CellTemp = &rounded
+		}
+		power := math.Max(0, settings.CapacityKWp*poa/1000*factor*(1-settings.LossesPct/100))
+		fraction := math.Min(1, now.Sub(input.Time).Hours())
+		hour.PowerKW = roundTo(power, 2)
+		hour.EnergyKWh = roundTo(power*fraction, 3)
+		hours = append(hours, hour)
+	}
+	return hours
+}
+
+// planeOfArrayIrradiance переводит суммарную радиацию на горизонталь в радиацию
+// в плоскости модулей: доля рассеянной по индексу ясности (Erbs), рассеянная —
+// по модели изотропного неба (Liu–Jordan), плюс отражённая от земли.
+func planeOfArrayIrradiance(ghi, elevation, sunAzimuth, tilt, panelAzimuth float64, dayOfYear int) float64 {
+	if ghi <= 0 {
+		return 0
+	}
+	rad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	cosTilt := math.Cos(rad(tilt))
+	skyView := (1 + cosTilt) / 2
+	ground := ghi * PV_ALBEDO * (1 - cosTilt) / 2
+
+	if elevation < PV_MIN_BEAM_ELEVATION {
+		return ghi*skyView + ground
+	}
+
+	sinElevation := math.Sin(rad(elevation))
+	extraterrestrial := SOLAR_CONSTANT * (1 + 0.033*math.Cos(2*math.Pi*float64(dayOfYear)/365))
+	clearness := math.Min(1, ghi/(extraterrestrial*sinElevation))
+	diffuse := ghi * erbsDiffuseFraction(clearness)
+	direct := math.Min(extraterrestrial, (ghi-diffuse)/sinElevation)
+
+	cosIncidence := sinElevation*cosTilt + math.Cos(rad(elevation))*math.Sin(rad(tilt))*math.Cos(rad(sunAzimuth-panelAzimuth))
+	return direct*math.Max(0, cosIncidence) + diffuse*skyView + ground
+}
+
+// erbsDiffuseFraction — доля рассеянной радиации по индексу ясности kt (Erbs, 1982)
+func erbsDiffuseFraction(kt float64) float64 {
+	switch {
+	case kt <= 0.22:
+		return 1 - 0.09*kt
+	case kt <= 0.8:
+		return 0.9511 - 0.1604*kt + 4.388*kt*kt - 16.638*kt*kt*kt + 12.336*kt*kt*kt*kt
+	default:
+		return 0.165
+	}
+}
+
+// cellTemperature — температура модуля по модели NOCT
+func cellTemperature(airTemp, poa float64) float64 {
+	return airTemp + poa/800*(PV_NOCT-20)
+}
+
+// pvDays собирает часы в сутки по местному времени, от старых к новым
+func pvDays(hours []models.PVHour, settings PVSettings, now time.Time, loc *time.Location) []models.PVDay {
+	byDay := make(map[time.Time][]models.PVHour)
+	var dates []time.Time
+	for _, hour := range hours {
+		date := dayStart(hour.Time, loc)
+		if _, ok := byDay[date]; !ok {
+			dates = append(dates, date)
+		}
+		byDay[date] = append(byDay[date], hour)
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+	days := make([]models.PVDay, 0, len(dates))
+	for _, date := range dates {
+		days = append(days, pvDay(date, byDay[date], settings, now))
+	}
+	return days
+}
+
+// pvDay считает итог суток date по часам, попавшим в эти сутки
+func pvDay(date time.Time, hours []models.PVHour, settings PVSettings, now time.Time) models.PVDay {
+	end := date.AddDate(0, 0, 1)
+	day := models.PVDay{Date: date, Partial: now.Before(end), Hours: []models.PVHour{}}
+
+	var energy, insolation float64
+	for _, hour := range hours {
+		if hour.Time.Before(date) || !hour.Time.Before(end) {
+			continue
+		}
+		day.Hours = append(day.Hours, hour)
+		energy += hour.EnergyKWh
+		insolation += hour.POA / 1000 * math.Min(1, now.Sub(hour.Time).Hours())
+		day.PeakPowerKW = math.Max(day.PeakPowerKW, hour.PowerKW)
+	}
+	day.EnergyKWh = roundTo(energy, 2)
+	day.Insolation = roundTo(insolation, 2)
+	if settings.CapacityKWp > 0 {
+		day.SpecificYield = roundTo(energy/settings.CapacityKWp, 2)
+	}
+	return day
+}
+
+// pvMonths возвращает все календарные месяцы от from по to, включая месяцы без данных
+func pvMonths(days []models.PVDay, from, to time.Time, settings PVSettings, now time.Time) []models.PVMonth {
+	loc := to.Location()
+	var months []models.PVMonth
+	for month := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, loc); !month.After(to); month = month.AddDate(0, 1, 0) {
+		next := month.AddDate(0, 1, 0)
+		item := models.PVMonth{Month: month, Label: russianMonthYear(month), Partial: now.Before(next)}
+		var energy float64
+		for _, day := range days {
+			if day.Date.Before(month) || !day.Date.Before(next) {
+				continue
+			}
+			item.Days++
+			energy += day.EnergyKWh
+		}
+		item.EnergyKWh = roundTo(energy, 1)
+		if settings.CapacityKWp > 0 {
+			item.SpecificYield = roundTo(energy/settings.CapacityKWp, 1)
+		}
+		if item.Days > 0 {
+			item.AvgDailyKWh = roundTo(energy/float64(item.Days), 1)
+		}
+		months = append(months, item)
+	}
+	return months
+}
+
+// buildPVMonthlyReport выбирает отчётный месяц, соседние для сравнения и крайние дни.
+// months — от старых к новым и заканчиваются отчётным месяцем.
+func buildPVMonthlyReport(months []models.PVMonth, days []models.PVDay, month time.Time) *models.PVMonthlyReport {
+	report := &models.PVMonthlyReport{Months: []models.PVMonth{}}
+	for i := range months {
+		item := months[i]
+		switch {
+		case item.Month.Equal(month):
+			report.Month = item
+		case item.Month.Equal(month.AddDate(0, -1, 0)) && item.Days > 0:
+			report.PreviousMonth = &item
+		case item.Month.Equal(month.AddDate(-1, 0, 0)) && item.Days > 0:
+			report.LastYear = &item
+		}
+		if item.Month.After(month.AddDate(0, -PV_MONTHS_SHOWN, 0)) && !item.Month.After(month) {
+			report.Months = append(report.Months, item)
+		}
+	}
+
+	next := month.AddDate(0, 1, 0)
+	for i := range days {
+		day := days[i]
+		if day.Date.Before(month) || !day.Date.Before(next) || day.Partial {
+			continue
+		}
+		day.Hours = nil
+		if report.BestDay == nil || day.EnergyKWh > report.BestDay.EnergyKWh {
+			best := day
+			report.BestDay = &best
+		}
+		if report.WorstDay == nil || day.EnergyKWh < report.WorstDay.EnergyKWh {
+			worst := day
+			report.WorstDay = &worst
+		}
+	}
+
+	if report.Month.Days > 0 {
+		report.Rank = 1
+		for _, item := range report.Months {
+			if item.Days > 0 && item.EnergyKWh > report.Month.EnergyKWh {
+				report.Rank++
+			}
+		}
+	}
+	return report
+}
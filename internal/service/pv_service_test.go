@@ -0,0 +1,139 @@
+package service
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+func TestPlaneOfArrayIrradianceTiltAndOrientation(t *testing.T) {
+	// Ясный зимний полдень: низкое солнце на юге
+	noon := time.Date(2026, time.January, 15, 9, 15, 0, 0, time.UTC)
+	elevation, azimuth := solarPosition(noon, 44.995574, 41.128354)
+	ghi, _ := clearSkyRadiation(elevation, 158)
+
+	flat := planeOfArrayIrradiance(ghi, elevation, azimuth, 0, 180, noon.YearDay())
+	if math.Abs(flat-ghi) > 1 {
+		t.Fatalf("horizontal panel must receive the measured radiation: got %.0f, want %.0f", flat, ghi)
+	}
+	south := planeOfArrayIrradiance(ghi, elevation, azimuth, 45, 180, noon.YearDay())
+	if south < ghi*1.4 {
+		t.Fatalf("south-facing tilted panel should gain in winter: poa %.0f, ghi %.0f", south, ghi)
+	}
+	north := planeOfArrayIrradiance(ghi, elevation, azimuth, 45, 0, noon.YearDay())
+	if north >= ghi*0.5 {
+		t.Fatalf("north-facing panel should only see diffuse light: poa %.0f, ghi %.0f", north, ghi)
+	}
+
+	// В сплошной облачности свет рассеянный — ориентация почти не важна
+	overcast := ghi * 0.15
+	southOvercast := planeOfArrayIrradiance(overcast, elevation, azimuth, 45, 180, noon.YearDay())
+	northOvercast := planeOfArrayIrradiance(overcast, elevation, azimuth, 45, 0, noon.YearDay())
+	if southOvercast-northOvercast > overcast*0.1 {
+		t.Fatalf("overcast light should be nearly isotropic: south %.0f, north %.0f", southOvercast, northOvercast)
+	}
+}
+
+func TestPVHoursClearSummerDayAndHeatLoss(t *testing.T) {
+	sun := testSunService(t)
+	loc := sun.timezone
+	date := time.Date(2026, time.June, 21, 0, 0, 0, 0, loc)
+	coefficient := -0.4
+	settings := PVSettings{CapacityKWp: 5, Tilt: 30, Azimuth: 180, LossesPct: 14, TempCoefficient: &coefficient}
+
+	clearDay := func(temp float64) []models.PVHourInput {
+		var inputs []models.PVHourInput
+		for hour := date; hour.Before(date.AddDate(0, 0, 1)); hour = hour.Add(time.Hour) {
+			elevation, _ := solarPosition(hour.Add(30*time.Minute), sun.latitude, sun.longitude)
+			global, _ := clearSkyRadiation(elevation, sun.elevation)
+			inputs = append(inputs, models.PVHourInput{Time: hour, Radiation: global, TempOutdoor: &temp})
+		}
+		return inputs
+	}
+
+	now := date.AddDate(0, 0, 2)
+	mild := pvDay(date, pvHours(clearDay(15), settings, sun, now), settings, now)
+	hot := pvDay(date, pvHours(clearDay(35), settings, sun, now), settings, now)
+
+	// 5 кВт на юг в ясный день летнего солнцестояния — около 6 кВт·ч на кВт
+	if mild.Partial || mild.SpecificYield < 5 || mild.SpecificYield > 8 {
+		t.Fatalf("unexpected clear-day yield: %.1f kWh (%.2f kWh/kWp)", mild.EnergyKWh, mild.SpecificYield)
+	}
+	if mild.PeakPowerKW > settings.CapacityKWp {
+		t.Fatalf("peak power %.2f exceeds capacity", mild.PeakPowerKW)
+	}
+	if ratio := hot.EnergyKWh / mild.EnergyKWh; ratio < 0.88 || ratio > 0.96 {
+		t.Fatalf("20 °C hotter air should cost about 8%%, got ratio %.3f", ratio)
+	}
+}
+
+func TestPVHoursCountsElapsedPartOfCurrentHour(t *testing.T) {
+	sun := testSunService(t)
+	hour := time.Date(2026, time.June, 21, 12, 0, 0, 0, sun.timezone)
+	settings := PVSettings{CapacityKWp: 4, Tilt: 0, Azimuth: 180}
+
+	hours := pvHours([]models.PVHourInput{{Time: hour, Radiation: 1000}}, settings, sun, hour.Add(15*time.Minute))
+	if len(hours) != 1 || hours[0].PowerKW != 4 || hours[0].EnergyKWh != 1 {
+		t.Fatalf("quarter of an hour at 4 kW should give 1 kWh, got %#v", hours)
+	}
+}
+
+func TestBuildPVMonthlyReportComparesMonths(t *testing.T) {
+	loc := time.UTC
+	month := time.Date(2026, time.September, 1, 0, 0, 0, 0, loc)
+	settings := PVSettings{CapacityKWp: 5}
+	now := time.Date(2026, time.October, 1, 10, 0, 0, 0, loc)
+
+	var days []models.PVDay
+	addMonth := func(start time.Time, daily float64) {
+		for d := start; d.Before(start.AddDate(0, 1, 0)); d = d.AddDate(0, 0, 1) {
+			energy := daily
+			if d.Day() == 10 {
+				energy = daily * 2
+			}
+			if d.Day() == 20 {
+				energy = daily / 4
+			}
+			days = append(days, models.PVDay{Date: d, EnergyKWh: energy})
+		}
+	}
+	addMonth(time.Date(2025, time.September, 1, 0, 0, 0, 0, loc), 15)
+	addMonth(time.Date(2026, time.July, 1, 0, 0, 0, 0, loc), 25)
+	addMonth(time.Date(2026, time.August, 1, 0, 0, 0, 0, loc), 22)
+	addMonth(month, 18)
+
+	months := pvMonths(days, month.AddDate(-1, 0, 0), month.AddDate(0, 1, -1), settings, now)
+	report := buildPVMonthlyReport(months, days, month)
+
+	if report.Month.Label != "Сентябрь 2026" || report.Month.Days != 30 || report.Month.Partial {
+		t.Fatalf("unexpected report month: %#v", report.Month)
+	}
+	if report.PreviousMonth == nil || report.PreviousMonth.Label != "Август 2026" {
+		t.Fatalf("previous month = %#v", report.PreviousMonth)
+	}
+	if report.LastYear == nil || report.LastYear.Label != "Сентябрь 2025" {
+		t.Fatalf("last year = %#v", report.LastYear)
+	}
+	if len(report.Months) != PV_MONTHS_SHOWN {
+		t.Fatalf("got %d months, want %d", len(report.Months), PV_MONTHS_SHOWN)
+	}
+	// Сентябрь прошлого года не входит в 12 последних месяцев: впереди июль и август
+	if report.Rank != 3 {
+		t.Fatalf("rank = %d, want 3", report.Rank)
+	}
+	if report.BestDay == nil || report.BestDay.Date.Day() != 10 || report.WorstDay == nil || report.WorstDay.Date.Day() != 20 {
+		t.Fatalf("best %#v, worst %#v", report.BestDay, report.WorstDay)
+	}
+}
+
+func TestPVSettingsTempCoefficient(t *testing.T) {
+	if got := (PVSettings{}).tempCoefficient(); got != -0.4 {
+		t.Fatalf("коэффициент по умолчанию = %v, want -0.4", got)
+	}
+	zero := 0.0
+	if got := (PVSettings{TempCoefficient: &zero}).tempCoefficient(); got != 0 {
+		t.Fatalf("явный 0 заменён на %v", got)
+	}
+}
@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+// Параметры прогноза «дождь в ближайший час»
+const (
+	RAIN_SOON_THRESHOLD      = 60  // % уверенности, с которого отправляется уведомление
+	RAIN_SOON_EPISODE_HOURS  = 3   // дождь за последние N часов — эпизод ещё идёт, повторно не предупреждаем
+	RAIN_SOON_PRESSURE_DROP  = 2.0 // мм рт.ст. за 3 часа — полный балл по давлению
+	RAIN_SOON_HUMIDITY_RISE  = 8.0 // % за час — полный балл по влажности
+	RAIN_SOON_HUMID_LEVEL    = 85  // % — влажность, при которой добавляется небольшой балл
+	RAIN_SOON_SOLAR_MIN      = 150 // Вт/м² — ниже этого пика солнце как признак не используется
+	RAIN_SOON_PRECIP_FULL_MM = 0.5 // мм/ч в прогнозе — полный балл по количеству осадков
+
+	rainWeightForecast = 0.45
+	rainWeightPressure = 0.20
+	rainWeightHumidity = 0.15
+	rainWeightSolar    = 0.20
+)
+
+// RainNowcastService объединяет прогноз на ближайший час и тренды станции
+// (давление, влажность, солнечная радиация), чтобы предупредить о дожде до его начала.
+type RainNowcastService struct {
+	weatherSvc  *WeatherService
+	forecastSvc *ForecastService
+}
+
+func NewRainNowcastService(weatherSvc *WeatherService, forecastSvc *ForecastService) *RainNowcastService {
+	return &RainNowcastService{weatherSvc: weatherSvc, forecastSvc: forecastSvc}
+}
+
+// GetRainSoon возвращает текущую оценку вероятности дождя в ближайший час
+func (s *RainNowcastService) GetRainSoon(ctx context.Context) (*models.RainNowcast, error) {
+	now := time.Now()
+
+	data, err := s.weatherSvc.repo.GetDataForEventDetection(ctx, now.Add(-RAIN_SOON_EPISODE_HOURS*time.Hour), now)
+	if err != nil {
+		return nil, err
+	}
+
+	var next *models.HourlyForecast
+	if s.forecastSvc != nil {
+		// Прогноз хранится в локальном времени станции, поэтому берём запас по часам
+		// и выбираем ближайший час по локальным часам
+		hourly, err := s.forecastSvc.GetHourlyForecast(ctx, 6)
+		if err != nil {
+			// Без прогноза остаются признаки станции, scoreRainSoon снизит уверенность
+			slog.Warn("failed to get hourly forecast for rain nowcast", "error", err)
+		} else {
+			next = nextForecastHour(hourly, now, s.weatherSvc.location)
+		}
+	}
+
+	nowcast := scoreRainSoon(data, next, now)
+	return &nowcast, nil
+}
+
+// GetRainSoonEvent возвращает событие rain_soon, если уверенность выше порога
+// и дождя не было последние RAIN_SOON_EPISODE_HOURS часов. Иначе nil.
+func (s *RainNowcastService) GetRainSoonEvent(ctx context.Context) (*models.WeatherEvent, error) {
+	nowcast, err := s.GetRainSoon(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if nowcast.Raining || nowcast.RainedRecently || nowcast.Confidence < RAIN_SOON_THRESHOLD {
+		return nil, nil
+	}
+	return rainSoonEvent(nowcast), nil
+}
+
+func rainSoonEvent(nowcast *models.RainNowcast) *models.WeatherEvent {
+	lines := make([]string, 0, len(nowcast.Signals)+1)
+	lines = append(lines, fmt.Sprintf("Уверенность %d%%", nowcast.Confidence))
+	for _, signal := range nowcast.Signals {
+		if signal.Available && signal.Score > 0 && signal.Detail != "" {
+			lines = append(lines, "• "+signal.Detail)
+		}
+	}
+
+	return &models.WeatherEvent{
+		Type:        "rain_soon",
+		Time:        nowcast.Time,
+		Value:       float64(nowcast.Confidence),
+		Description: "Скоро дождь — в ближайший час",
+		Details:     strings.Join(lines, "\n"),
+		Icon:        "☂️",
+	}
+}
+
+// nextForecastHour выбирает прогноз на ближайший час. Время прогноза —
+// локальное время станции, помеченное как UTC (см. forecast-fetcher). Осадки и их
+// вероятность в строке Open-Meteo относятся к часу до её времени, поэтому в 14:20
+// нужна строка 15:00 (14:00–15:00), а строка 14:00 описывает уже прошедший час.
+func nextForecastHour(hourly []models.HourlyForecast, now time.Time, loc *time.Location) *models.HourlyForecast {
+	if loc == nil {
+		loc = time.Local
+	}
+	target := wallClockUTC(now, loc).Truncate(time.Hour).Add(time.Hour)
+	for i := range hourly {
+		t := hourly[i].Time.UTC()
+		if !t.Before(target) && t.Before(target.Add(2*time.Hour)) {
+			return &hourly[i]
+		}
+	}
+	return nil
+}
+
+// scoreRainSoon считает уверенность по признакам. data — 5-минутные данные станции
+// за последние часы (по возрастанию времени), next — прогноз на ближайший час.
+// Прогноз всегда входит в знаменатель: без него уверенность ниже.
+// Солнце учитывается только днём, когда было чему падать.
+func scoreRainSoon(data []models.WeatherData, next *models.HourlyForecast, now time.Time) models.RainNowcast {
+	result := models.RainNowcast{Time: now}
+	if len(data) == 0 {
+		return result
+	}
+	latest := data[len(data)-1]
+
+	for _, d := range data {
+		if d.RainRate != nil && *d.RainRate >= RAIN_THRESHOLD {
+			result.RainedRecently = true
+		}
+	}
+	result.Raining = latest.RainRate != nil && *latest.RainRate >= RAIN_THRESHOLD
+
+	// 1. Прогноз на ближайший час
+	forecast := models.RainSignal{Name: "forecast", Weight: rainWeightForecast, Available: next != nil}
+	if next != nil {
+		prob := float64(next.PrecipitationProbability) / 100
+		amount := clamp01(float64(next.Precipitation) / RAIN_SOON_PRECIP_FULL_MM)
+		forecast.Score = math.Max(prob, amount)
+		forecast.Detail = fmt.Sprintf("прогноз: осадки %d%%", next.PrecipitationProbability)
+		if next.Precipitation > 0 {
+			forecast.Detail += fmt.Sprintf(", %.1f мм", next.Precipitation)
+		}
+	}
+
+	// 2. Падение давления за 3 часа
+	pressure := models.RainSignal{Name: "pressure", Weight: rainWeightPressure}
+	if first := valueAtOrAfter(data, now.Add(-PRESSURE_PERIOD_HOURS*time.Hour), pressureOf); first != nil && latest.PressureRelative != nil {
+		change := float64(*latest.PressureRelative) - *first
+		pressure.Available = true
+		pressure.Score = clamp01(-change / RAIN_SOON_PRESSURE_DROP)
+		pressure.Detail = fmt.Sprintf("давление %+.1f мм за 3 ч", change)
+	}
+
+	// 3. Рост влажности за час
+	humidity := models.RainSignal{Name: "humidity", Weight: rainWeightHumidity}
+	if first := valueAtOrAfter(data, now.Add(-time.Hour), humidityOf); first != nil && latest.HumidityOutdoor != nil {
+		current := float64(*latest.HumidityOutdoor)
+		change := current - *first
+		humidity.Available = true
+		humidity.Score = clamp01(change / RAIN_SOON_HUMIDITY_RISE)
+		if current >= RAIN_SOON_HUMID_LEVEL {
+			humidity.Score = math.Min(1, humidity.Score+0.25)
+		}
+		humidity.Detail = fmt.Sprintf("влажность %.0f%% (%+.0f%% за час)", current, change)
+	}
+
+	// 4. Падение солнечной радиации за последний час (набегают облака)
+	solar := models.RainSignal{Name: "solar", Weight: rainWeightSolar}
+	if peak, recent, ok := solarDrop(data, now); ok {
+		drop := 1 - recent/peak
+		solar.Available = true
+		solar.Score = clamp01((drop - 0.3) / 0.4)
+		solar.Detail = fmt.Sprintf("солнце −%.0f%% за час", drop*100)
+	}
+
+	result.Signals = []models.RainSignal{forecast, pressure, humidity, solar}
+
+	var sum, weights float64
+	for _, signal := range result.Signals {
+		if !signal.Available && signal.Name != "forecast" {
+			continue
+		}
+		sum += signal.Score * signal.Weight
+		weights += signal.Weight
+	}
+	if weights > 0 {
+		result.Confidence = int(math.Round(sum / weights * 100))
+	}
+
+	return result
+}
+
+func pressureOf(d models.WeatherData) *float64 {
+	if d.PressureRelative == nil {
+		return nil
+	}
+	v := float64(*d.PressureRelative)
+	return &v
+}
+
+func humidityOf(d models.WeatherData) *float64 {
+	if d.HumidityOutdoor == nil {
+		return nil
+	}
+	v := float64(*d.HumidityOutdoor)
+	return &v
+}
+
+// valueAtOrAfter возвращает первое доступное значение начиная с момента from
+func valueAtOrAfter(data []models.WeatherData, from time.Time, get func(models.WeatherData) *float64) *float64 {
+	for _, d := range data {
+		if d.Time.Before(from) {
+			continue
+		}
+		if v := get(d); v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+// solarDrop возвращает пик радиации за последний час и среднее за последние 15 минут
+func solarDrop(data []models.WeatherData, now time.Time) (peak, recent float64, ok bool) {
+	var recentSum float64
+	var recentCount int
+	for _, d := range data {
+		if d.SolarRadiation == nil || d.Time.Before(now.Add(-time.Hour)) {
+			continue
+		}
+		v := float64(*d.SolarRadiation)
+		peak = math.Max(peak, v)
+		if !d.Time.Before(now.Add(-15 * time.Minute)) {
+			recentSum += v
+			recentCount++
+		}
+	}
+	if peak < RAIN_SOON_SOLAR_MIN || recentCount == 0 {
+		return 0, 0, false
+	}
+	return peak, recentSum / float64(recentCount), true
+}
+
+func clamp01(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
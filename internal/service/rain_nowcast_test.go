@@ -0,0 +1,134 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+// rainNowcastSeries строит 5-минутный ряд за 3 часа до now с линейным изменением давления,
+// влажности и (опционально) солнечной радиации
+func rainNowcastSeries(now time.Time, pressureFrom, pressureTo, humidityFrom, humidityTo float32, solar func(minutesAgo int) *float32) []models.WeatherData {
+	var data []models.WeatherData
+	const steps = 36
+	for i := 0; i <= steps; i++ {
+		frac := float32(i) / steps
+		p := pressureFrom + (pressureTo-pressureFrom)*frac
+		h := int16(humidityFrom + (humidityTo-humidityFrom)*frac)
+		minutesAgo := (steps - i) * 5
+		d := models.WeatherData{
+			Time:             now.Add(-time.Duration(minutesAgo) * time.Minute),
+			PressureRelative: &p,
+			HumidityOutdoor:  &h,
+		}
+		if solar != nil {
+			d.SolarRadiation = solar(minutesAgo)
+		}
+		data = append(data, d)
+	}
+	return data
+}
+
+func TestScoreRainSoon_HighConfidence(t *testing.T) {
+	now := time.Date(2026, time.June, 10, 14, 20, 0, 0, time.UTC)
+	data := rainNowcastSeries(now, 740, 737.5, 60, 78, func(minutesAgo int) *float32 {
+		v := float32(700)
+		if minutesAgo <= 15 {
+			v = 150
+		}
+		return &v
+	})
+	next := &models.HourlyForecast{PrecipitationProbability: 90, Precipitation: 1.2}
+
+	result := scoreRainSoon(data, next, now)
+
+	if result.Confidence < RAIN_SOON_THRESHOLD {
+		t.Fatalf("уверенность = %d, ожидалось не ниже %d", result.Confidence, RAIN_SOON_THRESHOLD)
+	}
+	if result.Raining || result.RainedRecently {
+		t.Fatal("дождя на станции не было")
+	}
+	for _, s := range result.Signals {
+		if !s.Available {
+			t.Fatalf("признак %s должен быть доступен днём", s.Name)
+		}
+	}
+
+	event := rainSoonEvent(&result)
+	if event.Type != "rain_soon" || int(event.Value) != result.Confidence {
+		t.Fatalf("событие = %+v", event)
+	}
+}
+
+func TestScoreRainSoon_NightWithoutSolar(t *testing.T) {
+	now := time.Date(2026, time.June, 10, 1, 0, 0, 0, time.UTC)
+	data := rainNowcastSeries(now, 740, 738, 80, 90, nil)
+	next := &models.HourlyForecast{PrecipitationProbability: 80}
+
+	result := scoreRainSoon(data, next, now)
+
+	for _, s := range result.Signals {
+		if s.Name == "solar" && s.Available {
+			t.Fatal("ночью солнце не должно учитываться")
+		}
+	}
+	// Без солнца его вес не занижает уверенность
+	if result.Confidence < RAIN_SOON_THRESHOLD {
+		t.Fatalf("уверенность = %d, ожидалось не ниже %d", result.Confidence, RAIN_SOON_THRESHOLD)
+	}
+}
+
+func TestScoreRainSoon_NoForecastLowersConfidence(t *testing.T) {
+	now := time.Date(2026, time.June, 10, 1, 0, 0, 0, time.UTC)
+	data := rainNowcastSeries(now, 740, 738, 80, 90, nil)
+
+	withForecast := scoreRainSoon(data, &models.HourlyForecast{PrecipitationProbability: 80}, now)
+	withoutForecast := scoreRainSoon(data, nil, now)
+
+	if withoutForecast.Confidence >= withForecast.Confidence {
+		t.Fatalf("без прогноза уверенность %d должна быть ниже, чем с прогнозом %d", withoutForecast.Confidence, withForecast.Confidence)
+	}
+	if withoutForecast.Confidence >= RAIN_SOON_THRESHOLD {
+		t.Fatalf("одних трендов станции недостаточно для уведомления, получено %d", withoutForecast.Confidence)
+	}
+}
+
+func TestScoreRainSoon_RainedRecently(t *testing.T) {
+	now := time.Date(2026, time.June, 10, 14, 0, 0, 0, time.UTC)
+	data := rainNowcastSeries(now, 740, 737, 70, 95, nil)
+	rain := float32(1.5)
+	data[10].RainRate = &rain
+
+	result := scoreRainSoon(data, &models.HourlyForecast{PrecipitationProbability: 100}, now)
+
+	if !result.RainedRecently {
+		t.Fatal("дождь в пределах эпизода должен подавлять повторное предупреждение")
+	}
+	if result.Raining {
+		t.Fatal("сейчас дождя нет")
+	}
+}
+
+func TestNextForecastHour(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Skipf("нет данных часового пояса: %v", err)
+	}
+	// 11:20 UTC = 14:20 по Москве; прогноз хранит локальное время с пометкой UTC
+	now := time.Date(2026, time.June, 10, 11, 20, 0, 0, time.UTC)
+	hourly := []models.HourlyForecast{
+		{Time: time.Date(2026, time.June, 10, 13, 0, 0, 0, time.UTC)},
+		{Time: time.Date(2026, time.June, 10, 14, 0, 0, 0, time.UTC), PrecipitationProbability: 20},
+		{Time: time.Date(2026, time.June, 10, 15, 0, 0, 0, time.UTC), PrecipitationProbability: 70},
+	}
+
+	next := nextForecastHour(hourly, now, loc)
+	if next == nil || next.PrecipitationProbability != 70 {
+		t.Fatalf("выбран час %+v, ожидался 15:00 по местному времени (осадки 14:00–15:00)", next)
+	}
+
+	if nextForecastHour(hourly[:2], now, loc) != nil {
+		t.Fatal("строка 14:00 описывает прошедший час и не должна выбираться")
+	}
+}
@@ -0,0 +1,362 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+// Параметры анализа отклика реки на дожди
+const (
+	RAIN_RIVER_DEFAULT_DAYS           = 365
+	RAIN_RIVER_DEFAULT_RESPONSE_HOURS = 72
+	RAIN_RIVER_DEFAULT_MIN_RAIN_MM    = 5
+	RAIN_RIVER_FORECAST_HOURS         = 48
+	RAIN_RIVER_WET_HOUR_MM            = 0.1 // час с меньшими осадками считается сухим
+	RAIN_RIVER_GAP_HOURS              = 6   // дожди с меньшим перерывом — один эпизод
+	RAIN_RIVER_BASE_HOURS             = 3   // насколько раньше начала дождя можно взять исходный уровень
+	RAIN_RIVER_MIN_REGRESSION         = 3   // завершённых эпизодов для регрессии
+	RAIN_RIVER_BAND_Z                 = 1.28
+	rainRiverCacheTTL                 = time.Hour
+)
+
+// RainRiverSettings — глубина истории, окно отклика и порог эпизода
+type RainRiverSettings struct {
+	Days          int
+	ResponseHours int     // сколько часов после дождя искать пик
+	MinRainMM     float64 // дожди слабее не разбираются: отклик теряется в колебаниях уровня
+}
+
+// RainRiverService сопоставляет дожди станции с уровнем реки у гидропоста: для каждого
+// эпизода находит подъём, время от центра масс осадков до пика и время спада на
+// половину подъёма, строит регрессию подъёма по сумме осадков и по ней оценивает
+// подъём от осадков в прогнозе.
+type RainRiverService struct {
+	weatherSvc  *WeatherService
+	forecastSvc *ForecastService
+	hydroSvc    *HydroService
+	settings    RainRiverSettings
+
+	mu      sync.Mutex
+	cached  *models.RainRiverAnalysis
+	expires time.Time
+}
+
+func NewRainRiverService(weatherSvc *WeatherService, forecastSvc *ForecastService, hydroSvc *HydroService, settings RainRiverSettings) *RainRiverService {
+	if settings.Days <= 0 {
+		settings.Days = RAIN_RIVER_DEFAULT_DAYS
+	}
+	if settings.ResponseHours <= 0 {
+		settings.ResponseHours = RAIN_RIVER_DEFAULT_RESPONSE_HOURS
+	}
+	if settings.MinRainMM <= 0 {
+		settings.MinRainMM = RAIN_RIVER_DEFAULT_MIN_RAIN_MM
+	}
+	return &RainRiverService{weatherSvc: weatherSvc, forecastSvc: forecastSvc, hydroSvc: hydroSvc, settings: settings}
+}
+
+// GetAnalysis возвращает эпизоды, регрессию и ожидаемый подъём от прогноза осадков
+func (s *RainRiverService) GetAnalysis(ctx context.Context, now time.Time) (*models.RainRiverAnalysis, error) {
+	s.mu.Lock()
+	if s.cached != nil && now.Before(s.expires) {
+		cached := s.cached
+		s.mu.Unlock()
+		return cached, nil
+	}
+	s.mu.Unlock()
+
+	from := now.AddDate(0, 0, -s.settings.Days).Truncate(time.Hour)
+	weather, err := s.weatherSvc.GetHistory(ctx, from, now, "1h")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hourly weather: %w", err)
+	}
+	readings, err := s.hydroSvc.GetRange(ctx, from, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hydro readings: %w", err)
+	}
+	var forecast []models.HourlyForecast
+	if s.forecastSvc != nil {
+		forecast, err = s.forecastSvc.GetHourlyForecast(ctx, RAIN_RIVER_FORECAST_HOURS)
+		if err != nil {
+			// Без прогноза теряется только ожидаемый подъём, разбор эпизодов остаётся
+			slog.Warn("failed to get hourly forecast for rain-river analysis", "error", err)
+			forecast = nil
+		}
+	}
+
+	analysis := buildRainRiverAnalysis(weather, readings, forecast, from, now, s.weatherSvc.location, s.settings)
+
+	s.mu.Lock()
+	s.cached = analysis
+	s.expires = now.Add(rainRiverCacheTTL)
+	s.mu.Unlock()
+	return analysis, nil
+}
+
+// rainEpisode — часы [start, end] ряда осадков
+type rainEpisode struct {
+	start, end int
+}
+
+func buildRainRiverAnalysis(weather []models.WeatherData, readings []models.HydroLevelReading, forecast []models.HourlyForecast, from, now time.Time, loc *time.Location, settings RainRiverSettings) *models.RainRiverAnalysis {
+	analysis := &models.RainRiverAnalysis{Days: settings.Days, MinRainMM: settings.MinRainMM, Episodes: []models.RainRiverEpisode{}}
+	n := int(now.Sub(from)/time.Hour) + 1
+	rain := hourlyRainMM(weather, from, n)
+	levels := hourlyHydroLevels(dropHydroSpikes(readings), from, n)
+	hourAt := func(i int) time.Time { return from.Add(time.Duration(i) * time.Hour) }
+
+	episodes := findRainEpisodes(rain, settings.MinRainMM)
+	for k, e := range episodes {
+		base := math.NaN()
+		for i := e.start; i >= max(0, e.start-RAIN_RIVER_BASE_HOURS) && math.IsNaN(base); i-- {
+			base = levels[i]
+		}
+		if math.IsNaN(base) {
+			continue
+		}
+
+		// Пик ищем до конца окна отклика, но не дальше начала следующего дождя
+		limit := min(e.end+settings.ResponseHours, n-1)
+		complete := e.end+settings.ResponseHours <= n-1
+		if k+1 < len(episodes) && episodes[k+1].start <= limit {
+			limit = episodes[k+1].start
+		}
+		peak := -1
+		for i := e.start; i <= limit; i++ {
+			if !math.IsNaN(levels[i]) && (peak < 0 || levels[i] > levels[peak]) {
+				peak = i
+			}
+		}
+		if peak < 0 {
+			continue
+		}
+
+		var total, weighted, maxRate float64
+		for i := e.start; i <= e.end; i++ {
+			total += rain[i]
+			weighted += rain[i] * float64(i)
+			maxRate = math.Max(maxRate, rain[i])
+		}
+		centroid := weighted / total
+		rise := math.Max(levels[peak]-base, 0)
+		episode := models.RainRiverEpisode{
+			RainStart:    hourAt(e.start),
+			RainEnd:      hourAt(e.end).Add(time.Hour),
+			RainMM:       roundTo(total, 1),
+			MaxRateMM:    roundTo(maxRate, 1),
+			BaseLevelBSM: roundTo(base, 3),
+			PeakLevelBSM: roundTo(levels[peak], 3),
+			PeakAt:       hourAt(peak),
+			RiseCm:       roundTo(rise*100, 0),
+			LagHours:     roundTo(math.Max(float64(peak)-centroid, 0), 1),
+			Complete:     complete,
+		}
+
+		// Спад: первый час после пика, когда ушла половина подъёма; следующий дождь спад обрывает
+		if rise > 0 {
+			end := n - 1
+			if k+1 < len(episodes) {
+				end = min(end, episodes[k+1].start)
+			}
+			for i := peak + 1; i <= end; i++ {
+				// Миллиметр допуска — точность хранения уровня в float32
+				if !math.IsNaN(levels[i]) && levels[i] <= levels[peak]-rise/2+0.001 {
+					hours := float64(i - peak)
+					episode.RecessionHours = &hours
+					break
+				}
+			}
+		}
+		analysis.Episodes = append(analysis.Episodes, episode)
+	}
+
+	var lags, recessions []float64
+	var xs, ys []float64
+	for _, e := range analysis.Episodes {
+		if !e.Complete {
+			continue
+		}
+		xs, ys = append(xs, e.RainMM), append(ys, e.RiseCm)
+		if e.RiseCm > 0 {
+			lags = append(lags, e.LagHours)
+		}
+		if e.RecessionHours != nil {
+			recessions = append(recessions, *e.RecessionHours)
+		}
+	}
+	analysis.Regression = fitRainRiverRegression(xs, ys)
+	analysis.MedianLagHours = medianFloat64(lags)
+	analysis.MedianRecessionHours = medianFloat64(recessions)
+	analysis.Expected = expectedRainRiverRise(analysis, forecast, now, loc)
+	analysis.Summary = rainRiverSummary(analysis)
+	return analysis
+}
+
+// hourlyRainMM — осадки за каждый час: средняя интенсивность часа, мм/ч, равна сумме за час
+func hourlyRainMM(weather []models.WeatherData, from time.Time, n int) []float64 {
+	rain := make([]float64, n)
+	for _, d := range weather {
+		i := int(d.Time.Sub(from) / time.Hour)
+		if d.Time.Before(from) || i >= n || d.RainRate == nil || *d.RainRate < 0 {
+			continue
+		}
+		rain[i] += float64(*d.RainRate)
+	}
+	return rain
+}
+
+// findRainEpisodes объединяет дождливые часы с перерывами короче RAIN_RIVER_GAP_HOURS
+// и оставляет эпизоды с суммой не меньше minRainMM
+func findRainEpisodes(rain []float64, minRainMM float64) []rainEpisode {
+	var episodes []rainEpisode
+	var current *rainEpisode
+	flush := func() {
+		if current == nil {
+			return
+		}
+		var total float64
+		for i := current.start; i <= current.end; i++ {
+			total += rain[i]
+		}
+		if total >= minRainMM {
+			episodes = append(episodes, *current)
+		}
+		current = nil
+	}
+	for i, mm := range rain {
+		if mm < RAIN_RIVER_WET_HOUR_MM {
+			continue
+		}
+		if current != nil && i-current.end > RAIN_RIVER_GAP_HOURS {
+			flush()
+		}
+		if current == nil {
+			current = &rainEpisode{start: i, end: i}
+		}
+		current.end = i
+	}
+	flush()
+	return episodes
+}
+
+// fitRainRiverRegression — МНК подъёма по сумме осадков
+func fitRainRiverRegression(xs, ys []float64) *models.RainRiverRegression {
+	n := len(xs)
+	if n < RAIN_RIVER_MIN_REGRESSION {
+		return nil
+	}
+	var sx, sy float64
+	for i := range xs {
+		sx, sy = sx+xs[i], sy+ys[i]
+	}
+	mx, my := sx/float64(n), sy/float64(n)
+	var sxx, sxy, syy float64
+	for i := range xs {
+		dx, dy := xs[i]-mx, ys[i]-my
+		sxx, sxy, syy = sxx+dx*dx, sxy+dx*dy, syy+dy*dy
+	}
+	if sxx == 0 {
+		return nil
+	}
+	slope := sxy / sxx
+	intercept := my - slope*mx
+	var sse float64
+	for i := range xs {
+		e := ys[i] - (intercept + slope*xs[i])
+		sse += e * e
+	}
+	r2 := 0.0
+	if syy > 0 {
+		r2 = 1 - sse/syy
+	}
+	residual := 0.0
+	if n > 2 {
+		residual = math.Sqrt(sse / float64(n-2))
+	}
+	return &models.RainRiverRegression{
+		Slope:     roundTo(slope, 2),
+		Intercept: roundTo(intercept, 1),
+		R2:        roundTo(r2, 2),
+		Residual:  roundTo(residual, 1),
+		Samples:   n,
+	}
+}
+
+// expectedRainRiverRise оценивает подъём от суммы осадков в прогнозе по регрессии.
+// Время прогноза — локальное время станции с пометкой UTC, поэтому сравнивается
+// с wallClockUTC(now), а пик считается смещением от настоящего now.
+func expectedRainRiverRise(analysis *models.RainRiverAnalysis, forecast []models.HourlyForecast, now time.Time, loc *time.Location) *models.RainRiverExpectation {
+	if analysis.Regression == nil || len(forecast) == 0 {
+		return nil
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+	localNow := wallClockUTC(now, loc)
+	var total, weighted float64
+	for _, f := range forecast {
+		if f.Time.Before(localNow) || f.Precipitation <= 0 {
+			continue
+		}
+		total += float64(f.Precipitation)
+		weighted += float64(f.Precipitation) * f.Time.Sub(localNow).Hours()
+	}
+	reg := analysis.Regression
+	rise := math.Max(reg.Intercept+reg.Slope*total, 0)
+	band := RAIN_RIVER_BAND_Z * reg.Residual
+	expected := &models.RainRiverExpectation{
+		Hours:          RAIN_RIVER_FORECAST_HOURS,
+		RainMM:         roundTo(total, 1),
+		RiseCm:         roundTo(rise, 0),
+		LowerCm:        roundTo(math.Max(rise-band, 0), 0),
+		UpperCm:        roundTo(rise+band, 0),
+		BelowThreshold: total < analysis.MinRainMM,
+	}
+	if expected.BelowThreshold {
+		expected.RiseCm, expected.LowerCm, expected.UpperCm = 0, 0, 0
+		return expected
+	}
+	if analysis.MedianLagHours != nil {
+		peak := now.Add(time.Duration((weighted/total + *analysis.MedianLagHours) * float64(time.Hour))).Round(time.Hour)
+		expected.PeakAt = &peak
+	}
+	return expected
+}
+
+func rainRiverSummary(analysis *models.RainRiverAnalysis) string {
+	reg := analysis.Regression
+	if reg == nil {
+		return fmt.Sprintf("Пока мало дождей от %.0f мм с наблюдениями уровня — нужно хотя бы %d", analysis.MinRainMM, RAIN_RIVER_MIN_REGRESSION)
+	}
+	summary := fmt.Sprintf("Каждые 10 мм дождя на станции поднимают реку в среднем на %.0f см", reg.Slope*10)
+	if analysis.MedianLagHours != nil {
+		summary += fmt.Sprintf(", пик приходит через ~%.0f ч", *analysis.MedianLagHours)
+	}
+	if analysis.MedianRecessionHours != nil {
+		summary += fmt.Sprintf(", половина подъёма уходит за ~%.0f ч", *analysis.MedianRecessionHours)
+	}
+	if reg.R2 < 0.3 {
+		summary += ". Связь слабая: подъём больше зависит от дождей в горах, чем от местных"
+	}
+	return summary
+}
+
+func medianFloat64(values []float64) *float64 {
+	if len(values) == 0 {
+		return nil
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	m := sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		m = (sorted[len(sorted)/2-1] + m) / 2
+	}
+	m = roundTo(m, 1)
+	return &m
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+// syntheticRainRiver — дожди по 5 часов и река, поднимающаяся на 2 см на мм
+// через 12 ч после центра масс осадков и спадающая за сутки
+func syntheticRainRiver(from time.Time, hours int, rains map[int]float64) ([]models.WeatherData, []models.HydroLevelReading) {
+	levels := make([]float64, hours)
+	for i := range levels {
+		levels[i] = 250
+	}
+	var weather []models.WeatherData
+	rates := make(map[int]float32)
+	for start, mm := range rains {
+		for h := 0; h < 5; h++ {
+			rates[start+h] = float32(mm / 5)
+		}
+		peak := start + 2 + 12
+		rise := 2 * mm / 100
+		for i := start; i < hours && i <= peak+24; i++ {
+			switch {
+			case i <= peak:
+				levels[i] += rise * float64(i-start) / float64(peak-start)
+			default:
+				levels[i] += rise * (1 - float64(i-peak)/24)
+			}
+		}
+	}
+	var readings []models.HydroLevelReading
+	for i := 0; i < hours; i++ {
+		at := from.Add(time.Duration(i) * time.Hour)
+		rate := rates[i]
+		weather = append(weather, models.WeatherData{Time: at, RainRate: &rate})
+		readings = append(readings, models.HydroLevelReading{ObservedAt: at.Add(5 * time.Minute), LevelBSM: float32(levels[i])})
+	}
+	return weather, readings
+}
+
+func TestBuildRainRiverAnalysis(t *testing.T) {
+	from := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	hours := 30 * 24
+	now := from.Add(time.Duration(hours-1) * time.Hour)
+	weather, readings := syntheticRainRiver(from, hours, map[int]float64{72: 10, 240: 20, 432: 30, 600: 15, 715: 12})
+	forecast := []models.HourlyForecast{
+		{Time: now.Add(3 * time.Hour), Precipitation: 6},
+		{Time: now.Add(5 * time.Hour), Precipitation: 4},
+		{Time: now.Add(7 * time.Hour), Precipitation: 0},
+	}
+	settings := RainRiverSettings{Days: 30, ResponseHours: 72, MinRainMM: 5}
+
+	analysis := buildRainRiverAnalysis(weather, readings, forecast, from, now, time.UTC, settings)
+	if len(analysis.Episodes) != 5 {
+		t.Fatalf("episodes = %d, want 5", len(analysis.Episodes))
+	}
+	first := analysis.Episodes[0]
+	if first.RainMM != 10 || first.RiseCm != 20 || first.LagHours != 12 {
+		t.Fatalf("first episode = %+v, want 10 mm, 20 cm, lag 12 h", first)
+	}
+	if first.RecessionHours == nil || *first.RecessionHours != 12 {
+		t.Fatalf("first recession = %v, want 12 h", *first.RecessionHours)
+	}
+	if last := analysis.Episodes[4]; last.Complete {
+		t.Fatalf("last episode should be incomplete: %+v", last)
+	}
+
+	reg := analysis.Regression
+	if reg == nil || reg.Samples != 4 || math.Abs(reg.Slope-2) > 0.01 || math.Abs(reg.Intercept) > 0.5 || reg.R2 < 0.99 {
+		t.Fatalf("regression = %+v, want slope 2 through zero on 4 episodes", reg)
+	}
+	if analysis.MedianLagHours == nil || *analysis.MedianLagHours != 12 {
+		t.Fatalf("MedianLagHours = %v, want 12", analysis.MedianLagHours)
+	}
+
+	expected := analysis.Expected
+	if expected == nil || expected.RainMM != 10 || expected.RiseCm != 20 || expected.BelowThreshold {
+		t.Fatalf("expected = %+v, want 20 cm from 10 mm", expected)
+	}
+	if expected.PeakAt == nil || !expected.PeakAt.Equal(now.Add(16*time.Hour)) {
+		t.Fatalf("expected peak = %v, want now+16h", expected.PeakAt)
+	}
+}
+
+func TestFindRainEpisodes(t *testing.T) {
+	rain := []float64{0, 2, 0, 0, 3, 0, 0, 0, 0, 0, 0, 0, 0.05, 1, 0}
+	episodes := findRainEpisodes(rain, 4)
+	if len(episodes) != 1 || episodes[0].start != 1 || episodes[0].end != 4 {
+		t.Fatalf("episodes = %+v, want one merged episode 1..4 and the weak one dropped", episodes)
+	}
+}
+
+func TestExpectedRainRiverRiseBelowThreshold(t *testing.T) {
+	now := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	analysis := &models.RainRiverAnalysis{MinRainMM: 5, Regression: &models.RainRiverRegression{Slope: 2, Intercept: 3, Residual: 4}}
+	expected := expectedRainRiverRise(analysis, []models.HourlyForecast{{Time: now.Add(time.Hour), Precipitation: 2}}, now, time.UTC)
+	if expected == nil || !expected.BelowThreshold || expected.RiseCm != 0 {
+		t.Fatalf("expected = %+v, want below threshold without rise", expected)
+	}
+}
+
+func TestExpectedRainRiverRiseUsesStationWallClock(t *testing.T) {
+	loc := time.FixedZone("MSK", 3*60*60)
+	// 09:00 UTC — 12:00 на станции; строки прогноза хранят местное время с пометкой UTC
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	lag := 12.0
+	analysis := &models.RainRiverAnalysis{MinRainMM: 5, MedianLagHours: &lag, Regression: &models.RainRiverRegression{Slope: 2}}
+	forecast := []models.HourlyForecast{
+		{Time: time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC), Precipitation: 10}, // уже прошёл
+		{Time: time.Date(2026, 5, 1, 13, 0, 0, 0, time.UTC), Precipitation: 10},
+	}
+
+	expected := expectedRainRiverRise(analysis, forecast, now, loc)
+	if expected == nil || expected.RainMM != 10 || expected.RiseCm != 20 {
+		t.Fatalf("expected = %+v, want 20 cm from the 10 mm still ahead", expected)
+	}
+	if expected.PeakAt == nil || !expected.PeakAt.Equal(now.Add(13*time.Hour)) {
+		t.Fatalf("expected peak = %v, want %v", expected.PeakAt, now.Add(13*time.Hour))
+	}
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+	"github.com/iRootPro/weather/internal/repository"
+)
+
+const defaultReanalysisBaselineYears = 30
+
+// SetReanalysisBaseline enables the long-term baseline from reanalysis model data
+// (Open-Meteo historical archive) for insights and the archive.
+func (s *WeatherService) SetReanalysisBaseline(repo repository.ReanalysisRepository, source string, years int) {
+	if source == "" {
+		source = models.ReanalysisSourceERA5
+	}
+	if years <= 0 {
+		years = defaultReanalysisBaselineYears
+	}
+	s.reanalysisRepo = repo
+	s.reanalysisSource = source
+	s.reanalysisYears = years
+}
+
+// getReanalysisDays loads reanalysis days in [from, to) as daily insights, optionally limited to calendar months.
+func (s *WeatherService) getReanalysisDays(ctx context.Context, from, to time.Time, months []int) ([]models.DailyWeatherInsight, error) {
+	days, err := s.reanalysisRepo.GetRange(ctx, s.reanalysisSource, from, to, months)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.DailyWeatherInsight, 0, len(days))
+	for _, day := range days {
+		result = append(result, day.DailyInsight())
+	}
+	return result, nil
+}
+
+// getReanalysisMonthBenchmark compares the selected month with the same month across reanalysis years.
+func (s *WeatherService) getReanalysisMonthBenchmark(ctx context.Context, monthStart, analysisDate time.Time, current models.MonthlyWeatherInsights, loc *time.Location) (models.WeatherArchiveBenchmark, error) {
+	if s.reanalysisRepo == nil {
+		return models.WeatherArchiveBenchmark{}, nil
+	}
+	from := monthStart.AddDate(-s.reanalysisYears, 0, 0)
+	days, err := s.getReanalysisDays(ctx, from, monthStart, []int{int(monthStart.Month())})
+	if err != nil {
+		return models.WeatherArchiveBenchmark{}, err
+	}
+	benchmark := buildSameMonthBenchmark(analysisDate, current, days, loc)
+	labelReanalysisBenchmark(&benchmark, s.reanalysisSource, days, loc, fmt.Sprintf("%s к %d числу", monthName(analysisDate.Month()), analysisDate.Day()))
+	return benchmark, nil
+}
+
+// getReanalysisSeasonBenchmark compares the selected season with the same season across reanalysis years.
+func (s *WeatherService) getReanalysisSeasonBenchmark(ctx context.Context, seasonStart time.Time, seasonYear int, seasonCode string, compareDays int, current models.MonthlyWeatherInsights, loc *time.Location) (models.WeatherArchiveBenchmark, error) {
+	if s.reanalysisRepo == nil {
+		return models.WeatherArchiveBenchmark{}, nil
+	}
+	months := make([]int, 0, 3)
+	for i := 0; i < 3; i++ {
+		months = append(months, int(seasonStart.AddDate(0, i, 0).Month()))
+	}
+	from := seasonStart.AddDate(-s.reanalysisYears, 0, 0)
+	days, err := s.getReanalysisDays(ctx, from, seasonStart, months)
+	if err != nil {
+		return models.WeatherArchiveBenchmark{}, err
+	}
+	benchmark := buildSameSeasonBenchmark(current, days, seasonYear, seasonCode, compareDays, loc)
+	labelReanalysisBenchmark(&benchmark, s.reanalysisSource, days, loc, fmt.Sprintf("%s к %d дню сезона", seasonNameByCode(seasonCode), compareDays))
+	return benchmark, nil
+}
+
+// labelReanalysisBenchmark rewrites station-archive wording so the norm is clearly presented as model data.
+func labelReanalysisBenchmark(benchmark *models.WeatherArchiveBenchmark, source string, days []models.DailyWeatherInsight, loc *time.Location, period string) {
+	benchmark.ModelData = true
+	benchmark.SourceLabel = reanalysisSourceLabel(source)
+	benchmark.Title = "Многолетняя норма по реанализу"
+	benchmark.Subtitle = period + " · модельные данные, не станция"
+	if !benchmark.Available {
+		benchmark.StatusText = "Данных реанализа для этого периода пока нет — загрузите их командой climate-backfill."
+		return
+	}
+	firstYear, lastYear := days[0].Date.In(loc).Year(), days[len(days)-1].Date.In(loc).Year()
+	benchmark.Subtitle = fmt.Sprintf("%s, %d–%d · модельные данные, не станция", period, firstYear, lastYear)
+	benchmark.StatusText = fmt.Sprintf("Норма по %d годам данных: %s. Это модель с сеткой около 25 км: осадки в ней сглажены и могут заметно отличаться от измерений станции.", benchmark.SampleSize, benchmark.SourceLabel)
+}
+
+// getArchiveBaseline averages the same calendar window over previous reanalysis years.
+func (s *WeatherService) getArchiveBaseline(ctx context.Context, start, end time.Time, summary models.WeatherArchiveSummary, loc *time.Location) (models.WeatherArchiveBaseline, error) {
+	if s.reanalysisRepo == nil {
+		return models.WeatherArchiveBaseline{}, nil
+	}
+	days, err := s.getReanalysisDays(ctx, start.AddDate(-s.reanalysisYears, 0, 0), end.AddDate(-1, 0, 0), archiveMonths(start, end))
+	if err != nil {
+		return models.WeatherArchiveBaseline{}, err
+	}
+	baseline := buildArchiveBaseline(start, end, summary, days, s.reanalysisYears, loc)
+	baseline.Source = s.reanalysisSource
+	baseline.SourceLabel = reanalysisSourceLabel(s.reanalysisSource)
+	return baseline, nil
+}
+
+// archiveMonths lists the calendar months touched by [start, end); nil means the whole year.
+func archiveMonths(start, end time.Time) []int {
+	seen := make(map[time.Month]bool)
+	months := make([]int, 0, 12)
+	for month := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, start.Location()); month.Before(end); month = month.AddDate(0, 1, 0) {
+		if !seen[month.Month()] {
+			seen[month.Month()] = true
+			months = append(months, int(month.Month()))
+		}
+		if len(months) == 12 {
+			return nil
+		}
+	}
+	return months
+}
+
+func buildArchiveBaseline(start, end time.Time, current models.WeatherArchiveSummary, days []models.DailyWeatherInsight, years int, loc *time.Location) models.WeatherArchiveBaseline {
+	baseline := models.WeatherArchiveBaseline{}
+	expected := daysBetween(start, end)
+	minDays := maxInt(1, int(math.Ceil(float64(expected)*0.9)))
+
+	var tempSum, rainSum, rainDaysSum float64
+	var tempYears, rainYears int
+	for offset := 1; offset <= years; offset++ {
+		yearStart, yearEnd := start.AddDate(-offset, 0, 0), end.AddDate(-offset, 0, 0)
+		yearDays := filterDaysBetween(days, yearStart, yearEnd, loc)
+		if len(yearDays) < minDays {
+			continue
+		}
+		summary := buildArchiveSummary(yearDays, expected)
+		if summary.HasTemp {
+			tempSum += summary.TempAvg
+			tempYears++
+		}
+		if summary.HasRain {
+			rainSum += summary.RainTotal
+			rainDaysSum += float64(summary.RainDays)
+			rainYears++
+		}
+		if baseline.YearTo == 0 {
+			baseline.YearTo = yearStart.Year()
+		}
+		baseline.YearFrom = yearStart.Year()
+		baseline.Years++
+	}
+
+	if baseline.Years == 0 {
+		return baseline
+	}
+	baseline.Available = true
+	if tempYears > 0 {
+		baseline.HasTemp = true
+		baseline.TempAvg = tempSum / float64(tempYears)
+		if current.HasTemp {
+			baseline.TempDelta = math.Round((current.TempAvg-baseline.TempAvg)*10) / 10
+		}
+	}
+	if rainYears > 0 {
+		baseline.HasRain = true
+		baseline.RainTotal = rainSum / float64(rainYears)
+		baseline.RainDays = rainDaysSum / float64(rainYears)
+		if current.HasRain {
+			baseline.RainRatioPercent = int(math.Round(ratioPercent(current.RainTotal, baseline.RainTotal)))
+		}
+	}
+	return baseline
+}
+
+func reanalysisSourceLabel(source string) string {
+	switch strings.ToLower(source) {
+	case "era5":
+		return "реанализ ERA5"
+	case "era5_land":
+		return "реанализ ERA5-Land"
+	case "cerra":
+		return "реанализ CERRA"
+	default:
+		return "реанализ " + source
+	}
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+func reanalysisYearDays(start time.Time, days int, temp, rain float32) []models.DailyWeatherInsight {
+	result := make([]models.DailyWeatherInsight, 0, days)
+	for i := 0; i < days; i++ {
+		t, r := temp, float32(0)
+		if i == 0 {
+			r = rain
+		}
+		result = append(result, models.DailyWeatherInsight{Date: start.AddDate(0, 0, i), TempMin: &t, TempMax: &t, TempAvg: &t, RainTotal: &r})
+	}
+	return result
+}
+
+func TestBuildArchiveBaselineAveragesPreviousYears(t *testing.T) {
+	loc := time.UTC
+	start := time.Date(2026, time.March, 1, 0, 0, 0, 0, loc)
+	end := start.AddDate(0, 1, 0)
+	var days []models.DailyWeatherInsight
+	days = append(days, reanalysisYearDays(start.AddDate(-1, 0, 0), 31, 2, 30)...)
+	days = append(days, reanalysisYearDays(start.AddDate(-2, 0, 0), 31, 0, 10)...)
+	// Неполный год не должен попадать в норму
+	days = append(days, reanalysisYearDays(start.AddDate(-3, 0, 0), 10, 20, 100)...)
+
+	current := models.WeatherArchiveSummary{TempAvg: 3, HasTemp: true, RainTotal: 30, HasRain: true}
+	baseline := buildArchiveBaseline(start, end, current, days, 30, loc)
+	if !baseline.Available || baseline.Years != 2 || baseline.YearFrom != 2024 || baseline.YearTo != 2025 {
+		t.Fatalf("unexpected baseline years: %#v", baseline)
+	}
+	if baseline.TempAvg != 1 || baseline.TempDelta != 2 {
+		t.Fatalf("unexpected temperature baseline: %#v", baseline)
+	}
+	if baseline.RainTotal != 20 || baseline.RainDays != 1 || baseline.RainRatioPercent != 150 {
+		t.Fatalf("unexpected rain baseline: %#v", baseline)
+	}
+}
+
+func TestBuildArchiveBaselineWithoutData(t *testing.T) {
+	start := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	baseline := buildArchiveBaseline(start, start.AddDate(0, 1, 0), models.WeatherArchiveSummary{}, nil, 30, time.UTC)
+	if baseline.Available {
+		t.Fatalf("baseline must be unavailable without reanalysis data: %#v", baseline)
+	}
+}
+
+func TestArchiveMonths(t *testing.T) {
+	winter := archiveMonths(time.Date(2025, time.December, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC))
+	if len(winter) != 3 || winter[0] != 12 || winter[1] != 1 || winter[2] != 2 {
+		t.Fatalf("unexpected winter months: %v", winter)
+	}
+	if year := archiveMonths(time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)); year != nil {
+		t.Fatalf("whole year must not filter by month, got %v", year)
+	}
+}
+
+func TestLabelReanalysisBenchmarkMarksModelData(t *testing.T) {
+	benchmark := models.WeatherArchiveBenchmark{}
+	labelReanalysisBenchmark(&benchmark, models.ReanalysisSourceERA5, nil, time.UTC, "март к 10 числу")
+	if !benchmark.ModelData || benchmark.SourceLabel != "реанализ ERA5" || benchmark.StatusText == "" {
+		t.Fatalf("unexpected labels: %#v", benchmark)
+	}
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+// Параметры анализа солнечной активности
+const (
+	SOLAR_ROTATION_DAYS  = 27 // синодический оборот Солнца: активные области и корональные дыры возвращаются
+	SOLAR_DISTURBED_AP   = 15 // Ap, с которого день считается возмущённым
+	SOLAR_DISTURBED_KP   = 4  // максимум Kp, с которого день считается возмущённым
+	SOLAR_DEFAULT_DAYS   = 90
+	SOLAR_MAX_DAYS       = 1095
+	SOLAR_WEEK_DAYS      = 7
+	SOLAR_OUTLOOK_DAYS   = SOLAR_ROTATION_DAYS
+	SOLAR_MIN_RECURRENCE = 3 // минимум пар «день — день через оборот» для доли повторяемости
+)
+
+// GetSolarActivity возвращает ряды F10.7, числа пятен и Ap за последние days суток,
+// отмечает возмущения, повторившиеся через оборот Солнца, и по ним даёт прогноз
+// вероятных возмущённых дней на оборот вперёд.
+func (s *GeomagneticService) GetSolarActivity(ctx context.Context, now time.Time, days int) (*models.SolarActivity, error) {
+	if days <= 0 {
+		days = SOLAR_DEFAULT_DAYS
+	}
+	days = min(days, SOLAR_MAX_DAYS)
+
+	// Колонка date хранит календарную дату как UTC-полночь
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	from := today.AddDate(0, 0, -(days - 1))
+	// Для отметки повторяемости нужен ещё один оборот до начала периода
+	daily, err := s.repo.GetDailyRange(ctx, from.AddDate(0, 0, -SOLAR_ROTATION_DAYS), today)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get geomagnetic daily: %w", err)
+	}
+	return buildSolarActivity(daily, from, today), nil
+}
+
+// solarDayDisturbed — возмущённый день: Ap или максимум Kp выше спокойного уровня
+func solarDayDisturbed(d models.GeomagneticDaily) bool {
+	return (d.Ap != nil && *d.Ap >= SOLAR_DISTURBED_AP) || (d.MaxKp != nil && *d.MaxKp >= SOLAR_DISTURBED_KP)
+}
+
+func buildSolarActivity(daily []models.GeomagneticDaily, from, today time.Time) *models.SolarActivity {
+	byDate := make(map[time.Time]models.GeomagneticDaily, len(daily))
+	for _, d := range daily {
+		byDate[d.Date.UTC()] = d
+	}
+	rotationAgo := func(t time.Time) (models.GeomagneticDaily, bool) {
+		d, ok := byDate[t.AddDate(0, 0, -SOLAR_ROTATION_DAYS)]
+		return d, ok
+	}
+
+	activity := &models.SolarActivity{From: from, To: today, Days: []models.SolarActivityDay{}, Outlook: []models.SolarActivityOutlook{}}
+	var f10, sn, ap []*float32
+	pairs, hits := 0, 0
+	for _, d := range daily {
+		date := d.Date.UTC()
+		if date.After(today) {
+			continue
+		}
+		disturbed := solarDayDisturbed(d)
+		prev, hasPrev := rotationAgo(date)
+		if hasPrev && solarDayDisturbed(prev) {
+			pairs++
+			if disturbed {
+				hits++
+			}
+		}
+		if date.Before(from) {
+			continue
+		}
+		activity.Days = append(activity.Days, models.SolarActivityDay{
+			Date:      date,
+			F10:       d.F10,
+			Sn:        d.Sn,
+			Ap:        d.Ap,
+			MaxKp:     d.MaxKp,
+			Disturbed: disturbed,
+			Recurrent: disturbed && hasPrev && solarDayDisturbed(prev),
+		})
+		f10, sn, ap = append(f10, d.F10), append(sn, d.Sn), append(ap, d.Ap)
+	}
+	activity.F10Avg, activity.SnAvg, activity.ApAvg = averageFloat32(f10, 1), averageFloat32(sn, 0), averageFloat32(ap, 1)
+	if pairs >= SOLAR_MIN_RECURRENCE {
+		rate := hits * 100 / pairs
+		activity.RecurrenceRate = &rate
+	}
+
+	for i := 1; i <= SOLAR_OUTLOOK_DAYS; i++ {
+		date := today.AddDate(0, 0, i)
+		if src, ok := rotationAgo(date); ok && solarDayDisturbed(src) {
+			activity.Outlook = append(activity.Outlook, models.SolarActivityOutlook{Date: date, SourceDate: src.Date.UTC(), Ap: src.Ap, MaxKp: src.MaxKp})
+		}
+	}
+
+	activity.Week = buildSolarWeek(byDate, activity.Outlook, today)
+	return activity
+}
+
+// buildSolarWeek сравнивает последние 7 суток с предыдущими и берёт прогноз повторяемости на неделю вперёд
+func buildSolarWeek(byDate map[time.Time]models.GeomagneticDaily, outlook []models.SolarActivityOutlook, today time.Time) models.SolarActivityWeek {
+	week := models.SolarActivityWeek{From: today.AddDate(0, 0, -(SOLAR_WEEK_DAYS - 1)), To: today, Outlook: []models.SolarActivityOutlook{}}
+	var f10, sn, ap, prevF10, prevSn, prevAp []*float32
+	hasData := false
+	for i := 0; i < 2*SOLAR_WEEK_DAYS; i++ {
+		d, ok := byDate[today.AddDate(0, 0, -i)]
+		if !ok {
+			continue
+		}
+		if i >= SOLAR_WEEK_DAYS {
+			prevF10, prevSn, prevAp = append(prevF10, d.F10), append(prevSn, d.Sn), append(prevAp, d.Ap)
+			continue
+		}
+		hasData = true
+		f10, sn, ap = append(f10, d.F10), append(sn, d.Sn), append(ap, d.Ap)
+		if solarDayDisturbed(d) {
+			week.DisturbedDays++
+		}
+		if d.Ap != nil && (week.MaxAp == nil || *d.Ap > *week.MaxAp) {
+			date := d.Date.UTC()
+			week.MaxAp, week.MaxApDate = d.Ap, &date
+		}
+	}
+	week.F10Avg, week.SnAvg, week.ApAvg = averageFloat32(f10, 1), averageFloat32(sn, 0), averageFloat32(ap, 1)
+	week.PrevF10Avg, week.PrevSnAvg, week.PrevApAvg = averageFloat32(prevF10, 1), averageFloat32(prevSn, 0), averageFloat32(prevAp, 1)
+	for _, o := range outlook {
+		if !o.Date.After(today.AddDate(0, 0, SOLAR_WEEK_DAYS)) {
+			week.Outlook = append(week.Outlook, o)
+		}
+	}
+
+	if !hasData {
+		week.Summary = "нет данных о солнечной активности за неделю"
+		return week
+	}
+	var parts []string
+	if week.F10Avg != nil {
+		parts = append(parts, fmt.Sprintf("поток F10.7 в среднем %.0f sfu", *week.F10Avg)+solarDelta(week.F10Avg, week.PrevF10Avg, "%+.0f"))
+	}
+	if week.SnAvg != nil {
+		parts = append(parts, fmt.Sprintf("пятен %.0f", *week.SnAvg)+solarDelta(week.SnAvg, week.PrevSnAvg, "%+.0f"))
+	}
+	if week.ApAvg != nil {
+		parts = append(parts, fmt.Sprintf("Ap %.0f", *week.ApAvg)+solarDelta(week.ApAvg, week.PrevApAvg, "%+.0f"))
+	}
+	summary := strings.Join(parts, ", ")
+	if week.DisturbedDays > 0 {
+		summary += fmt.Sprintf("; возмущённых дней: %d", week.DisturbedDays)
+	} else {
+		summary += "; магнитосфера спокойна"
+	}
+	if len(week.Outlook) > 0 {
+		dates := make([]string, 0, len(week.Outlook))
+		for _, o := range week.Outlook {
+			dates = append(dates, o.Date.Format("02.01"))
+		}
+		summary += ". По 27-дневной повторяемости возможны возмущения " + strings.Join(dates, ", ")
+	}
+	runes := []rune(summary)
+	runes[0] = unicode.ToUpper(runes[0])
+	week.Summary = string(runes)
+	return week
+}
+
+// solarDelta — изменение к предыдущей неделе в скобках, пусто без сравнения
+func solarDelta(current, previous *float64, format string) string {
+	if current == nil || previous == nil {
+		return ""
+	}
+	return " (" + fmt.Sprintf(format, *current-*previous) + " к прошлой неделе)"
+}
+
+// averageFloat32 — среднее по известным значениям, nil если значений нет
+func averageFloat32(values []*float32, prec int) *float64 {
+	var sum float64
+	n := 0
+	for _, v := range values {
+		if v != nil {
+			sum += float64(*v)
+			n++
+		}
+	}
+	if n == 0 {
+		return nil
+	}
+	avg := roundTo(sum/float64(n), prec)
+	return &avg
+}
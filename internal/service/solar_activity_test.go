@@ -0,0 +1,92 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+func TestBuildSolarActivity(t *testing.T) {
+	today := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+	day := func(offset int) time.Time { return today.AddDate(0, 0, offset) }
+	f32 := func(v float32) *float32 { return &v }
+
+	disturbedAp := map[int]float32{-40: 20, -38: 18, -30: 20, -11: 20, -10: 30, -3: 25}
+	var daily []models.GeomagneticDaily
+	for i := -40; i <= 1; i++ {
+		d := models.GeomagneticDaily{Date: day(i), F10: f32(140), Sn: f32(80), Ap: f32(5), MaxKp: f32(2)}
+		if i > -7 {
+			d.F10, d.Sn = f32(150), f32(100)
+		}
+		if ap, ok := disturbedAp[i]; ok {
+			d.Ap, d.MaxKp = f32(ap), f32(4)
+		}
+		if i == -25 {
+			d.MaxKp = f32(5) // возмущение по Kp при спокойном Ap
+		}
+		if i == 1 {
+			d.Ap = f32(50) // прогноз источника на завтра не входит в ряд
+		}
+		daily = append(daily, d)
+	}
+
+	activity := buildSolarActivity(daily, day(-29), today)
+
+	if len(activity.Days) != 30 {
+		t.Fatalf("len(Days) = %d, want 30", len(activity.Days))
+	}
+	if last := activity.Days[len(activity.Days)-1]; !last.Date.Equal(today) {
+		t.Errorf("last day = %s, want today", last.Date.Format("2006-01-02"))
+	}
+	recurrent := map[time.Time]bool{}
+	for _, d := range activity.Days {
+		if d.Recurrent {
+			recurrent[d.Date] = true
+		}
+	}
+	if len(recurrent) != 2 || !recurrent[day(-11)] || !recurrent[day(-3)] {
+		t.Errorf("recurrent days = %v, want %s and %s", recurrent, day(-11).Format("02.01"), day(-3).Format("02.01"))
+	}
+	// Пары «возмущение — через оборот»: -40→-13 нет, -38→-11 да, -30→-3 да
+	if activity.RecurrenceRate == nil || *activity.RecurrenceRate != 66 {
+		t.Errorf("RecurrenceRate = %v, want 66", activity.RecurrenceRate)
+	}
+
+	wantOutlook := []time.Time{day(2), day(16), day(17), day(24)}
+	if len(activity.Outlook) != len(wantOutlook) {
+		t.Fatalf("Outlook = %+v, want %d days", activity.Outlook, len(wantOutlook))
+	}
+	for i, want := range wantOutlook {
+		if got := activity.Outlook[i]; !got.Date.Equal(want) || !got.SourceDate.Equal(want.AddDate(0, 0, -SOLAR_ROTATION_DAYS)) {
+			t.Errorf("Outlook[%d] = %s from %s, want %s", i, got.Date.Format("02.01"), got.SourceDate.Format("02.01"), want.Format("02.01"))
+		}
+	}
+
+	week := activity.Week
+	if week.DisturbedDays != 1 || week.MaxAp == nil || *week.MaxAp != 25 || !week.MaxApDate.Equal(day(-3)) {
+		t.Errorf("week disturbed = %d, max Ap = %v on %v, want 1, 25 on %s", week.DisturbedDays, week.MaxAp, week.MaxApDate, day(-3).Format("02.01"))
+	}
+	if *week.F10Avg != 150 || *week.PrevF10Avg != 140 || *week.ApAvg != 7.9 || *week.PrevApAvg != 10.7 {
+		t.Errorf("week averages F10 %v/%v Ap %v/%v", *week.F10Avg, *week.PrevF10Avg, *week.ApAvg, *week.PrevApAvg)
+	}
+	if len(week.Outlook) != 1 || !week.Outlook[0].Date.Equal(day(2)) {
+		t.Errorf("week outlook = %+v, want only %s", week.Outlook, day(2).Format("02.01"))
+	}
+	wantSummary := "Поток F10.7 в среднем 150 sfu (+10 к прошлой неделе), пятен 100 (+20 к прошлой неделе), Ap 8 (-3 к прошлой неделе); возмущённых дней: 1. По 27-дневной повторяемости возможны возмущения 21.10"
+	if week.Summary != wantSummary {
+		t.Errorf("Summary = %q\nwant %q", week.Summary, wantSummary)
+	}
+}
+
+func TestBuildSolarActivityWithoutData(t *testing.T) {
+	today := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+	activity := buildSolarActivity(nil, today.AddDate(0, 0, -89), today)
+
+	if len(activity.Days) != 0 || len(activity.Outlook) != 0 || activity.RecurrenceRate != nil || activity.F10Avg != nil {
+		t.Errorf("activity without data = %+v", activity)
+	}
+	if activity.Week.Summary != "нет данных о солнечной активности за неделю" {
+		t.Errorf("Summary = %q", activity.Week.Summary)
+	}
+}
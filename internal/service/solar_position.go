@@ -0,0 +1,82 @@
+package service
+
+import (
+	"math"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+// SetElevation задаёт высоту станции над уровнем моря (м) для модели ясного неба
+func (s *SunService) SetElevation(elevation float64) {
+	s.elevation = elevation
+}
+
+// GetSolarPosition returns solar elevation, azimuth and clear-sky global radiation at the given moment.
+func (s *SunService) GetSolarPosition(t time.Time) models.SolarPosition {
+	elevation, azimuth := solarPosition(t, s.latitude, s.longitude)
+	global, _ := clearSkyRadiation(elevation, s.elevation)
+	return models.SolarPosition{
+		Time:      t,
+		Elevation: roundTo(elevation, 1),
+		Azimuth:   roundTo(azimuth, 1),
+		ClearSky:  roundTo(global, 0),
+	}
+}
+
+// solarPosition считает геометрическую высоту и азимут Солнца (от севера по часовой
+// стрелке) по алгоритму NOAA Solar Calculator, без поправки на рефракцию.
+func solarPosition(t time.Time, latitude, longitude float64) (elevation, azimuth float64) {
+	rad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	deg := func(r float64) float64 { return r * 180 / math.Pi }
+
+	t = t.UTC()
+	jd := float64(t.UnixNano())/float64(24*time.Hour) + 2440587.5
+	jc := (jd - 2451545.0) / 36525.0
+
+	meanLong := math.Mod(280.46646+jc*(36000.76983+0.0003032*jc), 360)
+	meanAnomaly := 357.52911 + jc*(35999.05029-0.0001537*jc)
+	eccentricity := 0.016708634 - jc*(0.000042037+0.0000001267*jc)
+	sinM := math.Sin(rad(meanAnomaly))
+	eqCenter := sinM*(1.914602-jc*(0.004817+0.000014*jc)) +
+		math.Sin(rad(2*meanAnomaly))*(0.019993-0.000101*jc) +
+		math.Sin(rad(3*meanAnomaly))*0.000289
+	omega := 125.04 - 1934.136*jc
+	appLong := meanLong + eqCenter - 0.00569 - 0.00478*math.Sin(rad(omega))
+	meanObliq := 23 + (26+(21.448-jc*(46.8150+jc*(0.00059-jc*0.001813)))/60)/60
+	obliq := meanObliq + 0.00256*math.Cos(rad(omega))
+	declination := math.Asin(math.Sin(rad(obliq)) * math.Sin(rad(appLong)))
+
+	// Уравнение времени, минуты
+	y := math.Tan(rad(obliq/2)) * math.Tan(rad(obliq/2))
+	eqTime := 4 * deg(y*math.Sin(2*rad(meanLong))-2*eccentricity*sinM+
+		4*eccentricity*y*sinM*math.Cos(2*rad(meanLong))-
+		0.5*y*y*math.Sin(4*rad(meanLong))-1.25*eccentricity*eccentricity*math.Sin(2*rad(meanAnomaly)))
+
+	minutes := float64(t.Hour()*60+t.Minute()) + float64(t.Second())/60
+	trueSolarTime := math.Mod(minutes+eqTime+4*longitude, 1440)
+	hourAngle := rad(trueSolarTime/4 - 180)
+
+	lat := rad(latitude)
+	cosZenith := math.Sin(lat)*math.Sin(declination) + math.Cos(lat)*math.Cos(declination)*math.Cos(hourAngle)
+	elevation = 90 - deg(math.Acos(math.Max(-1, math.Min(1, cosZenith))))
+
+	azimuth = deg(math.Atan2(math.Sin(hourAngle), math.Cos(hourAngle)*math.Sin(lat)-math.Tan(declination)*math.Cos(lat))) + 180
+	azimuth = math.Mod(azimuth+360, 360)
+	return elevation, azimuth
+}
+
+// clearSkyRadiation — модель ясного неба Майнела с поправкой на высоту места (Laue):
+// прямая радиация по нормали DNI и суммарная на горизонтальную поверхность (1.1 × прямая
+// горизонтальная — рассеянная составляет около 10% прямой). Вт/м².
+func clearSkyRadiation(elevation, altitude float64) (global, direct float64) {
+	if elevation <= 0 {
+		return 0, 0
+	}
+	// Оптическая масса атмосферы по Kasten–Young
+	airMass := 1 / (math.Sin(elevation*math.Pi/180) + 0.50572*math.Pow(elevation+6.07995, -1.6364))
+	h := math.Max(0, altitude) / 1000
+	direct = 1353 * ((1-0.14*h)*math.Pow(0.7, math.Pow(airMass, 0.678)) + 0.14*h)
+	global = 1.1 * direct * math.Sin(elevation*math.Pi/180)
+	return global, direct
+}
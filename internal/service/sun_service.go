@@ -8,6 +8,7 @@ import (
 type SunService struct {
 	latitude  float64
 	longitude float64
+	elevation float64 // высота над уровнем моря, м
 	timezone  *time.Location
 }
 
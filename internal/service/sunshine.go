@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+// Параметры оценки солнечного сияния по датчику радиации
+const (
+	SUNSHINE_DIRECT_THRESHOLD = 120.0 // Вт/м² — прямая радиация, с которой WMO считает солнце сияющим
+	SUNSHINE_MIN_CLEARNESS    = 0.5   // индекс ясности G/Gясн ниже — яркая облачность, а не солнце
+	SUNSHINE_MIN_ELEVATION    = 3.0   // °, у горизонта датчик не отличает прямое солнце от рассеянного света
+	CLOUDINESS_MIN_ELEVATION  = 10.0  // °, ниже индекс облачности по радиации недостоверен
+	SUNSHINE_SAMPLE_MINUTES   = 10    // интервал осреднения радиации, минуты
+)
+
+// SetSunshine подключает оценку продолжительности солнечного сияния: положение Солнца
+// и модель ясного неба берутся по координатам и высоте SunService.
+func (s *WeatherService) SetSunshine(sun *SunService) {
+	s.sun = sun
+}
+
+// GetSunshineDay returns the estimated sunshine duration and hourly cloudiness for the local day of date.
+// Returns nil without error when sunshine estimates are not configured.
+func (s *WeatherService) GetSunshineDay(ctx context.Context, date time.Time) (*models.SunshineDay, error) {
+	if s.sun == nil {
+		return nil, nil
+	}
+	loc := s.location
+	if loc == nil {
+		loc = time.Local
+	}
+	start := dayStart(date.In(loc), loc)
+	samples, err := s.repo.GetSolarRadiationSeries(ctx, start, start.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get solar radiation: %w", err)
+	}
+	day := buildSunshineDay(start, samples, time.Now(), s.sun)
+	return &day, nil
+}
+
+// getDailyInsightsWithSunshine дополняет суточные агрегаты продолжительностью сияния и облачностью.
+func (s *WeatherService) getDailyInsightsWithSunshine(ctx context.Context, from, to time.Time) ([]models.DailyWeatherInsight, error) {
+	days, err := s.repo.GetDailyInsights(ctx, from, to, s.timezone)
+	if err != nil || s.sun == nil || len(days) == 0 {
+		return days, err
+	}
+	samples, err := s.repo.GetSolarRadiationSeries(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get solar radiation: %w", err)
+	}
+	loc := s.location
+	if loc == nil {
+		loc = time.Local
+	}
+	applySunshine(days, samples, s.sun, time.Now(), loc)
+	return days, nil
+}
+
+func applySunshine(days []models.DailyWeatherInsight, samples []models.SolarRadiationSample, sun *SunService, now time.Time, loc *time.Location) {
+	byDay := make(map[string][]models.SolarRadiationSample)
+	for _, sample := range samples {
+		key := sample.Time.In(loc).Format("2006-01-02")
+		byDay[key] = append(byDay[key], sample)
+	}
+	for i := range days {
+		// Дата из SQL — полночь в часовом поясе станции, помеченная как UTC
+		date := time.Date(days[i].Date.Year(), days[i].Date.Month(), days[i].Date.Day(), 0, 0, 0, 0, loc)
+		daySamples := byDay[date.Format("2006-01-02")]
+		if len(daySamples) == 0 {
+			continue
+		}
+		sunshine := buildSunshineDay(date, daySamples, now, sun)
+		hours, pct := float32(sunshine.SunshineHours), float32(sunshine.RelativePct)
+		days[i].SunshineHours = &hours
+		days[i].SunshinePct = &pct
+		if sunshine.CloudinessPct != nil {
+			cloudiness := float32(*sunshine.CloudinessPct)
+			days[i].CloudinessPct = &cloudiness
+		}
+	}
+}
+
+type sunshineAccumulator struct {
+	radiation  float64
+	samples    int
+	sunshine   float64 // минуты
+	cloudMeas  float64
+	cloudClear float64
+}
+
+// buildSunshineDay раскладывает 10-минутные средние радиации по часам суток date:
+// минуты сияния по критерию WMO, индекс облачности и астрономически возможное сияние.
+func buildSunshineDay(date time.Time, samples []models.SolarRadiationSample, now time.Time, sun *SunService) models.SunshineDay {
+	loc := date.Location()
+	end := date.AddDate(0, 0, 1)
+	limit := minTime(end, now)
+	step := SUNSHINE_SAMPLE_MINUTES * time.Minute
+	day := models.SunshineDay{Date: date, Partial: now.Before(end), Hours: []models.SunshineHour{}}
+
+	var possible float64
+	for t := date; t.Before(limit); t = t.Add(step) {
+		if elevation, _ := solarPosition(t.Add(step/2), sun.latitude, sun.longitude); elevation >= SUNSHINE_MIN_ELEVATION {
+			possible += SUNSHINE_SAMPLE_MINUTES
+		}
+	}
+
+	hours := make(map[int]*sunshineAccumulator)
+	for _, sample := range samples {
+		local := sample.Time.In(loc)
+		if local.Before(date) || !local.Before(end) {
+			continue
+		}
+		acc := hours[local.Hour()]
+		if acc == nil {
+			acc = &sunshineAccumulator{}
+			hours[local.Hour()] = acc
+		}
+		elevation, _ := solarPosition(sample.Time.Add(step/2), sun.latitude, sun.longitude)
+		acc.radiation += sample.Radiation
+		acc.samples++
+		if isSunshineSample(sample.Radiation, elevation, sun.elevation) {
+			acc.sunshine += SUNSHINE_SAMPLE_MINUTES
+		}
+		if elevation >= CLOUDINESS_MIN_ELEVATION {
+			clear, _ := clearSkyRadiation(elevation, sun.elevation)
+			acc.cloudMeas += sample.Radiation
+			acc.cloudClear += clear
+		}
+	}
+
+	var sunshine, cloudMeas, cloudClear float64
+	for hour := 0; hour < 24; hour++ {
+		start := time.Date(date.Year(), date.Month(), date.Day(), hour, 0, 0, 0, loc)
+		if !start.Before(limit) {
+			break
+		}
+		elevation, _ := solarPosition(start.Add(30*time.Minute), sun.latitude, sun.longitude)
+		acc := hours[hour]
+		// Ночные часы без данных пропускаем
+		if elevation <= 0 && (acc == nil || acc.radiation <= 0) {
+			continue
+		}
+		clear, _ := clearSkyRadiation(elevation, sun.elevation)
+		item := models.SunshineHour{Time: start, Elevation: roundTo(elevation, 1), ClearSky: roundTo(clear, 0)}
+		if acc != nil {
+			radiation := roundTo(acc.radiation/float64(acc.samples), 0)
+			item.Radiation = &radiation
+			item.SunshineMinutes = acc.sunshine
+			if acc.cloudClear > 0 {
+				cloudiness := cloudinessIndex(acc.cloudMeas, acc.cloudClear)
+				item.CloudinessPct = &cloudiness
+			}
+			sunshine += acc.sunshine
+			cloudMeas += acc.cloudMeas
+			cloudClear += acc.cloudClear
+		}
+		day.Hours = append(day.Hours, item)
+	}
+
+	day.SunshineHours = roundTo(sunshine/60, 1)
+	day.PossibleHours = roundTo(possible/60, 1)
+	if possible > 0 {
+		day.RelativePct = roundTo(math.Min(100, sunshine/possible*100), 0)
+	}
+	if cloudClear > 0 {
+		cloudiness := cloudinessIndex(cloudMeas, cloudClear)
+		day.CloudinessPct = &cloudiness
+	}
+	return day
+}
+
+// isSunshineSample применяет критерий WMO (прямая радиация ≥ 120 Вт/м²) к суммарной
+// радиации: прямая оценивается как избыток над рассеянной частью ясного неба.
+func isSunshineSample(radiation, elevation, altitude float64) bool {
+	if elevation < SUNSHINE_MIN_ELEVATION {
+		return false
+	}
+	global, direct := clearSkyRadiation(elevation, altitude)
+	if global <= 0 || radiation/global < SUNSHINE_MIN_CLEARNESS {
+		return false
+	}
+	sinElevation := math.Sin(elevation * math.Pi / 180)
+	diffuse := global - direct*sinElevation
+	return (radiation-diffuse)/sinElevation >= SUNSHINE_DIRECT_THRESHOLD
+}
+
+// cloudinessIndex — доля «недобранной» до ясного неба радиации, %
+func cloudinessIndex(measured, clearSky float64) float64 {
+	return roundTo(100*(1-math.Min(1, measured/clearSky)), 0)
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+func testSunService(t *testing.T) *SunService {
+	t.Helper()
+	sun, err := NewSunService(44.995574, 41.128354, "Europe/Moscow")
+	if err != nil {
+		t.Fatalf("NewSunService() error = %v", err)
+	}
+	sun.SetElevation(158)
+	return sun
+}
+
+func TestSolarPositionAtSummerSolsticeNoon(t *testing.T) {
+	// Истинный полдень в Армавире — около 09:17 UTC
+	elevation, azimuth := solarPosition(time.Date(2026, time.June, 21, 9, 17, 0, 0, time.UTC), 44.995574, 41.128354)
+
+	if math.Abs(elevation-68.4) > 0.3 {
+		t.Fatalf("elevation = %.2f, want about 68.4", elevation)
+	}
+	if math.Abs(azimuth-180) > 2 {
+		t.Fatalf("azimuth = %.2f, want about 180", azimuth)
+	}
+
+	elevation, azimuth = solarPosition(time.Date(2026, time.June, 21, 4, 0, 0, 0, time.UTC), 44.995574, 41.128354)
+	if elevation <= 0 || azimuth < 45 || azimuth > 110 {
+		t.Fatalf("morning sun should be low in the east, got elevation %.1f azimuth %.1f", elevation, azimuth)
+	}
+}
+
+func TestIsSunshineSampleSeparatesDirectSunFromBrightOvercast(t *testing.T) {
+	global, _ := clearSkyRadiation(50, 158)
+
+	if !isSunshineSample(global, 50, 158) {
+		t.Fatal("clear-sky radiation must count as sunshine")
+	}
+	if isSunshineSample(150, 50, 158) {
+		t.Fatal("overcast radiation must not count as sunshine")
+	}
+	if isSunshineSample(global, 2, 158) {
+		t.Fatal("sun below the minimum elevation must not count")
+	}
+}
+
+func TestBuildSunshineDayClearAndOvercast(t *testing.T) {
+	sun := testSunService(t)
+	loc := sun.timezone
+	date := time.Date(2026, time.June, 21, 0, 0, 0, 0, loc)
+	noon := time.Date(2026, time.June, 21, 12, 0, 0, 0, loc)
+
+	var samples []models.SolarRadiationSample
+	for t := date; t.Before(date.AddDate(0, 0, 1)); t = t.Add(SUNSHINE_SAMPLE_MINUTES * time.Minute) {
+		elevation, _ := solarPosition(t.Add(5*time.Minute), sun.latitude, sun.longitude)
+		global, _ := clearSkyRadiation(elevation, sun.elevation)
+		// До полудня ясно, после — сплошная облачность
+		if !t.Before(noon) {
+			global *= 0.2
+		}
+		samples = append(samples, models.SolarRadiationSample{Time: t, Radiation: global})
+	}
+
+	day := buildSunshineDay(date, samples, date.AddDate(0, 0, 2), sun)
+
+	if day.Partial || day.PossibleHours < 14 || day.PossibleHours > 16 {
+		t.Fatalf("unexpected possible sunshine: %#v", day)
+	}
+	if day.RelativePct < 40 || day.RelativePct > 60 {
+		t.Fatalf("half a clear day should give about half the possible sunshine, got %.0f%%", day.RelativePct)
+	}
+	if day.CloudinessPct == nil || *day.CloudinessPct < 30 || *day.CloudinessPct > 50 {
+		t.Fatalf("unexpected day cloudiness: %v", day.CloudinessPct)
+	}
+	for _, hour := range day.Hours {
+		if hour.Time.Hour() == 10 && (hour.SunshineMinutes != 60 || hour.CloudinessPct == nil || *hour.CloudinessPct != 0) {
+			t.Fatalf("clear morning hour: %#v", hour)
+		}
+		if hour.Time.Hour() == 14 && (hour.SunshineMinutes != 0 || hour.CloudinessPct == nil || *hour.CloudinessPct != 80) {
+			t.Fatalf("overcast afternoon hour: %#v", hour)
+		}
+	}
+}
+
+func TestClassifyDayTypeUsesSunshineDuration(t *testing.T) {
+	// Высокий пик радиации в разрывах облаков не делает день солнечным
+	day := models.DailyWeatherInsight{SolarRadiationMax: f32(800), SunshinePct: f32(15), RainTotal: f32(0)}
+	if got := classifyDayType(day); got != "cloudy" {
+		t.Fatalf("classifyDayType() = %q, want cloudy", got)
+	}
+	day.SunshinePct = f32(75)
+	if got := classifyDayType(day); got != "sunny" {
+		t.Fatalf("classifyDayType() = %q, want sunny", got)
+	}
+}
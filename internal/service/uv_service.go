@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+// Параметры расчёта UV-дозы
+const (
+	UV_INDEX_IRRADIANCE      = 0.025 // Вт/м² эритемно-взвешенной радиации на единицу UV-индекса
+	UV_SED                   = 100.0 // Дж/м² в стандартной эритемной дозе (SED)
+	UV_STATION_MAX_AGE       = 30 * time.Minute
+	UV_DEFAULT_ALERT_MINUTES = 30 // предупреждать, когда до покраснения кожи остаётся не больше
+	UV_DEFAULT_SKIN_TYPE     = 2
+)
+
+// uvSkinTypes — минимальная эритемная доза (Дж/м²) для фототипов кожи по Фицпатрику
+var uvSkinTypes = []struct {
+	med  float64
+	name string
+}{
+	{200, "очень светлая, всегда сгорает"},
+	{250, "светлая, легко сгорает"},
+	{300, "светлая, загорает постепенно"},
+	{450, "смуглая, сгорает редко"},
+	{600, "тёмная, почти не сгорает"},
+	{1000, "очень тёмная"},
+}
+
+// ValidSkinType проверяет номер фототипа (1–6)
+func ValidSkinType(skinType int) bool {
+	return skinType >= 1 && skinType <= len(uvSkinTypes)
+}
+
+// UVSkinTypeName возвращает описание фототипа
+func UVSkinTypeName(skinType int) string {
+	if !ValidSkinType(skinType) {
+		return ""
+	}
+	return uvSkinTypes[skinType-1].name
+}
+
+// UVBurnMinutes — минуты на открытом солнце до покраснения кожи при постоянном UV-индексе.
+// nil — UV ниже 1, за световой день не сгореть.
+func UVBurnMinutes(uv float64, skinType int) *int {
+	if uv < 1 || !ValidSkinType(skinType) {
+		return nil
+	}
+	minutes := int(math.Round(uvSkinTypes[skinType-1].med / (uv * UV_INDEX_IRRADIANCE * 60)))
+	return &minutes
+}
+
+// UVLevel возвращает уровень UV-индекса по шкале ВОЗ
+func UVLevel(uv float64) string {
+	switch {
+	case uv < 3:
+		return "низкий"
+	case uv < 6:
+		return "умеренный"
+	case uv < 8:
+		return "высокий"
+	case uv < 11:
+		return "очень высокий"
+	default:
+		return "экстремальный"
+	}
+}
+
+// UVSettings — порог предупреждения и фототип по умолчанию
+type UVSettings struct {
+	AlertMinutes    int // предупреждать, когда до покраснения не больше стольких минут
+	DefaultSkinType int // фототип, если пользователь свой не указал
+}
+
+// UVService оценивает время до солнечного ожога для фототипов кожи 1–6 по измеренному
+// UV-индексу станции (а без свежего замера — по прогнозу) и дневную кривую дозы:
+// замеры станции с утра плюс прогноз UV до вечера.
+type UVService struct {
+	weatherSvc  *WeatherService
+	forecastSvc *ForecastService
+	settings    UVSettings
+}
+
+func NewUVService(weatherSvc *WeatherService, forecastSvc *ForecastService, settings UVSettings) *UVService {
+	if settings.AlertMinutes <= 0 {
+		settings.AlertMinutes = UV_DEFAULT_ALERT_MINUTES
+	}
+	if !ValidSkinType(settings.DefaultSkinType) {
+		settings.DefaultSkinType = UV_DEFAULT_SKIN_TYPE
+	}
+	return &UVService{weatherSvc: weatherSvc, forecastSvc: forecastSvc, settings: settings}
+}
+
+// DefaultSkinType возвращает фототип для пользователей, не указавших свой
+func (s *UVService) DefaultSkinType() int {
+	return s.settings.DefaultSkinType
+}
+
+// GetExposure возвращает текущий UV, время до ожога по фототипам и кривую дозы за сегодня
+func (s *UVService) GetExposure(ctx context.Context) (*models.UVExposure, error) {
+	loc := s.weatherSvc.location
+	if loc == nil {
+		loc = time.Local
+	}
+	now := time.Now()
+	localNow := wallClockUTC(now, loc)
+	currentHour := localNow.Truncate(time.Hour)
+	today := dayStart(localNow, time.UTC)
+
+	// Средние замеры станции по часам с местной полуночи
+	measured := make(map[time.Time]float64)
+	data, err := s.weatherSvc.repo.GetAggregated(ctx, dayStart(now, loc), now, "1h")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hourly uv: %w", err)
+	}
+	for _, d := range data {
+		if d.UVIndex != nil {
+			measured[wallClockUTC(d.Time, loc).Truncate(time.Hour)] = float64(*d.UVIndex)
+		}
+	}
+
+	forecast := make(map[time.Time]float64)
+	if s.forecastSvc != nil {
+		hourly, err := s.forecastSvc.GetHourlyForecast(ctx, 30)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get hourly forecast: %w", err)
+		}
+		for _, h := range hourly {
+			if t := h.Time.UTC(); !t.Before(currentHour) && t.Before(today.AddDate(0, 0, 1)) {
+				forecast[t] = float64(h.UVIndex)
+			}
+		}
+	}
+
+	exposure := &models.UVExposure{GeneratedAt: now, Hours: buildUVHours(today, currentHour, measured, forecast)}
+
+	// Текущий UV — по свежему замеру станции, иначе по прогнозу на этот час
+	latest, err := s.weatherSvc.repo.GetLatest(ctx)
+	if err == nil && latest != nil && latest.UVIndex != nil && now.Sub(latest.Time) <= UV_STATION_MAX_AGE {
+		uv := float64(*latest.UVIndex)
+		exposure.UVIndex, exposure.Source = &uv, "station"
+	} else if uv, ok := forecast[currentHour]; ok {
+		exposure.UVIndex, exposure.Source = &uv, "forecast"
+	}
+	if exposure.UVIndex != nil {
+		exposure.Level = UVLevel(*exposure.UVIndex)
+	}
+
+	fillUVExposure(exposure)
+	return exposure, nil
+}
+
+// AlertFor сообщает, сколько минут до покраснения у фототипа и пора ли предупредить
+func (s *UVService) AlertFor(exposure *models.UVExposure, skinType int) (int, bool) {
+	if exposure == nil || exposure.UVIndex == nil {
+		return 0, false
+	}
+	if !ValidSkinType(skinType) {
+		skinType = s.settings.DefaultSkinType
+	}
+	minutes := UVBurnMinutes(*exposure.UVIndex, skinType)
+	if minutes == nil {
+		return 0, false
+	}
+	return *minutes, *minutes <= s.settings.AlertMinutes
+}
+
+// buildUVHours собирает часы сегодняшнего дня: прошедшие — по замерам станции,
+// текущий — по замеру, если он есть, остальные — по прогнозу. Ночные часы без UV отбрасываются.
+func buildUVHours(today, currentHour time.Time, measured, forecast map[time.Time]float64) []models.UVHour {
+	var hours []models.UVHour
+	for i := 0; i < 24; i++ {
+		t := today.Add(time.Duration(i) * time.Hour)
+		hour := models.UVHour{Time: t}
+		if uv, ok := measured[t]; ok && !t.After(currentHour) {
+			hour.UVIndex = uv
+		} else if uv, ok := forecast[t]; ok && !t.Before(currentHour) {
+			hour.UVIndex, hour.Forecast = uv, true
+		} else {
+			continue
+		}
+		hours = append(hours, hour)
+	}
+
+	first, last := 0, len(hours)-1
+	for first <= last && hours[first].UVIndex < 0.05 {
+		first++
+	}
+	for last >= first && hours[last].UVIndex < 0.05 {
+		last--
+	}
+	return hours[first : last+1]
+}
+
+// fillUVExposure считает накопленную дозу, пик и время до ожога по фототипам
+func fillUVExposure(e *models.UVExposure) {
+	var dose float64
+	for i := range e.Hours {
+		h := &e.Hours[i]
+		dose += h.UVIndex * UV_INDEX_IRRADIANCE * 3600 / UV_SED
+		h.DoseSED = roundTo(dose, 2)
+		if h.UVIndex > e.PeakUV {
+			e.PeakUV, e.PeakTime = h.UVIndex, h.Time
+		}
+	}
+	e.DailyDoseSED = roundTo(dose, 1)
+
+	e.SkinTypes = make([]models.UVSkinType, 0, len(uvSkinTypes))
+	for i, st := range uvSkinTypes {
+		skin := models.UVSkinType{Type: i + 1, Name: st.name, MED: st.med, DailyMEDs: roundTo(dose*UV_SED/st.med, 1)}
+		if e.UVIndex != nil {
+			skin.BurnMinutes = UVBurnMinutes(*e.UVIndex, i+1)
+		}
+		e.SkinTypes = append(e.SkinTypes, skin)
+	}
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iRootPro/weather/internal/models"
+)
+
+func TestUVBurnMinutes(t *testing.T) {
+	tests := []struct {
+		uv       float64
+		skinType int
+		want     int // -1 — сгореть нельзя
+	}{
+		{uv: 0.5, skinType: 1, want: -1},
+		{uv: 4, skinType: 1, want: 33},  // 200 / (4·0.025·60)
+		{uv: 8, skinType: 2, want: 21},  // 250 / 12
+		{uv: 8, skinType: 6, want: 83},  // 1000 / 12
+		{uv: 10, skinType: 3, want: 20}, // 300 / 15
+		{uv: 10, skinType: 7, want: -1}, // нет такого фототипа
+	}
+	for _, tt := range tests {
+		got := -1
+		if minutes := UVBurnMinutes(tt.uv, tt.skinType); minutes != nil {
+			got = *minutes
+		}
+		if got != tt.want {
+			t.Fatalf("UVBurnMinutes(%v, %d) = %d, want %d", tt.uv, tt.skinType, got, tt.want)
+		}
+	}
+}
+
+func TestBuildUVHoursSplitsMeasuredAndForecast(t *testing.T) {
+	today := time.Date(2026, time.June, 21, 0, 0, 0, 0, time.UTC)
+	current := today.Add(12 * time.Hour)
+	measured := map[time.Time]float64{
+		today.Add(4 * time.Hour):  0,
+		today.Add(10 * time.Hour): 4.2,
+		today.Add(11 * time.Hour): 5.5,
+		today.Add(12 * time.Hour): 6.1,
+	}
+	forecast := map[time.Time]float64{
+		today.Add(12 * time.Hour): 7,
+		today.Add(13 * time.Hour): 6.5,
+		today.Add(21 * time.Hour): 0,
+	}
+
+	hours := buildUVHours(today, current, measured, forecast)
+	if len(hours) != 4 {
+		t.Fatalf("len(hours) = %d, want 4 (night hours trimmed): %+v", len(hours), hours)
+	}
+	if hours[0].Time.Hour() != 10 || hours[len(hours)-1].Time.Hour() != 13 {
+		t.Fatalf("hours span %v..%v, want 10..13", hours[0].Time, hours[len(hours)-1].Time)
+	}
+	if hours[2].UVIndex != 6.1 || hours[2].Forecast {
+		t.Fatalf("current hour = %+v, want station reading 6.1", hours[2])
+	}
+	if hours[3].UVIndex != 6.5 || !hours[3].Forecast {
+		t.Fatalf("next hour = %+v, want forecast 6.5", hours[3])
+	}
+}
+
+func TestFillUVExposureDoseAndPeak(t *testing.T) {
+	today := time.Date(2026, time.June, 21, 0, 0, 0, 0, time.UTC)
+	uv := 6.0
+	e := &models.UVExposure{UVIndex: &uv, Hours: []models.UVHour{
+		{Time: today.Add(11 * time.Hour), UVIndex: 4},
+		{Time: today.Add(12 * time.Hour), UVIndex: 8},
+		{Time: today.Add(13 * time.Hour), UVIndex: 4},
+	}}
+	fillUVExposure(e)
+
+	// 16 UV-часов · 0.025 Вт/м² · 3600 с = 1440 Дж/м² = 14.4 SED
+	if e.DailyDoseSED != 14.4 {
+		t.Fatalf("DailyDoseSED = %v, want 14.4", e.DailyDoseSED)
+	}
+	if e.Hours[1].DoseSED != 10.8 {
+		t.Fatalf("cumulative dose at noon = %v, want 10.8", e.Hours[1].DoseSED)
+	}
+	if e.PeakUV != 8 || e.PeakTime.Hour() != 12 {
+		t.Fatalf("peak = %v at %v, want 8 at 12:00", e.PeakUV, e.PeakTime)
+	}
+	if len(e.SkinTypes) != 6 {
+		t.Fatalf("len(SkinTypes) = %d, want 6", len(e.SkinTypes))
+	}
+	if st := e.SkinTypes[0]; st.BurnMinutes == nil || *st.BurnMinutes != 22 || st.DailyMEDs != 7.2 {
+		t.Fatalf("skin type I = %+v, want 22 min and 7.2 MED", st)
+	}
+}
+
+func TestUVAlertForUsesSkinTypeAndThreshold(t *testing.T) {
+	svc := NewUVService(nil, nil, UVSettings{AlertMinutes: 30, DefaultSkinType: 2})
+	uv := 6.0
+	exposure := &models.UVExposure{UVIndex: &uv}
+
+	if minutes, alert := svc.AlertFor(exposure, 1); !alert || minutes != 22 {
+		t.Fatalf("AlertFor(type I) = %d, %v; want 22, true", minutes, alert)
+	}
+	if minutes, alert := svc.AlertFor(exposure, 4); alert || minutes != 50 {
+		t.Fatalf("AlertFor(type IV) = %d, %v; want 50, false", minutes, alert)
+	}
+	if minutes, alert := svc.AlertFor(exposure, 0); !alert || minutes != 28 {
+		t.Fatalf("AlertFor(unset) = %d, %v; want default type II 28, true", minutes, alert)
+	}
+
+	low := 0.8
+	if _, alert := svc.AlertFor(&models.UVExposure{UVIndex: &low}, 1); alert {
+		t.Fatal("AlertFor() alerted with UV below 1")
+	}
+}
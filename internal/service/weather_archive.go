@@ -56,7 +56,7 @@ func (s *WeatherService) GetArchive(ctx context.Context, period, metric, monthPa
 		return nil, ErrInvalidArchiveRange
 	}
 
-	currentDays, err := s.repo.GetDailyInsights(ctx, start, dataEnd, s.timezone)
+	currentDays, err := s.getDailyInsightsWithSunshine(ctx, start, dataEnd)
 	if err != nil {
 		return nil, err
 	}
@@ -70,6 +70,15 @@ func (s *WeatherService) GetArchive(ctx context.Context, period, metric, monthPa
 	events := filterArchiveEvents(buildArchiveEvents(currentDays), metric)
 	displayDays := filterArchiveDays(currentDays, search)
 	search.MatchedDays = len(displayDays)
+	summary := buildArchiveSummary(currentDays, daysInPeriod)
+	baseline, err := s.getArchiveBaseline(ctx, start, calendarEnd, summary, loc)
+	if err != nil {
+		return nil, err
+	}
+	normals, err := s.getClimateAnomaly(ctx, period, label, start, end, now, loc)
+	if err != nil {
+		return nil, err
+	}
 
 	page := &models.WeatherArchivePage{
 		GeneratedAt:    now,
@@ -85,10 +94,12 @@ func (s *WeatherService) GetArchive(ctx context.Context, period, metric, monthPa
 		YearParam:      start.Year(),
 		SeasonOptions:  archiveSeasonOptions(availabilityDays, now, loc),
 		YearOptions:    archiveYearOptions(availabilityDays, now),
-		Summary:        buildArchiveSummary(currentDays, daysInPeriod),
+		Summary:        summary,
 		Coverage:       coverage,
 		Events:         events,
 		Search:         search,
+		Baseline:       baseline,
+		Normals:        normals,
 		Daily:          displayDays,
 	}
 	return page, nil
@@ -231,7 +242,7 @@ func buildArchiveCoverage(start, end time.Time, periodDays, availabilityDays []m
 }
 
 func buildArchiveEvents(days []models.DailyWeatherInsight) []models.WeatherArchiveEvent {
-	var hottest, coldest, wettest, windiest, sunniest *models.WeatherArchiveEvent
+	var hottest, coldest, wettest, windiest, sunniest, brightest *models.WeatherArchiveEvent
 	for _, day := range days {
 		if day.TempMax != nil && (hottest == nil || float64(*day.TempMax) > hottest.Value) {
 			hottest = &models.WeatherArchiveEvent{Group: "temperature", Icon: "🌡️", Title: "Самый жаркий день", Date: day.Date, Value: float64(*day.TempMax), Unit: "°C"}
@@ -245,10 +256,17 @@ func buildArchiveEvents(days []models.DailyWeatherInsight) []models.WeatherArchi
 		if day.WindGustMax != nil && (windiest == nil || float64(*day.WindGustMax) > windiest.Value) {
 			windiest = &models.WeatherArchiveEvent{Group: "wind", Icon: "💨", Title: "Самый сильный порыв", Date: day.Date, Value: float64(*day.WindGustMax), Unit: "м/с"}
 		}
-		if day.SolarRadiationMax != nil && *day.SolarRadiationMax > 0 && (sunniest == nil || float64(*day.SolarRadiationMax) > sunniest.Value) {
-			sunniest = &models.WeatherArchiveEvent{Group: "sun", Icon: "☀️", Title: "Самый солнечный день", Date: day.Date, Value: float64(*day.SolarRadiationMax), Unit: "Вт/м²"}
+		if day.SunshineHours != nil && *day.SunshineHours > 0 && (sunniest == nil || float64(*day.SunshineHours) > sunniest.Value) {
+			sunniest = &models.WeatherArchiveEvent{Group: "sun", Icon: "☀️", Title: "Самый солнечный день", Date: day.Date, Value: float64(*day.SunshineHours), Unit: "ч солнца"}
+		}
+		if day.SolarRadiationMax != nil && *day.SolarRadiationMax > 0 && (brightest == nil || float64(*day.SolarRadiationMax) > brightest.Value) {
+			brightest = &models.WeatherArchiveEvent{Group: "sun", Icon: "☀️", Title: "Самый солнечный день", Date: day.Date, Value: float64(*day.SolarRadiationMax), Unit: "Вт/м²"}
 		}
 	}
+	// Без оценки сияния солнечный день определяется по пику радиации
+	if sunniest == nil {
+		sunniest = brightest
+	}
 	events := make([]models.WeatherArchiveEvent, 0, 5)
 	for _, event := range []*models.WeatherArchiveEvent{hottest, coldest, wettest, windiest, sunniest} {
 		if event != nil {
@@ -429,6 +447,9 @@ func buildArchiveSummary(days []models.DailyWeatherInsight, daysInPeriod int) mo
 			summary.SolarRadiationMax = math.Max(summary.SolarRadiationMax, float64(*day.SolarRadiationMax))
 			summary.HasSun = true
 		}
+		if day.SunshineHours != nil {
+			summary.SunshineHours += float64(*day.SunshineHours)
+		}
 	}
 	if tempCount > 0 {
 		summary.TempAvg = tempSum / float64(tempCount)
@@ -436,6 +457,7 @@ func buildArchiveSummary(days []models.DailyWeatherInsight, daysInPeriod int) mo
 	if humidityCount > 0 {
 		summary.HumidityAvg = humiditySum / float64(humidityCount)
 	}
+	summary.SunshineHours = roundTo(summary.SunshineHours, 1)
 	if pressureCount > 0 {
 		summary.PressureAvg = pressureSum / float64(pressureCount)
 	}
@@ -24,6 +24,8 @@ const (
 	sunnySolarThreshold   = 500.0
 	cloudySolarThreshold  = 150.0
 	highUVThreshold       = 6.0
+	sunnyDaySunshinePct   = 60.0 // share of possible sunshine that makes a day sunny
+	cloudyDaySunshinePct  = 20.0 // share of possible sunshine below which a day is cloudy
 )
 
 // GetInsights returns human-friendly monthly weather analytics for the current month.
@@ -59,7 +61,7 @@ func (s *WeatherService) GetInsightsForMonth(ctx context.Context, month time.Tim
 	previousStart := currentStart.AddDate(0, -1, 0)
 	previousEnd := currentStart
 
-	currentDays, err := s.repo.GetDailyInsights(ctx, currentStart, periodEnd, s.timezone)
+	currentDays, err := s.getDailyInsightsWithSunshine(ctx, currentStart, periodEnd)
 	if err != nil {
 		return nil, err
 	}
@@ -128,6 +130,10 @@ func (s *WeatherService) GetInsightsForMonth(ctx context.Context, month time.Tim
 	actualSeasonStart, _ := seasonBounds(now, loc)
 	actualSeasonYear, actualSeasonCode := seasonIDFromStart(actualSeasonStart)
 	sameMonthBenchmark := buildSameMonthBenchmark(analysisDate, current, archiveDays, loc)
+	reanalysisBenchmark, err := s.getReanalysisMonthBenchmark(ctx, currentStart, analysisDate, current, loc)
+	if err != nil {
+		return nil, err
+	}
 	last7Title := "Последние 7 дней"
 	last30Title := "Последние 30 дней"
 	if !isCurrentMonth {
@@ -151,7 +157,7 @@ func (s *WeatherService) GetInsightsForMonth(ctx context.Context, month time.Tim
 	if err != nil {
 		return nil, err
 	}
-	comparisonCards := buildComparisonCards(current, previous, previousSame, previousYear, sameMonthBenchmark, last7Days, last30Days, "месяц", "месяца")
+	comparisonCards := withReanalysisComparisonCard(buildComparisonCards(current, previous, previousSame, previousYear, sameMonthBenchmark, last7Days, last30Days, "месяц", "месяца"), sameMonthBenchmark, reanalysisBenchmark)
 
 	page := &models.WeatherInsightsPage{
 		GeneratedAt:               now,
@@ -176,6 +182,7 @@ func (s *WeatherService) GetInsightsForMonth(ctx context.Context, month time.Tim
 		PreviousSamePeriod:        previousSame,
 		Season:                    season,
 		SameMonthBenchmark:        sameMonthBenchmark,
+		ReanalysisBenchmark:       reanalysisBenchmark,
 		Last7Days:                 last7Days,
 		Last30Days:                last30Days,
 		DayTypes:                  dayTypes,
@@ -250,7 +257,7 @@ func (s *WeatherService) GetInsightsForSeason(ctx context.Context, seasonParam s
 	previousCompareDays := minInt(daysInSelectedPeriod, daysBetween(previousStart, previousEnd))
 	previousSameEnd := previousStart.AddDate(0, 0, previousCompareDays)
 
-	currentDays, err := s.repo.GetDailyInsights(ctx, currentStart, periodEnd, s.timezone)
+	currentDays, err := s.getDailyInsightsWithSunshine(ctx, currentStart, periodEnd)
 	if err != nil {
 		return nil, err
 	}
@@ -298,6 +305,10 @@ func (s *WeatherService) GetInsightsForSeason(ctx context.Context, seasonParam s
 	previousYearSame := buildMonthlyInsights("Год назад", previousYearStart, previousYearEnd.Add(-time.Nanosecond), previousYearDays, previousYearDaysCount)
 	season := buildSeasonContext(analysisDate, currentStart, currentEnd, current)
 	benchmark := buildSameSeasonBenchmark(current, archiveDays, selectedYear, selectedCode, daysInSelectedPeriod, loc)
+	reanalysisBenchmark, err := s.getReanalysisSeasonBenchmark(ctx, currentStart, selectedYear, selectedCode, daysInSelectedPeriod, current, loc)
+	if err != nil {
+		return nil, err
+	}
 	last7Title := "Последние 7 дней"
 	last30Title := "Последние 30 дней"
 	if !isCurrentSeason {
@@ -325,7 +336,7 @@ func (s *WeatherService) GetInsightsForSeason(ctx context.Context, seasonParam s
 	if err != nil {
 		return nil, err
 	}
-	comparisonCards := buildComparisonCards(current, previous, previousSame, previousYearSame, benchmark, last7Days, last30Days, "сезон", "сезона")
+	comparisonCards := withReanalysisComparisonCard(buildComparisonCards(current, previous, previousSame, previousYearSame, benchmark, last7Days, last30Days, "сезон", "сезона"), benchmark, reanalysisBenchmark)
 
 	page := &models.WeatherInsightsPage{
 		GeneratedAt:               now,
@@ -353,6 +364,7 @@ func (s *WeatherService) GetInsightsForSeason(ctx context.Context, seasonParam s
 		PreviousSamePeriod:        previousSame,
 		Season:                    season,
 		SameMonthBenchmark:        benchmark,
+		ReanalysisBenchmark:       reanalysisBenchmark,
 		Last7Days:                 last7Days,
 		Last30Days:                last30Days,
 		DayTypes:                  dayTypes,
@@ -455,7 +467,7 @@ func (s *WeatherService) buildArchiveCards(ctx context.Context, now time.Time, l
 
 	cards := make([]models.WeatherInsightsArchiveCard, 0, len(requests))
 	for _, request := range requests {
-		days, err := s.repo.GetDailyInsights(ctx, request.start, request.end, s.timezone)
+		days, err := s.getDailyInsightsWithSunshine(ctx, request.start, request.end)
 		if err != nil {
 			return nil, err
 		}
@@ -510,6 +522,21 @@ func buildComparisonCards(current, previous, previousSame, previousYear models.M
 	return cards
 }
 
+// withReanalysisComparisonCard puts the reanalysis norm right after the station archive norm.
+func withReanalysisComparisonCard(cards []models.WeatherComparisonCard, stationBenchmark, reanalysisBenchmark models.WeatherArchiveBenchmark) []models.WeatherComparisonCard {
+	if !reanalysisBenchmark.Available {
+		return cards
+	}
+	position := 0
+	if stationBenchmark.Available {
+		position = 1
+	}
+	result := make([]models.WeatherComparisonCard, 0, len(cards)+1)
+	result = append(result, cards[:position]...)
+	result = append(result, buildBenchmarkComparisonCard(reanalysisBenchmark))
+	return append(result, cards[position:]...)
+}
+
 func buildPeriodComparisonCard(title, subtitle, icon string, current, baseline models.MonthlyWeatherInsights) models.WeatherComparisonCard {
 	rainDelta := current.RainTotal - baseline.RainTotal
 	tempDelta := current.AvgTemp - baseline.AvgTemp
@@ -540,10 +567,14 @@ func buildBenchmarkComparisonCard(benchmark models.WeatherArchiveBenchmark) mode
 	} else if rainDelta <= -15 {
 		tone = "dry"
 	}
+	title, icon := "Архивная норма", "📚"
+	if benchmark.ModelData {
+		title, icon = "Норма по реанализу", "🛰️"
+	}
 	return models.WeatherComparisonCard{
-		Title:             "Архивная норма",
+		Title:             title,
 		Subtitle:          benchmark.Subtitle,
-		Icon:              "📚",
+		Icon:              icon,
 		Tone:              tone,
 		RainDeltaText:     formatSignedInt(rainDelta, "%"),
 		TempDeltaText:     formatSignedFloat(benchmark.TempDelta, 1, "°C"),
@@ -680,13 +711,16 @@ func buildMonthlyInsights(title string, start, end time.Time, days []models.Dail
 			}
 		}
 
+		if isSunnyDay(day) {
+			result.SunnyDays++
+		}
+		if isCloudyDay(day) {
+			result.CloudyDays++
+		}
+		if day.SunshineHours != nil {
+			result.SunshineHours += float64(*day.SunshineHours)
+		}
 		if day.SolarRadiationMax != nil {
-			if solar >= sunnySolarThreshold {
-				result.SunnyDays++
-			}
-			if solar < cloudySolarThreshold {
-				result.CloudyDays++
-			}
 			if result.SunniestDay == nil || solar > result.SunniestDay.Value {
 				result.SunniestDay = &models.DayInsightValue{Date: day.Date, Value: solar}
 			}
@@ -703,6 +737,7 @@ func buildMonthlyInsights(title string, start, end time.Time, days []models.Dail
 	if tempCount > 0 {
 		result.AvgTemp = tempSum / float64(tempCount)
 	}
+	result.SunshineHours = roundTo(result.SunshineHours, 1)
 
 	return result
 }
@@ -1060,7 +1095,6 @@ func classifyDayType(day models.DailyWeatherInsight) string {
 	gust := value32(day.WindGustMax)
 	tempMax := value32(day.TempMax)
 	tempAvg := value32(day.TempAvg)
-	solar := value32(day.SolarRadiationMax)
 
 	switch {
 	case rain >= heavyRainThreshold:
@@ -1071,17 +1105,33 @@ func classifyDayType(day models.DailyWeatherInsight) string {
 		return "hot"
 	case tempAvg >= 18 && tempAvg <= 26 && rain < wetDayRainThreshold && gust < 8:
 		return "comfortable"
-	case solar >= sunnySolarThreshold && rain < wetDayRainThreshold:
+	case isSunnyDay(day) && rain < wetDayRainThreshold:
 		return "sunny"
 	case rain >= wetDayRainThreshold:
 		return "wet"
-	case day.SolarRadiationMax != nil && solar < cloudySolarThreshold:
+	case isCloudyDay(day):
 		return "cloudy"
 	default:
 		return "calm"
 	}
 }
 
+// isSunnyDay classifies a day by its sunshine duration, falling back to the
+// peak solar radiation when sunshine is not available.
+func isSunnyDay(day models.DailyWeatherInsight) bool {
+	if day.SunshinePct != nil {
+		return float64(*day.SunshinePct) >= sunnyDaySunshinePct
+	}
+	return value32(day.SolarRadiationMax) >= sunnySolarThreshold
+}
+
+func isCloudyDay(day models.DailyWeatherInsight) bool {
+	if day.SunshinePct != nil {
+		return float64(*day.SunshinePct) < cloudyDaySunshinePct
+	}
+	return day.SolarRadiationMax != nil && value32(day.SolarRadiationMax) < cloudySolarThreshold
+}
+
 func buildWindInsight(current models.MonthlyWeatherInsights) models.WeatherFactorInsight {
 	insight := models.WeatherFactorInsight{
 		Icon:       "💨",
@@ -1368,7 +1418,7 @@ func dayBadges(day models.DailyWeatherInsight) []string {
 	if value32(day.TempMax) >= hotDayTempThreshold {
 		badges = append(badges, "🔥")
 	}
-	if value32(day.SolarRadiationMax) >= sunnySolarThreshold {
+	if isSunnyDay(day) {
 		badges = append(badges, "☀️")
 	}
 	return badges
@@ -1377,7 +1427,6 @@ func dayBadges(day models.DailyWeatherInsight) []string {
 func calendarClass(day models.DailyWeatherInsight) string {
 	rain := value32(day.RainTotal)
 	tempMax := value32(day.TempMax)
-	solar := value32(day.SolarRadiationMax)
 	switch {
 	case rain >= heavyRainThreshold:
 		return "bg-blue-100 dark:bg-blue-900/40 border-blue-300 dark:border-blue-700"
@@ -1385,7 +1434,7 @@ func calendarClass(day models.DailyWeatherInsight) string {
 		return "bg-cyan-50 dark:bg-cyan-900/30 border-cyan-200 dark:border-cyan-800"
 	case tempMax >= hotDayTempThreshold:
 		return "bg-red-50 dark:bg-red-900/25 border-red-200 dark:border-red-800"
-	case solar >= sunnySolarThreshold:
+	case isSunnyDay(day):
 		return "bg-yellow-50 dark:bg-yellow-900/25 border-yellow-200 dark:border-yellow-800"
 	default:
 		return "bg-white dark:bg-gray-800 border-gray-200 dark:border-gray-700"
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/iRootPro/weather/internal/models"
@@ -13,6 +14,18 @@ type WeatherService struct {
 	repo     repository.WeatherRepository
 	timezone string
 	location *time.Location
+
+	reanalysisRepo   repository.ReanalysisRepository
+	reanalysisSource string
+	reanalysisYears  int
+
+	normalsRepo    repository.ClimateNormalsRepository
+	normalsStation string
+	normalsPeriod  string
+
+	climatology *climatologyState
+
+	sun *SunService
 }
 
 func NewWeatherService(repo repository.WeatherRepository) *WeatherService {
@@ -215,6 +228,13 @@ func (s *WeatherService) GetRecentEvents(ctx context.Context, hours int) ([]mode
 	pressureEvents := detectPressureChanges(data)
 	events = append(events, pressureEvents...)
 
+	// Необычные для даты и часа значения по климатологии станции
+	unusualEvents, err := s.getUnusualEvents(ctx, data)
+	if err != nil {
+		slog.Warn("failed to detect unusual weather", "error", err)
+	}
+	events = append(events, unusualEvents...)
+
 	// Сортируем события по времени (от новых к старым)
 	sortEvents(events)
 
@@ -16,6 +16,10 @@ type BotHandler struct {
 	moonSvc     *service.MoonService
 	forecastSvc *service.ForecastService
 	geomagSvc   *service.GeomagneticService
+	activitySvc *service.ActivityService
+	clothingSvc *service.ClothingService
+	uvSvc       *service.UVService
+	pvEnabled   bool
 	userRepo    repository.TelegramUserRepository
 	subRepo     repository.TelegramSubscriptionRepository
 	notifRepo   repository.TelegramNotificationRepository
@@ -33,6 +37,10 @@ func NewBotHandler(
 	moonSvc *service.MoonService,
 	forecastSvc *service.ForecastService,
 	geomagSvc *service.GeomagneticService,
+	activitySvc *service.ActivityService,
+	clothingSvc *service.ClothingService,
+	uvSvc *service.UVService,
+	pvEnabled bool,
 	userRepo repository.TelegramUserRepository,
 	subRepo repository.TelegramSubscriptionRepository,
 	notifRepo repository.TelegramNotificationRepository,
@@ -49,6 +57,10 @@ func NewBotHandler(
 		moonSvc:     moonSvc,
 		forecastSvc: forecastSvc,
 		geomagSvc:   geomagSvc,
+		activitySvc: activitySvc,
+		clothingSvc: clothingSvc,
+		uvSvc:       uvSvc,
+		pvEnabled:   pvEnabled,
 		userRepo:    userRepo,
 		subRepo:     subRepo,
 		notifRepo:   notifRepo,
@@ -117,13 +129,13 @@ func isImageMimeType(mimeType string) bool {
 		"image/jpeg",
 		"image/jpg",
 		"image/png",
-		"image/heic",  // iPhone (High Efficiency Image Container)
-		"image/heif",  // iPhone альтернативный формат
-		"image/webp",  // Android/Google формат
-		"image/avif",  // Новый формат (Android 12+)
-		"image/bmp",   // Windows Bitmap
-		"image/gif",   // GIF анимация
-		"image/tiff",  // TIFF формат
+		"image/heic",        // iPhone (High Efficiency Image Container)
+		"image/heif",        // iPhone альтернативный формат
+		"image/webp",        // Android/Google формат
+		"image/avif",        // Новый формат (Android 12+)
+		"image/bmp",         // Windows Bitmap
+		"image/gif",         // GIF анимация
+		"image/tiff",        // TIFF формат
 		"image/x-canon-cr2", // Canon RAW
 		"image/x-nikon-nef", // Nikon RAW
 		"image/x-sony-arw",  // Sony RAW
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/wcharczuk/go-chart/v2"
+	"github.com/wcharczuk/go-chart/v2/drawing"
 	"github.com/iRootPro/weather/internal/models"
 	"github.com/iRootPro/weather/internal/service"
 )
@@ -255,3 +256,89 @@ func generateHumidityChart(data []models.WeatherData, period ChartPeriod) ([]byt
 
 	return buffer.Bytes(), nil
 }
+
+// GenerateHydroChart создаёт график уровня воды с линиями неблагоприятного и опасного
+// уровней. Порог рисуется, только если он не дальше метра от графика — иначе линия
+// уровня сжимается в полосу.
+func GenerateHydroChart(readings []models.HydroLevelReading, gauge *models.HydroGauge, title string) ([]byte, error) {
+	if len(readings) < 2 {
+		return nil, fmt.Errorf("no water level data")
+	}
+
+	xValues := make([]time.Time, 0, len(readings))
+	yValues := make([]float64, 0, len(readings))
+	maxLevel := float64(readings[0].LevelBSM)
+	for _, r := range readings {
+		xValues = append(xValues, r.ObservedAt.In(time.Local))
+		yValues = append(yValues, float64(r.LevelBSM))
+		maxLevel = max(maxLevel, float64(r.LevelBSM))
+	}
+
+	series := []chart.Series{
+		chart.TimeSeries{
+			Name:    "Уровень",
+			XValues: xValues,
+			YValues: yValues,
+			Style: chart.Style{
+				StrokeColor: chart.ColorBlue,
+				StrokeWidth: 2,
+			},
+		},
+	}
+	threshold := func(name string, level *float32, color drawing.Color) {
+		if level == nil || float64(*level)-maxLevel > 1 {
+			return
+		}
+		series = append(series, chart.TimeSeries{
+			Name:    name,
+			XValues: []time.Time{xValues[0], xValues[len(xValues)-1]},
+			YValues: []float64{float64(*level), float64(*level)},
+			Style: chart.Style{
+				StrokeColor:     color,
+				StrokeWidth:     1.5,
+				StrokeDashArray: []float64{6, 4},
+			},
+		})
+	}
+	if gauge != nil {
+		threshold("Неблагоприятный", gauge.FloodingPreventionBM, drawing.ColorFromHex("f97316"))
+		threshold("Опасный", gauge.FloodingDangerBSM, chart.ColorRed)
+	}
+
+	graph := chart.Chart{
+		Title:      title,
+		TitleStyle: chart.Style{FontSize: 14},
+		Width:      800,
+		Height:     400,
+		XAxis: chart.XAxis{
+			Style: chart.Style{FontSize: 8},
+			ValueFormatter: func(v interface{}) string {
+				if t, ok := v.(time.Time); ok {
+					return t.Format("02.01 15:04")
+				}
+				return ""
+			},
+		},
+		YAxis: chart.YAxis{
+			Name:      "м БСВ",
+			NameStyle: chart.Style{FontSize: 10},
+			Style:     chart.Style{FontSize: 10},
+			ValueFormatter: func(v interface{}) string {
+				if f, ok := v.(float64); ok {
+					return fmt.Sprintf("%.2f", f)
+				}
+				return ""
+			},
+		},
+		Series: series,
+	}
+	graph.Elements = []chart.Renderable{chart.Legend(&graph)}
+
+	// Рендерим в PNG
+	buffer := bytes.NewBuffer([]byte{})
+	if err := graph.Render(chart.PNG, buffer); err != nil {
+		return nil, fmt.Errorf("failed to render chart: %w", err)
+	}
+
+	return buffer.Bytes(), nil
+}
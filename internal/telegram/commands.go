@@ -21,14 +21,30 @@ const (
 	CmdForecast    = "forecast"     // Прогноз погоды на несколько дней
 	CmdAnnounce        = "announce"         // Массовая рассылка анонса (только админы)
 	CmdAnnouncePreview = "announce_preview" // Предпросмотр анонса (только админы)
+	CmdTodayHistory    = "today_history"    // Погода этой даты в прошлые годы
+	CmdActivities      = "activities"       // Оценка погоды для бега, рыбалки, шашлыков и т.п.
+	CmdSkin            = "skin"             // Фототип кожи и время до солнечного ожога
 )
 
 // Типы событий для подписок
 const (
-	EventAll          = "all"
-	EventRain         = "rain"
-	EventTemperature  = "temperature"
-	EventWind         = "wind"
-	EventPressure     = "pressure"
-	EventDailySummary = "daily_summary" // Ежедневная утренняя сводка
+	EventAll              = "all"
+	EventRain             = "rain"
+	EventTemperature      = "temperature"
+	EventWind             = "wind"
+	EventPressure         = "pressure"
+	EventDailySummary     = "daily_summary"     // Ежедневная утренняя сводка
+	EventRainSoon         = "rain_soon"         // Прогноз дождя в ближайший час
+	EventFrostRisk        = "frost_risk"        // Вечернее предупреждение о ночном заморозке
+	EventIceRisk          = "ice_risk"          // Вечернее предупреждение о гололёде
+	EventGardenWeekly     = "garden_weekly"     // Еженедельная сводка «Огород»
+	EventHeatingSeason    = "heating_season"    // Начало и окончание отопительного сезона
+	EventIndoorClimate    = "indoor_climate"    // Риск плесени и сухой воздух в доме
+	EventPVMonthly        = "pv_monthly"        // Ежемесячный отчёт о выработке солнечных панелей
+	EventDrought          = "drought"           // Засуха: SPI и ГТК ниже порога, затяжной сухой период
+	EventMeteoSensitivity = "meteo_sensitivity" // Утренний индекс метеочувствительности
+	EventUVExposure       = "uv_exposure"       // Риск солнечного ожога для фототипа кожи
+	EventAurora           = "aurora"            // Шанс увидеть полярное сияние этой ночью
+	EventFloodForecast    = "flood_forecast"    // Прогноз выхода реки к неблагоприятному или опасному уровню
+	EventHydro            = "hydro"             // Смена статуса уровня воды, быстрый подъём, подъём на верхних постах
 )
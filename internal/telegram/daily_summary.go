@@ -17,6 +17,7 @@ type DailySummaryService struct {
 	sunSvc      *service.SunService
 	forecastSvc *service.ForecastService
 	geomagSvc   *service.GeomagneticService
+	clothingSvc *service.ClothingService
 	subRepo     repository.TelegramSubscriptionRepository
 	userRepo    repository.TelegramUserRepository
 	sendTime    string // Время отправки в формате "07:00"
@@ -29,6 +30,7 @@ func NewDailySummaryService(
 	sunSvc *service.SunService,
 	forecastSvc *service.ForecastService,
 	geomagSvc *service.GeomagneticService,
+	clothingSvc *service.ClothingService,
 	subRepo repository.TelegramSubscriptionRepository,
 	userRepo repository.TelegramUserRepository,
 	sendTime string,
@@ -40,6 +42,7 @@ func NewDailySummaryService(
 		sunSvc:      sunSvc,
 		forecastSvc: forecastSvc,
 		geomagSvc:   geomagSvc,
+		clothingSvc: clothingSvc,
 		subRepo:     subRepo,
 		userRepo:    userRepo,
 		sendTime:    sendTime,
@@ -101,77 +104,132 @@ func (s *DailySummaryService) sendDailySummary(ctx context.Context) {
 
 	s.logger.Info("processing daily summary", "subscribers", len(subscribers))
 
-	// Получаем текущие данные о погоде
-	current, err := s.weatherSvc.GetLatest(ctx)
+	input, err := BuildDailySummaryInput(ctx, DailySummarySources{
+		Weather:     s.weatherSvc,
+		Sun:         s.sunSvc,
+		Forecast:    s.forecastSvc,
+		Geomagnetic: s.geomagSvc,
+		Clothing:    s.clothingSvc,
+	}, s.logger)
 	if err != nil {
 		s.logger.Error("failed to get current weather", "error", err)
 		return
 	}
 
-	// Получаем данные за вчера в это же время
-	yesterdaySame, err := s.weatherSvc.GetDataNearTime(ctx, current.Time.Add(-24*time.Hour))
+	// Форматируем сообщение
+	text := FormatDailySummary(input)
+
+	// Отправляем всем подписчикам
+	for _, chatID := range subscribers {
+		msg := tgbotapi.NewMessage(chatID, text)
+		msg.ParseMode = "Markdown"
+
+		if _, err := s.bot.Send(msg); err != nil {
+			s.logger.Error("failed to send daily summary",
+				"chat_id", chatID,
+				"error", err)
+		} else {
+			s.logger.Debug("daily summary sent", "chat_id", chatID)
+		}
+	}
+
+	s.logger.Info("daily summary sent to all subscribers")
+}
+
+// DailySummaryInput — данные утренней сводки, общие для Telegram и Max
+type DailySummaryInput struct {
+	Current       *models.WeatherData
+	YesterdaySame *models.WeatherData     // данные вчера в это же время
+	NightMinMax   *repository.DailyMinMax // min/max за ночь (00:00 - 07:00)
+	Sun           *service.SunTimesWithComparison
+	TodayForecast []DayForecastInfo
+	Geomagnetic   *service.DashboardSnapshot
+	Anomalies     []models.ClimateAnomaly // отклонения от климатической нормы
+	OnThisDay     *models.OnThisDay       // погода этой даты в прошлые годы
+	Sunshine      *models.SunshineDay     // вчерашнее солнечное сияние
+	Clothing      *models.ClothingAdvice  // совет по одежде на утро, день и вечер
+}
+
+// DailySummarySources — сервисы, из которых собирается сводка. Необязательные сервисы
+// (прогноз, магнитная обстановка, одежда) могут быть nil — тогда раздел пропускается.
+type DailySummarySources struct {
+	Weather     *service.WeatherService
+	Sun         *service.SunService
+	Forecast    *service.ForecastService
+	Geomagnetic *service.GeomagneticService
+	Clothing    *service.ClothingService
+}
+
+// BuildDailySummaryInput собирает данные утренней сводки. Ошибкой считается только
+// отсутствие текущих данных: остальные разделы при ошибке пропускаются с предупреждением.
+func BuildDailySummaryInput(ctx context.Context, src DailySummarySources, logger *slog.Logger) (DailySummaryInput, error) {
+	var input DailySummaryInput
+	current, err := src.Weather.GetLatest(ctx)
 	if err != nil {
-		s.logger.Warn("failed to get yesterday weather", "error", err)
+		return input, err
+	}
+	input.Current = current
+
+	// Получаем данные за вчера в это же время
+	if input.YesterdaySame, err = src.Weather.GetDataNearTime(ctx, current.Time.Add(-24*time.Hour)); err != nil {
+		logger.Warn("failed to get yesterday weather", "error", err)
 	}
 
 	// Получаем min/max за ночь (00:00 - 07:00 сегодня)
 	now := time.Now()
 	nightStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 	nightEnd := time.Date(now.Year(), now.Month(), now.Day(), 7, 0, 0, 0, now.Location())
-	nightMinMax, err := s.weatherSvc.GetMinMaxInRange(ctx, nightStart, nightEnd)
-	if err != nil {
-		s.logger.Warn("failed to get night min/max", "error", err)
-	}
-
-	// Получаем min/max за сегодня
-	dailyMinMax, err := s.weatherSvc.GetDailyMinMax(ctx)
-	if err != nil {
-		s.logger.Warn("failed to get daily min/max", "error", err)
+	if input.NightMinMax, err = src.Weather.GetMinMaxInRange(ctx, nightStart, nightEnd); err != nil {
+		logger.Warn("failed to get night min/max", "error", err)
 	}
 
 	// Получаем данные о солнце
-	sunData := s.sunSvc.GetTodaySunTimesWithComparison()
+	if src.Sun != nil {
+		input.Sun = src.Sun.GetTodaySunTimesWithComparison()
+	}
 
 	// Получаем прогноз на сегодня
-	var todayForecast []DayForecastInfo
-	if s.forecastSvc != nil {
-		forecast, err := s.forecastSvc.GetTodayForecast(ctx)
+	if src.Forecast != nil {
+		forecast, err := src.Forecast.GetTodayForecast(ctx)
 		if err != nil {
-			s.logger.Warn("failed to get today forecast", "error", err)
+			logger.Warn("failed to get today forecast", "error", err)
 		} else if len(forecast) > 0 {
-			todayForecast = formatTodayForecast(forecast)
+			input.TodayForecast = formatTodayForecast(forecast)
 		}
 	}
 
 	// Получаем магнитную обстановку (если сервис подключён)
-	var geomagSnap *service.DashboardSnapshot
-	if s.geomagSvc != nil {
-		snap, err := s.geomagSvc.GetDashboardSnapshot(ctx, time.Now())
+	if src.Geomagnetic != nil {
+		snap, err := src.Geomagnetic.GetDashboardSnapshot(ctx, now)
 		if err != nil {
-			s.logger.Warn("failed to get geomagnetic snapshot", "error", err)
+			logger.Warn("failed to get geomagnetic snapshot", "error", err)
 		} else if snap != nil && snap.HasData {
-			geomagSnap = snap
+			input.Geomagnetic = snap
 		}
 	}
 
-	// Форматируем сообщение
-	text := FormatDailySummary(current, yesterdaySame, nightMinMax, dailyMinMax, sunData, todayForecast, geomagSnap)
+	// Сравниваем с климатической нормой (если нормы импортированы)
+	if input.Anomalies, err = src.Weather.GetDailySummaryAnomalies(ctx); err != nil {
+		logger.Warn("failed to get climate anomalies", "error", err)
+	}
 
-	// Отправляем всем подписчикам
-	for _, chatID := range subscribers {
-		msg := tgbotapi.NewMessage(chatID, text)
-		msg.ParseMode = "Markdown"
+	// Погода этой даты в прошлые годы
+	if input.OnThisDay, err = src.Weather.GetOnThisDay(ctx, ""); err != nil {
+		logger.Warn("failed to get on this day history", "error", err)
+	}
 
-		if _, err := s.bot.Send(msg); err != nil {
-			s.logger.Error("failed to send daily summary",
-				"chat_id", chatID,
-				"error", err)
-		} else {
-			s.logger.Debug("daily summary sent", "chat_id", chatID)
-		}
+	// Вчерашнее солнечное сияние по датчику радиации
+	if input.Sunshine, err = src.Weather.GetSunshineDay(ctx, now.AddDate(0, 0, -1)); err != nil {
+		logger.Warn("failed to get yesterday sunshine", "error", err)
 	}
 
-	s.logger.Info("daily summary sent to all subscribers")
+	// Совет по одежде на утро, день и вечер
+	if src.Clothing != nil {
+		if input.Clothing, err = src.Clothing.GetAdvice(ctx); err != nil {
+			logger.Warn("failed to get clothing advice", "error", err)
+		}
+	}
+	return input, nil
 }
 
 // DayForecastInfo содержит информацию о прогнозе на день
@@ -2,6 +2,7 @@ package telegram
 
 import (
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
@@ -353,12 +354,25 @@ func FormatEventNotification(event models.WeatherEvent) string {
 // GetEventTypeName возвращает название типа события на русском
 func GetEventTypeName(eventType string) string {
 	names := map[string]string{
-		"all":           "Все события",
-		"rain":          "Дождь",
-		"temperature":   "Изменения температуры",
-		"wind":          "Сильный ветер",
-		"pressure":      "Изменения давления",
-		"daily_summary": "Утренняя сводка",
+		"all":               "Все события",
+		"rain":              "Дождь",
+		"temperature":       "Изменения температуры",
+		"wind":              "Сильный ветер",
+		"pressure":          "Изменения давления",
+		"daily_summary":     "Утренняя сводка",
+		"rain_soon":         "Скоро дождь",
+		"frost_risk":        "Заморозки",
+		"ice_risk":          "Гололёд",
+		"garden_weekly":     "Огород (раз в неделю)",
+		"heating_season":    "Отопительный сезон",
+		"indoor_climate":    "Микроклимат в доме",
+		"pv_monthly":        "Солнечные панели (раз в месяц)",
+		"drought":           "Засуха",
+		"meteo_sensitivity": "Метеозависимость (утром)",
+		"uv_exposure":       "UV-ожог",
+		"aurora":            "Полярное сияние",
+		"flood_forecast":    "Прогноз паводка",
+		"hydro":             "Уровень воды",
 	}
 	if name, ok := names[eventType]; ok {
 		return name
@@ -492,80 +506,83 @@ func escapeMarkdown(s string) string {
 }
 
 // FormatDailySummary форматирует утреннюю сводку погоды
-func FormatDailySummary(current, yesterdaySame *models.WeatherData, nightMinMax, dailyMinMax *repository.DailyMinMax, sunData *service.SunTimesWithComparison, todayForecast []DayForecastInfo, geomagSnap *service.DashboardSnapshot) string {
+func FormatDailySummary(in DailySummaryInput) string {
 	// Форматируем дату
 	months := []string{"", "января", "февраля", "марта", "апреля", "мая", "июня",
 		"июля", "августа", "сентября", "октября", "ноября", "декабря"}
-	day := current.Time.Day()
-	month := months[current.Time.Month()]
+	day := in.Current.Time.Day()
+	month := months[in.Current.Time.Month()]
 
 	text := "🌅 *Доброе утро! Сводка погоды*\n\n"
 	text += fmt.Sprintf("📍 Армавир · %d %s\n\n", day, month)
 
 	// СЕЙЧАС
 	text += "🌡️ *СЕЙЧАС*\n"
-	if current.TempOutdoor != nil {
-		text += fmt.Sprintf("Температура: %.1f°C", *current.TempOutdoor)
-		if current.TempFeelsLike != nil {
-			text += fmt.Sprintf(" (ощущается %.1f°C)", *current.TempFeelsLike)
+	if in.Current.TempOutdoor != nil {
+		text += fmt.Sprintf("Температура: %.1f°C", *in.Current.TempOutdoor)
+		if in.Current.TempFeelsLike != nil {
+			text += fmt.Sprintf(" (ощущается %.1f°C)", *in.Current.TempFeelsLike)
 		}
 		text += "\n"
 	}
-	if current.HumidityOutdoor != nil {
-		text += fmt.Sprintf("Влажность: %d%%", *current.HumidityOutdoor)
+	if in.Current.HumidityOutdoor != nil {
+		text += fmt.Sprintf("Влажность: %d%%", *in.Current.HumidityOutdoor)
 	}
-	if current.PressureRelative != nil {
-		text += fmt.Sprintf(" · Давление: %.0f мм", *current.PressureRelative)
+	if in.Current.PressureRelative != nil {
+		text += fmt.Sprintf(" · Давление: %.0f мм", *in.Current.PressureRelative)
 	}
 	text += "\n\n"
 
 	// ЗА НОЧЬ
-	if nightMinMax != nil && nightMinMax.TempMin != nil && nightMinMax.TempMax != nil {
+	if in.NightMinMax != nil && in.NightMinMax.TempMin != nil && in.NightMinMax.TempMax != nil {
 		text += "🌙 *ЗА НОЧЬ* (00:00 - 07:00)\n"
-		text += fmt.Sprintf("Температура: %.1f°C ... %.1f°C\n", *nightMinMax.TempMin, *nightMinMax.TempMax)
-		if current.WindGust != nil {
-			text += fmt.Sprintf("Ветер до %.1f м/с\n", *current.WindGust)
+		text += fmt.Sprintf("Температура: %.1f°C ... %.1f°C\n", *in.NightMinMax.TempMin, *in.NightMinMax.TempMax)
+		if in.Current.WindGust != nil {
+			text += fmt.Sprintf("Ветер до %.1f м/с\n", *in.Current.WindGust)
 		}
 		text += "\n"
 	}
 
 	// СОЛНЦЕ
-	if sunData != nil {
+	if in.Sun != nil {
 		text += "☀️ *СОЛНЦЕ*\n"
 		text += fmt.Sprintf("Восход: %s · Закат: %s\n",
-			sunData.Sunrise.Format("15:04"),
-			sunData.Sunset.Format("15:04"))
+			in.Sun.Sunrise.Format("15:04"),
+			in.Sun.Sunset.Format("15:04"))
 
-		if sunData.DayChangeDay != 0 {
-			changeText := formatDurationChange(sunData.DayChangeDay)
-			if sunData.DayChangeDay > 0 {
-				text += fmt.Sprintf("Световой день: %s (+%s к вчера)\n", formatDurationChange(sunData.DayLength), changeText)
+		if in.Sun.DayChangeDay != 0 {
+			changeText := formatDurationChange(in.Sun.DayChangeDay)
+			if in.Sun.DayChangeDay > 0 {
+				text += fmt.Sprintf("Световой день: %s (+%s к вчера)\n", formatDurationChange(in.Sun.DayLength), changeText)
 			} else {
-				text += fmt.Sprintf("Световой день: %s (-%s к вчера)\n", formatDurationChange(sunData.DayLength), changeText)
+				text += fmt.Sprintf("Световой день: %s (-%s к вчера)\n", formatDurationChange(in.Sun.DayLength), changeText)
 			}
 		} else {
-			text += fmt.Sprintf("Световой день: %s\n", formatDurationChange(sunData.DayLength))
+			text += fmt.Sprintf("Световой день: %s\n", formatDurationChange(in.Sun.DayLength))
+		}
+		if line := FormatSunshineLine(in.Sunshine); line != "" {
+			text += line + "\n"
 		}
 		text += "\n"
 	}
 
 	// МАГНИТНАЯ ОБСТАНОВКА
-	if geomagSnap != nil && geomagSnap.HasData && geomagSnap.Current != nil {
+	if in.Geomagnetic != nil && in.Geomagnetic.HasData && in.Geomagnetic.Current != nil {
 		text += "🌞 *МАГНИТНАЯ ОБСТАНОВКА*\n"
 		text += fmt.Sprintf("%s Сейчас %s\n",
-			geomagSnap.Status.Emoji(),
-			geomagSnap.Status.Label())
+			in.Geomagnetic.Status.Emoji(),
+			in.Geomagnetic.Status.Label())
 
-		if geomagSnap.TodayMaxKp != nil {
-			maxStatus := models.ClassifyKp(geomagSnap.TodayMaxKp.Kp)
-			if maxStatus > geomagSnap.Status {
+		if in.Geomagnetic.TodayMaxKp != nil {
+			maxStatus := models.ClassifyKp(in.Geomagnetic.TodayMaxKp.Kp)
+			if maxStatus > in.Geomagnetic.Status {
 				text += fmt.Sprintf("За сутки доходило до: %s\n", maxStatus.Label())
 			}
 		}
 
-		if geomagSnap.NextStorm != nil {
-			when := geomagSnap.NextStorm.SlotTime.In(time.Local).Format("02.01 в 15:04")
-			if gLevel, desc, ok := models.StormLevel(geomagSnap.NextStorm.Kp); ok {
+		if in.Geomagnetic.NextStorm != nil {
+			when := in.Geomagnetic.NextStorm.SlotTime.In(time.Local).Format("02.01 в 15:04")
+			if gLevel, desc, ok := models.StormLevel(in.Geomagnetic.NextStorm.Kp); ok {
 				text += fmt.Sprintf("Ожидается буря %s «%s» %s\n", gLevel, desc, when)
 			}
 		}
@@ -573,10 +590,10 @@ func FormatDailySummary(current, yesterdaySame *models.WeatherData, nightMinMax,
 	}
 
 	// СРАВНЕНИЕ С ВЧЕРА
-	if yesterdaySame != nil {
+	if in.YesterdaySame != nil {
 		text += "📊 *СРАВНЕНИЕ С ВЧЕРА*\n"
-		if current.TempOutdoor != nil && yesterdaySame.TempOutdoor != nil {
-			diff := *current.TempOutdoor - *yesterdaySame.TempOutdoor
+		if in.Current.TempOutdoor != nil && in.YesterdaySame.TempOutdoor != nil {
+			diff := *in.Current.TempOutdoor - *in.YesterdaySame.TempOutdoor
 			if diff > 0 {
 				text += fmt.Sprintf("Температура: +%.1f°C теплее\n", diff)
 			} else if diff < 0 {
@@ -585,8 +602,8 @@ func FormatDailySummary(current, yesterdaySame *models.WeatherData, nightMinMax,
 				text += "Температура: без изменений\n"
 			}
 		}
-		if current.PressureRelative != nil && yesterdaySame.PressureRelative != nil {
-			diff := *current.PressureRelative - *yesterdaySame.PressureRelative
+		if in.Current.PressureRelative != nil && in.YesterdaySame.PressureRelative != nil {
+			diff := *in.Current.PressureRelative - *in.YesterdaySame.PressureRelative
 			if diff > 0 {
 				text += fmt.Sprintf("Давление: +%.0f мм выше\n", diff)
 			} else if diff < 0 {
@@ -598,10 +615,26 @@ func FormatDailySummary(current, yesterdaySame *models.WeatherData, nightMinMax,
 		text += "\n"
 	}
 
+	// ОТНОСИТЕЛЬНО НОРМЫ
+	if len(in.Anomalies) > 0 {
+		text += fmt.Sprintf("📐 *ОТНОСИТЕЛЬНО НОРМЫ* (%s)\n", in.Anomalies[0].NormalsPeriod)
+		for _, a := range in.Anomalies {
+			if line := FormatClimateAnomalyLine(a); line != "" {
+				text += line + "\n"
+			}
+		}
+		text += "\n"
+	}
+
+	// В ЭТОТ ДЕНЬ
+	if line := FormatOnThisDayLine(in.OnThisDay); line != "" {
+		text += "📜 " + line + "\n\n"
+	}
+
 	// ПРОГНОЗ НА СЕГОДНЯ
-	if len(todayForecast) > 0 {
+	if len(in.TodayForecast) > 0 {
 		text += "🔮 *ПРОГНОЗ НА СЕГОДНЯ*\n"
-		for _, f := range todayForecast {
+		for _, f := range in.TodayForecast {
 			text += fmt.Sprintf("%s В %02d:00: %.0f°C", f.Icon, f.Hour, f.Temperature)
 			if f.PrecipitationProbability > 0 {
 				text += fmt.Sprintf(" · 💧%d%%", f.PrecipitationProbability)
@@ -611,6 +644,11 @@ func FormatDailySummary(current, yesterdaySame *models.WeatherData, nightMinMax,
 		text += "\n"
 	}
 
+	// ЧТО НАДЕТЬ
+	if section := FormatClothingAdvice(in.Clothing); section != "" {
+		text += section + "\n"
+	}
+
 	// Пожелание
 	greetings := []string{
 		"Хорошего дня! ☀️",
@@ -633,6 +671,136 @@ func FormatDailySummary(current, yesterdaySame *models.WeatherData, nightMinMax,
 	return text
 }
 
+// FormatClothingAdvice форматирует совет по одежде на отрезки дня
+func FormatClothingAdvice(c *models.ClothingAdvice) string {
+	if c == nil || len(c.Windows) == 0 {
+		return ""
+	}
+
+	text := "👕 *ЧТО НАДЕТЬ*\n"
+	for _, w := range c.Windows {
+		feels := fmt.Sprintf("%+.0f°", w.FeelsLikeMin)
+		if math.Round(w.FeelsLikeMin) != math.Round(w.FeelsLikeMax) {
+			feels = fmt.Sprintf("%+.0f…%+.0f°", w.FeelsLikeMin, w.FeelsLikeMax)
+		}
+		text += fmt.Sprintf("%s (ощущается %s): %s\n", w.Name, feels, w.Clothes)
+	}
+	if len(c.Extras) > 0 {
+		text += "С собой: " + strings.Join(c.Extras, ", ") + "\n"
+	}
+	return text
+}
+
+// FormatClimateAnomalyLine форматирует строку аномалии: «Вчера: на 4.2° теплее нормы, осадков 140% нормы»
+func FormatClimateAnomalyLine(a models.ClimateAnomaly) string {
+	parts := make([]string, 0, 2)
+	if a.HasTemp {
+		parts = append(parts, a.TempText)
+	}
+	if a.PrecipitationText != "" {
+		parts = append(parts, a.PrecipitationText)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s: %s", a.Label, strings.Join(parts, ", "))
+}
+
+// FormatOnThisDay форматирует погоду этой даты в прошлые годы
+func FormatOnThisDay(d *models.OnThisDay) string {
+	if d == nil {
+		return "❌ Нет данных за эту дату"
+	}
+
+	text := fmt.Sprintf("📜 *В этот день, %s*\n\n", d.Label)
+	if len(d.Years) == 0 {
+		return text + "Наблюдений за эту дату в прошлые годы пока нет."
+	}
+
+	if d.Current != nil {
+		label := "Сегодня"
+		if d.Current.Partial {
+			label = "Сегодня пока"
+		}
+		text += fmt.Sprintf("*%s:* %s\n", label, formatOnThisDayValues(*d.Current))
+		if d.TempMaxRank != nil {
+			text += fmt.Sprintf("🔥 Максимум: %s\n", d.TempMaxRank.Text)
+		}
+		if d.TempMinRank != nil {
+			text += fmt.Sprintf("🥶 Минимум: %s\n", d.TempMinRank.Text)
+		}
+		if d.RainRank != nil {
+			text += fmt.Sprintf("🌧️ Осадки: %s\n", d.RainRank.Text)
+		}
+		text += "\n"
+	}
+
+	text += "*Прошлые годы:*\n"
+	for _, y := range d.Years {
+		text += fmt.Sprintf("• %d: %s\n", y.Year, formatOnThisDayValues(y))
+	}
+
+	if d.Warmest != nil || d.Coldest != nil || d.Wettest != nil {
+		text += "\n"
+	}
+	if d.Warmest != nil {
+		text += fmt.Sprintf("🔥 Теплее всего: %.1f°C в %d\n", *d.Warmest.TempMax, d.Warmest.Year)
+	}
+	if d.Coldest != nil {
+		text += fmt.Sprintf("❄️ Холоднее всего: %.1f°C в %d\n", *d.Coldest.TempMin, d.Coldest.Year)
+	}
+	if d.Wettest != nil {
+		text += fmt.Sprintf("☔ Больше всего осадков: %.1f мм в %d\n", *d.Wettest.RainTotal, d.Wettest.Year)
+	}
+	return strings.TrimRight(text, "\n")
+}
+
+// FormatSunshineLine форматирует вчерашнюю продолжительность солнечного сияния: «Вчера солнце светило 6.5 ч из 10.2 (64%)»
+func FormatSunshineLine(d *models.SunshineDay) string {
+	if d == nil || d.PossibleHours <= 0 || len(d.Hours) == 0 {
+		return ""
+	}
+	text := fmt.Sprintf("Вчера солнце светило %.1f ч из %.1f (%.0f%%)", d.SunshineHours, d.PossibleHours, d.RelativePct)
+	if d.CloudinessPct != nil {
+		text += fmt.Sprintf(", облачность %.0f%%", *d.CloudinessPct)
+	}
+	return text
+}
+
+// FormatOnThisDayLine форматирует строку для утренней сводки: «В этот день в 2025: 8.1…19.4°C, без осадков»
+func FormatOnThisDayLine(d *models.OnThisDay) string {
+	if d == nil || len(d.Years) == 0 {
+		return ""
+	}
+	last := d.Years[0]
+	line := fmt.Sprintf("В этот день в %d: %s", last.Year, formatOnThisDayValues(last))
+	if d.Warmest != nil && d.Warmest.Year != last.Year {
+		line += fmt.Sprintf("; теплее всего было в %d: %.1f°C", d.Warmest.Year, *d.Warmest.TempMax)
+	}
+	return line
+}
+
+func formatOnThisDayValues(y models.OnThisDayYear) string {
+	parts := make([]string, 0, 2)
+	switch {
+	case y.TempMin != nil && y.TempMax != nil:
+		parts = append(parts, fmt.Sprintf("%.1f…%.1f°C", *y.TempMin, *y.TempMax))
+	case y.TempMax != nil:
+		parts = append(parts, fmt.Sprintf("до %.1f°C", *y.TempMax))
+	}
+	if y.RainTotal != nil {
+		if *y.RainTotal > 0 {
+			parts = append(parts, fmt.Sprintf("%.1f мм", *y.RainTotal))
+		} else {
+			parts = append(parts, "без осадков")
+		}
+	}
+	if len(parts) == 0 {
+		return "нет данных"
+	}
+	return strings.Join(parts, ", ")
+}
+
 // FormatForecast форматирует прогноз погоды на несколько дней
 func FormatForecast(forecast []models.DailyForecast) string {
 	text := "🔮 *Прогноз погоды*\n\n"
@@ -641,6 +809,7 @@ func FormatForecast(forecast []models.DailyForecast) string {
 	months := []string{"", "января", "февраля", "марта", "апреля", "мая", "июня",
 		"июля", "августа", "сентября", "октября", "ноября", "декабря"}
 
+	ensembleMembers := 0
 	for i, day := range forecast {
 		// Разделитель между днями
 		if i > 0 {
@@ -679,16 +848,48 @@ func FormatForecast(forecast []models.DailyForecast) string {
 			text += fmt.Sprintf("💨 Ветер: %.0f м/с %s\n", day.WindSpeedMax, windDir)
 		}
 
+		// Расхождение моделей
+		if day.Ensemble != nil {
+			ensembleMembers = max(ensembleMembers, len(day.Ensemble.Members))
+			text += formatForecastUncertainty(day.Ensemble)
+		}
+
 		text += "\n"
 	}
 
 	text += "━━━━━━━━━━━━━━━\n"
-	text += "📡 Данные от Open-Meteo\n"
+	if ensembleMembers > 1 {
+		text += fmt.Sprintf("📡 Данные от Open-Meteo, разброс по %d моделям\n", ensembleMembers)
+	} else {
+		text += "📡 Данные от Open-Meteo\n"
+	}
 	text += "🔄 Обновляется каждый час"
 
 	return text
 }
 
+// formatForecastUncertainty описывает согласие моделей по дню прогноза
+func formatForecastUncertainty(e *models.DailyEnsemble) string {
+	total := len(e.Members)
+	spread := max(e.TemperatureMin.Spread, e.TemperatureMax.Spread)
+
+	var label string
+	switch e.Uncertainty {
+	case models.ForecastUncertaintyHigh:
+		label = "🔴 Модели сильно расходятся"
+	case models.ForecastUncertaintyMedium:
+		label = "🟡 Модели расходятся"
+	default:
+		return fmt.Sprintf("🎯 Модели согласны (±%.0f°)\n", spread)
+	}
+
+	details := fmt.Sprintf("±%.0f°", spread)
+	if e.RainyMembers > 0 && e.RainyMembers < total {
+		details += fmt.Sprintf(", дождь у %d из %d", e.RainyMembers, total)
+	}
+	return fmt.Sprintf("%s: %s\n", label, details)
+}
+
 // FormatGeomagneticAlert форматирует уведомление о геомагнитной буре.
 // kind: "now" — буря уже идёт, "fct" — прогнозируется в ближайшие 24 часа.
 func FormatGeomagneticAlert(kind string, slot *models.GeomagneticKp) string {
@@ -720,3 +921,357 @@ func FormatGeomagneticAlert(kind string, slot *models.GeomagneticKp) string {
 	}
 	return ""
 }
+
+// FormatAuroraAlert форматирует уведомление о шансе увидеть полярное сияние этой ночью
+func FormatAuroraAlert(f *models.AuroraForecast, night *models.AuroraNight) string {
+	text := fmt.Sprintf("🌌 *Полярное сияние: шанс %d%%*\n\n", night.Probability)
+	text += fmt.Sprintf("Прогноз Kp до *%.1f* — для нашей широты сияние возможно с Kp %.1f.\n", night.MaxKp, f.KpNeeded)
+	if night.BestTime != nil {
+		text += fmt.Sprintf("Лучшее время: около *%s*, темно с %s до %s.\n",
+			night.BestTime.Format("15:04"), night.DarkFrom.Format("15:04"), night.DarkTo.Format("15:04"))
+	}
+	if night.CloudCover != nil {
+		text += fmt.Sprintf("Облачность: %.0f%%. ", *night.CloudCover)
+	}
+	text += fmt.Sprintf("Луна освещена на %.0f%%.\n", night.MoonIllumination)
+	text += "\nСмотрите на север, подальше от городской засветки. Камера смартфона с длинной выдержкой видит сияние раньше глаза."
+	return text
+}
+
+// FormatHydroAlert форматирует уведомление подписки hydro: смену статуса основного поста,
+// быстрый подъём или подъём на верхнем посту
+func FormatHydroAlert(a models.HydroAlert) string {
+	var text string
+	switch a.Kind {
+	case models.HydroAlertStatus:
+		emoji := "🌊"
+		switch a.To {
+		case models.HydroStatusDanger:
+			emoji = "🔴"
+		case models.HydroStatusPrevention:
+			emoji = "🟠"
+		case models.HydroStatusNear:
+			emoji = "🟡"
+		case models.HydroStatusNormal:
+			emoji = "🟢"
+		}
+		text = fmt.Sprintf("%s *Уровень воды: %s*\n\n", emoji, a.To.Label())
+		text += fmt.Sprintf("Было: %s, с %s — %s.\n", a.From.Label(), a.StartedAt.In(time.Local).Format("02.01 15:04"), a.To.Label())
+	case models.HydroAlertRapidRise:
+		text = "📈 *Быстрый подъём воды*\n\n"
+		text += fmt.Sprintf("Уровень растёт на *%.0f см/ч* с %s. Сейчас — %s.\n", a.ChangeCmPerHour, a.StartedAt.In(time.Local).Format("15:04"), a.To.Label())
+	case models.HydroAlertUpstreamRise:
+		text = "⬆️ *Подъём воды выше по течению*\n\n"
+		text += fmt.Sprintf("За %d ч уровень поднялся на *%.0f см*. Волна дойдёт до нашего поста позже — следите за уровнем.\n", a.WindowHours, a.RiseCm)
+	}
+	text += fmt.Sprintf("\n%s: %.2f м БСВ, %s", a.StationName, a.LevelBSM, a.ObservedAt.In(time.Local).Format("02.01 15:04"))
+	return text
+}
+
+// FormatFloodForecastAlert форматирует раннее предупреждение: прогноз по верхним постам
+// выводит уровень основного поста к неблагоприятной или опасной отметке
+func FormatFloodForecastAlert(f *models.HydroForecast) string {
+	emoji := "🟠"
+	if f.PeakStatus == models.HydroStatusDanger {
+		emoji = "🔴"
+	}
+	text := fmt.Sprintf("🌊 *Прогноз паводка: %s* %s\n\n", f.PeakStatus.Label(), emoji)
+	text += fmt.Sprintf("Сейчас уровень %.2f м БСВ (%s).\n", f.BaseLevelBSM, f.CurrentStatus.Label())
+	if f.DangerAt != nil {
+		text += fmt.Sprintf("Опасный уровень ожидается к *%s*.\n", f.DangerAt.In(time.Local).Format("02.01 15:04"))
+	}
+	if f.PreventionAt != nil {
+		text += fmt.Sprintf("Неблагоприятный уровень ожидается к *%s*.\n", f.PreventionAt.In(time.Local).Format("02.01 15:04"))
+	}
+	if f.Peak != nil {
+		text += fmt.Sprintf("Максимум прогноза: *%.2f м* (%.2f–%.2f) около %s.\n",
+			f.Peak.LevelBSM, f.Peak.LowerBSM, f.Peak.UpperBSM, f.Peak.Time.In(time.Local).Format("02.01 15:04"))
+	}
+	for _, link := range f.Links {
+		if link.Used {
+			text += fmt.Sprintf("\n%s: добегание ~%d ч, доходит %.0f%% подъёма.", link.StationName, link.LagHours, link.Gain*100)
+		}
+	}
+	text += "\n\nПрогноз построен по верхним постам и истории уровней — это оценка, следите за предупреждениями МЧС."
+	return text
+}
+
+// FormatGardenWeekly форматирует еженедельную сводку «Огород» с рекомендацией по поливу
+func FormatGardenWeekly(s *models.AgroSummary) string {
+	if s == nil {
+		return "❌ Нет данных для сводки «Огород»"
+	}
+
+	text := "🥕 *Огород: итоги недели*\n\n"
+
+	text += "💧 *Вода*\n"
+	text += fmt.Sprintf("Испарение ET₀: %.0f мм, расход культурой: %.0f мм\n", s.ET0Week, s.ETcWeek)
+	text += fmt.Sprintf("Дождь: %.0f мм\n", s.RainWeek)
+	text += fmt.Sprintf("Влага в почве: %.0f из %.0f мм (дефицит %d%%)\n", s.SoilWater, s.SoilWaterCapacity, s.DepletionPercent)
+	if s.ForecastRain >= 1 {
+		text += fmt.Sprintf("Дождь в прогнозе на 3 дня: ~%.0f мм\n", s.ForecastRain)
+	}
+	text += "\n"
+
+	switch s.WateringLevel {
+	case models.WateringNow:
+		text += fmt.Sprintf("🚿 *%s*\n", s.Advice)
+	case models.WateringSoon:
+		text += fmt.Sprintf("🕐 %s\n", s.Advice)
+	default:
+		text += fmt.Sprintf("✅ %s\n", s.Advice)
+	}
+	if s.WaterWeekMM > 0 {
+		text += fmt.Sprintf("План на неделю: ~%.0f л/м²", s.WaterWeekMM)
+		if s.WaterWeekMM > 25 {
+			text += " в 2–3 приёма"
+		}
+		text += "\n"
+	}
+	text += "\n"
+
+	text += "🌡️ *Тепло*\n"
+	text += fmt.Sprintf("Градусо-дни (выше %.0f°): +%.0f за неделю, %.0f с %s\n",
+		s.GDDBase, s.GDDWeek, s.GDDSeason, s.GDDSeasonStart.Format("02.01"))
+	if s.ChillHours > 0 {
+		text += fmt.Sprintf("Часы охлаждения с %s: %.0f\n", s.ChillSeasonStart.Format("02.01"), s.ChillHours)
+	}
+
+	return text
+}
+
+// FormatPVMonthly форматирует отчёт о выработке солнечных панелей за месяц
+// в сравнении с прошлым месяцем и тем же месяцем год назад
+func FormatPVMonthly(r *models.PVMonthlyReport) string {
+	if r == nil || r.Month.Days == 0 {
+		return "❌ Нет данных радиации для отчёта о выработке"
+	}
+
+	text := fmt.Sprintf("🔆 *Солнечные панели: %s*\n\n", r.Month.Label)
+	text += fmt.Sprintf("Выработка: *%.0f кВт·ч* (%.0f кВт·ч на 1 кВт)\n", r.Month.EnergyKWh, r.Month.SpecificYield)
+	text += fmt.Sprintf("В среднем %.1f кВт·ч в сутки", r.Month.AvgDailyKWh)
+	if r.Month.Days < daysInMonth(r.Month.Month) {
+		text += fmt.Sprintf(" (данные за %d сут.)", r.Month.Days)
+	}
+	text += "\n"
+	if r.BestDay != nil && r.WorstDay != nil {
+		text += fmt.Sprintf("Лучший день: %s — %.1f кВт·ч, худший: %s — %.1f кВт·ч\n",
+			r.BestDay.Date.Format("02.01"), r.BestDay.EnergyKWh, r.WorstDay.Date.Format("02.01"), r.WorstDay.EnergyKWh)
+	}
+
+	var compare string
+	if r.PreviousMonth != nil {
+		compare += fmt.Sprintf("%s: %.0f кВт·ч — %s\n", r.PreviousMonth.Label, r.PreviousMonth.EnergyKWh, pvCompare(r.Month.AvgDailyKWh, r.PreviousMonth.AvgDailyKWh))
+	}
+	if r.LastYear != nil {
+		compare += fmt.Sprintf("%s: %.0f кВт·ч — %s\n", r.LastYear.Label, r.LastYear.EnergyKWh, pvCompare(r.Month.AvgDailyKWh, r.LastYear.AvgDailyKWh))
+	}
+	withData := 0
+	for _, m := range r.Months {
+		if m.Days > 0 {
+			withData++
+		}
+	}
+	if withData > 1 {
+		compare += fmt.Sprintf("%d-е место по выработке из %d последних месяцев\n", r.Rank, withData)
+	}
+	if compare != "" {
+		text += "\n📊 *Сравнение*\n" + compare
+	}
+
+	text += fmt.Sprintf("\n_Оценка по датчику радиации станции для %.1f кВт, наклон %.0f°, азимут %.0f°. Сверьте с показаниями инвертора._",
+		r.System.CapacityKWp, r.System.Tilt, r.System.Azimuth)
+	return text
+}
+
+// FormatMeteoSensitivity форматирует утренний индекс метеочувствительности
+func FormatMeteoSensitivity(m *models.MeteoSensitivity) string {
+	if m == nil {
+		return "❌ Нет данных для индекса метеочувствительности"
+	}
+
+	months := []string{"", "января", "февраля", "марта", "апреля", "мая", "июня",
+		"июля", "августа", "сентября", "октября", "ноября", "декабря"}
+	emoji := "🟢"
+	switch m.Today.Level {
+	case models.RiskHigh:
+		emoji = "🔴"
+	case models.RiskMedium:
+		emoji = "🟠"
+	case models.RiskLow:
+		emoji = "🟡"
+	}
+
+	text := fmt.Sprintf("🩺 *Метеозависимость · %d %s*\n\n", m.Today.Date.Day(), months[m.Today.Date.Month()])
+	text += fmt.Sprintf("%s *%s*\nИндекс: *%.1f из 10*\n", emoji, m.Title, m.Today.Index)
+
+	var factors string
+	for _, f := range m.Today.Factors {
+		if f.Text != "" {
+			factors += "• " + f.Text + "\n"
+		}
+	}
+	if factors != "" {
+		text += "\n🌡️ *Что влияет*\n" + factors
+	}
+
+	if len(m.Advice) > 0 {
+		text += "\n💡 *Советы*\n"
+		for _, a := range m.Advice {
+			text += "• " + a + "\n"
+		}
+	}
+
+	text += "\n_Индекс учитывает изменение давления за сутки, перепад температуры, влажность и геомагнитный Kp. Это не медицинская рекомендация._"
+	return text
+}
+
+// activityEmoji — цветной кружок для оценки активности
+func activityEmoji(score int) string {
+	switch {
+	case score >= 80:
+		return "🟢"
+	case score >= 60:
+		return "🟡"
+	case score >= 40:
+		return "🟠"
+	default:
+		return "🔴"
+	}
+}
+
+func FormatActivities(f *models.ActivityForecast) string {
+	if f == nil || len(f.Activities) == 0 {
+		return "❌ Нет прогноза для оценки занятий"
+	}
+
+	text := fmt.Sprintf("🎯 *Чем заняться · ближайшие %d ч*\n\n", f.Hours)
+	for _, a := range f.Activities {
+		text += fmt.Sprintf("%s %s *%s* — %d, %s\n", activityEmoji(a.Now.Score), a.Icon, a.Name, a.Now.Score, a.Now.Rating)
+		text += "    " + a.Summary + "\n"
+	}
+
+	text += "\n"
+	if f.PressureChange != nil {
+		text += fmt.Sprintf("🔽 Давление за 3 ч: %+.1f мм рт. ст.\n", *f.PressureChange)
+	}
+	if f.MoonPhase != "" {
+		text += "🌙 " + f.MoonPhase + "\n"
+	}
+	text += "\n_Оценка 0–100 сейчас. Нажмите на занятие, чтобы увидеть оценку по часам._"
+	return text
+}
+
+func FormatActivityDetail(a *models.ActivityScore) string {
+	if a == nil {
+		return "❌ Это занятие не оценивается"
+	}
+
+	text := fmt.Sprintf("%s *%s* — %s\n\n", a.Icon, a.Name, a.Summary)
+	for _, h := range a.Hours {
+		line := fmt.Sprintf("`%s` %s %d", h.Time.Format("15:04"), activityEmoji(h.Score), h.Score)
+		if h.Observed {
+			line += " (сейчас по станции)"
+		}
+		if len(h.Reasons) > 0 {
+			line += " — " + strings.Join(h.Reasons, ", ")
+		}
+		text += line + "\n"
+	}
+	return text
+}
+
+// skinTypeRoman возвращает номер фототипа римскими цифрами
+func skinTypeRoman(skinType int) string {
+	roman := []string{"", "I", "II", "III", "IV", "V", "VI"}
+	if skinType < 1 || skinType >= len(roman) {
+		return "?"
+	}
+	return roman[skinType]
+}
+
+// formatBurnMinutes форматирует время до покраснения кожи
+func formatBurnMinutes(minutes *int) string {
+	switch {
+	case minutes == nil:
+		return "не сгореть"
+	case *minutes >= 120:
+		return fmt.Sprintf("~%.1f ч", float64(*minutes)/60)
+	default:
+		return fmt.Sprintf("~%d мин", *minutes)
+	}
+}
+
+// FormatUVExposure форматирует время до ожога по фототипам и отмечает фототип пользователя
+func FormatUVExposure(e *models.UVExposure, skinType int, skinTypeSet bool) string {
+	if e == nil {
+		return "❌ Нет данных об UV"
+	}
+
+	text := "☀️ *UV и солнечный ожог*\n\n"
+	if e.UVIndex != nil {
+		source := "по станции"
+		if e.Source == "forecast" {
+			source = "по прогнозу"
+		}
+		text += fmt.Sprintf("Сейчас UV *%.1f* — %s (%s)\n", *e.UVIndex, e.Level, source)
+	} else {
+		text += "Сейчас UV неизвестен\n"
+	}
+	if e.PeakUV > 0 {
+		text += fmt.Sprintf("Пик сегодня: %.1f в %s · доза за день %.0f SED\n", e.PeakUV, e.PeakTime.Format("15:04"), e.DailyDoseSED)
+	}
+
+	text += "\n*До покраснения кожи на открытом солнце:*\n"
+	for _, st := range e.SkinTypes {
+		line := fmt.Sprintf("%s *%s* %s — %s", skinMarker(st.Type == skinType), skinTypeRoman(st.Type), st.Name, formatBurnMinutes(st.BurnMinutes))
+		text += line + "\n"
+	}
+
+	if skinTypeSet {
+		text += fmt.Sprintf("\nВаш фототип: *%s*. Изменить — кнопками ниже.", skinTypeRoman(skinType))
+	} else {
+		text += fmt.Sprintf("\nФототип не выбран, для уведомлений используется *%s*. Выберите свой:", skinTypeRoman(skinType))
+	}
+	return text
+}
+
+func skinMarker(selected bool) string {
+	if selected {
+		return "👉"
+	}
+	return "•"
+}
+
+// FormatUVAlert форматирует предупреждение о солнечном ожоге для фототипа
+func FormatUVAlert(e *models.UVExposure, skinType, minutes int) string {
+	text := fmt.Sprintf("☀️ *UV %.1f — %s*\n\n", *e.UVIndex, e.Level)
+	text += fmt.Sprintf("Коже типа %s (%s) хватит %s на открытом солнце до покраснения.\n",
+		skinTypeRoman(skinType), service.UVSkinTypeName(skinType), formatBurnMinutes(&minutes))
+	if e.PeakUV > *e.UVIndex {
+		text += fmt.Sprintf("Пик ожидается в %s: UV %.1f.\n", e.PeakTime.Format("15:04"), e.PeakUV)
+	}
+	text += "\n🧴 Крем SPF 30+ за 20 минут до выхода, головной убор и очки, в полдень — тень.\n"
+	text += "\n_Фототип можно изменить командой /skin_"
+	return text
+}
+
+// pvCompare сравнивает среднесуточную выработку — месяцы с пропусками данных остаются сопоставимыми
+func pvCompare(value, baseline float64) string {
+	if baseline <= 0 {
+		return "нет сравнения"
+	}
+	diff := (value - baseline) / baseline * 100
+	switch {
+	case math.Abs(diff) < 1:
+		return "столько же в сутки"
+	case diff > 0:
+		return fmt.Sprintf("в сутки на %.0f%% больше", diff)
+	default:
+		return fmt.Sprintf("в сутки на %.0f%% меньше", -diff)
+	}
+}
+
+func daysInMonth(month time.Time) int {
+	return time.Date(month.Year(), month.Month()+1, 0, 0, 0, 0, 0, month.Location()).Day()
+}
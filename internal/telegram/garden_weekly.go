@@ -0,0 +1,99 @@
+package telegram
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/iRootPro/weather/internal/repository"
+	"github.com/iRootPro/weather/internal/service"
+)
+
+// GardenWeeklyService раз в неделю рассылает сводку «Огород» подписчикам
+type GardenWeeklyService struct {
+	bot      *tgbotapi.BotAPI
+	agroSvc  *service.AgroService
+	subRepo  repository.TelegramSubscriptionRepository
+	weekday  time.Weekday
+	sendTime string // Время отправки в формате "18:00"
+	logger   *slog.Logger
+}
+
+func NewGardenWeeklyService(
+	bot *tgbotapi.BotAPI,
+	agroSvc *service.AgroService,
+	subRepo repository.TelegramSubscriptionRepository,
+	weekday time.Weekday,
+	sendTime string,
+	logger *slog.Logger,
+) *GardenWeeklyService {
+	return &GardenWeeklyService{
+		bot:      bot,
+		agroSvc:  agroSvc,
+		subRepo:  subRepo,
+		weekday:  weekday,
+		sendTime: sendTime,
+		logger:   logger,
+	}
+}
+
+// Start запускает фоновый процесс еженедельной рассылки
+func (s *GardenWeeklyService) Start(ctx context.Context) {
+	s.logger.Info("garden weekly service started", "weekday", s.weekday, "send_time", s.sendTime)
+
+	hour, minute := 18, 0
+	if parsed, err := time.Parse("15:04", s.sendTime); err == nil {
+		hour, minute = parsed.Hour(), parsed.Minute()
+	}
+
+	ticker := time.NewTicker(1 * time.Minute)
+	defer ticker.Stop()
+
+	lastSent := time.Time{}
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("garden weekly service stopped")
+			return
+		case now := <-ticker.C:
+			if now.Weekday() != s.weekday || now.Hour() != hour || now.Minute() != minute {
+				continue
+			}
+			if lastSent.Year() == now.Year() && lastSent.YearDay() == now.YearDay() {
+				continue
+			}
+			s.sendGardenWeekly(ctx)
+			lastSent = now
+		}
+	}
+}
+
+func (s *GardenWeeklyService) sendGardenWeekly(ctx context.Context) {
+	subscribers, err := s.subRepo.GetActiveSubscribers(ctx, EventGardenWeekly)
+	if err != nil {
+		s.logger.Error("failed to get garden weekly subscribers", "error", err)
+		return
+	}
+	if len(subscribers) == 0 {
+		return
+	}
+
+	summary, err := s.agroSvc.GetSummary(ctx)
+	if err != nil {
+		s.logger.Error("failed to get agro summary", "error", err)
+		return
+	}
+
+	text := FormatGardenWeekly(summary)
+	for _, chatID := range subscribers {
+		msg := tgbotapi.NewMessage(chatID, text)
+		msg.ParseMode = "Markdown"
+		if _, err := s.bot.Send(msg); err != nil {
+			s.logger.Error("failed to send garden weekly", "chat_id", chatID, "error", err)
+		}
+	}
+
+	s.logger.Info("garden weekly sent", "subscribers", len(subscribers))
+}
@@ -69,6 +69,12 @@ func (h *BotHandler) handleCommand(ctx context.Context, msg *tgbotapi.Message) {
 		h.handleRecords(ctx, msg)
 	case CmdHistory:
 		h.handleHistory(ctx, msg)
+	case CmdTodayHistory:
+		h.handleTodayHistory(ctx, msg)
+	case CmdActivities:
+		h.handleActivities(ctx, msg)
+	case CmdSkin:
+		h.handleSkin(ctx, msg)
 	case CmdSun:
 		h.handleSun(ctx, msg)
 	case CmdMoon:
@@ -133,6 +139,9 @@ func (h *BotHandler) handleHelp(ctx context.Context, msg *tgbotapi.Message) {
 /stats - статистика за период
 /records - рекорды за всё время
 /history - история данных
+/today_history - этот день в прошлые годы
+/activities - бег, рыбалка, шашлыки: когда лучше
+/skin - фототип кожи и время до солнечного ожога
 
 *Астрономия:*
 /sun - восход и закат
@@ -264,6 +273,20 @@ func (h *BotHandler) handleHistory(ctx context.Context, msg *tgbotapi.Message) {
 	h.sendMessage(msg.Chat.ID, "История в разработке. Используйте /stats для статистики.")
 }
 
+func (h *BotHandler) handleTodayHistory(ctx context.Context, msg *tgbotapi.Message) {
+	onThisDay, err := h.weatherSvc.GetOnThisDay(ctx, "")
+	if err != nil {
+		h.sendMessage(msg.Chat.ID, "❌ Ошибка получения истории за эту дату")
+		h.logger.Error("failed to get on this day history", "error", err)
+		return
+	}
+
+	reply := tgbotapi.NewMessage(msg.Chat.ID, FormatOnThisDay(onThisDay))
+	reply.ParseMode = "Markdown"
+	reply.ReplyMarkup = GetMainKeyboard()
+	h.bot.Send(reply)
+}
+
 func (h *BotHandler) handleSun(ctx context.Context, msg *tgbotapi.Message) {
 	sunData := h.sunSvc.GetTodaySunTimesWithComparison()
 
@@ -288,7 +311,7 @@ func (h *BotHandler) handleMoon(ctx context.Context, msg *tgbotapi.Message) {
 
 func (h *BotHandler) handleSubscribe(ctx context.Context, msg *tgbotapi.Message) {
 	reply := tgbotapi.NewMessage(msg.Chat.ID, "Выберите тип уведомлений:")
-	reply.ReplyMarkup = GetSubscriptionKeyboard()
+	reply.ReplyMarkup = GetSubscriptionKeyboard(h.pvEnabled)
 	h.bot.Send(reply)
 }
 
@@ -323,6 +346,11 @@ func (h *BotHandler) handleCallbackQuery(ctx context.Context, callback *tgbotapi
 	// Обработка подписок
 	if strings.HasPrefix(data, "sub_") {
 		eventType := strings.TrimPrefix(data, "sub_")
+		// Кнопка могла остаться в старом сообщении, когда панели ещё были настроены
+		if eventType == EventPVMonthly && !h.pvEnabled {
+			h.bot.Send(tgbotapi.NewMessage(callback.Message.Chat.ID, "Отчёт о солнечных панелях на этой станции не настроен."))
+			return
+		}
 
 		sub := &models.TelegramSubscription{
 			UserID:    user.ID,
@@ -367,6 +395,18 @@ func (h *BotHandler) handleCallbackQuery(ctx context.Context, callback *tgbotapi
 		return
 	}
 
+	// Оценка занятия по часам
+	if strings.HasPrefix(data, "act_") {
+		h.handleActivityDetail(ctx, callback.Message, strings.TrimPrefix(data, "act_"))
+		return
+	}
+
+	// Выбор фототипа кожи
+	if strings.HasPrefix(data, "skin_") {
+		h.handleSkinTypeSelect(ctx, callback.Message, user, strings.TrimPrefix(data, "skin_"))
+		return
+	}
+
 	// Обработка команд через кнопки
 	switch data {
 	case "cmd_weather":
@@ -381,6 +421,8 @@ func (h *BotHandler) handleCallbackQuery(ctx context.Context, callback *tgbotapi
 		h.handleMoon(ctx, callback.Message)
 	case "cmd_subscribe":
 		h.handleSubscribe(ctx, callback.Message)
+	case "cmd_activities":
+		h.handleActivities(ctx, callback.Message)
 	case "stats_day", "stats_week", "stats_month", "stats_year":
 		period := strings.TrimPrefix(data, "stats_")
 		msg := &tgbotapi.Message{
@@ -434,62 +476,21 @@ func (h *BotHandler) handleTestSummary(ctx context.Context, msg *tgbotapi.Messag
 		return
 	}
 
-	// Получаем текущие данные о погоде
-	current, err := h.weatherSvc.GetLatest(ctx)
+	input, err := BuildDailySummaryInput(ctx, DailySummarySources{
+		Weather:     h.weatherSvc,
+		Sun:         h.sunSvc,
+		Forecast:    h.forecastSvc,
+		Geomagnetic: h.geomagSvc,
+		Clothing:    h.clothingSvc,
+	}, h.logger)
 	if err != nil {
 		h.sendMessage(msg.Chat.ID, "❌ Ошибка получения данных о погоде")
 		h.logger.Error("failed to get current weather", "error", err)
 		return
 	}
 
-	// Получаем данные за вчера в это же время
-	yesterdaySame, err := h.weatherSvc.GetDataNearTime(ctx, current.Time.Add(-24*time.Hour))
-	if err != nil {
-		h.logger.Warn("failed to get yesterday weather", "error", err)
-	}
-
-	// Получаем min/max за ночь (00:00 - 07:00 сегодня)
-	now := time.Now()
-	nightStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-	nightEnd := time.Date(now.Year(), now.Month(), now.Day(), 7, 0, 0, 0, now.Location())
-	nightMinMax, err := h.weatherSvc.GetMinMaxInRange(ctx, nightStart, nightEnd)
-	if err != nil {
-		h.logger.Warn("failed to get night min/max", "error", err)
-	}
-
-	// Получаем min/max за сегодня
-	dailyMinMax, err := h.weatherSvc.GetDailyMinMax(ctx)
-	if err != nil {
-		h.logger.Warn("failed to get daily min/max", "error", err)
-	}
-
-	// Получаем данные о солнце
-	sunData := h.sunSvc.GetTodaySunTimesWithComparison()
-
-	// Получаем прогноз на сегодня
-	var todayForecast []DayForecastInfo
-	if h.forecastSvc != nil {
-		forecast, err := h.forecastSvc.GetTodayForecast(ctx)
-		if err != nil {
-			h.logger.Warn("failed to get today forecast", "error", err)
-		} else if len(forecast) > 0 {
-			todayForecast = formatTodayForecast(forecast)
-		}
-	}
-
-	// Получаем магнитную обстановку (если сервис подключён)
-	var geomagSnap *service.DashboardSnapshot
-	if h.geomagSvc != nil {
-		snap, err := h.geomagSvc.GetDashboardSnapshot(ctx, time.Now())
-		if err != nil {
-			h.logger.Warn("failed to get geomagnetic snapshot", "error", err)
-		} else if snap != nil && snap.HasData {
-			geomagSnap = snap
-		}
-	}
-
 	// Форматируем сообщение
-	text := FormatDailySummary(current, yesterdaySame, nightMinMax, dailyMinMax, sunData, todayForecast, geomagSnap)
+	text := FormatDailySummary(input)
 
 	// Добавляем пометку о тестовой рассылке
 	testNote := "\n\n🧪 *Тестовая рассылка* (только для админа)"
@@ -528,6 +529,91 @@ func (h *BotHandler) handleForecast(ctx context.Context, msg *tgbotapi.Message)
 	h.bot.Send(reply)
 }
 
+func (h *BotHandler) handleActivities(ctx context.Context, msg *tgbotapi.Message) {
+	if h.activitySvc == nil {
+		h.sendMessage(msg.Chat.ID, "❌ Оценка занятий временно недоступна")
+		return
+	}
+
+	forecast, err := h.activitySvc.GetForecast(ctx)
+	if err != nil {
+		h.sendMessage(msg.Chat.ID, "❌ Ошибка оценки погоды для занятий")
+		h.logger.Error("failed to get activity forecast", "error", err)
+		return
+	}
+
+	reply := tgbotapi.NewMessage(msg.Chat.ID, FormatActivities(forecast))
+	reply.ParseMode = "Markdown"
+	if len(forecast.Activities) > 0 {
+		reply.ReplyMarkup = GetActivitiesKeyboard(forecast.Activities)
+	}
+	h.bot.Send(reply)
+}
+
+func (h *BotHandler) handleActivityDetail(ctx context.Context, msg *tgbotapi.Message, key string) {
+	if h.activitySvc == nil {
+		h.sendMessage(msg.Chat.ID, "❌ Оценка занятий временно недоступна")
+		return
+	}
+
+	activity, _, err := h.activitySvc.GetActivity(ctx, key)
+	if err != nil {
+		h.sendMessage(msg.Chat.ID, "❌ Ошибка оценки погоды для занятий")
+		h.logger.Error("failed to get activity", "activity", key, "error", err)
+		return
+	}
+
+	reply := tgbotapi.NewMessage(msg.Chat.ID, FormatActivityDetail(activity))
+	reply.ParseMode = "Markdown"
+	reply.ReplyMarkup = GetActivityDetailKeyboard()
+	h.bot.Send(reply)
+}
+
+func (h *BotHandler) handleSkin(ctx context.Context, msg *tgbotapi.Message) {
+	if h.uvSvc == nil {
+		h.sendMessage(msg.Chat.ID, "❌ Расчёт UV временно недоступен")
+		return
+	}
+
+	exposure, err := h.uvSvc.GetExposure(ctx)
+	if err != nil {
+		h.sendMessage(msg.Chat.ID, "❌ Ошибка расчёта UV")
+		h.logger.Error("failed to get uv exposure", "error", err)
+		return
+	}
+
+	skinType, skinTypeSet := h.uvSvc.DefaultSkinType(), false
+	if user, err := h.userRepo.GetByChatID(ctx, msg.Chat.ID); err == nil && user.SkinType != nil {
+		skinType, skinTypeSet = int(*user.SkinType), true
+	}
+
+	reply := tgbotapi.NewMessage(msg.Chat.ID, FormatUVExposure(exposure, skinType, skinTypeSet))
+	reply.ParseMode = "Markdown"
+	reply.ReplyMarkup = GetSkinTypeKeyboard()
+	h.bot.Send(reply)
+}
+
+func (h *BotHandler) handleSkinTypeSelect(ctx context.Context, msg *tgbotapi.Message, user *models.TelegramUser, value string) {
+	skinType, err := strconv.Atoi(value)
+	if err != nil || !service.ValidSkinType(skinType) {
+		return
+	}
+
+	if err := h.userRepo.UpdateSkinType(ctx, user.ChatID, skinType); err != nil {
+		h.sendMessage(msg.Chat.ID, "❌ Не удалось сохранить фототип")
+		h.logger.Error("failed to update skin type", "chat_id", user.ChatID, "error", err)
+		return
+	}
+
+	text := fmt.Sprintf("✅ Фототип %s — %s", skinTypeRoman(skinType), service.UVSkinTypeName(skinType))
+	if h.uvSvc != nil {
+		if exposure, err := h.uvSvc.GetExposure(ctx); err == nil && exposure.UVIndex != nil {
+			text += fmt.Sprintf("\nСейчас UV %.1f: до покраснения %s", *exposure.UVIndex, formatBurnMinutes(service.UVBurnMinutes(*exposure.UVIndex, skinType)))
+		}
+	}
+	h.sendMessage(msg.Chat.ID, text)
+}
+
 func (h *BotHandler) handleMessage(ctx context.Context, msg *tgbotapi.Message) {
 	// Обработка нажатий на кнопки постоянной клавиатуры
 	switch msg.Text {
@@ -859,19 +945,19 @@ func formatWeatherDescription(w *models.WeatherData) string {
 func getFileExtension(mimeType, fileName string) string {
 	// Маппинг MIME типов на расширения
 	mimeToExt := map[string]string{
-		"image/jpeg":         ".jpg",
-		"image/jpg":          ".jpg",
-		"image/png":          ".png",
-		"image/heic":         ".heic",
-		"image/heif":         ".heic",
-		"image/webp":         ".webp",
-		"image/avif":         ".avif",
-		"image/bmp":          ".bmp",
-		"image/gif":          ".gif",
-		"image/tiff":         ".tiff",
-		"image/x-canon-cr2":  ".cr2",
-		"image/x-nikon-nef":  ".nef",
-		"image/x-sony-arw":   ".arw",
+		"image/jpeg":        ".jpg",
+		"image/jpg":         ".jpg",
+		"image/png":         ".png",
+		"image/heic":        ".heic",
+		"image/heif":        ".heic",
+		"image/webp":        ".webp",
+		"image/avif":        ".avif",
+		"image/bmp":         ".bmp",
+		"image/gif":         ".gif",
+		"image/tiff":        ".tiff",
+		"image/x-canon-cr2": ".cr2",
+		"image/x-nikon-nef": ".nef",
+		"image/x-sony-arw":  ".arw",
 	}
 
 	// Сначала пробуем по MIME типу
@@ -957,7 +1043,7 @@ func (h *BotHandler) sendPhotoModerationToAdmins(ctx context.Context, photo *mod
 		}
 
 		photoBytes := tgbotapi.FileBytes{
-			Name:  photo.Filename,
+			Name: photo.Filename,
 			Bytes: func() []byte {
 				defer photoFile.Close()
 				data, _ := io.ReadAll(photoFile)
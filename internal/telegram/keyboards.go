@@ -1,7 +1,11 @@
 package telegram
 
 import (
+	"fmt"
+
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/iRootPro/weather/internal/models"
 )
 
 // GetReplyKeyboard возвращает постоянную клавиатуру с основными командами
@@ -93,8 +97,15 @@ func GetStatsKeyboard() tgbotapi.InlineKeyboardMarkup {
 	)
 }
 
-// GetSubscriptionKeyboard возвращает клавиатуру подписок
-func GetSubscriptionKeyboard() tgbotapi.InlineKeyboardMarkup {
+// GetSubscriptionKeyboard возвращает клавиатуру подписок. Отчёт о панелях
+// предлагается, только если задана их мощность — иначе он не рассылается.
+func GetSubscriptionKeyboard(pvEnabled bool) tgbotapi.InlineKeyboardMarkup {
+	indoorRow := tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🏠 Микроклимат в доме", "sub_indoor_climate"),
+	)
+	if pvEnabled {
+		indoorRow = append(indoorRow, tgbotapi.NewInlineKeyboardButtonData("🔆 Панели (раз в месяц)", "sub_pv_monthly"))
+	}
 	return tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData("🌅 Утренняя сводка", "sub_daily_summary"),
@@ -104,8 +115,34 @@ func GetSubscriptionKeyboard() tgbotapi.InlineKeyboardMarkup {
 		),
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData("🌧️ Дождь", "sub_rain"),
+			tgbotapi.NewInlineKeyboardButtonData("☂️ Скоро дождь", "sub_rain_soon"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData("🌡️ Температура", "sub_temperature"),
 		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("❄️ Заморозки", "sub_frost_risk"),
+			tgbotapi.NewInlineKeyboardButtonData("🧊 Гололёд", "sub_ice_risk"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🥕 Огород (раз в неделю)", "sub_garden_weekly"),
+			tgbotapi.NewInlineKeyboardButtonData("🔥 Отопление", "sub_heating_season"),
+		),
+		indoorRow,
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🏜️ Засуха", "sub_drought"),
+			tgbotapi.NewInlineKeyboardButtonData("🩺 Метеозависимость (утром)", "sub_meteo_sensitivity"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("☀️ UV-ожог (тип кожи — /skin)", "sub_uv_exposure"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🌌 Полярное сияние", "sub_aurora"),
+			tgbotapi.NewInlineKeyboardButtonData("🌊 Прогноз паводка", "sub_flood_forecast"),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("💧 Уровень воды", "sub_hydro"),
+		),
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData("💨 Ветер", "sub_wind"),
 			tgbotapi.NewInlineKeyboardButtonData("🔽 Давление", "sub_pressure"),
@@ -115,3 +152,42 @@ func GetSubscriptionKeyboard() tgbotapi.InlineKeyboardMarkup {
 		),
 	)
 }
+
+// GetActivitiesKeyboard возвращает клавиатуру занятий для оценки по часам
+func GetActivitiesKeyboard(activities []models.ActivityScore) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	var row []tgbotapi.InlineKeyboardButton
+	for _, a := range activities {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(a.Icon+" "+a.Name, "act_"+a.Key))
+		if len(row) == 2 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// GetActivityDetailKeyboard возвращает кнопку возврата к списку занятий
+func GetActivityDetailKeyboard() tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("⬅️ Все занятия", "cmd_activities"),
+		),
+	)
+}
+
+// GetSkinTypeKeyboard возвращает клавиатуру выбора фототипа кожи
+func GetSkinTypeKeyboard() tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for start := 1; start <= 6; start += 3 {
+		var row []tgbotapi.InlineKeyboardButton
+		for skinType := start; skinType < start+3; skinType++ {
+			row = append(row, tgbotapi.NewInlineKeyboardButtonData("Тип "+skinTypeRoman(skinType), fmt.Sprintf("skin_%d", skinType)))
+		}
+		rows = append(rows, row)
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
@@ -0,0 +1,96 @@
+package telegram
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/iRootPro/weather/internal/repository"
+	"github.com/iRootPro/weather/internal/service"
+)
+
+// MeteoDailyService каждое утро рассылает индекс метеочувствительности подписчикам
+type MeteoDailyService struct {
+	bot      *tgbotapi.BotAPI
+	meteoSvc *service.MeteoSensitivityService
+	subRepo  repository.TelegramSubscriptionRepository
+	sendTime string // Время отправки в формате "08:00"
+	logger   *slog.Logger
+}
+
+func NewMeteoDailyService(
+	bot *tgbotapi.BotAPI,
+	meteoSvc *service.MeteoSensitivityService,
+	subRepo repository.TelegramSubscriptionRepository,
+	sendTime string,
+	logger *slog.Logger,
+) *MeteoDailyService {
+	return &MeteoDailyService{
+		bot:      bot,
+		meteoSvc: meteoSvc,
+		subRepo:  subRepo,
+		sendTime: sendTime,
+		logger:   logger,
+	}
+}
+
+// Start запускает фоновый процесс ежедневной рассылки
+func (s *MeteoDailyService) Start(ctx context.Context) {
+	s.logger.Info("meteo daily service started", "send_time", s.sendTime)
+
+	hour, minute := 8, 0
+	if parsed, err := time.Parse("15:04", s.sendTime); err == nil {
+		hour, minute = parsed.Hour(), parsed.Minute()
+	}
+
+	ticker := time.NewTicker(1 * time.Minute)
+	defer ticker.Stop()
+
+	lastSent := time.Time{}
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("meteo daily service stopped")
+			return
+		case now := <-ticker.C:
+			if now.Hour() != hour || now.Minute() != minute {
+				continue
+			}
+			if lastSent.Year() == now.Year() && lastSent.YearDay() == now.YearDay() {
+				continue
+			}
+			s.sendMeteoSensitivity(ctx)
+			lastSent = now
+		}
+	}
+}
+
+func (s *MeteoDailyService) sendMeteoSensitivity(ctx context.Context) {
+	subscribers, err := s.subRepo.GetActiveSubscribers(ctx, EventMeteoSensitivity)
+	if err != nil {
+		s.logger.Error("failed to get meteo sensitivity subscribers", "error", err)
+		return
+	}
+	if len(subscribers) == 0 {
+		return
+	}
+
+	meteo, err := s.meteoSvc.GetMeteoSensitivity(ctx)
+	if err != nil {
+		s.logger.Error("failed to get meteo sensitivity index", "error", err)
+		return
+	}
+
+	text := FormatMeteoSensitivity(meteo)
+	for _, chatID := range subscribers {
+		msg := tgbotapi.NewMessage(chatID, text)
+		msg.ParseMode = "Markdown"
+		if _, err := s.bot.Send(msg); err != nil {
+			s.logger.Error("failed to send meteo sensitivity", "chat_id", chatID, "error", err)
+		}
+	}
+
+	s.logger.Info("meteo sensitivity sent", "subscribers", len(subscribers), "index", meteo.Today.Index)
+}
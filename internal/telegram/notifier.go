@@ -21,6 +21,15 @@ type Notifier struct {
 	userRepo        repository.TelegramUserRepository
 	geomagRepo      repository.GeomagneticRepository
 	geomagThreshold float32
+	rainNowcast     *service.RainNowcastService
+	frostRisk       *service.FrostRiskService
+	heating         *service.HeatingService
+	indoor          *service.IndoorClimateService
+	drought         *service.DroughtService
+	uv              *service.UVService
+	aurora          *service.AuroraService
+	hydroForecast   *service.HydroForecastService
+	hydroAlerts     *service.HydroAlertService
 	interval        time.Duration
 	logger          *slog.Logger
 }
@@ -33,6 +42,15 @@ func NewNotifier(
 	userRepo repository.TelegramUserRepository,
 	geomagRepo repository.GeomagneticRepository,
 	geomagThreshold float32,
+	rainNowcast *service.RainNowcastService,
+	frostRisk *service.FrostRiskService,
+	heating *service.HeatingService,
+	indoor *service.IndoorClimateService,
+	drought *service.DroughtService,
+	uv *service.UVService,
+	aurora *service.AuroraService,
+	hydroForecast *service.HydroForecastService,
+	hydroAlerts *service.HydroAlertService,
 	interval int,
 	logger *slog.Logger,
 ) *Notifier {
@@ -44,6 +62,15 @@ func NewNotifier(
 		userRepo:        userRepo,
 		geomagRepo:      geomagRepo,
 		geomagThreshold: geomagThreshold,
+		rainNowcast:     rainNowcast,
+		frostRisk:       frostRisk,
+		heating:         heating,
+		indoor:          indoor,
+		drought:         drought,
+		uv:              uv,
+		aurora:          aurora,
+		hydroForecast:   hydroForecast,
+		hydroAlerts:     hydroAlerts,
 		interval:        time.Duration(interval) * time.Second,
 		logger:          logger,
 	}
@@ -86,10 +113,412 @@ func (n *Notifier) checkAndNotify(ctx context.Context) {
 		}
 	}
 
+	// Прогноз дождя в ближайший час
+	n.checkRainSoon(ctx)
+
+	// Вечерние предупреждения о заморозке и гололёде
+	n.checkFrostRisk(ctx)
+
+	// Начало и окончание отопительного сезона
+	n.checkHeatingSeason(ctx)
+
+	// Риск плесени и сухой воздух в доме
+	n.checkIndoorClimate(ctx)
+
+	// Переход индексов засухи через пороги
+	n.checkDrought(ctx)
+
+	// Риск солнечного ожога по фототипу кожи подписчика
+	n.checkUVExposure(ctx)
+
+	// Шанс увидеть полярное сияние этой ночью
+	n.checkAurora(ctx)
+
+	// Ранний прогноз выхода реки к неблагоприятному уровню по верхним постам
+	n.checkFloodForecast(ctx)
+
+	// Смена статуса уровня воды, быстрый подъём и подъём на верхних постах
+	n.checkHydro(ctx)
+
 	// Геомагнитные алерты обрабатываются независимо от обычных событий
 	n.checkGeomagneticStorms(ctx)
 }
 
+// checkRainSoon предупреждает о дожде до того, как его увидит датчик станции
+func (n *Notifier) checkRainSoon(ctx context.Context) {
+	if n.rainNowcast == nil {
+		return
+	}
+	event, err := n.rainNowcast.GetRainSoonEvent(ctx)
+	if err != nil {
+		n.logger.Error("failed to get rain nowcast", "error", err)
+		return
+	}
+	if event != nil {
+		n.processEvent(ctx, *event)
+	}
+}
+
+// checkFrostRisk вечером предупреждает о заморозке и гололёде на ближайшую ночь
+func (n *Notifier) checkFrostRisk(ctx context.Context) {
+	if n.frostRisk == nil {
+		return
+	}
+	events, err := n.frostRisk.GetFrostRiskEvents(ctx)
+	if err != nil {
+		n.logger.Error("failed to get frost risk", "error", err)
+		return
+	}
+	for _, event := range events {
+		n.processEvent(ctx, event)
+	}
+}
+
+// checkHeatingSeason сообщает, что критерий начала или окончания отопительного сезона выполнен
+func (n *Notifier) checkHeatingSeason(ctx context.Context) {
+	if n.heating == nil {
+		return
+	}
+	events, err := n.heating.GetHeatingEvents(ctx)
+	if err != nil {
+		n.logger.Error("failed to get heating season events", "error", err)
+		return
+	}
+	for _, event := range events {
+		n.processEvent(ctx, event)
+	}
+}
+
+// checkIndoorClimate предупреждает о риске плесени и сухом воздухе по комнатному датчику
+func (n *Notifier) checkIndoorClimate(ctx context.Context) {
+	if n.indoor == nil {
+		return
+	}
+	events, err := n.indoor.GetIndoorEvents(ctx)
+	if err != nil {
+		n.logger.Error("failed to get indoor climate events", "error", err)
+		return
+	}
+	for _, event := range events {
+		n.processEvent(ctx, event)
+	}
+}
+
+// checkDrought предупреждает, когда SPI или ГТК опускаются ниже порога или затягивается сухой период
+func (n *Notifier) checkDrought(ctx context.Context) {
+	if n.drought == nil {
+		return
+	}
+	events, err := n.drought.GetDroughtEvents(ctx)
+	if err != nil {
+		n.logger.Error("failed to get drought events", "error", err)
+		return
+	}
+	for _, event := range events {
+		n.processEvent(ctx, event)
+	}
+}
+
+// checkFloodForecast заранее предупреждает о подъёме реки: прогноз по верхним постам
+// выводит уровень к неблагоприятной или опасной отметке, которой сейчас нет. Ключ
+// включает ожидаемый статус — переход прогноза от неблагоприятного к опасному уходит
+// отдельным сообщением.
+func (n *Notifier) checkFloodForecast(ctx context.Context) {
+	if n.hydroForecast == nil {
+		return
+	}
+
+	chatIDs, err := n.getSubscribersForEvent(ctx, EventFloodForecast)
+	if err != nil || len(chatIDs) == 0 {
+		return
+	}
+
+	forecast, err := n.hydroForecast.GetForecast(ctx, time.Now())
+	if err != nil {
+		n.logger.Error("failed to get hydro forecast", "error", err)
+		return
+	}
+	if !n.hydroForecast.Alert(forecast) {
+		return
+	}
+
+	key := fmt.Sprintf("%s_%s", EventFloodForecast, forecast.PeakStatus)
+	text := FormatFloodForecastAlert(forecast)
+
+	for _, chatID := range chatIDs {
+		user, err := n.userRepo.GetByChatID(ctx, chatID)
+		if err != nil {
+			n.logger.Error("failed to get user", "chat_id", chatID, "error", err)
+			continue
+		}
+
+		wasSent, err := n.notifRepo.WasRecentlySent(ctx, user.ID, key, 12*time.Hour)
+		if err != nil {
+			n.logger.Error("failed to check flood forecast dedup", "user_id", user.ID, "error", err)
+			continue
+		}
+		if wasSent {
+			continue
+		}
+
+		msg := tgbotapi.NewMessage(chatID, text)
+		msg.ParseMode = "Markdown"
+		if _, err := n.bot.Send(msg); err != nil {
+			n.logger.Error("failed to send flood forecast alert", "chat_id", chatID, "error", err)
+			continue
+		}
+
+		eventData, _ := json.Marshal(map[string]any{
+			"peak_status":   forecast.PeakStatus,
+			"peak_level":    forecast.Peak.LevelBSM,
+			"peak_time":     forecast.Peak.Time,
+			"prevention_at": forecast.PreventionAt,
+			"danger_at":     forecast.DangerAt,
+		})
+		notification := &models.TelegramNotification{
+			UserID:    user.ID,
+			EventType: key,
+			EventData: eventData,
+			SentAt:    time.Now(),
+		}
+		if err := n.notifRepo.Create(ctx, notification); err != nil {
+			n.logger.Error("failed to save flood forecast notification", "error", err)
+		}
+
+		n.logger.Info("flood forecast alert sent", "chat_id", chatID, "peak_status", forecast.PeakStatus)
+	}
+}
+
+// checkHydro рассылает уведомления подписки hydro. Каждое уведомление — график уровня
+// поста за двое суток с подписью; дедуп-ключ эпизода приходит из сервиса, поэтому
+// эпизод сообщается один раз.
+func (n *Notifier) checkHydro(ctx context.Context) {
+	if n.hydroAlerts == nil {
+		return
+	}
+
+	chatIDs, err := n.getSubscribersForEvent(ctx, EventHydro)
+	if err != nil || len(chatIDs) == 0 {
+		return
+	}
+
+	now := time.Now()
+	alerts, err := n.hydroAlerts.GetAlerts(ctx, now)
+	if err != nil {
+		n.logger.Error("failed to get hydro alerts", "error", err)
+		return
+	}
+
+	type recipient struct {
+		chatID int64
+		userID int64
+	}
+	for _, alert := range alerts {
+		// Сначала отбираем, кому оповещение ещё не отправлялось: график рисуется,
+		// только если его есть кому показать
+		var pending []recipient
+		for _, chatID := range chatIDs {
+			user, err := n.userRepo.GetByChatID(ctx, chatID)
+			if err != nil {
+				n.logger.Error("failed to get user", "chat_id", chatID, "error", err)
+				continue
+			}
+
+			wasSent, err := n.notifRepo.WasRecentlySent(ctx, user.ID, alert.Key, service.HYDRO_ALERT_HISTORY_HOURS*time.Hour)
+			if err != nil {
+				n.logger.Error("failed to check hydro dedup", "user_id", user.ID, "error", err)
+				continue
+			}
+			if !wasSent {
+				pending = append(pending, recipient{chatID: chatID, userID: user.ID})
+			}
+		}
+		if len(pending) == 0 {
+			continue
+		}
+
+		text := FormatHydroAlert(alert)
+		var png []byte
+		readings, gauge, err := n.hydroAlerts.ChartData(ctx, alert.StationUUID, now)
+		if err == nil {
+			png, err = GenerateHydroChart(readings, gauge, alert.StationName)
+		}
+		if err != nil {
+			n.logger.Warn("failed to build hydro alert chart", "station_uuid", alert.StationUUID, "error", err)
+		}
+		eventData, _ := json.Marshal(alert)
+
+		for _, r := range pending {
+			var msg tgbotapi.Chattable
+			if png != nil {
+				photo := tgbotapi.NewPhoto(r.chatID, tgbotapi.FileBytes{Name: "hydro.png", Bytes: png})
+				photo.Caption = text
+				photo.ParseMode = "Markdown"
+				msg = photo
+			} else {
+				message := tgbotapi.NewMessage(r.chatID, text)
+				message.ParseMode = "Markdown"
+				msg = message
+			}
+			if _, err := n.bot.Send(msg); err != nil {
+				n.logger.Error("failed to send hydro alert", "chat_id", r.chatID, "error", err)
+				continue
+			}
+
+			notification := &models.TelegramNotification{
+				UserID:    r.userID,
+				EventType: alert.Key,
+				EventData: eventData,
+				SentAt:    time.Now(),
+			}
+			if err := n.notifRepo.Create(ctx, notification); err != nil {
+				n.logger.Error("failed to save hydro notification", "error", err)
+			}
+			n.logger.Info("hydro alert sent", "chat_id", r.chatID, "kind", alert.Kind, "station_uuid", alert.StationUUID)
+		}
+	}
+}
+
+// checkAurora сообщает подписчикам о шансе увидеть полярное сияние в текущую ночь.
+// Уведомление уходит один раз за ночь — дедуп-ключ включает дату вечера.
+func (n *Notifier) checkAurora(ctx context.Context) {
+	if n.aurora == nil {
+		return
+	}
+
+	chatIDs, err := n.getSubscribersForEvent(ctx, EventAurora)
+	if err != nil || len(chatIDs) == 0 {
+		return
+	}
+
+	forecast, err := n.aurora.GetForecast(ctx)
+	if err != nil {
+		n.logger.Error("failed to get aurora forecast", "error", err)
+		return
+	}
+	night, alert := n.aurora.AlertNight(forecast)
+	if !alert || !time.Now().Before(night.DarkTo) {
+		return
+	}
+
+	key := fmt.Sprintf("%s_%s", EventAurora, night.Date.Format("20060102"))
+	text := FormatAuroraAlert(forecast, night)
+
+	for _, chatID := range chatIDs {
+		user, err := n.userRepo.GetByChatID(ctx, chatID)
+		if err != nil {
+			n.logger.Error("failed to get user", "chat_id", chatID, "error", err)
+			continue
+		}
+
+		wasSent, err := n.notifRepo.WasRecentlySent(ctx, user.ID, key, 24*time.Hour)
+		if err != nil {
+			n.logger.Error("failed to check aurora dedup", "user_id", user.ID, "error", err)
+			continue
+		}
+		if wasSent {
+			continue
+		}
+
+		msg := tgbotapi.NewMessage(chatID, text)
+		msg.ParseMode = "Markdown"
+		if _, err := n.bot.Send(msg); err != nil {
+			n.logger.Error("failed to send aurora alert", "chat_id", chatID, "error", err)
+			continue
+		}
+
+		eventData, _ := json.Marshal(map[string]any{
+			"probability": night.Probability,
+			"max_kp":      night.MaxKp,
+			"night":       night.Date,
+		})
+		notification := &models.TelegramNotification{
+			UserID:    user.ID,
+			EventType: key,
+			EventData: eventData,
+			SentAt:    time.Now(),
+		}
+		if err := n.notifRepo.Create(ctx, notification); err != nil {
+			n.logger.Error("failed to save aurora notification", "error", err)
+		}
+
+		n.logger.Info("aurora alert sent", "chat_id", chatID, "probability", night.Probability)
+	}
+}
+
+// checkUVExposure предупреждает подписчиков, когда до покраснения их кожи на солнце
+// остаётся меньше порога. Порог у каждого свой — зависит от фототипа, поэтому общий
+// processEvent здесь не подходит.
+func (n *Notifier) checkUVExposure(ctx context.Context) {
+	if n.uv == nil {
+		return
+	}
+
+	chatIDs, err := n.getSubscribersForEvent(ctx, EventUVExposure)
+	if err != nil || len(chatIDs) == 0 {
+		return
+	}
+
+	exposure, err := n.uv.GetExposure(ctx)
+	if err != nil {
+		n.logger.Error("failed to get uv exposure", "error", err)
+		return
+	}
+	if exposure.UVIndex == nil {
+		return
+	}
+
+	for _, chatID := range chatIDs {
+		user, err := n.userRepo.GetByChatID(ctx, chatID)
+		if err != nil {
+			n.logger.Error("failed to get user", "chat_id", chatID, "error", err)
+			continue
+		}
+
+		skinType := n.uv.DefaultSkinType()
+		if user.SkinType != nil {
+			skinType = int(*user.SkinType)
+		}
+		minutes, alert := n.uv.AlertFor(exposure, skinType)
+		if !alert {
+			continue
+		}
+
+		wasSent, err := n.notifRepo.WasRecentlySent(ctx, user.ID, EventUVExposure, notificationDedupWindow(EventUVExposure))
+		if err != nil {
+			n.logger.Error("failed to check recent notification", "user_id", user.ID, "error", err)
+			continue
+		}
+		if wasSent {
+			continue
+		}
+
+		msg := tgbotapi.NewMessage(chatID, FormatUVAlert(exposure, skinType, minutes))
+		msg.ParseMode = "Markdown"
+		if _, err := n.bot.Send(msg); err != nil {
+			n.logger.Error("failed to send uv alert", "chat_id", chatID, "error", err)
+			continue
+		}
+
+		eventData, _ := json.Marshal(map[string]any{
+			"uv_index":     *exposure.UVIndex,
+			"skin_type":    skinType,
+			"burn_minutes": minutes,
+		})
+		notification := &models.TelegramNotification{
+			UserID:    user.ID,
+			EventType: EventUVExposure,
+			EventData: eventData,
+			SentAt:    time.Now(),
+		}
+		if err := n.notifRepo.Create(ctx, notification); err != nil {
+			n.logger.Error("failed to save notification", "error", err)
+		}
+
+		n.logger.Info("uv alert sent", "chat_id", chatID, "skin_type", skinType, "burn_minutes", minutes)
+	}
+}
+
 // processEvent обрабатывает одно событие
 func (n *Notifier) processEvent(ctx context.Context, event models.WeatherEvent) {
 	// Определяем тип подписки для этого события
@@ -158,9 +587,9 @@ func (n *Notifier) sendNotification(ctx context.Context, chatID int64, event mod
 		return
 	}
 
-	// Проверяем, не отправляли ли мы это уведомление недавно (за последние 60 минут)
+	// Проверяем, не отправляли ли мы это уведомление недавно
 	subscriptionType := getSubscriptionTypeForEvent(event.Type)
-	wasSent, err := n.notifRepo.WasRecentlySent(ctx, user.ID, subscriptionType, 60*time.Minute)
+	wasSent, err := n.notifRepo.WasRecentlySent(ctx, user.ID, subscriptionType, notificationDedupWindow(subscriptionType))
 	if err != nil {
 		n.logger.Error("failed to check recent notification", "user_id", user.ID, "error", err)
 		return
@@ -309,7 +738,36 @@ func getSubscriptionTypeForEvent(eventType string) string {
 		return EventWind
 	case "pressure_rise", "pressure_drop":
 		return EventPressure
+	case "rain_soon":
+		return EventRainSoon
+	case "frost_risk":
+		return EventFrostRisk
+	case "ice_risk":
+		return EventIceRisk
+	case "heating_start", "heating_end":
+		return EventHeatingSeason
+	case "indoor_mold", "indoor_dry":
+		return EventIndoorClimate
+	case "drought":
+		return EventDrought
 	default:
 		return ""
 	}
 }
+
+// notificationDedupWindow возвращает окно подавления повторов для типа подписки.
+// Для rain_soon окно покрывает эпизод: после дождя сервис сам молчит ещё
+// RAIN_SOON_EPISODE_HOURS часов, а ложная тревога не повторяется раньше этого срока.
+// Заморозок и гололёд предупреждаются один раз за вечер, микроклимат в доме и UV-ожог — не чаще
+// двух раз в день, смена отопительного сезона — раз в сутки.
+func notificationDedupWindow(subscriptionType string) time.Duration {
+	switch subscriptionType {
+	case EventRainSoon:
+		return service.RAIN_SOON_EPISODE_HOURS * time.Hour
+	case EventFrostRisk, EventIceRisk, EventIndoorClimate, EventUVExposure:
+		return 12 * time.Hour
+	case EventHeatingSeason, EventDrought:
+		return 24 * time.Hour
+	}
+	return 60 * time.Minute
+}
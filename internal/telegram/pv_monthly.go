@@ -0,0 +1,107 @@
+package telegram
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/iRootPro/weather/internal/repository"
+	"github.com/iRootPro/weather/internal/service"
+)
+
+// PVMonthlyService раз в месяц рассылает отчёт о выработке солнечных панелей за прошлый месяц
+type PVMonthlyService struct {
+	bot      *tgbotapi.BotAPI
+	pvSvc    *service.PVService
+	subRepo  repository.TelegramSubscriptionRepository
+	day      int    // число месяца
+	sendTime string // Время отправки в формате "10:00"
+	logger   *slog.Logger
+}
+
+func NewPVMonthlyService(
+	bot *tgbotapi.BotAPI,
+	pvSvc *service.PVService,
+	subRepo repository.TelegramSubscriptionRepository,
+	day int,
+	sendTime string,
+	logger *slog.Logger,
+) *PVMonthlyService {
+	if day < 1 || day > 28 {
+		day = 1
+	}
+	return &PVMonthlyService{
+		bot:      bot,
+		pvSvc:    pvSvc,
+		subRepo:  subRepo,
+		day:      day,
+		sendTime: sendTime,
+		logger:   logger,
+	}
+}
+
+// Start запускает фоновый процесс ежемесячной рассылки
+func (s *PVMonthlyService) Start(ctx context.Context) {
+	s.logger.Info("pv monthly service started", "day", s.day, "send_time", s.sendTime)
+
+	hour, minute := 10, 0
+	if parsed, err := time.Parse("15:04", s.sendTime); err == nil {
+		hour, minute = parsed.Hour(), parsed.Minute()
+	}
+
+	ticker := time.NewTicker(1 * time.Minute)
+	defer ticker.Stop()
+
+	lastSent := time.Time{}
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("pv monthly service stopped")
+			return
+		case now := <-ticker.C:
+			if now.Day() != s.day || now.Hour() != hour || now.Minute() != minute {
+				continue
+			}
+			if lastSent.Year() == now.Year() && lastSent.YearDay() == now.YearDay() {
+				continue
+			}
+			s.sendPVMonthly(ctx, now.AddDate(0, 0, -s.day))
+			lastSent = now
+		}
+	}
+}
+
+// sendPVMonthly отправляет отчёт за месяц, в который попадает month
+func (s *PVMonthlyService) sendPVMonthly(ctx context.Context, month time.Time) {
+	subscribers, err := s.subRepo.GetActiveSubscribers(ctx, EventPVMonthly)
+	if err != nil {
+		s.logger.Error("failed to get pv monthly subscribers", "error", err)
+		return
+	}
+	if len(subscribers) == 0 {
+		return
+	}
+
+	report, err := s.pvSvc.GetMonthlyReport(ctx, month)
+	if err != nil {
+		s.logger.Error("failed to get pv monthly report", "error", err)
+		return
+	}
+	if report.Month.Days == 0 {
+		s.logger.Warn("no solar radiation data for pv monthly report", "month", report.Month.Label)
+		return
+	}
+
+	text := FormatPVMonthly(report)
+	for _, chatID := range subscribers {
+		msg := tgbotapi.NewMessage(chatID, text)
+		msg.ParseMode = "Markdown"
+		if _, err := s.bot.Send(msg); err != nil {
+			s.logger.Error("failed to send pv monthly", "chat_id", chatID, "error", err)
+		}
+	}
+
+	s.logger.Info("pv monthly sent", "subscribers", len(subscribers), "month", report.Month.Label)
+}
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Пары «прогноз — наблюдение» для статистической коррекции прогноза под станцию.
+-- forecast_time хранится в том же виде, что и в forecast_data (локальное время Open-Meteo).
+CREATE TABLE IF NOT EXISTS forecast_verification (
+    forecast_time TIMESTAMPTZ NOT NULL,
+
+    -- Прогноз Open-Meteo на этот час
+    forecast_temperature REAL,
+    forecast_humidity SMALLINT,
+    forecast_wind_speed REAL,
+
+    -- Средние показания станции в окне ±15 минут
+    observed_temperature REAL,
+    observed_humidity REAL,
+    observed_wind_speed REAL,
+
+    forecast_fetched_at TIMESTAMPTZ,
+    recorded_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (forecast_time)
+);
+
+SELECT create_hypertable('forecast_verification', 'forecast_time', if_not_exists => TRUE);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS forecast_verification;
+
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Несколько источников прогноза: строки различаются провайдером и моделью.
+-- Существующие строки — основной прогноз Open-Meteo (best_match).
+ALTER TABLE forecast_data ADD COLUMN IF NOT EXISTS provider TEXT NOT NULL DEFAULT 'open-meteo';
+ALTER TABLE forecast_data ADD COLUMN IF NOT EXISTS model TEXT NOT NULL DEFAULT 'best_match';
+
+DROP INDEX IF EXISTS idx_forecast_unique;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_forecast_unique ON forecast_data (forecast_time, forecast_type, provider, model);
+
+-- Провайдеры запрашивают ветер в м/с, как у станции; до этого Open-Meteo отдавал км/ч.
+-- Переводим накопленную историю, иначе коррекция и оценки ветра смешают единицы.
+UPDATE forecast_data SET wind_speed = wind_speed / 3.6, wind_gusts = wind_gusts / 3.6;
+UPDATE forecast_verification SET forecast_wind_speed = forecast_wind_speed / 3.6;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DELETE FROM forecast_data WHERE provider <> 'open-meteo' OR model <> 'best_match';
+
+UPDATE forecast_data SET wind_speed = wind_speed * 3.6, wind_gusts = wind_gusts * 3.6;
+UPDATE forecast_verification SET forecast_wind_speed = forecast_wind_speed * 3.6;
+
+DROP INDEX IF EXISTS idx_forecast_unique;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_forecast_unique ON forecast_data (forecast_time, forecast_type);
+
+ALTER TABLE forecast_data DROP COLUMN IF EXISTS model;
+ALTER TABLE forecast_data DROP COLUMN IF EXISTS provider;
+
+-- +goose StatementEnd
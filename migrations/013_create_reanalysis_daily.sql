@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Суточные данные реанализа (Open-Meteo Historical API, ERA5) для координат станции.
+-- Это модельные данные, а не наблюдения: используются только как многолетний фон
+-- для архива и инсайтов, пока у станции мало лет наблюдений.
+CREATE TABLE IF NOT EXISTS reanalysis_daily (
+    date DATE NOT NULL,
+    source VARCHAR(32) NOT NULL DEFAULT 'era5',
+
+    temp_min REAL,
+    temp_max REAL,
+    temp_avg REAL,
+    precipitation_sum REAL,      -- мм
+    wind_speed_max REAL,         -- м/с на 10 м
+    wind_gusts_max REAL,         -- м/с
+    shortwave_radiation_sum REAL, -- МДж/м²
+
+    fetched_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (date, source)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS reanalysis_daily;
+
+-- +goose StatementEnd
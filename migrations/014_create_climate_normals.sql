@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Официальные климатические нормы (Росгидромет, 1991–2020) ближайшей метеостанции.
+-- day = 0 — норма за месяц (осадки — месячная сумма), иначе — норма календарного дня.
+CREATE TABLE IF NOT EXISTS climate_normals (
+    station_code VARCHAR(32) NOT NULL,
+    period VARCHAR(16) NOT NULL DEFAULT '1991-2020',
+    month SMALLINT NOT NULL CHECK (month BETWEEN 1 AND 12),
+    day SMALLINT NOT NULL DEFAULT 0 CHECK (day BETWEEN 0 AND 31),
+
+    temp_avg REAL,
+    temp_min REAL,
+    temp_max REAL,
+    precipitation REAL, -- мм за месяц (day = 0) или за сутки
+
+    imported_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (station_code, period, month, day)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS climate_normals;
+
+-- +goose StatementEnd
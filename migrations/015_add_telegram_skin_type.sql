@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Фототип кожи по Фицпатрику (1–6) для расчёта времени до солнечного ожога.
+-- NULL — пользователь не указал, используется UV_DEFAULT_SKIN_TYPE.
+ALTER TABLE telegram_users
+    ADD COLUMN IF NOT EXISTS skin_type SMALLINT CHECK (skin_type BETWEEN 1 AND 6);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE telegram_users DROP COLUMN IF EXISTS skin_type;
+
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- geomagnetic-fetcher объединяет Kp из нескольких источников (xras.ru, NOAA SWPC)
+-- в одну серию: один слот — одна запись, в source — откуда взято значение
+-- ("xras.ru", "swpc.noaa.gov" или "xras.ru+swpc.noaa.gov" для усреднённого прогноза).
+-- До этой миграции источник был один, дублей по slot_time нет.
+ALTER TABLE geomagnetic_kp DROP CONSTRAINT IF EXISTS geomagnetic_kp_pkey;
+ALTER TABLE geomagnetic_kp ADD PRIMARY KEY (slot_time);
+ALTER TABLE geomagnetic_kp ALTER COLUMN source DROP DEFAULT;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE geomagnetic_kp ALTER COLUMN source SET DEFAULT 'xras.ru';
+ALTER TABLE geomagnetic_kp DROP CONSTRAINT IF EXISTS geomagnetic_kp_pkey;
+ALTER TABLE geomagnetic_kp ADD PRIMARY KEY (slot_time, source);
+
+-- +goose StatementEnd
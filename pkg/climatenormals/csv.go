@@ -0,0 +1,141 @@
+// Package climatenormals читает таблицы климатических норм метеостанций
+// (справочники Росгидромета/ВНИИГМИ-МЦД, сведённые в CSV).
+//
+// Ожидается строка заголовка с колонками (регистр не важен, порядок любой):
+//
+//	month,day,temp_avg,temp_min,temp_max,precipitation
+//
+// Пустой или нулевой day — норма за месяц (precipitation — месячная сумма),
+// иначе — норма календарного дня. Разделитель — запятая или точка с запятой;
+// при точке с запятой допускается десятичная запятая («-3,4»). Пустая ячейка — нет нормы.
+package climatenormals
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Record — одна строка таблицы норм.
+type Record struct {
+	Month int
+	Day   int // 0 — месячная норма
+
+	TempAvg       *float64
+	TempMin       *float64
+	TempMax       *float64
+	Precipitation *float64 // мм
+}
+
+var daysPerMonth = [13]int{0, 31, 29, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
+
+// Parse читает CSV и проверяет месяц и день каждой строки.
+func Parse(r io.Reader) ([]Record, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read normals: %w", err)
+	}
+	text := strings.TrimPrefix(string(data), "\ufeff")
+
+	firstLine, _, _ := strings.Cut(text, "\n")
+	delimiter := ','
+	if strings.Count(firstLine, ";") > strings.Count(firstLine, ",") {
+		delimiter = ';'
+	}
+
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.Comma = delimiter
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("normals file is empty")
+		}
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["month"]; !ok {
+		return nil, errors.New("normals header must contain a month column")
+	}
+
+	var records []Record
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		record, err := parseRow(row, columns, delimiter == ';')
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		records = append(records, record)
+	}
+	if len(records) == 0 {
+		return nil, errors.New("normals file has no rows")
+	}
+	return records, nil
+}
+
+func parseRow(row []string, columns map[string]int, decimalComma bool) (Record, error) {
+	cell := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	var record Record
+	month, err := strconv.Atoi(cell("month"))
+	if err != nil || month < 1 || month > 12 {
+		return Record{}, fmt.Errorf("invalid month %q", cell("month"))
+	}
+	record.Month = month
+	if value := cell("day"); value != "" {
+		day, err := strconv.Atoi(value)
+		if err != nil || day < 0 || day > daysPerMonth[month] {
+			return Record{}, fmt.Errorf("invalid day %q for month %d", value, month)
+		}
+		record.Day = day
+	}
+
+	fields := []struct {
+		name   string
+		target **float64
+	}{
+		{"temp_avg", &record.TempAvg},
+		{"temp_min", &record.TempMin},
+		{"temp_max", &record.TempMax},
+		{"precipitation", &record.Precipitation},
+	}
+	for _, field := range fields {
+		value := cell(field.name)
+		if value == "" {
+			continue
+		}
+		if decimalComma {
+			value = strings.Replace(value, ",", ".", 1)
+		}
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return Record{}, fmt.Errorf("invalid %s %q", field.name, value)
+		}
+		*field.target = &parsed
+	}
+	if record.Precipitation != nil && *record.Precipitation < 0 {
+		return Record{}, fmt.Errorf("negative precipitation %v", *record.Precipitation)
+	}
+	return record, nil
+}
@@ -0,0 +1,60 @@
+package climatenormals
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCommaSeparated(t *testing.T) {
+	input := "month,day,temp_avg,temp_min,temp_max,precipitation\n" +
+		"# январь\n" +
+		"1,,-0.8,-4.6,3.6,38\n" +
+		"1,15,-1.1,-5.0,3.2,\n" +
+		"2,29,0.4,,,\n"
+
+	records, err := Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("got %d records, want 3", len(records))
+	}
+	monthly := records[0]
+	if monthly.Month != 1 || monthly.Day != 0 || *monthly.TempAvg != -0.8 || *monthly.Precipitation != 38 {
+		t.Errorf("unexpected monthly normal: %+v", monthly)
+	}
+	if records[1].Day != 15 || records[1].Precipitation != nil {
+		t.Errorf("empty precipitation must stay nil: %+v", records[1])
+	}
+	if records[2].Month != 2 || records[2].Day != 29 || records[2].TempMin != nil {
+		t.Errorf("unexpected leap day normal: %+v", records[2])
+	}
+}
+
+func TestParseSemicolonWithDecimalComma(t *testing.T) {
+	input := "\ufeffMonth;Temp_Avg;Precipitation\n7;24,3;52,1\n"
+	records, err := Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(records) != 1 || *records[0].TempAvg != 24.3 || *records[0].Precipitation != 52.1 {
+		t.Fatalf("unexpected records: %+v", records)
+	}
+}
+
+func TestParseRejectsInvalidRows(t *testing.T) {
+	tests := map[string]string{
+		"no month column": "day,temp_avg\n1,2\n",
+		"bad month":       "month,temp_avg\n13,2\n",
+		"bad day":         "month,day,temp_avg\n4,31,2\n",
+		"bad number":      "month,temp_avg\n4,warm\n",
+		"no rows":         "month,temp_avg\n",
+	}
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Parse(strings.NewReader(input)); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
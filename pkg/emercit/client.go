@@ -98,6 +98,34 @@ type HistoryValue struct {
 	Zero *float64 `json:"zero"`
 }
 
+// HistoryPoint — значение архива уровня с разобранным временем.
+type HistoryPoint struct {
+	ObservedAt time.Time
+	BS         float64
+	Zero       *float64
+}
+
+// Points возвращает значения канала уровня из ответа архива. Пустые значения и
+// значения с неразборчивым временем пропускаются.
+func (h HistoryResponse) Points(waterLevelUUID string) []HistoryPoint {
+	series, ok := h[waterLevelUUID]
+	if !ok {
+		return nil
+	}
+	out := make([]HistoryPoint, 0, len(series.Values))
+	for _, v := range series.Values {
+		if v.BS == nil || v.Time == "" {
+			continue
+		}
+		observedAt, err := ParseTime(v.Time)
+		if err != nil {
+			continue
+		}
+		out = append(out, HistoryPoint{ObservedAt: observedAt, BS: *v.BS, Zero: v.Zero})
+	}
+	return out
+}
+
 func NewClient(timeout time.Duration, baseURL, username, password string) *Client {
 	if baseURL == "" {
 		baseURL = DefaultBaseURL
@@ -0,0 +1,71 @@
+package emercit
+
+import (
+	"math"
+	"sort"
+)
+
+// StationRef — пост и его канал уровня воды.
+type StationRef struct {
+	StationUUID    string
+	WaterLevelUUID string
+}
+
+// WaterLevelUUID возвращает канал уровня воды поста. Если каналов несколько, берётся
+// первый по алфавиту, чтобы выбор не менялся между запросами.
+func (s Station) WaterLevelUUID() string {
+	uuids := make([]string, 0, len(s.MCHs["waterlevel"]))
+	for uuid := range s.MCHs["waterlevel"] {
+		uuids = append(uuids, uuid)
+	}
+	if len(uuids) == 0 {
+		return ""
+	}
+	sort.Strings(uuids)
+	return uuids[0]
+}
+
+// WaterLevelStations возвращает посты с каналом уровня воды не дальше radiusKm от точки,
+// от ближних к дальним. radiusKm <= 0 снимает ограничение, посты без координат тогда идут в конце.
+func (a ActualResponse) WaterLevelStations(lat, lon, radiusKm float64) []StationRef {
+	type candidate struct {
+		ref      StationRef
+		distance float64
+	}
+	candidates := make([]candidate, 0, len(a))
+	for stationUUID, station := range a {
+		waterLevelUUID := station.WaterLevelUUID()
+		if waterLevelUUID == "" {
+			continue
+		}
+		distance := math.Inf(1)
+		if station.Lat != nil && station.Lon != nil {
+			distance = DistanceKm(lat, lon, *station.Lat, *station.Lon)
+		}
+		if radiusKm > 0 && distance > radiusKm {
+			continue
+		}
+		candidates = append(candidates, candidate{ref: StationRef{StationUUID: stationUUID, WaterLevelUUID: waterLevelUUID}, distance: distance})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].ref.StationUUID < candidates[j].ref.StationUUID
+	})
+	out := make([]StationRef, 0, len(candidates))
+	for _, c := range candidates {
+		out = append(out, c.ref)
+	}
+	return out
+}
+
+// DistanceKm — расстояние между точками по дуге большого круга, км.
+func DistanceKm(lat1, lon1, lat2, lon2 float64) float64 {
+	const earthRadiusKm = 6371.0
+	toRad := math.Pi / 180
+	dLat := (lat2 - lat1) * toRad
+	dLon := (lon2 - lon1) * toRad
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1*toRad)*math.Cos(lat2*toRad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
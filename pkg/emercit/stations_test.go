@@ -0,0 +1,49 @@
+package emercit
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// Урезанный ответ /api/actual/: два поста с уровнем воды, пост без координат и метеопост без уровня.
+const sampleActualJSON = `{
+  "near": {"name": "Армавир", "lat": 45.0, "lon": 41.13, "mchs": {"waterlevel": {"wl-b": {"name": "УВ"}, "wl-a": {"name": "УВ"}}}},
+  "far": {"name": "Краснодар", "lat": 45.04, "lon": 38.98, "mchs": {"waterlevel": {"wl-far": {"name": "УВ"}}}},
+  "nocoords": {"name": "Без координат", "mchs": {"waterlevel": {"wl-x": {"name": "УВ"}}}},
+  "meteo": {"name": "Метеопост", "lat": 45.0, "lon": 41.1, "mchs": {"meteo": {"m-1": {"name": "Метео"}}}}
+}`
+
+func TestWaterLevelStations(t *testing.T) {
+	var actual ActualResponse
+	if err := json.Unmarshal([]byte(sampleActualJSON), &actual); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if got := actual["near"].WaterLevelUUID(); got != "wl-a" {
+		t.Fatalf("WaterLevelUUID() = %q, want first channel wl-a", got)
+	}
+
+	nearby := actual.WaterLevelStations(44.995574, 41.128354, 50)
+	if len(nearby) != 1 || nearby[0].StationUUID != "near" {
+		t.Fatalf("WaterLevelStations(50 km) = %+v, want only near", nearby)
+	}
+
+	all := actual.WaterLevelStations(44.995574, 41.128354, 0)
+	want := []string{"near", "far", "nocoords"}
+	if len(all) != len(want) {
+		t.Fatalf("WaterLevelStations(0) = %+v, want %v", all, want)
+	}
+	for i, ref := range all {
+		if ref.StationUUID != want[i] {
+			t.Fatalf("WaterLevelStations(0)[%d] = %s, want %s", i, ref.StationUUID, want[i])
+		}
+	}
+}
+
+func TestDistanceKm(t *testing.T) {
+	// Армавир — Краснодар по прямой около 170 км
+	d := DistanceKm(44.995574, 41.128354, 45.04, 38.98)
+	if d < 165 || d > 175 {
+		t.Fatalf("DistanceKm() = %.1f, want ~170", d)
+	}
+}
@@ -0,0 +1,167 @@
+// Package metno implements a small client for MET Norway Locationforecast 2.0.
+package metno
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const DefaultBaseURL = "https://api.met.no/weatherapi/locationforecast/2.0/compact"
+
+// DefaultUserAgent — MET Norway требует идентифицирующий User-Agent, иначе отвечает 403.
+const DefaultUserAgent = "iRootPro-weather/1.0 github.com/iRootPro/weather"
+
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	userAgent  string
+}
+
+type Response struct {
+	Properties struct {
+		Meta struct {
+			UpdatedAt time.Time `json:"updated_at"`
+		} `json:"meta"`
+		Timeseries []TimeStep `json:"timeseries"`
+	} `json:"properties"`
+}
+
+type TimeStep struct {
+	Time time.Time `json:"time"`
+	Data struct {
+		Instant struct {
+			Details InstantDetails `json:"details"`
+		} `json:"instant"`
+		Next1Hours  *Period `json:"next_1_hours,omitempty"`
+		Next6Hours  *Period `json:"next_6_hours,omitempty"`
+		Next12Hours *Period `json:"next_12_hours,omitempty"`
+	} `json:"data"`
+}
+
+type InstantDetails struct {
+	AirTemperature        *float64 `json:"air_temperature"`
+	AirPressureAtSeaLevel *float64 `json:"air_pressure_at_sea_level"`
+	CloudAreaFraction     *float64 `json:"cloud_area_fraction"`
+	RelativeHumidity      *float64 `json:"relative_humidity"`
+	WindFromDirection     *float64 `json:"wind_from_direction"`
+	WindSpeed             *float64 `json:"wind_speed"` // м/с
+}
+
+type Period struct {
+	Summary struct {
+		SymbolCode string `json:"symbol_code"`
+	} `json:"summary"`
+	Details struct {
+		PrecipitationAmount *float64 `json:"precipitation_amount"`
+	} `json:"details"`
+}
+
+// NewClient создаёт клиент. Пустые baseURL и userAgent заменяются значениями по умолчанию.
+func NewClient(timeout time.Duration, baseURL, userAgent string) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
+	}
+	return &Client{
+		httpClient: &http.Client{Timeout: timeout},
+		baseURL:    baseURL,
+		userAgent:  userAgent,
+	}
+}
+
+// GetForecast запрашивает прогноз для точки. Время в ответе — UTC.
+func (c *Client) GetForecast(ctx context.Context, latitude, longitude float64) (*Response, error) {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse base URL: %w", err)
+	}
+
+	// API просит не более 4 знаков после запятой, иначе кэш не работает
+	q := u.Query()
+	q.Set("lat", fmt.Sprintf("%.4f", latitude))
+	q.Set("lon", fmt.Sprintf("%.4f", longitude))
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var out Response
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &out, nil
+}
+
+// SymbolToWMO переводит symbol_code MET Norway в код погоды WMO,
+// которым пользуется остальная часть приложения.
+func SymbolToWMO(symbol string) int16 {
+	// Суффиксы _day/_night/_polartwilight на код не влияют
+	if i := strings.Index(symbol, "_"); i >= 0 {
+		symbol = symbol[:i]
+	}
+
+	if strings.Contains(symbol, "thunder") {
+		return 95
+	}
+
+	switch symbol {
+	case "clearsky":
+		return 0
+	case "fair":
+		return 1
+	case "partlycloudy":
+		return 2
+	case "cloudy":
+		return 3
+	case "fog":
+		return 45
+	case "lightrain":
+		return 61
+	case "rain":
+		return 63
+	case "heavyrain":
+		return 65
+	case "lightrainshowers":
+		return 80
+	case "rainshowers":
+		return 81
+	case "heavyrainshowers":
+		return 82
+	case "lightsleet", "lightsleetshowers":
+		return 66
+	case "sleet", "heavysleet", "sleetshowers", "heavysleetshowers":
+		return 67
+	case "lightsnow":
+		return 71
+	case "snow":
+		return 73
+	case "heavysnow":
+		return 75
+	case "lightsnowshowers", "snowshowers":
+		return 85
+	case "heavysnowshowers":
+		return 86
+	default:
+		return 3
+	}
+}
@@ -0,0 +1,104 @@
+package metno
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// Сокращённый ответ compact: первый шаг с next_1_hours, второй — только с next_6_hours.
+const sampleJSON = `{
+  "type": "Feature",
+  "properties": {
+    "meta": {"updated_at": "2026-04-13T08:12:44Z", "units": {"air_temperature": "celsius"}},
+    "timeseries": [
+      {
+        "time": "2026-04-13T09:00:00Z",
+        "data": {
+          "instant": {"details": {"air_pressure_at_sea_level": 1012.4, "air_temperature": 14.2, "cloud_area_fraction": 80.5, "relative_humidity": 61.3, "wind_from_direction": 210.4, "wind_speed": 3.4}},
+          "next_1_hours": {"summary": {"symbol_code": "lightrainshowers_day"}, "details": {"precipitation_amount": 0.3}},
+          "next_6_hours": {"summary": {"symbol_code": "rain"}, "details": {"precipitation_amount": 2.1}}
+        }
+      },
+      {
+        "time": "2026-04-15T12:00:00Z",
+        "data": {
+          "instant": {"details": {"air_temperature": 18.0, "relative_humidity": 50.0, "wind_speed": 5.0}},
+          "next_6_hours": {"summary": {"symbol_code": "partlycloudy_day"}, "details": {"precipitation_amount": 0.0}}
+        }
+      }
+    ]
+  }
+}`
+
+func TestGetForecast(t *testing.T) {
+	var gotUA, gotLat string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUA = r.Header.Get("User-Agent")
+		gotLat = r.URL.Query().Get("lat")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(sampleJSON))
+	}))
+	defer srv.Close()
+
+	c := NewClient(5*time.Second, srv.URL, "")
+	resp, err := c.GetForecast(context.Background(), 44.995574, 41.128354)
+	if err != nil {
+		t.Fatalf("GetForecast: %v", err)
+	}
+
+	if gotUA != DefaultUserAgent {
+		t.Fatalf("User-Agent = %q, want %q", gotUA, DefaultUserAgent)
+	}
+	if gotLat != "44.9956" {
+		t.Fatalf("lat = %q, want 44.9956", gotLat)
+	}
+
+	steps := resp.Properties.Timeseries
+	if len(steps) != 2 {
+		t.Fatalf("timeseries len = %d, want 2", len(steps))
+	}
+	first := steps[0]
+	if first.Data.Instant.Details.AirTemperature == nil || *first.Data.Instant.Details.AirTemperature != 14.2 {
+		t.Fatalf("air_temperature = %v, want 14.2", first.Data.Instant.Details.AirTemperature)
+	}
+	if first.Data.Next1Hours == nil || first.Data.Next1Hours.Summary.SymbolCode != "lightrainshowers_day" {
+		t.Fatalf("next_1_hours не разобран: %+v", first.Data.Next1Hours)
+	}
+	if steps[1].Data.Next1Hours != nil {
+		t.Fatalf("у второго шага не должно быть next_1_hours")
+	}
+	if steps[1].Data.Instant.Details.AirPressureAtSeaLevel != nil {
+		t.Fatalf("отсутствующее давление должно быть nil")
+	}
+}
+
+func TestGetForecastStatusError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	c := NewClient(5*time.Second, srv.URL, "test")
+	if _, err := c.GetForecast(context.Background(), 45, 41); err == nil {
+		t.Fatal("ожидалась ошибка на 403")
+	}
+}
+
+func TestSymbolToWMO(t *testing.T) {
+	tests := map[string]int16{
+		"clearsky_night":            0,
+		"partlycloudy_day":          2,
+		"heavyrain":                 65,
+		"rainshowersandthunder_day": 95,
+		"lightsnow":                 71,
+		"unknown_symbol":            3,
+	}
+	for symbol, want := range tests {
+		if got := SymbolToWMO(symbol); got != want {
+			t.Fatalf("SymbolToWMO(%q) = %d, want %d", symbol, got, want)
+		}
+	}
+}
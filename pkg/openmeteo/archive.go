@@ -0,0 +1,87 @@
+package openmeteo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ArchiveRequest — запрос к историческому архиву Open-Meteo (реанализ ERA5 и др.)
+type ArchiveRequest struct {
+	Latitude  float64
+	Longitude float64
+	StartDate time.Time
+	EndDate   time.Time // включительно
+	Daily     []string
+	Timezone  string
+	Model     string // era5, era5_land, cerra; пусто — best_match архива
+	WindUnit  string // единицы ветра (ms, kmh); пусто — км/ч по умолчанию API
+}
+
+// SetArchiveURL меняет адрес исторического архива (зеркало или тестовый сервер)
+func (c *Client) SetArchiveURL(archiveURL string) {
+	c.archiveURL = archiveURL
+}
+
+// GetArchive загружает суточные данные из исторического архива за период
+func (c *Client) GetArchive(ctx context.Context, req ArchiveRequest) (*ForecastResponse, error) {
+	u, err := url.Parse(c.archiveURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse archive URL: %w", err)
+	}
+
+	q := u.Query()
+	q.Set("latitude", fmt.Sprintf("%.6f", req.Latitude))
+	q.Set("longitude", fmt.Sprintf("%.6f", req.Longitude))
+	q.Set("start_date", req.StartDate.Format("2006-01-02"))
+	q.Set("end_date", req.EndDate.Format("2006-01-02"))
+	q.Set("timezone", req.Timezone)
+	if req.Model != "" {
+		q.Set("models", req.Model)
+	}
+	if req.WindUnit != "" {
+		q.Set("wind_speed_unit", req.WindUnit)
+	}
+	for _, param := range req.Daily {
+		q.Add("daily", param)
+	}
+	u.RawQuery = q.Encode()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var archiveResp ForecastResponse
+	if err := json.NewDecoder(resp.Body).Decode(&archiveResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &archiveResp, nil
+}
+
+// GetDefaultArchiveDailyParams возвращает набор суточных параметров для климатического фона
+func GetDefaultArchiveDailyParams() []string {
+	return []string{
+		"temperature_2m_min",
+		"temperature_2m_max",
+		"temperature_2m_mean",
+		"precipitation_sum",
+		"wind_speed_10m_max",
+		"wind_gusts_10m_max",
+		"shortwave_radiation_sum",
+	}
+}
@@ -10,12 +10,14 @@ import (
 )
 
 const (
-	APIBaseURL = "https://api.open-meteo.com/v1/forecast"
+	APIBaseURL     = "https://api.open-meteo.com/v1/forecast"
+	ArchiveBaseURL = "https://archive-api.open-meteo.com/v1/archive"
 )
 
 type Client struct {
 	httpClient *http.Client
 	baseURL    string
+	archiveURL string
 }
 
 type ForecastRequest struct {
@@ -24,51 +26,63 @@ type ForecastRequest struct {
 	Hourly    []string // параметры для почасового прогноза
 	Daily     []string // параметры для дневного прогноза
 	Timezone  string
+	Model     string // модель Open-Meteo (icon_seamless, ecmwf_ifs025, gfs_seamless); пусто — best_match
+	WindUnit  string // единицы ветра (ms, kmh); пусто — км/ч по умолчанию API
 }
 
 type ForecastResponse struct {
-	Latitude  float64      `json:"latitude"`
-	Longitude float64      `json:"longitude"`
-	Timezone  string       `json:"timezone"`
-	Hourly    HourlyData   `json:"hourly"`
-	Daily     DailyData    `json:"daily"`
+	Latitude  float64    `json:"latitude"`
+	Longitude float64    `json:"longitude"`
+	Timezone  string     `json:"timezone"`
+	Hourly    HourlyData `json:"hourly"`
+	Daily     DailyData  `json:"daily"`
 }
 
+// Значения в массивах — указатели: у части моделей (models=...) некоторых
+// переменных нет, и API возвращает null.
 type HourlyData struct {
-	Time                     []string  `json:"time"`
-	Temperature              []float64 `json:"temperature_2m"`
-	FeelsLike                []float64 `json:"apparent_temperature"`
-	PrecipitationProbability []int     `json:"precipitation_probability"`
-	Precipitation            []float64 `json:"precipitation"`
-	WindSpeed                []float64 `json:"wind_speed_10m"`
-	WindDirection            []int     `json:"wind_direction_10m"`
-	WindGusts                []float64 `json:"wind_gusts_10m"`
-	CloudCover               []int     `json:"cloud_cover"`
-	Pressure                 []float64 `json:"pressure_msl"`
-	Humidity                 []int     `json:"relative_humidity_2m"`
-	UVIndex                  []float64 `json:"uv_index"`
-	WeatherCode              []int     `json:"weather_code"`
+	Time                     []string   `json:"time"`
+	Temperature              []*float64 `json:"temperature_2m"`
+	FeelsLike                []*float64 `json:"apparent_temperature"`
+	PrecipitationProbability []*int     `json:"precipitation_probability"`
+	Precipitation            []*float64 `json:"precipitation"`
+	WindSpeed                []*float64 `json:"wind_speed_10m"`
+	WindDirection            []*int     `json:"wind_direction_10m"`
+	WindGusts                []*float64 `json:"wind_gusts_10m"`
+	CloudCover               []*int     `json:"cloud_cover"`
+	Pressure                 []*float64 `json:"pressure_msl"`
+	Humidity                 []*int     `json:"relative_humidity_2m"`
+	UVIndex                  []*float64 `json:"uv_index"`
+	WeatherCode              []*int     `json:"weather_code"`
 }
 
 type DailyData struct {
-	Time                     []string  `json:"time"`
-	TemperatureMin           []float64 `json:"temperature_2m_min"`
-	TemperatureMax           []float64 `json:"temperature_2m_max"`
-	PrecipitationProbability []int     `json:"precipitation_probability_max"`
-	PrecipitationSum         []float64 `json:"precipitation_sum"`
-	WindSpeedMax             []float64 `json:"wind_speed_10m_max"`
-	WindDirection            []int     `json:"wind_direction_10m_dominant"`
-	WindGustsMax             []float64 `json:"wind_gusts_10m_max"`
-	UVIndexMax               []float64 `json:"uv_index_max"`
-	WeatherCode              []int     `json:"weather_code"`
+	Time                     []string   `json:"time"`
+	TemperatureMin           []*float64 `json:"temperature_2m_min"`
+	TemperatureMax           []*float64 `json:"temperature_2m_max"`
+	TemperatureMean          []*float64 `json:"temperature_2m_mean"`
+	PrecipitationProbability []*int     `json:"precipitation_probability_max"`
+	PrecipitationSum         []*float64 `json:"precipitation_sum"`
+	WindSpeedMax             []*float64 `json:"wind_speed_10m_max"`
+	WindDirection            []*int     `json:"wind_direction_10m_dominant"`
+	WindGustsMax             []*float64 `json:"wind_gusts_10m_max"`
+	UVIndexMax               []*float64 `json:"uv_index_max"`
+	ShortwaveRadiationSum    []*float64 `json:"shortwave_radiation_sum"`
+	WeatherCode              []*int     `json:"weather_code"`
 }
 
 func NewClient(timeout time.Duration) *Client {
+	return NewClientWithBaseURL(timeout, APIBaseURL)
+}
+
+// NewClientWithBaseURL создаёт клиент с другим адресом API (зеркало или тестовый сервер)
+func NewClientWithBaseURL(timeout time.Duration, baseURL string) *Client {
 	return &Client{
 		httpClient: &http.Client{
 			Timeout: timeout,
 		},
-		baseURL: APIBaseURL,
+		baseURL:    baseURL,
+		archiveURL: ArchiveBaseURL,
 	}
 }
 
@@ -82,6 +96,12 @@ func (c *Client) GetForecast(ctx context.Context, req ForecastRequest) (*Forecas
 	q.Set("latitude", fmt.Sprintf("%.6f", req.Latitude))
 	q.Set("longitude", fmt.Sprintf("%.6f", req.Longitude))
 	q.Set("timezone", req.Timezone)
+	if req.Model != "" {
+		q.Set("models", req.Model)
+	}
+	if req.WindUnit != "" {
+		q.Set("wind_speed_unit", req.WindUnit)
+	}
 
 	if len(req.Hourly) > 0 {
 		for _, param := range req.Hourly {
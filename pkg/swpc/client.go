@@ -0,0 +1,204 @@
+// Package swpc — клиент продуктов NOAA Space Weather Prediction Center.
+//
+// Источники:
+//   - https://services.swpc.noaa.gov/products/noaa-planetary-k-index.json —
+//     наблюдённый планетарный индекс Kp по 3-часовым слотам за неделю;
+//   - https://services.swpc.noaa.gov/products/noaa-planetary-k-index-forecast.json —
+//     наблюдения последних суток и прогноз Kp на трое суток.
+//
+// SWPC отдаёт продукты то таблицей (массив массивов, первая строка — заголовок,
+// числа строками), то массивом объектов. Парсер понимает оба варианта.
+// Время слотов — UTC.
+package swpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultKpURL       = "https://services.swpc.noaa.gov/products/noaa-planetary-k-index.json"
+	DefaultForecastURL = "https://services.swpc.noaa.gov/products/noaa-planetary-k-index-forecast.json"
+)
+
+// Статусы слота в прогнозном продукте
+const (
+	StatusObserved  = "observed"
+	StatusEstimated = "estimated"
+	StatusPredicted = "predicted"
+)
+
+// KpEntry — значение Kp на начало 3-часового слота.
+type KpEntry struct {
+	Time   time.Time
+	Kp     float64
+	Status string // observed / estimated / predicted
+}
+
+// IsPredicted сообщает, что слот — прогноз, а не наблюдение.
+func (e KpEntry) IsPredicted() bool {
+	return e.Status == StatusPredicted
+}
+
+// Client — HTTP-клиент SWPC.
+type Client struct {
+	httpClient  *http.Client
+	kpURL       string
+	forecastURL string
+}
+
+// NewClient создаёт клиент; пустые адреса заменяются продуктами SWPC по умолчанию.
+func NewClient(timeout time.Duration, kpURL, forecastURL string) *Client {
+	if kpURL == "" {
+		kpURL = DefaultKpURL
+	}
+	if forecastURL == "" {
+		forecastURL = DefaultForecastURL
+	}
+	return &Client{httpClient: &http.Client{Timeout: timeout}, kpURL: kpURL, forecastURL: forecastURL}
+}
+
+// GetPlanetaryKp возвращает наблюдённые значения Kp.
+func (c *Client) GetPlanetaryKp(ctx context.Context) ([]KpEntry, error) {
+	rows, err := c.getRows(ctx, c.kpURL)
+	if err != nil {
+		return nil, err
+	}
+	return parseEntries(rows, StatusObserved)
+}
+
+// GetKpForecast возвращает последние наблюдения и прогноз Kp на трое суток.
+func (c *Client) GetKpForecast(ctx context.Context) ([]KpEntry, error) {
+	rows, err := c.getRows(ctx, c.forecastURL)
+	if err != nil {
+		return nil, err
+	}
+	return parseEntries(rows, StatusPredicted)
+}
+
+func (c *Client) getRows(ctx context.Context, url string) ([]map[string]any, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var raw []json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return decodeRows(raw)
+}
+
+// decodeRows приводит таблицу или массив объектов к списку строк с ключами в нижнем регистре.
+func decodeRows(raw []json.RawMessage) ([]map[string]any, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	if first := strings.TrimSpace(string(raw[0])); strings.HasPrefix(first, "{") {
+		rows := make([]map[string]any, 0, len(raw))
+		for _, item := range raw {
+			var obj map[string]any
+			if err := json.Unmarshal(item, &obj); err != nil {
+				return nil, fmt.Errorf("failed to decode row: %w", err)
+			}
+			row := make(map[string]any, len(obj))
+			for k, v := range obj {
+				row[strings.ToLower(k)] = v
+			}
+			rows = append(rows, row)
+		}
+		return rows, nil
+	}
+
+	var header []string
+	if err := json.Unmarshal(raw[0], &header); err != nil {
+		return nil, fmt.Errorf("failed to decode header: %w", err)
+	}
+	rows := make([]map[string]any, 0, len(raw)-1)
+	for _, item := range raw[1:] {
+		var values []any
+		if err := json.Unmarshal(item, &values); err != nil {
+			return nil, fmt.Errorf("failed to decode row: %w", err)
+		}
+		row := make(map[string]any, len(header))
+		for i, name := range header {
+			if i < len(values) {
+				row[strings.ToLower(name)] = values[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// parseEntries разбирает строки продукта; строки без времени или Kp пропускаются.
+// Статус берётся из колонки observed, без неё — defaultStatus.
+func parseEntries(rows []map[string]any, defaultStatus string) ([]KpEntry, error) {
+	entries := make([]KpEntry, 0, len(rows))
+	for _, row := range rows {
+		tag, _ := row["time_tag"].(string)
+		if tag == "" {
+			continue
+		}
+		t, err := ParseTimeTag(tag)
+		if err != nil {
+			return nil, err
+		}
+		kp := parseNumber(row["kp"])
+		if kp == nil {
+			continue
+		}
+
+		status := defaultStatus
+		if s, ok := row["observed"].(string); ok && s != "" {
+			status = strings.ToLower(s)
+		}
+		entries = append(entries, KpEntry{Time: t, Kp: *kp, Status: status})
+	}
+	return entries, nil
+}
+
+// ParseTimeTag разбирает time_tag SWPC ("2026-04-12 00:00:00.000" или "2026-04-12T00:00:00") как UTC.
+func ParseTimeTag(s string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02 15:04:05.000", "2006-01-02 15:04:05", "2006-01-02T15:04:05", time.RFC3339} {
+		if t, err := time.ParseInLocation(layout, s, time.UTC); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time_tag %q", s)
+}
+
+// parseNumber принимает число или строку с числом; null и пустые строки — nil.
+func parseNumber(v any) *float64 {
+	switch n := v.(type) {
+	case float64:
+		return &n
+	case string:
+		n = strings.TrimSpace(n)
+		if n == "" || n == "null" {
+			return nil
+		}
+		f, err := strconv.ParseFloat(n, 64)
+		if err != nil {
+			return nil
+		}
+		return &f
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package swpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// Фикстуры в testdata записаны с services.swpc.noaa.gov (время сдвинуто на апрель 2026):
+// табличный формат с числами-строками и новый формат массива объектов.
+func fixtureServer(t *testing.T, name string) *httptest.Server {
+	t.Helper()
+	body, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestGetPlanetaryKp_Table(t *testing.T) {
+	srv := fixtureServer(t, "noaa-planetary-k-index.json")
+	c := NewClient(5*time.Second, srv.URL, "")
+
+	entries, err := c.GetPlanetaryKp(context.Background())
+	if err != nil {
+		t.Fatalf("GetPlanetaryKp: %v", err)
+	}
+
+	// 12 строк, у последней Kp = "null" — пропускается
+	if len(entries) != 11 {
+		t.Fatalf("len(entries) = %d, want 11", len(entries))
+	}
+	first := entries[0]
+	if !first.Time.Equal(time.Date(2026, 4, 11, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("first.Time = %v, want 2026-04-11 00:00 UTC", first.Time)
+	}
+	if first.Kp != 1.33 || first.Status != StatusObserved || first.IsPredicted() {
+		t.Errorf("first = %+v, want observed Kp 1.33", first)
+	}
+	if got := entries[7]; got.Kp != 5.33 || got.Time.Hour() != 21 {
+		t.Errorf("entries[7] = %+v, want Kp 5.33 at 21:00", got)
+	}
+}
+
+func TestGetPlanetaryKp_Objects(t *testing.T) {
+	srv := fixtureServer(t, "noaa-planetary-k-index-objects.json")
+	c := NewClient(5*time.Second, srv.URL, "")
+
+	entries, err := c.GetPlanetaryKp(context.Background())
+	if err != nil {
+		t.Fatalf("GetPlanetaryKp: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("len(entries) = %d, want 3", len(entries))
+	}
+	if got := entries[1]; got.Kp != 3.67 || !got.Time.Equal(time.Date(2026, 4, 12, 3, 0, 0, 0, time.UTC)) {
+		t.Errorf("entries[1] = %+v, want Kp 3.67 at 03:00 UTC", got)
+	}
+}
+
+func TestGetKpForecast(t *testing.T) {
+	srv := fixtureServer(t, "noaa-planetary-k-index-forecast.json")
+	c := NewClient(5*time.Second, "", srv.URL)
+
+	entries, err := c.GetKpForecast(context.Background())
+	if err != nil {
+		t.Fatalf("GetKpForecast: %v", err)
+	}
+	if len(entries) != 11 {
+		t.Fatalf("len(entries) = %d, want 11", len(entries))
+	}
+
+	counts := map[string]int{}
+	for _, e := range entries {
+		counts[e.Status]++
+	}
+	if counts[StatusObserved] != 4 || counts[StatusEstimated] != 1 || counts[StatusPredicted] != 6 {
+		t.Errorf("status counts = %v, want 4 observed, 1 estimated, 6 predicted", counts)
+	}
+	if storm := entries[8]; storm.Kp != 6 || !storm.IsPredicted() {
+		t.Errorf("entries[8] = %+v, want predicted Kp 6", storm)
+	}
+}
+
+func TestGetKpForecast_HTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	c := NewClient(5*time.Second, "", srv.URL)
+	if _, err := c.GetKpForecast(context.Background()); err == nil {
+		t.Fatal("expected error on 502, got nil")
+	}
+}
+
+func TestParseTimeTag(t *testing.T) {
+	want := time.Date(2026, 4, 12, 9, 0, 0, 0, time.UTC)
+	for _, in := range []string{"2026-04-12 09:00:00.000", "2026-04-12 09:00:00", "2026-04-12T09:00:00", "2026-04-12T09:00:00Z"} {
+		got, err := ParseTimeTag(in)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("ParseTimeTag(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	if _, err := ParseTimeTag("12.04.2026"); err == nil {
+		t.Error("ParseTimeTag() accepted unknown layout")
+	}
+}